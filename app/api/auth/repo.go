@@ -57,6 +57,19 @@ func CheckRepo(
 	return Check(ctx, authorizer, session, scope, resource, permission)
 }
 
+// CheckRepoForPrincipal checks if a repo specific permission is granted for the provided principal
+// that isn't the principal of the current auth session (e.g. a pull request reviewer).
+// The authorizer only accepts sessions, so the check runs with a session without any metadata.
+func CheckRepoForPrincipal(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	principal *types.Principal,
+	repo *types.Repository,
+	permission enum.Permission,
+) error {
+	return CheckRepo(ctx, authorizer, &auth.Session{Principal: *principal}, repo, permission, false)
+}
+
 func IsRepoOwner(
 	ctx context.Context,
 	authorizer authz.Authorizer,
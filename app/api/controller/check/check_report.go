@@ -25,6 +25,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -182,6 +183,14 @@ func (c *Controller) Report(
 		return nil, fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
 	}
 
+	c.reporter.Reported(ctx, &checkevents.ReportedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		CommitSHA:   commitSHA,
+		Identifier:  statusCheckReport.Identifier,
+		Status:      statusCheckReport.Status,
+	})
+
 	return statusCheckReport, nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -36,6 +37,7 @@ type Controller struct {
 	checkStore store.CheckStore
 	git        git.Interface
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+	reporter   *checkevents.Reporter
 }
 
 func NewController(
@@ -45,6 +47,7 @@ func NewController(
 	checkStore store.CheckStore,
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	reporter *checkevents.Reporter,
 ) *Controller {
 	return &Controller{
 		tx:         tx,
@@ -53,6 +56,7 @@ func NewController(
 		checkStore: checkStore,
 		git:        git,
 		sanitizers: sanitizers,
+		reporter:   reporter,
 	}
 }
 
@@ -23,7 +23,11 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -64,6 +68,11 @@ type Controller struct {
 	urlProvider       url.Provider
 	protectionManager *protection.Manager
 	resourceLimiter   limiter.ResourceLimiter
+	signatureVerifier *gitsignature.Verifier
+	pullMirrorStore   store.PullMirrorStore
+	auditService      *audit.Service
+	repoReporter      *repoevents.Reporter
+	secretScanService *secretscan.Service
 }
 
 func NewController(
@@ -76,6 +85,11 @@ func NewController(
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	limiter limiter.ResourceLimiter,
+	signatureVerifier *gitsignature.Verifier,
+	pullMirrorStore store.PullMirrorStore,
+	auditService *audit.Service,
+	repoReporter *repoevents.Reporter,
+	secretScanService *secretscan.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:        authorizer,
@@ -87,6 +101,11 @@ func NewController(
 		urlProvider:       urlProvider,
 		protectionManager: protectionManager,
 		resourceLimiter:   limiter,
+		signatureVerifier: signatureVerifier,
+		pullMirrorStore:   pullMirrorStore,
+		auditService:      auditService,
+		repoReporter:      repoReporter,
+		secretScanService: secretScanService,
 	}
 }
 
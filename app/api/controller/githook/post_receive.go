@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
@@ -52,7 +53,7 @@ func (c *Controller) PostReceive(
 	}
 
 	// report ref events (best effort)
-	c.reportReferenceEvents(ctx, repo, in.PrincipalID, in.PostReceiveInput)
+	c.reportReferenceEvents(ctx, repo, in.PrincipalID, in.ClientIP, in.PostReceiveInput)
 
 	// create output object and have following messages fill its messages
 	out := hook.Output{}
@@ -70,12 +71,13 @@ func (c *Controller) reportReferenceEvents(
 	ctx context.Context,
 	repo *types.Repository,
 	principalID int64,
+	clientIP string,
 	in hook.PostReceiveInput,
 ) {
 	for _, refUpdate := range in.RefUpdates {
 		switch {
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch):
-			c.reportBranchEvent(ctx, repo, principalID, refUpdate)
+			c.reportBranchEvent(ctx, repo, principalID, clientIP, refUpdate)
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag):
 			c.reportTagEvent(ctx, repo, principalID, refUpdate)
 		default:
@@ -88,6 +90,7 @@ func (c *Controller) reportBranchEvent(
 	ctx context.Context,
 	repo *types.Repository,
 	principalID int64,
+	clientIP string,
 	branchUpdate hook.ReferenceUpdate,
 ) {
 	switch {
@@ -128,6 +131,38 @@ func (c *Controller) reportBranchEvent(
 			NewSHA:      branchUpdate.New,
 			Forced:      forced,
 		})
+
+		if forced {
+			c.auditForcePush(ctx, repo, principalID, clientIP, branchUpdate)
+		}
+	}
+}
+
+// auditForcePush records a forced branch update in the audit log (best effort).
+func (c *Controller) auditForcePush(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	clientIP string,
+	branchUpdate hook.ReferenceUpdate,
+) {
+	principal, err := c.principalStore.Find(ctx, principalID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find principal for force push audit log")
+		return
+	}
+
+	err = c.auditService.Log(ctx,
+		principal,
+		audit.NewRepoResource(enum.AuditResourceTypeBranch,
+			strings.TrimPrefix(branchUpdate.Ref, gitReferenceNamePrefixBranch), repo),
+		enum.AuditActionForcePushed,
+		audit.WithData("old_sha", branchUpdate.Old),
+		audit.WithData("new_sha", branchUpdate.New),
+		audit.WithClientIP(clientIP),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for force push operation")
 	}
 }
 
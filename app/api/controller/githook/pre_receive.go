@@ -16,6 +16,7 @@ package githook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -23,15 +24,27 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/hook"
+	gittypes "github.com/harness/gitness/git/types"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/exp/slices"
 )
 
+// pushOptionBypassReason is the push option used to provide the reason for bypassing protection rules.
+const pushOptionBypassReason = "bypass_reason"
+
 // PreReceive executes the pre-receive hook for a git repository.
 //
 //nolint:revive // not yet fully implemented
@@ -61,8 +74,27 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// Branches and tags of a pull mirror are only updated by the mirror sync, which doesn't call pre-receive.
+	if !refUpdates.branches.isEmpty() || !refUpdates.tags.isEmpty() {
+		isMirror, err := c.isPullMirror(ctx, repo.ID)
+		if err != nil {
+			return hook.Output{}, err
+		}
+		if isMirror {
+			output.Error = ptr.String(usererror.ErrRepoIsPullMirror.Error())
+			return output, nil
+		}
+	}
+
 	if in.Internal {
 		// It's an internal call, so no need to verify protection rules.
+		// Commits with user provided content (e.g. created via the API) are still scanned for secrets.
+		if in.ScanSecrets {
+			if err := c.scanSecrets(ctx, repo, in, &output); err != nil {
+				return hook.Output{}, fmt.Errorf("failed to scan for secrets: %w", err)
+			}
+		}
+
 		return output, nil
 	}
 
@@ -82,14 +114,135 @@ func (c *Controller) PreReceive(
 		Metadata:  nil,
 	}
 
-	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+	unverifiedCommits := c.unverifiedCommitsFn(repo, in)
+
+	bypass, err := c.checkProtectionRules(ctx, dummySession, repo, refUpdates, unverifiedCommits, in, &output)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 	}
 
+	if output.Error != nil {
+		return output, nil
+	}
+
+	err = c.scanSecrets(ctx, repo, in, &output)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to scan for secrets: %w", err)
+	}
+
+	if output.Error != nil {
+		return output, nil
+	}
+
+	// The bypass is only recorded once all checks passed, pushes rejected for other reasons didn't bypass anything.
+	if bypass != nil {
+		c.recordRuleBypass(ctx, dummySession, repo, bypass, in.ClientIP)
+	}
+
 	return output, nil
 }
 
+// ruleBypass describes the protection rules bypassed by a push.
+type ruleBypass struct {
+	rules  []types.RuleInfo
+	reason string
+	refs   []string
+}
+
+// recordRuleBypass emits the rule bypassed event and records the bypass in the audit log.
+func (c *Controller) recordRuleBypass(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	bypass *ruleBypass,
+	clientIP string,
+) {
+	c.repoReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		Refs:        bypass.refs,
+		Rules:       bypass.rules,
+		Reason:      bypass.reason,
+	})
+
+	err := c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRepository, repo.Identifier, repo),
+		enum.AuditActionBypassed,
+		audit.WithData("rules", strings.Join(protection.RuleIdentifiers(bypass.rules), ",")),
+		audit.WithData("reason", bypass.reason),
+		audit.WithData("refs", strings.Join(bypass.refs, ",")),
+		audit.WithClientIP(clientIP),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for git push operation")
+	}
+}
+
+// scanSecrets scans the new commits of the pushed branches and tags for secrets
+// and rejects the push if the repository is configured to block pushes that contain secrets.
+func (c *Controller) scanSecrets(
+	ctx context.Context,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	var refUpdates []secretscan.RefUpdate
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) ||
+			strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag) {
+			refUpdates = append(refUpdates, secretscan.RefUpdate{
+				Ref: refUpdate.Ref,
+				New: refUpdate.New,
+			})
+		}
+	}
+
+	if len(refUpdates) == 0 {
+		return nil
+	}
+
+	out, err := c.secretScanService.ScanPush(ctx, secretscan.ScanPushInput{
+		Repo:                repo,
+		PrincipalID:         in.PrincipalID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		RefUpdates:          refUpdates,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(out.Findings) == 0 {
+		return nil
+	}
+
+	output.Messages = append(output.Messages,
+		fmt.Sprintf("Secret scanning found %d potential secret(s):", len(out.Findings)))
+	for _, finding := range out.Findings {
+		output.Messages = append(output.Messages, fmt.Sprintf("  %s:%d (rule %q) %s [fingerprint: %s]",
+			finding.Path, finding.Line, finding.RuleIdentifier, finding.Secret, finding.Fingerprint))
+	}
+
+	if out.Blocked {
+		output.Error = ptr.String("Push contains secrets. Remove them from the commits," +
+			" or add their fingerprints to the secret scanning allowlist file on the default branch.")
+	}
+
+	return nil
+}
+
+func (c *Controller) isPullMirror(ctx context.Context, repoID int64) (bool, error) {
+	_, err := c.pullMirrorStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull mirror of the repository: %w", err)
+	}
+
+	return true, nil
+}
+
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 	fn := func(ref string) bool {
 		return strings.HasPrefix(ref, gitReferenceNamePullReq)
@@ -100,21 +253,25 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 		slices.ContainsFunc(refUpdates.other.updated, fn)
 }
 
+// checkProtectionRules verifies the protection rules for the pushed refs. It returns the bypassed rules,
+// which are only recorded by the caller once all other checks of the push passed as well.
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
 	session *auth.Session,
 	repo *types.Repository,
 	refUpdates changedRefs,
+	unverifiedCommits func(ctx context.Context, branchName string) ([]string, error),
+	in types.GithookPreReceiveInput,
 	output *hook.Output,
-) error {
+) (*ruleBypass, error) {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
 	if err != nil {
-		return fmt.Errorf("failed to determine if user is repo owner: %w", err)
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
 	}
 
 	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
 	var ruleViolations []types.RuleViolations
@@ -125,6 +282,11 @@ func (c *Controller) checkProtectionRules(
 			return
 		}
 
+		refPrefix := gitReferenceNamePrefixBranch
+		if refType == protection.RefTypeTag {
+			refPrefix = gitReferenceNamePrefixTag
+		}
+
 		violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
 			Actor:       &session.Principal,
 			AllowBypass: true,
@@ -133,6 +295,9 @@ func (c *Controller) checkProtectionRules(
 			RefAction:   refAction,
 			RefType:     refType,
 			RefNames:    names,
+
+			UnverifiedCommits: unverifiedCommits,
+			NewCommits:        c.newCommitsFn(repo, in, refPrefix),
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -146,8 +311,12 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
 
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
+
 	if errCheckAction != nil {
-		return errCheckAction
+		return nil, errCheckAction
 	}
 
 	var criticalViolation bool
@@ -167,9 +336,172 @@ func (c *Controller) checkProtectionRules(
 
 	if criticalViolation {
 		output.Error = ptr.String("Blocked by protection rules.")
+		return nil, nil
 	}
 
-	return nil
+	bypassedRules := protection.BypassedRules(ruleViolations)
+	if len(bypassedRules) == 0 {
+		return nil, nil
+	}
+
+	bypassReason := getPushOption(in.PushOptions, pushOptionBypassReason)
+	if bypassReason == "" {
+		output.Error = ptr.String(fmt.Sprintf(
+			"Bypassing protection rules requires a reason. Push again with -o %s=\"<reason>\".",
+			pushOptionBypassReason))
+		return nil, nil
+	}
+
+	refNames := refUpdates.branches.all()
+	refNames = append(refNames, refUpdates.tags.all()...)
+
+	return &ruleBypass{
+		rules:  bypassedRules,
+		reason: bypassReason,
+		refs:   refNames,
+	}, nil
+}
+
+// getPushOption returns the trimmed value of the push option with the provided key ("<key>=<value>").
+func getPushOption(pushOptions []string, key string) string {
+	for _, option := range pushOptions {
+		k, v, ok := strings.Cut(option, "=")
+		if ok && k == key {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// unverifiedCommitsFn returns a function that lists the commits a push adds to a branch
+// which don't carry a verified signature. The quarantined objects of the push are accessed via the hook environment.
+// For updated branches these are the commits between the old and the new value of the branch, so commits that
+// already exist on other branches are verified as well. For created branches these are the commits that aren't
+// part of the default branch.
+func (c *Controller) unverifiedCommitsFn(
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) func(ctx context.Context, branchName string) ([]string, error) {
+	refUpdates := make(map[string]hook.ReferenceUpdate)
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			refUpdates[refUpdate.Ref[len(gitReferenceNamePrefixBranch):]] = refUpdate
+		}
+	}
+
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	return func(ctx context.Context, branchName string) ([]string, error) {
+		refUpdate, ok := refUpdates[branchName]
+		if !ok || refUpdate.New == types.NilSHA {
+			return nil, nil
+		}
+
+		baseRef := refUpdate.Old
+		if baseRef == types.NilSHA {
+			var err error
+			baseRef, err = c.defaultBranchBaseRef(ctx, repo, branchName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return c.signatureVerifier.ListUnverifiedCommits(ctx, readParams, baseRef, refUpdate.New)
+	}
+}
+
+// defaultBranchBaseRef returns the SHA of the default branch of the repository, which the commits of a created
+// branch are compared to. It returns an empty string if the default branch doesn't exist yet
+// or is the created branch itself, in which case all commits that are new to the repository are considered.
+func (c *Controller) defaultBranchBaseRef(
+	ctx context.Context,
+	repo *types.Repository,
+	branchName string,
+) (string, error) {
+	if branchName == repo.DefaultBranch {
+		return "", nil
+	}
+
+	ref, err := c.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       repo.DefaultBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if gittypes.IsNotFoundError(err) || gitnesserrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	return ref.SHA, nil
+}
+
+// newCommitsFn returns a function that lists the details of the new commits of a pushed branch or tag
+// (depending on the provided ref prefix). The quarantined objects of the push are accessed via the hook environment.
+// Results are cached, so rules that match the same ref don't list the commits repeatedly.
+func (c *Controller) newCommitsFn(
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	refPrefix string,
+) func(ctx context.Context, refName string) ([]protection.PushCommit, error) {
+	newSHAs := make(map[string]string)
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, refPrefix) {
+			newSHAs[refUpdate.Ref[len(refPrefix):]] = refUpdate.New
+		}
+	}
+
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	cache := make(map[string][]protection.PushCommit)
+
+	return func(ctx context.Context, refName string) ([]protection.PushCommit, error) {
+		sha, ok := newSHAs[refName]
+		if !ok || sha == types.NilSHA {
+			return nil, nil
+		}
+
+		if commits, ok := cache[sha]; ok {
+			return commits, nil
+		}
+
+		out, err := c.git.ListNewCommits(ctx, &git.ListNewCommitsParams{
+			ReadParams: readParams,
+			GitRef:     sha,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list new commits: %w", err)
+		}
+
+		commits := make([]protection.PushCommit, len(out.Commits))
+		for i, commit := range out.Commits {
+			files := make([]protection.PushCommitFile, len(commit.Files))
+			for j, file := range commit.Files {
+				files[j] = protection.PushCommitFile{
+					Path: file.Path,
+					Size: file.Size,
+				}
+			}
+
+			commits[i] = protection.PushCommit{
+				SHA:         commit.SHA,
+				AuthorEmail: commit.Author.Email,
+				Message:     commit.Message,
+				Files:       files,
+			}
+		}
+
+		cache[sha] = commits
+
+		return commits, nil
+	}
 }
 
 type changes struct {
@@ -178,6 +510,18 @@ type changes struct {
 	updated []string
 }
 
+func (c *changes) isEmpty() bool {
+	return len(c.created) == 0 && len(c.deleted) == 0 && len(c.updated) == 0
+}
+
+func (c *changes) all() []string {
+	names := make([]string, 0, len(c.created)+len(c.deleted)+len(c.updated))
+	names = append(names, c.created...)
+	names = append(names, c.deleted...)
+	names = append(names, c.updated...)
+	return names
+}
+
 func (c *changes) groupByAction(refUpdate hook.ReferenceUpdate, name string) {
 	switch {
 	case refUpdate.Old == types.NilSHA:
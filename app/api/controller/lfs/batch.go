@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Batch returns the actions the client has to take to upload or download the requested lfs objects.
+// The provided header is attached to every action so the client authenticates the same way for the transfer.
+func (c *Controller) Batch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+	header map[string]string,
+) (*BatchResponse, error) {
+	var reqPermission enum.Permission
+	var orPublic bool
+	switch in.Operation {
+	case OperationDownload:
+		reqPermission = enum.PermissionRepoView
+		orPublic = true
+	case OperationUpload:
+		reqPermission = enum.PermissionRepoPush
+	default:
+		return nil, usererror.UnprocessableEntityf("Operation %q is not supported.", in.Operation)
+	}
+
+	if len(in.Transfers) > 0 && !supportsBasicTransfer(in.Transfers) {
+		return nil, usererror.UnprocessableEntityf("Only the %q transfer adapter is supported.", TransferBasic)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != HashAlgoSHA256 {
+		return nil, usererror.UnprocessableEntityf("Only the %q hash algorithm is supported.", HashAlgoSHA256)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission, orPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, p := range in.Objects {
+		if isValidOID(p.OID) {
+			oids = append(oids, p.OID)
+		}
+	}
+
+	existing, err := c.lfsObjectStore.FindMany(ctx, repo.ID, oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs objects: %w", err)
+	}
+
+	existingMap := make(map[string]*types.LFSObject, len(existing))
+	for _, obj := range existing {
+		existingMap[obj.OID] = obj
+	}
+
+	objectsBaseURL := c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/objects/"
+	verifyURL := c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/verify"
+
+	var newSize int64
+	out := &BatchResponse{
+		Transfer: TransferBasic,
+		Objects:  make([]ObjectResponse, len(in.Objects)),
+		HashAlgo: HashAlgoSHA256,
+	}
+
+	for i, p := range in.Objects {
+		out.Objects[i] = ObjectResponse{Pointer: p}
+		obj := &out.Objects[i]
+
+		if !isValidOID(p.OID) || p.Size < 0 {
+			obj.Error = &ObjectError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: "Invalid object.",
+			}
+			continue
+		}
+
+		existingObj, exists := existingMap[p.OID]
+
+		if in.Operation == OperationDownload {
+			if !exists {
+				obj.Error = &ObjectError{
+					Code:    http.StatusNotFound,
+					Message: "Object not found.",
+				}
+				continue
+			}
+
+			obj.Size = existingObj.Size
+			obj.Actions = map[string]Action{
+				actionDownload: {Href: objectsBaseURL + p.OID, Header: header},
+			}
+			continue
+		}
+
+		// object already uploaded - nothing to do for the client.
+		if exists {
+			continue
+		}
+
+		newSize += p.Size
+		obj.Actions = map[string]Action{
+			actionUpload: {Href: objectsBaseURL + p.OID, Header: header},
+			actionVerify: {Href: verifyURL, Header: header},
+		}
+	}
+
+	if newSize > 0 {
+		if err = c.checkQuota(ctx, repo.ID, newSize); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// checkQuota returns an error in case storing additional lfs objects of the provided size
+// would exceed the lfs quota of the repository.
+func (c *Controller) checkQuota(ctx context.Context, repoID int64, size int64) error {
+	if c.repoQuota <= 0 {
+		return nil
+	}
+
+	totalSize, err := c.lfsObjectStore.GetTotalSize(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to get total size of lfs objects: %w", err)
+	}
+
+	if totalSize+size > c.repoQuota {
+		return usererror.Newf(http.StatusInsufficientStorage,
+			"The LFS storage quota of the repository (%d bytes) would be exceeded.", c.repoQuota)
+	}
+
+	return nil
+}
+
+func supportsBasicTransfer(transfers []string) bool {
+	for _, t := range transfers {
+		if t == TransferBasic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// blobPathFmt is the path of an lfs object in the blob store.
+	blobPathFmt = "lfs/%d/%s"
+)
+
+var regexOID = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+type Controller struct {
+	authorizer     authz.Authorizer
+	repoStore      store.RepoStore
+	lfsObjectStore store.LFSObjectStore
+	lfsLockStore   store.LFSLockStore
+	blobStore      blob.Store
+	urlProvider    url.Provider
+	repoQuota      int64
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	lfsObjectStore store.LFSObjectStore,
+	lfsLockStore store.LFSLockStore,
+	blobStore blob.Store,
+	urlProvider url.Provider,
+	repoQuota int64,
+) *Controller {
+	return &Controller{
+		authorizer:     authorizer,
+		repoStore:      repoStore,
+		lfsObjectStore: lfsObjectStore,
+		lfsLockStore:   lfsLockStore,
+		blobStore:      blobStore,
+		urlProvider:    urlProvider,
+		repoQuota:      repoQuota,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+	orPublic bool,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if repo.Importing {
+		return nil, usererror.BadRequest("Repository import is in progress.")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, orPublic); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+// GetBlobPath returns the path of an lfs object of a repository in the blob store.
+func GetBlobPath(repoID int64, oid string) string {
+	return fmt.Sprintf(blobPathFmt, repoID, oid)
+}
+
+func isValidOID(oid string) bool {
+	return regexOID.MatchString(oid)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Download returns the content of an lfs object.
+func (c *Controller) Download(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+) (*types.LFSObject, io.ReadCloser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !isValidOID(oid) {
+		return nil, nil, usererror.BadRequest("Invalid LFS object ID.")
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, usererror.NotFound("LFS object not found.")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	file, err := c.blobStore.Download(ctx, GetBlobPath(repo.ID, oid))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download lfs object from blobstore: %w", err)
+	}
+
+	return obj, file, nil
+}
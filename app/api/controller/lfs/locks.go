@@ -0,0 +1,305 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	defaultLockLimit = 100
+	maxLockLimit     = 100
+)
+
+type CreateLockInput struct {
+	Path string     `json:"path"`
+	Ref  *Reference `json:"ref,omitempty"`
+}
+
+type VerifyLocksInput struct {
+	Cursor string     `json:"cursor,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+	Ref    *Reference `json:"ref,omitempty"`
+}
+
+type UnlockInput struct {
+	Force bool       `json:"force,omitempty"`
+	Ref   *Reference `json:"ref,omitempty"`
+}
+
+// ListLocksInput contains the query parameters of the lfs list locks api.
+type ListLocksInput struct {
+	Path   string
+	ID     string
+	Cursor string
+	Limit  int
+}
+
+// CreateLock locks a file of the repository.
+func (c *Controller) CreateLock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateLockInput,
+) (*Lock, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	lockPath, err := sanitizeLockPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &types.LFSLock{
+		RepoID:    repo.ID,
+		Path:      lockPath,
+		Ref:       refName(in.Ref),
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+		Owner:     *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.lfsLockStore.Create(ctx, lock)
+	if errors.Is(err, store.ErrDuplicate) {
+		existing, errFind := c.lfsLockStore.FindByPath(ctx, repo.ID, lockPath)
+		if errFind != nil {
+			return nil, fmt.Errorf("failed to find existing lfs lock: %w", errFind)
+		}
+
+		return nil, &LockConflictError{Lock: mapLock(existing)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lfs lock: %w", err)
+	}
+
+	out := mapLock(lock)
+
+	return &out, nil
+}
+
+// ListLocks lists the locks of the repository.
+func (c *Controller) ListLocks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ListLocksInput,
+) (*LockList, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	filter := &types.LFSLockFilter{
+		Limit: sanitizeLockLimit(in.Limit),
+	}
+
+	if in.Path != "" {
+		filter.Path, err = sanitizeLockPath(in.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if in.ID != "" {
+		filter.ID, err = parseLockID(in.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if in.Cursor != "" {
+		filter.AfterID, err = parseLockID(in.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	out := &LockList{
+		Locks:      make([]Lock, len(locks)),
+		NextCursor: nextCursor(locks, filter.Limit),
+	}
+	for i := range locks {
+		out.Locks[i] = mapLock(locks[i])
+	}
+
+	return out, nil
+}
+
+// VerifyLocks lists the locks of the repository, split into the locks owned by the caller and all others.
+func (c *Controller) VerifyLocks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *VerifyLocksInput,
+) (*LockVerifyList, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	filter := &types.LFSLockFilter{
+		Limit: sanitizeLockLimit(in.Limit),
+	}
+
+	if in.Cursor != "" {
+		filter.AfterID, err = parseLockID(in.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	out := &LockVerifyList{
+		Ours:       []Lock{},
+		Theirs:     []Lock{},
+		NextCursor: nextCursor(locks, filter.Limit),
+	}
+	for _, lock := range locks {
+		if lock.CreatedBy == session.Principal.ID {
+			out.Ours = append(out.Ours, mapLock(lock))
+		} else {
+			out.Theirs = append(out.Theirs, mapLock(lock))
+		}
+	}
+
+	return out, nil
+}
+
+// Unlock removes a lock of the repository.
+// Locks owned by other users can only be removed with force and repo edit permission.
+func (c *Controller) Unlock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	lockID string,
+	in *UnlockInput,
+) (*Lock, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	id, err := parseLockID(lockID)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, repo.ID, id)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("LFS lock not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs lock: %w", err)
+	}
+
+	if lock.CreatedBy != session.Principal.ID {
+		if !in.Force {
+			return nil, usererror.Forbidden("The lock is owned by another user.")
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+	}
+
+	err = c.lfsLockStore.Delete(ctx, lock.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete lfs lock: %w", err)
+	}
+
+	out := mapLock(lock)
+
+	return &out, nil
+}
+
+func sanitizeLockPath(p string) (string, error) {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return "", usererror.BadRequest("Path is required.")
+	}
+
+	p = path.Clean(strings.TrimPrefix(p, "/"))
+	if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return "", usererror.BadRequest("Invalid path.")
+	}
+
+	return p, nil
+}
+
+func sanitizeLockLimit(limit int) int {
+	if limit <= 0 {
+		return defaultLockLimit
+	}
+	if limit > maxLockLimit {
+		return maxLockLimit
+	}
+	return limit
+}
+
+func parseLockID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usererror.BadRequestf("Invalid lock id '%s'.", s)
+	}
+	return id, nil
+}
+
+func refName(ref *Reference) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.Name
+}
+
+// nextCursor returns the cursor of the next page (empty if the provided page is the last one).
+func nextCursor(locks []*types.LFSLock, limit int) string {
+	if len(locks) < limit {
+		return ""
+	}
+	return strconv.FormatInt(locks[len(locks)-1].ID, 10)
+}
+
+func mapLock(lock *types.LFSLock) Lock {
+	return Lock{
+		ID:       strconv.FormatInt(lock.ID, 10),
+		Path:     lock.Path,
+		LockedAt: time.UnixMilli(lock.Created).UTC(),
+		Owner:    &LockOwner{Name: lock.Owner.DisplayName},
+	}
+}
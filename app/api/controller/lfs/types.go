@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	OperationDownload = "download"
+	OperationUpload   = "upload"
+
+	TransferBasic  = "basic"
+	HashAlgoSHA256 = "sha256"
+
+	actionDownload = "download"
+	actionUpload   = "upload"
+	actionVerify   = "verify"
+)
+
+// Pointer identifies an lfs object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// Reference is the git reference an lfs request is made for.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// BatchRequest is the request of the lfs batch api.
+type BatchRequest struct {
+	Operation string     `json:"operation"`
+	Transfers []string   `json:"transfers,omitempty"`
+	Ref       *Reference `json:"ref,omitempty"`
+	Objects   []Pointer  `json:"objects"`
+	HashAlgo  string     `json:"hash_algo,omitempty"`
+}
+
+// BatchResponse is the response of the lfs batch api.
+type BatchResponse struct {
+	Transfer string           `json:"transfer,omitempty"`
+	Objects  []ObjectResponse `json:"objects"`
+	HashAlgo string           `json:"hash_algo,omitempty"`
+}
+
+// ObjectResponse contains the actions available for a single lfs object.
+type ObjectResponse struct {
+	Pointer
+	Authenticated bool              `json:"authenticated,omitempty"`
+	Actions       map[string]Action `json:"actions,omitempty"`
+	Error         *ObjectError      `json:"error,omitempty"`
+}
+
+// Action describes how the client can execute an operation on an lfs object.
+type Action struct {
+	Href      string            `json:"href"`
+	Header    map[string]string `json:"header,omitempty"`
+	ExpiresIn int64             `json:"expires_in,omitempty"`
+}
+
+// ObjectError describes why an operation isn't possible for a single lfs object.
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// LockOwner is the owner of an lfs lock.
+type LockOwner struct {
+	Name string `json:"name"`
+}
+
+// Lock is the lfs api representation of a lock.
+type Lock struct {
+	ID       string     `json:"id"`
+	Path     string     `json:"path"`
+	LockedAt time.Time  `json:"locked_at"`
+	Owner    *LockOwner `json:"owner,omitempty"`
+}
+
+// LockList is the response of the lfs list locks api.
+type LockList struct {
+	Locks      []Lock `json:"locks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LockVerifyList is the response of the lfs verify locks api.
+type LockVerifyList struct {
+	Ours       []Lock `json:"ours"`
+	Theirs     []Lock `json:"theirs"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LockResponse is the response of the lfs create and delete lock apis.
+type LockResponse struct {
+	Lock    Lock   `json:"lock"`
+	Message string `json:"message,omitempty"`
+}
+
+// LockConflictError is returned in case a file is already locked.
+type LockConflictError struct {
+	Lock Lock
+}
+
+func (e *LockConflictError) Error() string {
+	return fmt.Sprintf("path %q is already locked", e.Lock.Path)
+}
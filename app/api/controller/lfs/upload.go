@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errObjectMismatch = errors.New("uploaded content doesn't match the lfs object")
+
+// Upload stores the content of an lfs object in the blob store.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pointer Pointer,
+	file io.Reader,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !isValidOID(pointer.OID) {
+		return usererror.BadRequest("Invalid LFS object ID.")
+	}
+
+	if pointer.Size < 0 {
+		return usererror.BadRequest("Invalid LFS object size.")
+	}
+
+	if file == nil {
+		return usererror.BadRequest("No file provided.")
+	}
+
+	_, err = c.lfsObjectStore.Find(ctx, repo.ID, pointer.OID)
+	if err == nil {
+		// object already exists - consume the body and report success.
+		_, _ = io.Copy(io.Discard, file)
+		return nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	if err = c.checkQuota(ctx, repo.ID, pointer.Size); err != nil {
+		return err
+	}
+
+	reader := &verifyingReader{
+		reader: io.LimitReader(file, pointer.Size+1),
+		hash:   sha256.New(),
+		oid:    pointer.OID,
+		size:   pointer.Size,
+	}
+
+	err = c.blobStore.Upload(ctx, reader, GetBlobPath(repo.ID, pointer.OID))
+	if errors.Is(err, errObjectMismatch) {
+		return usererror.UnprocessableEntityf("The uploaded content doesn't match the object %s.", pointer.OID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload lfs object: %w", err)
+	}
+
+	err = c.lfsObjectStore.Create(ctx, &types.LFSObject{
+		OID:       pointer.OID,
+		Size:      pointer.Size,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+		RepoID:    repo.ID,
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		log.Ctx(ctx).Debug().Msgf("lfs object %s was uploaded concurrently", pointer.OID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create lfs object: %w", err)
+	}
+
+	return nil
+}
+
+// verifyingReader returns an error at the end of the stream in case
+// the content doesn't match the expected size and sha256 of the lfs object.
+type verifyingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	oid    string
+	size   int64
+	read   int64
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	_, _ = r.hash.Write(p[:n])
+
+	if r.read > r.size {
+		return n, errObjectMismatch
+	}
+
+	if errors.Is(err, io.EOF) &&
+		(r.read != r.size || hex.EncodeToString(r.hash.Sum(nil)) != r.oid) {
+		return n, errObjectMismatch
+	}
+
+	return n, err
+}
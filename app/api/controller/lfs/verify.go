@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Verify verifies that an lfs object was successfully uploaded.
+func (c *Controller) Verify(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pointer Pointer,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !isValidOID(pointer.OID) {
+		return usererror.BadRequest("Invalid LFS object ID.")
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repo.ID, pointer.OID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.NotFound("LFS object not found.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	if obj.Size != pointer.Size {
+		return usererror.UnprocessableEntityf("LFS object size mismatch (expected %d, got %d).",
+			obj.Size, pointer.Size)
+	}
+
+	return nil
+}
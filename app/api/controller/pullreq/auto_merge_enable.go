@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AutoMergeEnableInput struct {
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+}
+
+func (in *AutoMergeEnableInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables auto-merge for a pull request. The pull request gets merged with the requested
+// merge method on behalf of the current user as soon as it satisfies all protection rules of the target branch.
+// Auto-merge gets canceled if another user pushes new commits to the source branch.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeEnableInput,
+) (*types.AutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	_, err = c.mergeQueue.Find(ctx, pr)
+	if err == nil {
+		return nil, usererror.BadRequest("Pull request is already in the merge queue.")
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to check merge queue: %w", err)
+	}
+
+	return c.pullreqService.EnableAutoMerge(ctx, &session.Principal, pr, in.Method)
+}
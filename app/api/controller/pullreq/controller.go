@@ -19,14 +19,22 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/merger"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -61,6 +69,13 @@ type Controller struct {
 	protectionManager   *protection.Manager
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
+	userGroupResolver   usergroup.Resolver
+	auditService        *audit.Service
+	repoReporter        *repoevents.Reporter
+	mergeQueue          *mergequeue.Service
+	merger              *merger.Service
+	labelService        *label.Service
+	signatureVerifier   *gitsignature.Verifier
 }
 
 func NewController(
@@ -85,6 +100,13 @@ func NewController(
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
+	userGroupResolver usergroup.Resolver,
+	auditService *audit.Service,
+	repoReporter *repoevents.Reporter,
+	mergeQueue *mergequeue.Service,
+	merger *merger.Service,
+	labelService *label.Service,
+	signatureVerifier *gitsignature.Verifier,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -108,6 +130,13 @@ func NewController(
 		protectionManager:   protectionManager,
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
+		userGroupResolver:   userGroupResolver,
+		auditService:        auditService,
+		repoReporter:        repoReporter,
+		mergeQueue:          mergeQueue,
+		merger:              merger,
+		labelService:        labelService,
+		signatureVerifier:   signatureVerifier,
 	}
 }
 
@@ -137,6 +166,32 @@ func (c *Controller) verifyBranchExistence(ctx context.Context,
 	return ref.SHA, nil
 }
 
+// fetchSourceCommits makes the commits of a pull request from a fork available in the target repository.
+// All git operations of a pull request (diff, merge, ...) are executed in the target repository.
+func (c *Controller) fetchSourceCommits(ctx context.Context,
+	session *auth.Session, sourceRepo, targetRepo *types.Repository, sourceSHA string,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceRepo.GitUID,
+		ObjectSHAs:  []string{sourceSHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commits into the target repository: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	session *auth.Session, repoRef string, reqPermission enum.Permission,
 ) (*types.Repository, error) {
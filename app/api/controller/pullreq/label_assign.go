@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LabelAssignInput struct {
+	LabelID int64 `json:"label_id"`
+}
+
+func (in *LabelAssignInput) sanitize() error {
+	if in.LabelID <= 0 {
+		return usererror.BadRequest("A valid label ID must be provided.")
+	}
+
+	return nil
+}
+
+// LabelAssign assigns a label to a pull request.
+// Assigning a scoped label replaces the label with the same key that is already assigned to the pull request.
+func (c *Controller) LabelAssign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *LabelAssignInput,
+) (*types.Label, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	result, err := c.labelService.AssignToPullReq(ctx, session.Principal.ID, pr, repo, in.LabelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Assigned {
+		return result.Label, nil
+	}
+
+	payload := &types.PullRequestActivityPayloadLabel{
+		Type:  enum.PullReqLabelActivityTypeAssign,
+		Label: result.Label.Name(),
+		Color: result.Label.Color,
+	}
+	if result.Replaced != nil {
+		payload.Type = enum.PullReqLabelActivityTypeReassign
+		payload.OldLabel = result.Replaced.Name()
+		payload.OldColor = result.Replaced.Color
+	}
+
+	c.writeLabelActivity(ctx, session, pr, payload)
+
+	c.eventReporter.LabelAssigned(ctx, &events.LabelAssignedPayload{
+		Base:    eventBase(pr, &session.Principal),
+		LabelID: result.Label.ID,
+		Key:     result.Label.Key,
+		Value:   result.Label.Value,
+	})
+
+	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return result.Label, nil
+}
+
+func (c *Controller) writeLabelActivity(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	payload *types.PullRequestActivityPayloadLabel,
+) {
+	pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update pull request activity sequence after label change")
+		return
+	}
+
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after label change")
+	}
+}
@@ -17,20 +17,24 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/app/bootstrap"
-	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/merger"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -41,7 +45,13 @@ type MergeInput struct {
 	Method      enum.MergeMethod `json:"method"`
 	SourceSHA   string           `json:"source_sha"`
 	BypassRules bool             `json:"bypass_rules"`
-	DryRun      bool             `json:"dry_run"`
+	// BypassReason explains why protection rules are bypassed. It's required if the merge bypasses any rule.
+	BypassReason string `json:"bypass_reason"`
+	DryRun       bool   `json:"dry_run"`
+	// Title and Message override the title and the message of the merge or squash commit.
+	// If not provided, they are generated from the repository merge templates.
+	Title   string `json:"title"`
+	Message string `json:"message"`
 }
 
 func (in *MergeInput) sanitize() error {
@@ -62,6 +72,15 @@ func (in *MergeInput) sanitize() error {
 		in.Method = method
 	}
 
+	in.BypassReason = strings.TrimSpace(in.BypassReason)
+
+	in.Title = strings.TrimSpace(in.Title)
+	if strings.ContainsAny(in.Title, "\r\n") {
+		return usererror.BadRequest("merge commit title must be a single line")
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+
 	return nil
 }
 
@@ -75,6 +94,9 @@ func (in *MergeInput) sanitize() error {
 //
 // If the pull request has been successfully merged the function will return the SHA of the merge commit.
 //
+// If a protection rule requires the merge queue for the target branch, the pull request is added to the queue
+// and the function returns its position in the queue. The merge queue merges it asynchronously.
+//
 //nolint:gocognit,gocyclo,cyclop
 func (c *Controller) Merge(
 	ctx context.Context,
@@ -139,6 +161,16 @@ func (c *Controller) Merge(
 		)
 	}
 
+	if !in.DryRun {
+		_, err = c.mergeQueue.Find(ctx, pr)
+		if err == nil {
+			return nil, nil, usererror.BadRequest("Pull request is already in the merge queue.")
+		}
+		if !errors.Is(err, store.ErrResourceNotFound) {
+			return nil, nil, fmt.Errorf("failed to check merge queue: %w", err)
+		}
+	}
+
 	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
@@ -152,14 +184,14 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
@@ -184,6 +216,11 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
 	}
 
+	labels, err := c.labelService.ListPullReqLabels(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
 		Actor:        &session.Principal,
 		AllowBypass:  in.BypassRules,
@@ -195,6 +232,9 @@ func (c *Controller) Merge(
 		Method:       in.Method,
 		CheckResults: checkResults,
 		CodeOwners:   codeOwnerWithApproval,
+		Labels:       labels,
+
+		UnverifiedCommits: c.signatureVerifier.PullReqUnverifiedCommitsFn(targetRepo, pr),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -264,7 +304,7 @@ func (c *Controller) Merge(
 		out := &types.MergeResponse{
 			DryRun:         true,
 			BranchDeleted:  ruleOut.DeleteSourceBranch,
-			AllowedMethods: ruleOut.AllowedMethods,
+			AllowedMethods: possibleMethods(pr, ruleOut.AllowedMethods),
 			ConflictFiles:  pr.MergeConflicts,
 			RuleViolations: violations,
 		}
@@ -276,181 +316,138 @@ func (c *Controller) Merge(
 		return nil, &types.MergeViolations{RuleViolations: violations}, nil
 	}
 
-	// commit details: author, committer and message
-
-	var author *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		author = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodSquash:
-		author = identityFromPrincipalInfo(pr.Author)
-	case enum.MergeMethodRebase:
-		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
+	bypassedRules := protection.BypassedRules(violations)
+	if len(bypassedRules) > 0 && in.BypassReason == "" {
+		return nil, nil, usererror.ErrBypassReasonRequired
 	}
 
-	var committer *git.Identity
+	if ruleOut.UseMergeQueue {
+		if len(bypassedRules) > 0 {
+			return nil, nil, usererror.BadRequest(
+				"Protection rules can't be bypassed for pull requests that are merged through the merge queue.")
+		}
 
-	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
-	case enum.MergeMethodRebase:
-		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+		return c.enqueue(ctx, session, targetRepo, sourceRepo, pr, in, ruleOut, violations)
 	}
 
-	var mergeTitle string
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		mergeTitle = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-	case enum.MergeMethodSquash:
-		mergeTitle = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-	case enum.MergeMethodRebase:
-		mergeTitle = "" // Not used.
+	deleteSourceBranch := ruleOut.DeleteSourceBranch
+	if deleteSourceBranch && sourceRepo.ID != targetRepo.ID {
+		// the source branch is in a fork - only delete it if the user is allowed to push to the fork.
+		errAuth := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false)
+		deleteSourceBranch = errAuth == nil
 	}
 
-	// create merge commit(s)
-
 	log.Ctx(ctx).Debug().Msgf("all pre-check passed, merge PR")
 
-	now := time.Now()
-	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
-		WriteParams:     targetWriteParams,
-		BaseBranch:      pr.TargetBranch,
-		HeadRepoUID:     sourceRepo.GitUID,
-		HeadBranch:      pr.SourceBranch,
-		Title:           mergeTitle,
-		Message:         "",
-		Committer:       committer,
-		CommitterDate:   &now,
-		Author:          author,
-		AuthorDate:      &now,
-		RefType:         gitenum.RefTypeBranch,
-		RefName:         pr.TargetBranch,
-		HeadExpectedSHA: in.SourceSHA,
-		Method:          gitenum.MergeMethod(in.Method),
+	mergeOut, err := c.merger.Merge(ctx, &merger.MergeInput{
+		Actor:              &session.Principal,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Method:             in.Method,
+		Title:              in.Title,
+		Message:            in.Message,
+		TargetWriteParams:  targetWriteParams,
+		SourceWriteParams:  sourceWriteParams,
+		DeleteSourceBranch: deleteSourceBranch,
+		RulesBypassed:      bypassedRules,
+		BypassReason:       in.BypassReason,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("merge check execution failed: %w", err)
+		return nil, nil, err
 	}
-	//nolint:nestif
-	if mergeOutput.MergeSHA == "" || len(mergeOutput.ConflictFiles) > 0 {
-		_, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-			if pr.SourceSHA != mergeOutput.HeadSHA {
-				return errors.New("source SHA has changed")
-			}
-
-			// update all Merge specific information
-			pr.MergeCheckStatus = enum.MergeCheckStatusConflict
-			pr.MergeBaseSHA = mergeOutput.MergeBaseSHA
-			pr.MergeTargetSHA = &mergeOutput.BaseSHA
-			pr.MergeSHA = nil
-			pr.MergeConflicts = mergeOutput.ConflictFiles
-			pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
-			return nil
-		})
-		if err != nil {
-			// non-critical error
-			log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request with conflict files")
-		} else {
-			if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
-				log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
-			}
-		}
 
+	if len(mergeOut.ConflictFiles) > 0 || mergeOut.MergeSHA == "" {
 		return nil, &types.MergeViolations{
-			ConflictFiles:  mergeOutput.ConflictFiles,
+			ConflictFiles:  mergeOut.ConflictFiles,
 			RuleViolations: violations,
 		}, nil
 	}
 
-	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
-
-	var activitySeqMerge, activitySeqBranchDeleted int64
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-		pr.State = enum.PullReqStateMerged
-
-		nowMilli := now.UnixMilli()
-		pr.Merged = &nowMilli
-		pr.MergedBy = &session.Principal.ID
-		pr.MergeMethod = &in.Method
+	pr = mergeOut.PullReq
 
-		// update all Merge specific information (might be empty if previous merge check failed)
-		// since this is the final operation on the PR, we update any sha that might've changed by now.
-		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
-		pr.SourceSHA = mergeOutput.HeadSHA
-		pr.MergeTargetSHA = &mergeOutput.BaseSHA
-		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA
-		pr.MergeSHA = &mergeOutput.MergeSHA
-		pr.MergeConflicts = nil
-		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
-
-		// update sequence for PR activities
-		pr.ActivitySeq++
-		activitySeqMerge = pr.ActivitySeq
+	if len(bypassedRules) > 0 {
+		c.repoReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			RepoID:      targetRepo.ID,
+			PrincipalID: session.Principal.ID,
+			PullReqID:   pr.ID,
+			Refs:        []string{pr.TargetBranch},
+			Rules:       bypassedRules,
+			Reason:      in.BypassReason,
+		})
 
-		if ruleOut.DeleteSourceBranch {
-			pr.ActivitySeq++
-			activitySeqBranchDeleted = pr.ActivitySeq
+		err = c.auditService.Log(ctx,
+			&session.Principal,
+			audit.NewRepoResource(enum.AuditResourceTypePullRequest, strconv.FormatInt(pr.Number, 10), targetRepo),
+			enum.AuditActionBypassed,
+			audit.WithData("rules", strings.Join(protection.RuleIdentifiers(bypassedRules), ",")),
+			audit.WithData("reason", in.BypassReason),
+			audit.WithData("merge_sha", mergeOut.MergeSHA),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for merge pull request operation")
 		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update pull request: %w", err)
 	}
 
-	pr.ActivitySeq = activitySeqMerge
-	activityPayload := &types.PullRequestActivityPayloadMerge{
-		MergeMethod: in.Method,
-		MergeSHA:    mergeOutput.MergeSHA,
-		TargetSHA:   mergeOutput.BaseSHA,
-		SourceSHA:   mergeOutput.HeadSHA,
-	}
-	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, activityPayload); errAct != nil {
-		// non-critical error
-		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
-	}
+	return &types.MergeResponse{
+		SHA:            mergeOut.MergeSHA,
+		BranchDeleted:  mergeOut.BranchDeleted,
+		RuleViolations: violations,
+	}, nil, nil
+}
 
-	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
-		Base:        eventBase(pr, &session.Principal),
-		MergeMethod: in.Method,
-		MergeSHA:    mergeOutput.MergeSHA,
-		TargetSHA:   mergeOutput.BaseSHA,
-		SourceSHA:   mergeOutput.HeadSHA,
-	})
+// enqueue adds the pull request to the merge queue of the target branch instead of merging it.
+// The merge queue merges the pull request once the required status checks of its merge commit succeed.
+func (c *Controller) enqueue(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	in *MergeInput,
+	ruleOut protection.MergeVerifyOutput,
+	violations []types.RuleViolations,
+) (*types.MergeResponse, *types.MergeViolations, error) {
+	if pr.MergeCheckStatus == enum.MergeCheckStatusConflict {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  pr.MergeConflicts,
+			RuleViolations: violations,
+		}, nil
+	}
 
-	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
-		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
-			WriteParams: sourceWriteParams,
-			BranchName:  pr.SourceBranch,
-		})
-		if errDelete != nil {
-			// non-critical error
-			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
-		} else {
-			branchDeleted = true
-
-			// NOTE: there is a chance someone pushed on the branch between merge and delete.
-			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
-			pr.ActivitySeq = activitySeqBranchDeleted
-			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID,
-				&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}); errAct != nil {
-				// non-critical error
-				log.Ctx(ctx).Err(errAct).
-					Msgf("failed to write pull request activity for successful automatic branch delete")
-			}
-		}
+	deleteSourceBranch := ruleOut.DeleteSourceBranch
+	if deleteSourceBranch && sourceRepo.ID != targetRepo.ID {
+		// the source branch is in a fork - only delete it if the user is allowed to push to the fork.
+		errAuth := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false)
+		deleteSourceBranch = errAuth == nil
 	}
 
-	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
-		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	entry, err := c.mergeQueue.Enqueue(ctx, &session.Principal, targetRepo, pr,
+		in.Method, in.Title, in.Message, deleteSourceBranch)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return &types.MergeResponse{
-		SHA:            mergeOutput.MergeSHA,
-		BranchDeleted:  branchDeleted,
+		Queued:         true,
+		QueuePosition:  entry.Position,
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// possibleMethods returns the allowed merge methods that can currently be used to merge the pull request.
+// The fast-forward merge is possible only if the source branch contains the latest commit of the target branch.
+func possibleMethods(pr *types.PullReq, allowedMethods []enum.MergeMethod) []enum.MergeMethod {
+	if pr.MergeTargetSHA == nil || *pr.MergeTargetSHA == pr.MergeBaseSHA {
+		return allowedMethods
+	}
+
+	methods := make([]enum.MergeMethod, 0, len(allowedMethods))
+	for _, method := range allowedMethods {
+		if method != enum.MergeMethodFastForward {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
@@ -53,19 +53,29 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("pull request title can't be empty")
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	// pull requests from another repository (fork) require push access to the source repository only.
+	targetPermission := enum.PermissionRepoPush
+	if in.SourceRepoRef != "" {
+		targetPermission = enum.PermissionRepoView
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, targetPermission)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access access to target repo: %w", err)
 	}
 
 	sourceRepo := targetRepo
 	if in.SourceRepoRef != "" {
-		sourceRepo, err = c.getRepoCheckAccess(ctx, session, in.SourceRepoRef, enum.PermissionRepoView)
+		sourceRepo, err = c.getRepoCheckAccess(ctx, session, in.SourceRepoRef, enum.PermissionRepoPush)
 		if err != nil {
 			return nil, fmt.Errorf("failed to acquire access access to source repo: %w", err)
 		}
 	}
 
+	if sourceRepo.ID != targetRepo.ID && !targetRepo.IsForkRelative(sourceRepo) {
+		return nil, usererror.BadRequest("The source repository must be a fork of the target repository or vice versa")
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -84,9 +94,13 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.fetchSourceCommits(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+		return nil, err
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA,
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
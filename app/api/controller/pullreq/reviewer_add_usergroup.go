@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ReviewerAddUserGroupInput struct {
+	// UserGroup is the scoped identifier of the user group, e.g. "acme/backend/developers".
+	UserGroup string `json:"user_group"`
+}
+
+// ReviewerAddUserGroup adds all members of a user group as reviewers of the pull request.
+// The user group has to belong to the space of the repository or to one of its ancestors.
+// The author of the pull request, existing reviewers and members without access to the repository are skipped.
+func (c *Controller) ReviewerAddUserGroup(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *ReviewerAddUserGroupInput,
+) ([]*types.PullReqReviewer, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	in.UserGroup = strings.Trim(strings.TrimSpace(in.UserGroup), "@")
+	if in.UserGroup == "" {
+		return nil, usererror.BadRequest("Must specify user group.")
+	}
+
+	spacePath, _, err := paths.DisectLeaf(in.UserGroup)
+	if err != nil || spacePath == "" || !paths.IsAncesterOf(spacePath, repo.Path) {
+		return nil, usererror.BadRequest(
+			"The user group has to belong to the space of the repository or one of its parent spaces.")
+	}
+
+	userGroup, err := c.userGroupResolver.Resolve(ctx, in.UserGroup)
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return nil, usererror.BadRequestf("User group '%s' not found.", in.UserGroup)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user group: %w", err)
+	}
+
+	addedByInfo := session.Principal.ToPrincipalInfo()
+
+	candidates := make([]*types.Principal, 0, len(userGroup.Users))
+	for _, uid := range userGroup.Users {
+		principal, err := c.principalStore.FindByUID(ctx, uid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user group member '%s': %w", uid, err)
+		}
+
+		if principal.ID == pr.CreatedBy {
+			continue
+		}
+
+		if err = apiauth.CheckRepoForPrincipal(ctx, c.authorizer, principal, repo,
+			enum.PermissionRepoView); err != nil {
+			log.Ctx(ctx).Info().Msgf("Skipping user group member %s without access to the repo: %s",
+				principal.UID, err)
+			continue
+		}
+
+		candidates = append(candidates, principal)
+	}
+
+	var added []*types.PullReqReviewer
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		added = make([]*types.PullReqReviewer, 0, len(candidates))
+
+		for _, principal := range candidates {
+			_, err := c.reviewerStore.Find(ctx, pr.ID, principal.ID)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, store.ErrResourceNotFound) {
+				return err
+			}
+
+			var reviewerType enum.PullReqReviewerType
+			switch session.Principal.ID {
+			case pr.CreatedBy:
+				reviewerType = enum.PullReqReviewerTypeRequested
+			case principal.ID:
+				reviewerType = enum.PullReqReviewerTypeSelfAssigned
+			default:
+				reviewerType = enum.PullReqReviewerTypeAssigned
+			}
+
+			reviewer := newPullReqReviewer(session, pr, repo, principal.ToPrincipalInfo(), addedByInfo,
+				reviewerType, &ReviewerAddInput{ReviewerID: principal.ID})
+
+			if err = c.reviewerStore.Create(ctx, reviewer); err != nil {
+				return err
+			}
+
+			added = append(added, reviewer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request reviewers: %w", err)
+	}
+
+	for _, reviewer := range added {
+		c.reportReviewerAddition(ctx, session, pr, reviewer)
+	}
+
+	return added, nil
+}
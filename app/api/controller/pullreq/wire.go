@@ -17,10 +17,17 @@ package pullreq
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/merger"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -46,7 +53,10 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service,
+	codeOwners *codeowners.Service, userGroupResolver usergroup.Resolver,
+	auditService *audit.Service, repoReporter *repoevents.Reporter,
+	mergeQueue *mergequeue.Service, merger *merger.Service,
+	labelService *label.Service, signatureVerifier *gitsignature.Verifier,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
@@ -57,5 +67,7 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		checkStore,
 		rpcClient, eventReporter,
 		mtxManager, codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners)
+		pullreqService, ruleManager, sseStreamer, codeOwners, userGroupResolver,
+		auditService, repoReporter, mergeQueue, merger,
+		labelService, signatureVerifier)
 }
@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// archiveBlobPathFmt is the path of a cached tag archive in the blob store.
+	archiveBlobPathFmt = "archives/%d/%s/%s.%s"
+)
+
+type ArchiveInput struct {
+	// GitRef is the branch, tag or commit that's archived.
+	GitRef string
+	Format gitenum.ArchiveFormat
+	// Paths restricts the archive to the files under the provided paths (optional).
+	Paths []string
+}
+
+type ArchiveOutput struct {
+	// Name is the file name of the archive (including the extension).
+	Name    string
+	Content io.ReadCloser
+}
+
+// Archive returns an archive (zip, tar.gz) of the repository at the provided git ref.
+// Archives of tags (without path filtering) are cached in the blob store.
+func (c *Controller) Archive(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ArchiveInput,
+) (*ArchiveOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeArchiveInput(ctx, repo, in); err != nil {
+		return nil, err
+	}
+
+	baseName := repo.Identifier + "-" + strings.ReplaceAll(in.GitRef, "/", "-")
+	params := &git.ArchiveParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitRef:     in.GitRef,
+		Format:     in.Format,
+		Prefix:     baseName + "/",
+		Paths:      in.Paths,
+	}
+	out := &ArchiveOutput{
+		Name: baseName + "." + string(in.Format),
+	}
+
+	if len(in.Paths) == 0 {
+		cachePath, err := c.getArchiveCachePath(ctx, repo, in.GitRef, baseName, in.Format)
+		if err != nil {
+			return nil, err
+		}
+
+		if cachePath != "" {
+			// use the full reference to avoid any ambiguity with branches of the same name.
+			params.GitRef, err = git.GetRefPath(in.GitRef, gitenum.RefTypeTag)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tag reference: %w", err)
+			}
+
+			out.Content, err = c.getCachedArchive(ctx, params, cachePath)
+			if err != nil {
+				return nil, err
+			}
+
+			return out, nil
+		}
+	}
+
+	out.Content = c.streamArchive(ctx, params)
+
+	return out, nil
+}
+
+func (c *Controller) sanitizeArchiveInput(
+	ctx context.Context,
+	repo *types.Repository,
+	in *ArchiveInput,
+) error {
+	if in.GitRef == "" {
+		return usererror.BadRequest("A git reference must be provided.")
+	}
+
+	if !in.Format.IsValid() {
+		return usererror.BadRequestf("Archive format '%s' is not supported.", in.Format)
+	}
+
+	paths := make([]string, 0, len(in.Paths))
+	for _, p := range in.Paths {
+		p = strings.Trim(strings.TrimSpace(p), "/")
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	in.Paths = paths
+
+	// verify that the ref and all paths exist to fail before any data is streamed.
+	readParams := git.CreateReadParams(repo)
+	if len(in.Paths) == 0 {
+		in.Paths = nil
+		_, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     in.GitRef,
+			Path:       "",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read tree of '%s': %w", in.GitRef, err)
+		}
+		return nil
+	}
+
+	for _, p := range in.Paths {
+		_, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     in.GitRef,
+			Path:       p,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read tree node '%s' of '%s': %w", p, in.GitRef, err)
+		}
+	}
+
+	return nil
+}
+
+// getArchiveCachePath returns the path of the cached archive in the blob store
+// in case the git ref is a tag, otherwise an empty string is returned.
+func (c *Controller) getArchiveCachePath(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	baseName string,
+	format gitenum.ArchiveFormat,
+) (string, error) {
+	tagRef, err := c.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.CreateReadParams(repo),
+		Name:       gitRef,
+		Type:       gitenum.RefTypeTag,
+	})
+	if gittypes.IsNotFoundError(err) || gitnesserrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get tag '%s': %w", gitRef, err)
+	}
+
+	// the sha of the tag is part of the path to ensure an updated tag doesn't return a stale archive.
+	return fmt.Sprintf(archiveBlobPathFmt, repo.ID, tagRef.SHA, baseName, format), nil
+}
+
+// getCachedArchive returns the archive from the blob store, and generates it first in case it doesn't exist yet.
+// In case the archive can't be cached, it's streamed directly.
+func (c *Controller) getCachedArchive(
+	ctx context.Context,
+	params *git.ArchiveParams,
+	cachePath string,
+) (io.ReadCloser, error) {
+	file, err := c.blobStore.Download(ctx, cachePath)
+	if err == nil {
+		return file, nil
+	}
+	if !errors.Is(err, blob.ErrNotFound) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to download cached archive '%s'", cachePath)
+		return c.streamArchive(ctx, params), nil
+	}
+
+	err = c.blobStore.Upload(ctx, c.streamArchive(ctx, params), cachePath)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to cache archive '%s'", cachePath)
+		return c.streamArchive(ctx, params), nil
+	}
+
+	file, err = c.blobStore.Download(ctx, cachePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download cached archive: %w", err)
+	}
+
+	return file, nil
+}
+
+// streamArchive returns a reader that streams the archive while it's being generated.
+// Closing the reader stops the generation of the archive.
+func (c *Controller) streamArchive(ctx context.Context, params *git.ArchiveParams) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		err := c.git.Archive(ctx, params, pw)
+		if err != nil {
+			err = fmt.Errorf("failed to generate archive: %w", err)
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	return pr
+}
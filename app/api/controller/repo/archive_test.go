@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+)
+
+const archiveTestTagSHA = "1111111111111111111111111111111111111111"
+
+var archiveTestRepo = &types.Repository{
+	ID:         1,
+	Identifier: "repo",
+	Path:       "space/repo",
+	GitUID:     "abcdefghij",
+	IsPublic:   true,
+}
+
+type archiveRepoStore struct {
+	store.RepoStore
+}
+
+func (archiveRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	repo := *archiveTestRepo
+	return &repo, nil
+}
+
+// archiveGit is a fake git service that contains the files "docs" and "src" on all references,
+// and the tag "v1.0". The generated archives describe the requested archive.
+type archiveGit struct {
+	git.Interface
+	archiveCalls atomic.Int32
+}
+
+func (g *archiveGit) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	switch params.Path {
+	case "", "docs", "src":
+		return &git.GetTreeNodeOutput{}, nil
+	default:
+		return nil, gitnesserrors.NotFound("path '%s' wasn't found", params.Path)
+	}
+}
+
+func (g *archiveGit) GetRef(_ context.Context, params git.GetRefParams) (git.GetRefResponse, error) {
+	if params.Type == gitenum.RefTypeTag && params.Name == "v1.0" {
+		return git.GetRefResponse{SHA: archiveTestTagSHA}, nil
+	}
+
+	return git.GetRefResponse{}, gitnesserrors.NotFound("reference '%s' wasn't found", params.Name)
+}
+
+func (g *archiveGit) Archive(_ context.Context, params *git.ArchiveParams, w io.Writer) error {
+	g.archiveCalls.Add(1)
+	_, err := fmt.Fprintf(w, "ref=%s format=%s prefix=%s paths=%s",
+		params.GitRef, params.Format, params.Prefix, strings.Join(params.Paths, ","))
+	return err
+}
+
+type archiveBlobStore struct {
+	blob.Store
+	files     map[string][]byte
+	uploadErr error
+}
+
+func (s *archiveBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	if s.uploadErr != nil {
+		return s.uploadErr
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	s.files[filePath] = data
+
+	return nil
+}
+
+func (s *archiveBlobStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func TestController_Archive(t *testing.T) {
+	tagCachePath := "archives/1/" + archiveTestTagSHA + "/repo-v1.0.tar.gz"
+
+	tests := []struct {
+		name      string
+		in        *ArchiveInput
+		uploadErr error
+		// cached contains the archives that are in the blob store already.
+		cached map[string][]byte
+
+		expectedStatus      int
+		expectedErr         bool
+		expectedName        string
+		expectedContent     string
+		expectedArchiveCall bool
+		expectedCached      []string
+	}{
+		{
+			name:           "missing git ref",
+			in:             &ArchiveInput{Format: gitenum.ArchiveFormatZip},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported format",
+			in:             &ArchiveInput{GitRef: "main", Format: "rar"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:                "branch as zip",
+			in:                  &ArchiveInput{GitRef: "main", Format: gitenum.ArchiveFormatZip},
+			expectedName:        "repo-main.zip",
+			expectedContent:     "ref=main format=zip prefix=repo-main/ paths=",
+			expectedArchiveCall: true,
+		},
+		{
+			name:                "branch with slashes as tar.gz",
+			in:                  &ArchiveInput{GitRef: "feature/x", Format: gitenum.ArchiveFormatTarGz},
+			expectedName:        "repo-feature-x.tar.gz",
+			expectedContent:     "ref=feature/x format=tar.gz prefix=repo-feature-x/ paths=",
+			expectedArchiveCall: true,
+		},
+		{
+			name: "paths are sanitized",
+			in: &ArchiveInput{
+				GitRef: "main",
+				Format: gitenum.ArchiveFormatZip,
+				Paths:  []string{" /docs/ ", "", "/", "src"},
+			},
+			expectedName:        "repo-main.zip",
+			expectedContent:     "ref=main format=zip prefix=repo-main/ paths=docs,src",
+			expectedArchiveCall: true,
+		},
+		{
+			name: "missing path",
+			in: &ArchiveInput{
+				GitRef: "main",
+				Format: gitenum.ArchiveFormatZip,
+				Paths:  []string{"docs", "missing"},
+			},
+			expectedErr: true,
+		},
+		{
+			name:                "tag is cached",
+			in:                  &ArchiveInput{GitRef: "v1.0", Format: gitenum.ArchiveFormatTarGz},
+			expectedName:        "repo-v1.0.tar.gz",
+			expectedContent:     "ref=refs/tags/v1.0 format=tar.gz prefix=repo-v1.0/ paths=",
+			expectedArchiveCall: true,
+			expectedCached:      []string{tagCachePath},
+		},
+		{
+			name:            "tag from cache",
+			in:              &ArchiveInput{GitRef: "v1.0", Format: gitenum.ArchiveFormatTarGz},
+			cached:          map[string][]byte{tagCachePath: []byte("cached archive")},
+			expectedName:    "repo-v1.0.tar.gz",
+			expectedContent: "cached archive",
+			expectedCached:  []string{tagCachePath},
+		},
+		{
+			name:                "tag is streamed if it can't be cached",
+			in:                  &ArchiveInput{GitRef: "v1.0", Format: gitenum.ArchiveFormatZip},
+			uploadErr:           errors.New("upload failed"),
+			expectedName:        "repo-v1.0.zip",
+			expectedContent:     "ref=refs/tags/v1.0 format=zip prefix=repo-v1.0/ paths=",
+			expectedArchiveCall: true,
+		},
+		{
+			name: "tag with paths isn't cached",
+			in: &ArchiveInput{
+				GitRef: "v1.0",
+				Format: gitenum.ArchiveFormatZip,
+				Paths:  []string{"docs"},
+			},
+			expectedName:        "repo-v1.0.zip",
+			expectedContent:     "ref=v1.0 format=zip prefix=repo-v1.0/ paths=docs",
+			expectedArchiveCall: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitService := &archiveGit{}
+			blobStore := &archiveBlobStore{files: map[string][]byte{}, uploadErr: test.uploadErr}
+			for filePath, data := range test.cached {
+				blobStore.files[filePath] = data
+			}
+
+			c := &Controller{
+				repoStore: archiveRepoStore{},
+				git:       gitService,
+				blobStore: blobStore,
+			}
+
+			out, err := c.Archive(context.Background(), &auth.Session{}, archiveTestRepo.Path, test.in)
+
+			if test.expectedStatus != 0 {
+				var uErr *usererror.Error
+				if !errors.As(err, &uErr) || uErr.Status != test.expectedStatus {
+					t.Fatalf("expected user error with status %d, got %v", test.expectedStatus, err)
+				}
+				return
+			}
+
+			if test.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if gitService.archiveCalls.Load() != 0 {
+					t.Errorf("expected no archive to be generated")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			content, err := io.ReadAll(out.Content)
+			if err != nil {
+				t.Fatalf("failed to read archive: %v", err)
+			}
+			_ = out.Content.Close()
+
+			if out.Name != test.expectedName {
+				t.Errorf("expected archive name %q, got %q", test.expectedName, out.Name)
+			}
+
+			if string(content) != test.expectedContent {
+				t.Errorf("expected archive content %q, got %q", test.expectedContent, string(content))
+			}
+
+			if archived := gitService.archiveCalls.Load() > 0; archived != test.expectedArchiveCall {
+				t.Errorf("expected archive generation=%t, got %t", test.expectedArchiveCall, archived)
+			}
+
+			if len(blobStore.files) != len(test.expectedCached) {
+				t.Errorf("expected cached archives %v, got %d archives", test.expectedCached, len(blobStore.files))
+			}
+			for _, filePath := range test.expectedCached {
+				if _, ok := blobStore.files[filePath]; !ok {
+					t.Errorf("expected archive %q to be cached", filePath)
+				}
+			}
+		})
+	}
+}
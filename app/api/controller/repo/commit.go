@@ -15,9 +15,12 @@
 package repo
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
@@ -86,30 +89,6 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		branchName = in.Branch
 	}
 
-	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-		Actor:       &session.Principal,
-		AllowBypass: in.BypassRules,
-		IsRepoOwner: isRepoOwner,
-		Repo:        repo,
-		RefAction:   refAction,
-		RefType:     protection.RefTypeBranch,
-		RefNames:    []string{branchName},
-	})
-	if err != nil {
-		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
-	}
-
-	if in.DryRunRules {
-		return types.CommitFilesResponse{
-			DryRunRules:    true,
-			RuleViolations: violations,
-		}, nil, nil
-	}
-
-	if protection.IsCritical(violations) {
-		return types.CommitFilesResponse{}, violations, nil
-	}
-
 	actions := make([]git.CommitFileAction, len(in.Actions))
 	for i, action := range in.Actions {
 		var rawPayload []byte
@@ -134,8 +113,37 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		}
 	}
 
-	// Create internal write params. Note: This will skip the pre-commit protection rules check.
-	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+		NewCommits: func(context.Context, string) ([]protection.PushCommit, error) {
+			return []protection.PushCommit{newPushCommit(session, in, actions)}, nil
+		},
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return types.CommitFilesResponse{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return types.CommitFilesResponse{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the protection rules check in the pre-receive hook,
+	// the rules, including the push rules verifying the content of the new commit, are verified above.
+	// The new commit is still scanned for secrets by the pre-receive hook.
+	writeParams, err := controller.CreateRPCInternalCommitWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
@@ -162,3 +170,44 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// newPushCommit returns the details of the commit that's about to be created, used to verify push rules.
+func newPushCommit(
+	session *auth.Session,
+	in *CommitFilesOptions,
+	actions []git.CommitFileAction,
+) protection.PushCommit {
+	message := strings.TrimSpace(in.Title)
+	if in.Message != "" {
+		message += "\n\n" + strings.TrimSpace(in.Message)
+	}
+
+	files := make([]protection.PushCommitFile, 0, len(actions))
+	for _, action := range actions {
+		switch action.Action {
+		case git.CreateAction, git.UpdateAction:
+			files = append(files, protection.PushCommitFile{
+				Path: cleanCommitFilePath(action.Path),
+				Size: int64(len(action.Payload)),
+			})
+		case git.MoveAction:
+			// The payload of a move action is the new path, optionally followed by a NUL byte and the new content.
+			newPath, content, _ := bytes.Cut(action.Payload, []byte{0})
+			files = append(files, protection.PushCommitFile{
+				Path: cleanCommitFilePath(string(newPath)),
+				Size: int64(len(content)),
+			})
+		}
+	}
+
+	return protection.PushCommit{
+		AuthorEmail: session.Principal.Email,
+		Message:     message,
+		Files:       files,
+	}
+}
+
+// cleanCommitFilePath returns the file path relative to the repository root, the way git stores it.
+func cleanCommitFilePath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(filePath, "\\", "/")), "/")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -68,6 +69,10 @@ type FileContent struct {
 	Data     string                   `json:"data"`
 	Size     int64                    `json:"size"`
 	DataSize int64                    `json:"data_size"`
+
+	// LFSObjectID is set in case the file is an lfs pointer and the data contains the actual lfs object.
+	LFSObjectID   string `json:"lfs_object_id,omitempty"`
+	LFSObjectSize int64  `json:"lfs_object_size,omitempty"`
 }
 
 func (c *FileContent) isContent() {}
@@ -134,7 +139,7 @@ func (c *Controller) GetContent(ctx context.Context,
 	case ContentTypeDir:
 		content, err = c.getDirContent(ctx, readParams, gitRef, repoPath, includeLatestCommit)
 	case ContentTypeFile:
-		content, err = c.getFileContent(ctx, readParams, info.SHA)
+		content, err = c.getFileContent(ctx, repo.ID, readParams, info.SHA)
 	case ContentTypeSymlink:
 		content, err = c.getSymlinkContent(ctx, readParams, info.SHA)
 	case ContentTypeSubmodule:
@@ -176,6 +181,7 @@ func (c *Controller) getSubmoduleContent(ctx context.Context,
 }
 
 func (c *Controller) getFileContent(ctx context.Context,
+	repoID int64,
 	readParams git.ReadParams,
 	blobSHA string,
 ) (*FileContent, error) {
@@ -199,6 +205,16 @@ func (c *Controller) getFileContent(ctx context.Context,
 		return nil, fmt.Errorf("failed to read blob content: %w", err)
 	}
 
+	if output.Size <= parser.LFSPointerMaxSize {
+		lfsContent, err := c.getLFSFileContent(ctx, repoID, content)
+		if err != nil {
+			return nil, err
+		}
+		if lfsContent != nil {
+			return lfsContent, nil
+		}
+	}
+
 	return &FileContent{
 		Size:     output.Size,
 		DataSize: output.ContentSize,
@@ -207,6 +223,43 @@ func (c *Controller) getFileContent(ctx context.Context,
 	}, nil
 }
 
+// getLFSFileContent returns the content of the lfs object the provided pointer file is pointing to.
+// In case the content isn't an lfs pointer, or the object isn't stored for the repo, nil is returned.
+func (c *Controller) getLFSFileContent(ctx context.Context,
+	repoID int64,
+	pointerContent []byte,
+) (*FileContent, error) {
+	obj, err := c.findLFSObject(ctx, repoID, pointerContent)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+
+	file, err := c.downloadLFSObject(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close lfs object reader.")
+		}
+	}()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxGetContentFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lfs object content: %w", err)
+	}
+
+	return &FileContent{
+		Size:          obj.Size,
+		DataSize:      int64(len(content)),
+		Encoding:      enum.ContentEncodingTypeBase64,
+		Data:          base64.StdEncoding.EncodeToString(content),
+		LFSObjectID:   obj.OID,
+		LFSObjectSize: obj.Size,
+	}, nil
+}
+
 func (c *Controller) getSymlinkContent(ctx context.Context,
 	readParams git.ReadParams,
 	blobSHA string,
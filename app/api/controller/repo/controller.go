@@ -26,12 +26,20 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -64,6 +72,21 @@ type Controller struct {
 	indexer            keywordsearch.Indexer
 	resourceLimiter    limiter.ResourceLimiter
 	mtxManager         lock.MutexManager
+	lfsObjectStore     store.LFSObjectStore
+	blobStore          blob.Store
+	signatureVerifier  *gitsignature.Verifier
+	pullMirrorStore    store.PullMirrorStore
+	encrypter          encrypt.Encrypter
+	pushMirrorStore    store.PushMirrorStore
+	secretStore        store.SecretStore
+	mirrorSvc          *mirror.Service
+
+	repoMembershipStore store.RepoMembershipStore
+	customRoleStore     store.CustomRoleStore
+	auditService        *audit.Service
+	secretScanService   *secretscan.Service
+	mergeTemplates      *mergetemplate.Service
+	labelService        *label.Service
 }
 
 func NewController(
@@ -85,6 +108,20 @@ func NewController(
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
+	lfsObjectStore store.LFSObjectStore,
+	blobStore blob.Store,
+	signatureVerifier *gitsignature.Verifier,
+	pullMirrorStore store.PullMirrorStore,
+	encrypter encrypt.Encrypter,
+	pushMirrorStore store.PushMirrorStore,
+	secretStore store.SecretStore,
+	mirrorSvc *mirror.Service,
+	repoMembershipStore store.RepoMembershipStore,
+	customRoleStore store.CustomRoleStore,
+	auditService *audit.Service,
+	secretScanService *secretscan.Service,
+	mergeTemplates *mergetemplate.Service,
+	labelService *label.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
@@ -106,6 +143,20 @@ func NewController(
 		indexer:                       indexer,
 		resourceLimiter:               limiter,
 		mtxManager:                    mtxManager,
+		lfsObjectStore:                lfsObjectStore,
+		blobStore:                     blobStore,
+		signatureVerifier:             signatureVerifier,
+		pullMirrorStore:               pullMirrorStore,
+		encrypter:                     encrypter,
+		pushMirrorStore:               pushMirrorStore,
+		secretStore:                   secretStore,
+		mirrorSvc:                     mirrorSvc,
+		repoMembershipStore:           repoMembershipStore,
+		customRoleStore:               customRoleStore,
+		auditService:                  auditService,
+		secretScanService:             secretScanService,
+		mergeTemplates:                mergeTemplates,
+		labelService:                  labelService,
 	}
 }
 
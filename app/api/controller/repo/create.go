@@ -111,6 +111,7 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 
 	// backfil GitURL
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
 
 	// index repository if files are created
 	if in.Readme || in.GitIgnore != "" || (in.License != "" && in.License != "none") {
@@ -221,6 +222,7 @@ func (c *Controller) createGitRepository(ctx context.Context, session *auth.Sess
 		session.Principal.ID,
 		true,
 		true,
+		false,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
@@ -16,10 +16,12 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
@@ -46,6 +48,10 @@ func (c *Controller) RawDiff(
 		return err
 	}
 
+	if err = c.fetchHeadFromFork(ctx, session, repo, &info); err != nil {
+		return err
+	}
+
 	return c.git.RawDiff(ctx, w, &git.DiffParams{
 		ReadParams: git.CreateReadParams(repo),
 		BaseRef:    info.BaseRef,
@@ -73,11 +79,15 @@ func (c *Controller) CommitDiff(
 }
 
 type CompareInfo struct {
-	BaseRef   string
-	HeadRef   string
-	MergeBase bool
+	BaseRef string
+	// HeadRepoRef is the reference of the repository containing the head (optional, default: same repository).
+	HeadRepoRef string
+	HeadRef     string
+	MergeBase   bool
 }
 
+// parseDiffPath parses the diff path of format "base...head" or "base..head".
+// The head can be prefixed with a repository reference ("base...repo:head") to compare against a fork.
 func parseDiffPath(path string) (CompareInfo, error) {
 	infos := strings.SplitN(path, "...", 2)
 	if len(infos) != 2 {
@@ -86,13 +96,81 @@ func parseDiffPath(path string) (CompareInfo, error) {
 	if len(infos) != 2 {
 		return CompareInfo{}, usererror.BadRequestf("invalid format \"%s\"", path)
 	}
+
+	// a colon can't be part of a git reference name nor of a repository path.
+	headRepoRef, headRef, ok := strings.Cut(infos[1], ":")
+	if !ok {
+		headRepoRef, headRef = "", infos[1]
+	}
+
 	return CompareInfo{
-		BaseRef:   infos[0],
-		HeadRef:   infos[1],
-		MergeBase: strings.Contains(path, "..."),
+		BaseRef:     infos[0],
+		HeadRepoRef: headRepoRef,
+		HeadRef:     headRef,
+		MergeBase:   strings.Contains(path, "..."),
 	}, nil
 }
 
+// fetchHeadFromFork makes the head available in the repository in case it's in another repository of the fork network.
+// The head reference is replaced with the commit SHA it's pointing to.
+func (c *Controller) fetchHeadFromFork(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	info *CompareInfo,
+) error {
+	if info.HeadRepoRef == "" {
+		return nil
+	}
+
+	headRepo, err := c.getRepoCheckAccess(ctx, session, info.HeadRepoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to head repo: %w", err)
+	}
+
+	info.HeadRepoRef = ""
+
+	if headRepo.ID == repo.ID {
+		return nil
+	}
+
+	if !repo.IsForkRelative(headRepo) {
+		return usererror.BadRequest("The head repository is not part of the fork network of the repository.")
+	}
+
+	commits, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(headRepo),
+		GitREF:     info.HeadRef,
+		Limit:      1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve head reference: %w", err)
+	}
+	if len(commits.Commits) == 0 {
+		return usererror.BadRequestf("Head reference '%s' doesn't point to a commit.", info.HeadRef)
+	}
+
+	headSHA := commits.Commits[0].SHA
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to create rpc write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      headRepo.GitUID,
+		ObjectSHAs:  []string{headSHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch head commit from the head repository: %w", err)
+	}
+
+	info.HeadRef = headSHA
+
+	return nil
+}
+
 func (c *Controller) DiffStats(
 	ctx context.Context,
 	session *auth.Session,
@@ -113,6 +191,10 @@ func (c *Controller) DiffStats(
 		return types.DiffStats{}, err
 	}
 
+	if err = c.fetchHeadFromFork(ctx, session, repo, &info); err != nil {
+		return types.DiffStats{}, err
+	}
+
 	output, err := c.git.DiffStats(ctx, &git.DiffParams{
 		ReadParams: git.CreateReadParams(repo),
 		BaseRef:    info.BaseRef,
@@ -148,6 +230,10 @@ func (c *Controller) Diff(
 		return nil, err
 	}
 
+	if err = c.fetchHeadFromFork(ctx, session, repo, &info); err != nil {
+		return nil, err
+	}
+
 	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
 		ReadParams:   git.CreateReadParams(repo),
 		BaseRef:      info.BaseRef,
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ForkInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// Fork creates a fork of the repository in the provided space.
+// The fork shares the git objects of the repository and starts with all of its branches and tags.
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*types.Repository, error) {
+	sourceRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeForkInput(in, sourceRepo); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		gitResp, err := c.forkGitRepository(ctx, session, sourceRepo)
+		if err != nil {
+			return fmt.Errorf("error forking repository on git: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   in.Description,
+			IsPublic:      in.IsPublic,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			ForkID:        sourceRepo.ID,
+			DefaultBranch: gitResp.DefaultBranch,
+		}
+
+		err = c.repoStore.Create(ctx, repo)
+		if err == nil {
+			_, err = c.repoStore.UpdateOptLock(ctx, sourceRepo, func(r *types.Repository) error {
+				r.NumForks++
+				return nil
+			})
+		}
+		if err != nil {
+			if dErr := c.deleteGitRepository(ctx, session, repo); dErr != nil {
+				log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete fork for cleanup")
+			}
+			return fmt.Errorf("failed to create fork in storage: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
+
+	err = c.indexer.Index(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index fork")
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, sourceRepo *types.Repository) error {
+	if in.IsPublic && !c.publicResourceCreationEnabled {
+		return errPublicRepoCreationDisabled
+	}
+
+	if in.IsPublic && !sourceRepo.IsPublic {
+		return usererror.BadRequest("A private repository can't be forked into a public repository.")
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if in.Identifier == "" {
+		in.Identifier = sourceRepo.Identifier
+	}
+
+	if err := check.RepoIdentifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = sourceRepo.Description
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) forkGitRepository(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+) (*git.ForkRepositoryOutput, error) {
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	resp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:         *identityFromPrincipal(session.Principal),
+		EnvVars:       envVars,
+		SourceRepoUID: sourceRepo.GitUID,
+		DefaultBranch: sourceRepo.DefaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork repo: %w", err)
+	}
+
+	return resp, nil
+}
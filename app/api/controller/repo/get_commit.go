@@ -50,5 +50,7 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	commit.Verification = c.getSignatureVerifications(ctx, repo, []string{commit.SHA})[commit.SHA]
+
 	return commit, nil
 }
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// LabelDelete deletes a pull request label of a repository.
+// The label gets removed from all pull requests it was assigned to.
+func (c *Controller) LabelDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	labelID int64,
+) error {
+	l, err := c.getLabelCheckAccess(ctx, session, repoRef, labelID, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.labelService.Delete(ctx, l.ID)
+}
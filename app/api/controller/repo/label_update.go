@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// LabelUpdate updates a pull request label of a repository.
+func (c *Controller) LabelUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	labelID int64,
+	in *label.UpdateInput,
+) (*types.Label, error) {
+	l, err := c.getLabelCheckAccess(ctx, session, repoRef, labelID, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.labelService.Update(ctx, l, in)
+}
+
+func (c *Controller) getLabelCheckAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	labelID int64,
+	permission enum.Permission,
+) (*types.Label, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission, false)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := c.labelService.FindRepoLabel(ctx, repo.ID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository label: %w", err)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// findLFSObject returns the lfs object the provided blob content is pointing to.
+// In case the content isn't an lfs pointer, or the object isn't stored for the repo, nil is returned.
+func (c *Controller) findLFSObject(
+	ctx context.Context,
+	repoID int64,
+	content []byte,
+) (*types.LFSObject, error) {
+	pointer, ok := parser.IsLFSPointer(content)
+	if !ok {
+		return nil, nil //nolint:nilnil // on purpose
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repoID, pointer.OID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // on purpose
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	return obj, nil
+}
+
+// downloadLFSObject returns the content of the provided lfs object.
+func (c *Controller) downloadLFSObject(
+	ctx context.Context,
+	obj *types.LFSObject,
+) (io.ReadCloser, error) {
+	file, err := c.blobStore.Download(ctx, lfs.GetBlobPath(obj.RepoID, obj.OID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download lfs object from blobstore: %w", err)
+	}
+
+	return file, nil
+}
@@ -33,6 +33,8 @@ type CommitTag struct {
 	Message     string           `json:"message,omitempty"`
 	Tagger      *types.Signature `json:"tagger,omitempty"`
 	Commit      *types.Commit    `json:"commit,omitempty"`
+
+	Verification *types.GitSignatureVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -61,11 +63,30 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 	}
 
 	tags := make([]CommitTag, len(rpcOut.Tags))
+	shas := make([]string, 0, len(rpcOut.Tags))
 	for i := range rpcOut.Tags {
 		tags[i], err = mapCommitTag(rpcOut.Tags[i])
 		if err != nil {
 			return nil, fmt.Errorf("failed to map CommitTag: %w", err)
 		}
+
+		// only annotated tags can be signed, lightweight tags reference the commit directly.
+		if tags[i].IsAnnotated {
+			shas = append(shas, tags[i].SHA)
+		}
+		if tags[i].Commit != nil {
+			shas = append(shas, tags[i].Commit.SHA)
+		}
+	}
+
+	verifications := c.getSignatureVerifications(ctx, repo, shas)
+	for i := range tags {
+		if tags[i].IsAnnotated {
+			tags[i].Verification = verifications[tags[i].SHA]
+		}
+		if tags[i].Commit != nil {
+			tags[i].Commit.Verification = verifications[tags[i].Commit.SHA]
+		}
 	}
 
 	return tags, nil
@@ -58,6 +58,7 @@ func (c *Controller) ListCommits(ctx context.Context,
 	}
 
 	commits := make([]types.Commit, len(rpcOut.Commits))
+	shas := make([]string, len(rpcOut.Commits))
 	for i := range rpcOut.Commits {
 		var commit *types.Commit
 		commit, err = controller.MapCommit(&rpcOut.Commits[i])
@@ -65,6 +66,12 @@ func (c *Controller) ListCommits(ctx context.Context,
 			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
 		}
 		commits[i] = *commit
+		shas[i] = commit.SHA
+	}
+
+	verifications := c.getSignatureVerifications(ctx, repo, shas)
+	for i := range commits {
+		commits[i].Verification = verifications[commits[i].SHA]
 	}
 
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MembershipAddInput struct {
+	UserUID string              `json:"user_uid"`
+	Role    enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipAddInput) Validate() error {
+	if in.UserUID == "" {
+		return usererror.BadRequest("UserUID must be provided")
+	}
+
+	if in.Role == "" {
+		return usererror.BadRequest("Role must be provided")
+	}
+
+	return nil
+}
+
+// MembershipAdd adds a new membership to a repo.
+func (c *Controller) MembershipAdd(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MembershipAddInput,
+) (*types.RepoMembershipUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	in.Role, err = c.sanitizeRepoMembershipRole(ctx, repo, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := types.RepoMembership{
+		RepoMembershipKey: types.RepoMembershipKey{
+			RepoID:      repo.ID,
+			PrincipalID: user.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      in.Role,
+	}
+
+	err = c.repoMembershipStore.Create(ctx, &membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new repo membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRepoMembership, user.UID, repo),
+		enum.AuditActionCreated,
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create repo membership operation")
+	}
+
+	result := &types.RepoMembershipUser{
+		RepoMembership: membership,
+		Principal:      *user.ToPrincipalInfo(),
+		AddedBy:        *session.Principal.ToPrincipalInfo(),
+	}
+
+	return result, nil
+}
+
+// sanitizeRepoMembershipRole returns the canonical form of the repo membership role. The role is either
+// one of the built-in repo roles or a custom role defined in any of the parent spaces of the repo.
+func (c *Controller) sanitizeRepoMembershipRole(ctx context.Context,
+	repo *types.Repository,
+	role enum.MembershipRole,
+) (enum.MembershipRole, error) {
+	if sanitized, ok := role.SanitizeRepoRole(); ok {
+		return sanitized, nil
+	}
+
+	customRole, err := c.customRoleStore.FindInherited(ctx, repo.ParentID, string(role))
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "", usererror.BadRequestf("Provided role '%s' is not suppored. "+
+			"Valid values are: %v or the identifier of a custom role.", role, enum.RepoMembershipRoles)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return enum.MembershipRole(customRole.Identifier), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MembershipDelete removes an existing repo membership.
+func (c *Controller) MembershipDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	userUID string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	key := types.RepoMembershipKey{
+		RepoID:      repo.ID,
+		PrincipalID: user.ID,
+	}
+
+	// make sure the membership exists so that a missing membership is reported as not found.
+	membership, err := c.repoMembershipStore.Find(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to find repo membership: %w", err)
+	}
+
+	err = c.repoMembershipStore.Delete(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete repo membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRepoMembership, user.UID, repo),
+		enum.AuditActionDeleted,
+		audit.WithOldObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for delete repo membership operation")
+	}
+
+	return nil
+}
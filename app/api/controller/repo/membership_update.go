@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MembershipUpdateInput struct {
+	Role enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipUpdateInput) Validate() error {
+	if in.Role == "" {
+		return usererror.BadRequest("Role must be provided")
+	}
+
+	return nil
+}
+
+// MembershipUpdate changes the role of an existing repo membership.
+func (c *Controller) MembershipUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	userUID string,
+	in *MembershipUpdateInput,
+) (*types.RepoMembershipUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	in.Role, err = c.sanitizeRepoMembershipRole(ctx, repo, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	membership, err := c.repoMembershipStore.FindUser(ctx, types.RepoMembershipKey{
+		RepoID:      repo.ID,
+		PrincipalID: user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo membership for update: %w", err)
+	}
+
+	if membership.Role == in.Role {
+		return membership, nil
+	}
+
+	oldMembership := membership.RepoMembership
+
+	membership.Role = in.Role
+
+	err = c.repoMembershipStore.Update(ctx, &membership.RepoMembership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repo membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRepoMembership, user.UID, repo),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(membership.RepoMembership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update repo membership operation")
+	}
+
+	return membership, nil
+}
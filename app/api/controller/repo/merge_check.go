@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types/enum"
@@ -45,6 +46,19 @@ func (c *Controller) MergeCheck(
 		return MergeCheck{}, err
 	}
 
+	headRepo := repo
+	if info.HeadRepoRef != "" {
+		headRepo, err = c.getRepoCheckAccess(ctx, session, info.HeadRepoRef, enum.PermissionRepoView, true)
+		if err != nil {
+			return MergeCheck{}, fmt.Errorf("failed to acquire access to head repo: %w", err)
+		}
+
+		if headRepo.ID != repo.ID && !repo.IsForkRelative(headRepo) {
+			return MergeCheck{}, usererror.BadRequest(
+				"The head repository is not part of the fork network of the repository.")
+		}
+	}
+
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
 		return MergeCheck{}, fmt.Errorf("failed to create rpc write params: %w", err)
@@ -53,7 +67,7 @@ func (c *Controller) MergeCheck(
 	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
 		WriteParams: writeParams,
 		BaseBranch:  info.BaseRef,
-		HeadRepoUID: writeParams.RepoUID, // forks are not supported for now
+		HeadRepoUID: headRepo.GitUID,
 		HeadBranch:  info.HeadRef,
 	})
 	if err != nil {
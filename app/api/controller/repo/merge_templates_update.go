@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MergeTemplatesUpdateInput struct {
+	MergeTitle    *string `json:"merge_title"`
+	MergeMessage  *string `json:"merge_message"`
+	SquashTitle   *string `json:"squash_title"`
+	SquashMessage *string `json:"squash_message"`
+}
+
+func (in *MergeTemplatesUpdateInput) sanitize() error {
+	for _, template := range []*string{in.MergeTitle, in.MergeMessage, in.SquashTitle, in.SquashMessage} {
+		if template != nil {
+			*template = strings.TrimSpace(*template)
+		}
+	}
+
+	if in.MergeTitle != nil && strings.ContainsAny(*in.MergeTitle, "\r\n") {
+		return usererror.BadRequest("Merge commit title template must be a single line.")
+	}
+
+	if in.SquashTitle != nil && strings.ContainsAny(*in.SquashTitle, "\r\n") {
+		return usererror.BadRequest("Squash commit title template must be a single line.")
+	}
+
+	return nil
+}
+
+// MergeTemplatesUpdate updates the merge commit templates of a repository.
+func (c *Controller) MergeTemplatesUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MergeTemplatesUpdateInput,
+) (*types.MergeTemplates, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	templates, err := c.mergeTemplates.FindTemplates(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	if templates.Created == 0 {
+		templates.CreatedBy = session.Principal.ID
+		templates.Created = now
+	}
+	templates.Updated = now
+
+	if in.MergeTitle != nil {
+		templates.MergeTitle = *in.MergeTitle
+	}
+	if in.MergeMessage != nil {
+		templates.MergeMessage = *in.MergeMessage
+	}
+	if in.SquashTitle != nil {
+		templates.SquashTitle = *in.SquashTitle
+	}
+	if in.SquashMessage != nil {
+		templates.SquashMessage = *in.SquashMessage
+	}
+
+	if err = c.mergeTemplates.UpdateTemplates(ctx, templates); err != nil {
+		return nil, fmt.Errorf("failed to update merge templates: %w", err)
+	}
+
+	return templates, nil
+}
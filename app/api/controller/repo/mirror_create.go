@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MirrorCreateInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	MirrorInput
+}
+
+// minMirrorSyncIntervalMinutes is the shortest sync interval a pull mirror can be configured with.
+const minMirrorSyncIntervalMinutes = 5
+
+type MirrorInput struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// SyncIntervalMinutes defines how often the mirror is synced (0 uses the default interval of the server).
+	SyncIntervalMinutes int64 `json:"sync_interval_minutes"`
+}
+
+// MirrorCreate creates a new repository that mirrors a remote repository.
+// The content of the remote repository is imported first, after which all branches and tags
+// are periodically synced from the remote repository. The mirror repository doesn't accept pushes.
+func (c *Controller) MirrorCreate(
+	ctx context.Context,
+	session *auth.Session,
+	in *MirrorCreateInput,
+) (*types.Repository, error) {
+	if err := c.sanitizeMirrorCreateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := c.encryptMirrorPassword(in.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		remoteRepository := importer.RepositoryInfo{
+			Identifier:    in.Identifier,
+			CloneURL:      in.URL,
+			IsPublic:      in.IsPublic,
+			DefaultBranch: c.defaultBranch,
+		}
+
+		repo = remoteRepository.ToRepo(
+			parentSpace.ID,
+			in.Identifier,
+			in.Description,
+			&session.Principal,
+			c.publicResourceCreationEnabled,
+		)
+
+		err = c.repoStore.Create(ctx, repo)
+		if err != nil {
+			return fmt.Errorf("failed to create repository in storage: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		err = c.pullMirrorStore.Create(ctx, &types.PullMirror{
+			RepoID:              repo.ID,
+			URL:                 in.URL,
+			Username:            in.Username,
+			Password:            password,
+			SyncIntervalMinutes: in.SyncIntervalMinutes,
+			CreatedBy:           session.Principal.ID,
+			Created:             now,
+			Updated:             now,
+			LastSyncStatus:      enum.MirrorSyncStatusPending,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create pull mirror in storage: %w", err)
+		}
+
+		// pipelines aren't converted, as any commit added to the repository would be overwritten by the next sync.
+		provider := importer.Provider{
+			Username: in.Username,
+			Password: in.Password,
+		}
+		err = c.importer.Run(ctx, provider, repo, in.URL, importer.PipelineOptionIgnore)
+		if err != nil {
+			return fmt.Errorf("failed to start import repository job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
+
+	return repo, nil
+}
+
+func (c *Controller) sanitizeMirrorCreateInput(in *MirrorCreateInput) error {
+	if in.IsPublic && !c.publicResourceCreationEnabled {
+		return errPublicRepoCreationDisabled
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if err := check.RepoIdentifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return sanitizeMirrorInput(&in.MirrorInput)
+}
+
+func sanitizeMirrorInput(in *MirrorInput) error {
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+
+	if in.SyncIntervalMinutes != 0 && in.SyncIntervalMinutes < minMirrorSyncIntervalMinutes {
+		return usererror.BadRequestf("The sync interval must be at least %d minutes.", minMirrorSyncIntervalMinutes)
+	}
+
+	return checkMirrorURL(in.URL)
+}
+
+// checkMirrorURL verifies that the URL of the remote repository of a mirror is a http or https URL
+// without credentials.
+func checkMirrorURL(remoteURLRaw string) error {
+	if remoteURLRaw == "" {
+		return usererror.BadRequest("The URL of the remote repository is required.")
+	}
+
+	remoteURL, err := url.Parse(remoteURLRaw)
+	if err != nil {
+		return usererror.BadRequestf("The URL of the remote repository is invalid: %s", err)
+	}
+
+	// only remote repositories are supported, local paths or other transports could be used to access the server.
+	if (remoteURL.Scheme != "http" && remoteURL.Scheme != "https") || remoteURL.Host == "" {
+		return usererror.BadRequest("The URL of the remote repository must be a http or https URL.")
+	}
+
+	if remoteURL.User != nil {
+		return usererror.BadRequest(
+			"The URL of the remote repository mustn't contain credentials, use username and password instead.")
+	}
+
+	return nil
+}
+
+func (c *Controller) encryptMirrorPassword(password string) ([]byte, error) {
+	if password == "" {
+		return nil, nil
+	}
+
+	encrypted, err := c.encrypter.Encrypt(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt password: %w", err)
+	}
+
+	return encrypted, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MirrorDelete stops mirroring the remote repository. The repository keeps its content and accepts pushes again.
+func (c *Controller) MirrorDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.pullMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	err = c.pullMirrorStore.Delete(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pull mirror: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MirrorUpdate updates the remote repository, the credentials and the sync interval of a pull mirror.
+func (c *Controller) MirrorUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorInput,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sanitizeMirrorInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	mirror, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	password, err := c.encryptMirrorPassword(in.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror.URL = in.URL
+	mirror.Username = in.Username
+	mirror.Password = password
+	mirror.SyncIntervalMinutes = in.SyncIntervalMinutes
+	mirror.Updated = time.Now().UnixMilli()
+
+	err = c.pullMirrorStore.Update(ctx, mirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull mirror: %w", err)
+	}
+
+	return mirror, nil
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -64,6 +66,10 @@ func (c *Controller) PurgeNoAuth(
 	session *auth.Session,
 	repo *types.Repository,
 ) error {
+	if err := c.detachForks(ctx, session, repo); err != nil {
+		return fmt.Errorf("failed to detach forks: %w", err)
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
@@ -72,6 +78,20 @@ func (c *Controller) PurgeNoAuth(
 		return fmt.Errorf("failed to delete git repository: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+
+	err := c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRepository, repo.Identifier, repo),
+		enum.AuditActionPurged,
+		audit.WithOldObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for purge repository operation")
+	}
+
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
@@ -110,3 +130,60 @@ func (c *Controller) deleteGitRepository(
 	}
 	return nil
 }
+
+// detachForks copies all objects the forks of the repo are borrowing from it,
+// which is required before the git repository can be deleted.
+func (c *Controller) detachForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	if repo.NumForks == 0 {
+		return nil
+	}
+
+	forks, err := c.repoStore.ListForks(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+
+		err = c.git.DetachFork(ctx, &git.DetachForkParams{
+			WriteParams: writeParams,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to detach fork %d: %w", fork.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) decrementNumForks(ctx context.Context, repoID int64) {
+	sourceRepo, err := c.repoStore.Find(ctx, repoID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find forked repository")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, sourceRepo, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of forked repository")
+	}
+}
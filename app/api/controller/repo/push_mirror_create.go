@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxPushMirrors is the maximum number of push mirrors of a repository.
+const maxPushMirrors = 10
+
+type PushMirrorCreateInput struct {
+	Identifier string `json:"identifier"`
+	URL        string `json:"url"`
+	Username   string `json:"username"`
+	// SecretIdentifier is the identifier of the secret in the parent space of the repository
+	// that contains the password or the access token for the remote repository.
+	SecretIdentifier string `json:"secret_identifier"`
+}
+
+// PushMirrorCreate creates a new push mirror of the repository.
+// All branches and tags are pushed to the remote repository after every change and periodically.
+func (c *Controller) PushMirrorCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PushMirrorCreateInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sanitizePushMirrorCreateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	if err = c.checkPushMirrorSecret(ctx, session, repo, in.SecretIdentifier); err != nil {
+		return nil, err
+	}
+
+	count, err := c.pushMirrorStore.Count(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count push mirrors: %w", err)
+	}
+
+	if count >= maxPushMirrors {
+		return nil, usererror.BadRequestf("A repository can't have more than %d push mirrors.", maxPushMirrors)
+	}
+
+	now := time.Now().UnixMilli()
+	mirror := &types.PushMirror{
+		RepoID:           repo.ID,
+		Identifier:       in.Identifier,
+		URL:              in.URL,
+		Username:         in.Username,
+		SecretIdentifier: in.SecretIdentifier,
+		CreatedBy:        session.Principal.ID,
+		Created:          now,
+		Updated:          now,
+		LastSyncStatus:   enum.MirrorSyncStatusPending,
+	}
+
+	err = c.pushMirrorStore.Create(ctx, mirror)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("A push mirror with identifier '%s' already exists.", in.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+func sanitizePushMirrorCreateInput(in *PushMirrorCreateInput) error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+	in.SecretIdentifier = strings.TrimSpace(in.SecretIdentifier)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	return checkMirrorURL(in.URL)
+}
+
+// checkPushMirrorSecret verifies that the secret of a push mirror exists in the parent space of the repository,
+// and that the user is allowed to view it - otherwise the secret could be sent to a remote controlled by the user.
+func (c *Controller) checkPushMirrorSecret(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	secretIdentifier string,
+) error {
+	if secretIdentifier == "" {
+		return nil
+	}
+
+	parentPath, _, err := paths.DisectLeaf(repo.Path)
+	if err != nil {
+		return fmt.Errorf("failed to get parent path of repository: %w", err)
+	}
+
+	err = apiauth.CheckSecret(ctx, c.authorizer, session, parentPath, secretIdentifier, enum.PermissionSecretView)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.secretStore.FindByIdentifier(ctx, repo.ParentID, secretIdentifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequestf("Secret '%s' doesn't exist in the parent space of the repository.",
+			secretIdentifier)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PushMirrorSync pushes all branches and tags of the repository to the remote repository of a push mirror.
+// The push is executed immediately, even if the push mirror is waiting for a retry after a failed push.
+// The outcome of the push is returned as the last sync status of the push mirror.
+func (c *Controller) PushMirrorSync(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	// a failed push is stored as the sync status of the push mirror, it's not an error of the request.
+	err = c.mirrorSvc.SyncPushMirror(ctx, mirror)
+	if err != nil && mirror.LastSyncStatus != enum.MirrorSyncStatusFailed {
+		return nil, fmt.Errorf("failed to sync push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type PushMirrorUpdateInput struct {
+	Identifier       *string `json:"identifier"`
+	URL              *string `json:"url"`
+	Username         *string `json:"username"`
+	SecretIdentifier *string `json:"secret_identifier"`
+}
+
+// PushMirrorUpdate updates the identifier, the remote repository and the credentials of a push mirror.
+func (c *Controller) PushMirrorUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *PushMirrorUpdateInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sanitizePushMirrorUpdateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	mirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if in.Identifier != nil {
+		mirror.Identifier = *in.Identifier
+	}
+	if in.URL != nil {
+		mirror.URL = *in.URL
+	}
+	if in.Username != nil {
+		mirror.Username = *in.Username
+	}
+	if in.SecretIdentifier != nil {
+		mirror.SecretIdentifier = *in.SecretIdentifier
+	}
+
+	// the secret is verified again if the url changes, as the secret is sent to the new remote.
+	if in.SecretIdentifier != nil || in.URL != nil {
+		if err = c.checkPushMirrorSecret(ctx, session, repo, mirror.SecretIdentifier); err != nil {
+			return nil, err
+		}
+	}
+
+	mirror.Updated = time.Now().UnixMilli()
+
+	err = c.pushMirrorStore.Update(ctx, mirror)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("A push mirror with identifier '%s' already exists.",
+			mirror.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+func sanitizePushMirrorUpdateInput(in *PushMirrorUpdateInput) error {
+	if in.Identifier != nil {
+		*in.Identifier = strings.TrimSpace(*in.Identifier)
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.URL != nil {
+		*in.URL = strings.TrimSpace(*in.URL)
+		if err := checkMirrorURL(*in.URL); err != nil {
+			return err
+		}
+	}
+
+	if in.Username != nil {
+		*in.Username = strings.TrimSpace(*in.Username)
+	}
+
+	if in.SecretIdentifier != nil {
+		*in.SecretIdentifier = strings.TrimSpace(*in.SecretIdentifier)
+	}
+
+	return nil
+}
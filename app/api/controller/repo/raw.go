@@ -15,6 +15,7 @@
 package repo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -22,7 +23,10 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Raw finds the file of the repo at the given path and returns its raw content.
@@ -71,5 +75,32 @@ func (c *Controller) Raw(ctx context.Context,
 		return nil, 0, fmt.Errorf("failed to read blob: %w", err)
 	}
 
-	return blobReader.Content, blobReader.ContentSize, nil
+	// small blobs could be lfs pointers - serve the actual file in that case.
+	if blobReader.Size > parser.LFSPointerMaxSize {
+		return blobReader.Content, blobReader.ContentSize, nil
+	}
+
+	content, err := io.ReadAll(blobReader.Content)
+	if cErr := blobReader.Content.Close(); cErr != nil {
+		log.Ctx(ctx).Warn().Err(cErr).Msg("failed to close blob content reader.")
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	lfsObj, err := c.findLFSObject(ctx, repo.ID, content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if lfsObj == nil {
+		return io.NopCloser(bytes.NewReader(content)), int64(len(content)), nil
+	}
+
+	file, err := c.downloadLFSObject(ctx, lfsObj)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return file, lfsObj.Size, nil
 }
@@ -21,8 +21,11 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type RestoreInput struct {
@@ -49,10 +52,23 @@ func (c *Controller) Restore(
 		return nil, usererror.BadRequest("cannot restore a repo that hasn't been deleted")
 	}
 
+	deletedRepo := *repo
+
 	repo, err = c.repoStore.Restore(ctx, repo, in.NewIdentifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore the repo: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRepository, repo.Identifier, repo),
+		enum.AuditActionRestored,
+		audit.WithOldObject(deletedRepo),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for restore repository operation")
+	}
+
 	return repo, nil
 }
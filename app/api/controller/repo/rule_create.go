@@ -22,10 +22,13 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type RuleCreateInput struct {
@@ -112,6 +115,16 @@ func (c *Controller) RuleCreate(ctx context.Context,
 		return nil, fmt.Errorf("failed to create repository-level protection rule: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRule, r.Identifier, repo),
+		enum.AuditActionCreated,
+		audit.WithNewObject(r),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create rule operation")
+	}
+
 	r.Users, err = c.getRuleUsers(ctx, r)
 	if err != nil {
 		return nil, err
@@ -19,7 +19,10 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // RuleDelete deletes a protection rule by identifier.
@@ -43,5 +46,15 @@ func (c *Controller) RuleDelete(ctx context.Context,
 		return fmt.Errorf("failed to delete repository-level protection rule: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRule, r.Identifier, repo),
+		enum.AuditActionDeleted,
+		audit.WithOldObject(r),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for delete rule operation")
+	}
+
 	return nil
 }
@@ -21,10 +21,13 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type RuleUpdateInput struct {
@@ -105,6 +108,8 @@ func (c *Controller) RuleUpdate(ctx context.Context,
 		return r, nil
 	}
 
+	oldRule := *r
+
 	if in.Identifier != nil {
 		r.Identifier = *in.Identifier
 	}
@@ -124,6 +129,8 @@ func (c *Controller) RuleUpdate(ctx context.Context,
 		}
 	}
 
+	newRule := *r
+
 	r.Users, err = c.getRuleUsers(ctx, r)
 	if err != nil {
 		return nil, err
@@ -134,5 +141,18 @@ func (c *Controller) RuleUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to update repository-level protection rule: %w", err)
 	}
 
+	newRule.Updated = r.Updated
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeRule, r.Identifier, repo),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldRule),
+		audit.WithNewObject(newRule),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update rule operation")
+	}
+
 	return r, nil
 }
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SecretScanningUpdateInput struct {
+	Enabled         *bool                       `json:"enabled"`
+	Mode            *enum.SecretScanningMode    `json:"mode"`
+	UseDefaultRules *bool                       `json:"use_default_rules"`
+	Rules           *[]types.SecretScanningRule `json:"rules"`
+}
+
+func (in *SecretScanningUpdateInput) sanitize() error {
+	if in.Mode != nil {
+		mode, ok := in.Mode.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid secret scanning mode %q.", *in.Mode)
+		}
+		in.Mode = &mode
+	}
+
+	if in.Rules != nil {
+		if err := secretscan.ValidateRules(*in.Rules); err != nil {
+			return usererror.BadRequestf("Invalid secret scanning rules: %s.", err)
+		}
+	}
+
+	return nil
+}
+
+// SecretScanningUpdate updates the secret scanning settings of a repository.
+func (c *Controller) SecretScanningUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SecretScanningUpdateInput,
+) (*types.SecretScanningSettings, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.secretScanService.FindSettings(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSettings := *settings
+
+	now := time.Now().UnixMilli()
+	if settings.Created == 0 {
+		settings.CreatedBy = session.Principal.ID
+		settings.Created = now
+	}
+	settings.Updated = now
+
+	if in.Enabled != nil {
+		settings.Enabled = *in.Enabled
+	}
+	if in.Mode != nil {
+		settings.Mode = *in.Mode
+	}
+	if in.UseDefaultRules != nil {
+		settings.UseDefaultRules = *in.UseDefaultRules
+	}
+	if in.Rules != nil {
+		settings.Rules = *in.Rules
+	}
+
+	if err = c.secretScanService.UpdateSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update secret scanning settings: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewRepoResource(enum.AuditResourceTypeSecretScanning, repo.Identifier, repo),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldSettings),
+		audit.WithNewObject(settings),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update secret scanning operation")
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// getSignatureVerifications verifies the signatures of the provided commits and annotated tags.
+// Verification is best effort - in case of a failure no verification results are returned.
+func (c *Controller) getSignatureVerifications(
+	ctx context.Context,
+	repo *types.Repository,
+	shas []string,
+) map[string]*types.GitSignatureVerification {
+	if len(shas) == 0 {
+		return nil
+	}
+
+	verifications, err := c.signatureVerifier.VerifyObjects(ctx, git.CreateReadParams(repo), shas)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repo.ID).
+			Msg("failed to verify signatures")
+		return nil
+	}
+
+	return verifications
+}
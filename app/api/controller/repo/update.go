@@ -72,6 +72,7 @@ func (c *Controller) Update(ctx context.Context,
 
 	// backfill repo url
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
 
 	return repo, nil
 }
@@ -18,12 +18,20 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -56,10 +64,27 @@ func ProvideController(
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
+	lfsObjectStore store.LFSObjectStore,
+	blobStore blob.Store,
+	signatureVerifier *gitsignature.Verifier,
+	pullMirrorStore store.PullMirrorStore,
+	encrypter encrypt.Encrypter,
+	pushMirrorStore store.PushMirrorStore,
+	secretStore store.SecretStore,
+	mirrorSvc *mirror.Service,
+	repoMembershipStore store.RepoMembershipStore,
+	customRoleStore store.CustomRoleStore,
+	auditService *audit.Service,
+	secretScanService *secretscan.Service,
+	mergeTemplates *mergetemplate.Service,
+	labelService *label.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer, repoStore,
 		spaceStore, pipelineStore,
 		principalStore, ruleStore, principalInfoCache, protectionManager,
-		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager)
+		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager,
+		lfsObjectStore, blobStore, signatureVerifier, pullMirrorStore, encrypter,
+		pushMirrorStore, secretStore, mirrorSvc, repoMembershipStore, customRoleStore,
+		auditService, secretScanService, mergeTemplates, labelService)
 }
@@ -18,9 +18,11 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
@@ -30,11 +32,12 @@ type Controller struct {
 	spaceStore        store.SpaceStore
 	repoStore         store.RepoStore
 	tokenStore        store.TokenStore
+	auditService      *audit.Service
 }
 
 func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
+	tokenStore store.TokenStore, auditService *audit.Service) *Controller {
 	return &Controller{
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
@@ -42,6 +45,7 @@ func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Author
 		spaceStore:        spaceStore,
 		repoStore:         repoStore,
 		tokenStore:        tokenStore,
+		auditService:      auditService,
 	}
 }
 
@@ -49,3 +53,25 @@ func findServiceAccountFromUID(ctx context.Context,
 	principalStore store.PrincipalStore, saUID string) (*types.ServiceAccount, error) {
 	return principalStore.FindServiceAccountByUID(ctx, saUID)
 }
+
+// getTokenAuditResource returns the audit log resource of a service account token.
+// The token is recorded in the audit log of the space the service account belongs to.
+func (c *Controller) getTokenAuditResource(
+	ctx context.Context,
+	sa *types.ServiceAccount,
+	identifier string,
+) audit.Resource {
+	//nolint:exhaustive // any other parent type is recorded as a system-wide resource
+	switch sa.ParentType {
+	case enum.ParentResourceTypeSpace:
+		if space, err := c.spaceStore.Find(ctx, sa.ParentID); err == nil {
+			return audit.NewSpaceResource(enum.AuditResourceTypeToken, identifier, space)
+		}
+	case enum.ParentResourceTypeRepo:
+		if repo, err := c.repoStore.Find(ctx, sa.ParentID); err == nil {
+			return audit.NewRepoResource(enum.AuditResourceTypeToken, identifier, repo)
+		}
+	}
+
+	return audit.NewResource(enum.AuditResourceTypeToken, identifier)
+}
@@ -21,10 +21,13 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreateTokenInput struct {
@@ -68,6 +71,17 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		c.getTokenAuditResource(ctx, sa, token.Identifier),
+		enum.AuditActionCreated,
+		audit.WithNewObject(token),
+		audit.WithData("principal", sa.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create token operation")
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -20,6 +20,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -56,5 +57,20 @@ func (c *Controller) DeleteToken(
 		return usererror.ErrNotFound
 	}
 
-	return c.tokenStore.Delete(ctx, token.ID)
+	if err = c.tokenStore.Delete(ctx, token.ID); err != nil {
+		return err
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		c.getTokenAuditResource(ctx, sa, token.Identifier),
+		enum.AuditActionDeleted,
+		audit.WithOldObject(token),
+		audit.WithData("principal", sa.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for delete token operation")
+	}
+
+	return nil
 }
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AuditLogList lists the audit log entries of a space.
+// Only principals that are allowed to edit the space have access to its audit log.
+func (c *Controller) AuditLogList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, int64, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, 0, err
+	}
+
+	var auditLogs []*types.AuditLog
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		auditLogs, err = c.auditLogStore.List(ctx, space.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit logs for space: %w", err)
+		}
+
+		if filter.Page == 1 && len(auditLogs) < filter.Size {
+			count = int64(len(auditLogs))
+			return nil
+		}
+
+		count, err = c.auditLogStore.Count(ctx, space.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count audit logs for space: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, count, nil
+}
@@ -19,8 +19,10 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -57,6 +59,13 @@ type Controller struct {
 	importer        *importer.Repository
 	exporter        *exporter.Repository
 	resourceLimiter limiter.ResourceLimiter
+
+	userGroupStore           store.UserGroupStore
+	userGroupMembershipStore store.UserGroupMembershipStore
+	customRoleStore          store.CustomRoleStore
+	auditLogStore            store.AuditLogStore
+	auditService             *audit.Service
+	labelService             *label.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -66,6 +75,10 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
 	limiter limiter.ResourceLimiter,
+	userGroupStore store.UserGroupStore, userGroupMembershipStore store.UserGroupMembershipStore,
+	customRoleStore store.CustomRoleStore,
+	auditLogStore store.AuditLogStore, auditService *audit.Service,
+	labelService *label.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled:           config.NestedSpacesEnabled,
@@ -88,5 +101,11 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		importer:                      importer,
 		exporter:                      exporter,
 		resourceLimiter:               limiter,
+		userGroupStore:                userGroupStore,
+		userGroupMembershipStore:      userGroupMembershipStore,
+		customRoleStore:               customRoleStore,
+		auditLogStore:                 auditLogStore,
+		auditService:                  auditService,
+		labelService:                  labelService,
 	}
 }
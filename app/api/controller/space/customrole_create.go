@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CustomRoleCreateInput struct {
+	Identifier  string            `json:"identifier"`
+	DisplayName string            `json:"display_name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+func (in *CustomRoleCreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if isBuiltinMembershipRole(in.Identifier) {
+		return usererror.BadRequestf("Identifier '%s' is reserved for a built-in role.", in.Identifier)
+	}
+
+	in.DisplayName = strings.TrimSpace(in.DisplayName)
+	if in.DisplayName == "" {
+		in.DisplayName = in.Identifier
+	}
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	permissions, err := sanitizeCustomRolePermissions(in.Permissions)
+	if err != nil {
+		return err
+	}
+
+	in.Permissions = permissions
+
+	return nil
+}
+
+// CustomRoleCreate creates a new custom membership role in a space.
+func (c *Controller) CustomRoleCreate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CustomRoleCreateInput,
+) (*types.CustomRole, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	role := &types.CustomRole{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		DisplayName: in.DisplayName,
+		Description: in.Description,
+		Permissions: in.Permissions,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.customRoleStore.Create(ctx, role)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("A custom role with identifier '%s' already exists.",
+			role.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom role: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeCustomRole, role.Identifier, space),
+		enum.AuditActionCreated,
+		audit.WithNewObject(role),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create custom role operation")
+	}
+
+	return role, nil
+}
+
+// isBuiltinMembershipRole returns true if the identifier matches any of the built-in membership roles.
+func isBuiltinMembershipRole(identifier string) bool {
+	for _, role := range enum.MembershipRoles {
+		if strings.EqualFold(string(role), identifier) {
+			return true
+		}
+	}
+
+	for _, role := range enum.RepoMembershipRoles {
+		if strings.EqualFold(string(role), identifier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sanitizeCustomRolePermissions(permissions []enum.Permission) ([]enum.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, usererror.BadRequest("At least one permission must be provided.")
+	}
+
+	sanitized := make([]enum.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		p, ok := permission.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Permission '%s' is not supported.", permission)
+		}
+
+		sanitized = append(sanitized, p)
+	}
+
+	slices.Sort(sanitized)
+
+	return slices.Compact(sanitized), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CustomRoleFind finds a custom membership role of a space.
+func (c *Controller) CustomRoleFind(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) (*types.CustomRole, error) {
+	_, role, err := c.getCustomRoleCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// getCustomRoleCheckAccess fetches a custom role of a space and checks if the current user has the permission
+// for the space.
+func (c *Controller) getCustomRoleCheckAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.Space, *types.CustomRole, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+		return nil, nil, err
+	}
+
+	role, err := c.customRoleStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return space, role, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CustomRoleUpdateInput struct {
+	DisplayName *string            `json:"display_name"`
+	Description *string            `json:"description"`
+	Permissions *[]enum.Permission `json:"permissions"`
+}
+
+func (in *CustomRoleUpdateInput) sanitize() error {
+	if in.DisplayName != nil {
+		*in.DisplayName = strings.TrimSpace(*in.DisplayName)
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Permissions != nil {
+		permissions, err := sanitizeCustomRolePermissions(*in.Permissions)
+		if err != nil {
+			return err
+		}
+
+		*in.Permissions = permissions
+	}
+
+	return nil
+}
+
+// CustomRoleUpdate updates the display name, the description and the permissions of a custom role.
+// The changed permissions apply immediately to all memberships that use the role.
+func (c *Controller) CustomRoleUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *CustomRoleUpdateInput,
+) (*types.CustomRole, error) {
+	space, role, err := c.getCustomRoleCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	oldRole := *role
+
+	if in.DisplayName != nil {
+		role.DisplayName = *in.DisplayName
+	}
+	if in.Description != nil {
+		role.Description = *in.Description
+	}
+	if in.Permissions != nil {
+		role.Permissions = *in.Permissions
+	}
+
+	role.Updated = time.Now().UnixMilli()
+
+	if err = c.customRoleStore.Update(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to update custom role: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeCustomRole, role.Identifier, space),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldRole),
+		audit.WithNewObject(role),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update custom role operation")
+	}
+
+	return role, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// LabelUpdate updates a pull request label of a space.
+func (c *Controller) LabelUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	labelID int64,
+	in *label.UpdateInput,
+) (*types.Label, error) {
+	l, err := c.getLabelCheckAccess(ctx, session, spaceRef, labelID, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.labelService.Update(ctx, l, in)
+}
+
+func (c *Controller) getLabelCheckAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	labelID int64,
+	permission enum.Permission,
+) (*types.Label, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+		return nil, err
+	}
+
+	l, err := c.labelService.FindSpaceLabel(ctx, space.ID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space label: %w", err)
+	}
+
+	return l, nil
+}
@@ -62,6 +62,7 @@ func (c *Controller) ListRepositoriesNoAuth(
 	// backfill URLs
 	for _, repo := range repos {
 		repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+		repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(repo.Path)
 	}
 
 	return repos, count, nil
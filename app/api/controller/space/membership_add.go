@@ -22,11 +22,13 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipAddInput struct {
@@ -43,15 +45,6 @@ func (in *MembershipAddInput) Validate() error {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
 	return nil
 }
 
@@ -75,6 +68,11 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, err
 	}
 
+	in.Role, err = c.sanitizeMembershipRole(ctx, space.ID, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
@@ -100,6 +98,16 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, fmt.Errorf("failed to create new membership: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeMembership, user.UID, space),
+		enum.AuditActionCreated,
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create membership operation")
+	}
+
 	result := &types.MembershipUser{
 		Membership: membership,
 		Principal:  *user.ToPrincipalInfo(),
@@ -108,3 +116,25 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 
 	return result, nil
 }
+
+// sanitizeMembershipRole returns the canonical form of the membership role. The role is either
+// one of the built-in roles or a custom role defined in the space or in any of its ancestors.
+func (c *Controller) sanitizeMembershipRole(ctx context.Context,
+	spaceID int64,
+	role enum.MembershipRole,
+) (enum.MembershipRole, error) {
+	if sanitized, ok := role.Sanitize(); ok {
+		return sanitized, nil
+	}
+
+	customRole, err := c.customRoleStore.FindInherited(ctx, spaceID, string(role))
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "", usererror.BadRequestf("Provided role '%s' is not suppored. "+
+			"Valid values are: %v or the identifier of a custom role.", role, enum.MembershipRoles)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return enum.MembershipRole(customRole.Identifier), nil
+}
@@ -21,8 +21,11 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipUpdateInput struct {
@@ -34,15 +37,6 @@ func (in *MembershipUpdateInput) Validate() error {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
 	return nil
 }
 
@@ -67,6 +61,11 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, err
 	}
 
+	in.Role, err = c.sanitizeMembershipRole(ctx, space.ID, in.Role)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, userUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by uid: %w", err)
@@ -84,6 +83,8 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return membership, nil
 	}
 
+	oldMembership := membership.Membership
+
 	membership.Role = in.Role
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
@@ -91,5 +92,16 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to update membership")
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeMembership, user.UID, space),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(membership.Membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update membership operation")
+	}
+
 	return membership, nil
 }
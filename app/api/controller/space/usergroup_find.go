@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UserGroupFind finds a user group of a space, including its members.
+func (c *Controller) UserGroupFind(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) (*types.UserGroup, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.userGroupStore.ListMembers(ctx, userGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	userGroup.Users = make([]string, len(members))
+	for i, member := range members {
+		userGroup.Users[i] = member.UID
+	}
+
+	return userGroup, nil
+}
+
+// getUserGroupCheckAccess fetches a user group of a space and checks if the current user has the permission
+// for the space.
+func (c *Controller) getUserGroupCheckAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.Space, *types.UserGroup, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+		return nil, nil, err
+	}
+
+	userGroup, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	return space, userGroup, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errUserGroupManagedExternally = usererror.New(http.StatusForbidden,
+	"The user group is managed by an external source and can't be changed.")
+
+type UserGroupMemberAddInput struct {
+	UserUID string `json:"user_uid"`
+}
+
+// UserGroupMemberList lists the members of a user group.
+func (c *Controller) UserGroupMemberList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) ([]types.PrincipalInfo, error) {
+	_, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.userGroupStore.ListMembers(ctx, userGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	return members, nil
+}
+
+// UserGroupMemberAdd adds a user to the members of a user group.
+func (c *Controller) UserGroupMemberAdd(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UserGroupMemberAddInput,
+) (*types.PrincipalInfo, error) {
+	space, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if userGroup.Source != enum.UserGroupSourceGitness {
+		return nil, errUserGroupManagedExternally
+	}
+
+	if in.UserUID == "" {
+		return nil, usererror.BadRequest("UserUID must be provided")
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	err = c.userGroupStore.AddMember(ctx, userGroup.ID, user.ID, time.Now().UnixMilli())
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("User '%s' is already a member of the user group.", user.UID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add user group member: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeUserGroupMember, user.UID, space),
+		enum.AuditActionCreated,
+		audit.WithData("user_group", userGroup.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for add user group member operation")
+	}
+
+	return user.ToPrincipalInfo(), nil
+}
+
+// UserGroupMemberDelete removes a user from the members of a user group.
+func (c *Controller) UserGroupMemberDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	userUID string,
+) error {
+	space, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	if userGroup.Source != enum.UserGroupSourceGitness {
+		return errUserGroupManagedExternally
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	if err = c.userGroupStore.RemoveMember(ctx, userGroup.ID, user.ID); err != nil {
+		return fmt.Errorf("failed to remove user group member: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeUserGroupMember, user.UID, space),
+		enum.AuditActionDeleted,
+		audit.WithData("user_group", userGroup.Identifier),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for remove user group member operation")
+	}
+
+	return nil
+}
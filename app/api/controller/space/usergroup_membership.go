@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UserGroupMembershipAddInput struct {
+	UserGroupID int64               `json:"user_group_id"`
+	Role        enum.MembershipRole `json:"role"`
+}
+
+func (in *UserGroupMembershipAddInput) Validate() error {
+	if in.UserGroupID == 0 {
+		return usererror.BadRequest("UserGroupID must be provided")
+	}
+
+	update := MembershipUpdateInput{Role: in.Role}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UserGroupMembershipList lists all memberships of user groups in a space.
+func (c *Controller) UserGroupMembershipList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]types.UserGroupMembershipInfo, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	memberships, err := c.userGroupMembershipStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group memberships: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// UserGroupMembershipAdd adds a user group as a member of a space, granting the role to all members of the group.
+// The user group has to belong to the space or to one of its ancestors.
+func (c *Controller) UserGroupMembershipAdd(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *UserGroupMembershipAddInput,
+) (*types.UserGroupMembershipInfo, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	if in.Role, err = c.sanitizeMembershipRole(ctx, space.ID, in.Role); err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.findUserGroupOfAncestor(ctx, space, in.UserGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{
+			SpaceID:     space.ID,
+			UserGroupID: userGroup.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      in.Role,
+	}
+
+	err = c.userGroupMembershipStore.Create(ctx, &membership)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("User group '%s' is already a member of the space.",
+			userGroup.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeUserGroupMembership, userGroup.Identifier, space),
+		enum.AuditActionCreated,
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create user group membership operation")
+	}
+
+	return &types.UserGroupMembershipInfo{
+		UserGroupMembership: membership,
+		UserGroup:           *userGroup,
+		AddedBy:             *session.Principal.ToPrincipalInfo(),
+	}, nil
+}
+
+// UserGroupMembershipUpdate changes the role of an existing membership of a user group in a space.
+func (c *Controller) UserGroupMembershipUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupID int64,
+	in *MembershipUpdateInput,
+) (*types.UserGroupMembership, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	if in.Role, err = c.sanitizeMembershipRole(ctx, space.ID, in.Role); err != nil {
+		return nil, err
+	}
+
+	membership, err := c.userGroupMembershipStore.Find(ctx, types.UserGroupMembershipKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group membership for update: %w", err)
+	}
+
+	if membership.Role == in.Role {
+		return membership, nil
+	}
+
+	oldMembership := *membership
+
+	membership.Role = in.Role
+	membership.Updated = time.Now().UnixMilli()
+
+	if err = c.userGroupMembershipStore.Update(ctx, membership); err != nil {
+		return nil, fmt.Errorf("failed to update user group membership: %w", err)
+	}
+
+	userGroupIdentifier := c.userGroupAuditIdentifier(ctx, userGroupID)
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeUserGroupMembership, userGroupIdentifier, space),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update user group membership operation")
+	}
+
+	return membership, nil
+}
+
+// UserGroupMembershipDelete removes an existing membership of a user group from a space.
+func (c *Controller) UserGroupMembershipDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupID int64,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	membershipKey := types.UserGroupMembershipKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroupID,
+	}
+
+	membership, err := c.userGroupMembershipStore.Find(ctx, membershipKey)
+	if err != nil {
+		return fmt.Errorf("failed to find user group membership: %w", err)
+	}
+
+	err = c.userGroupMembershipStore.Delete(ctx, membershipKey)
+	if err != nil {
+		return fmt.Errorf("failed to delete user group membership: %w", err)
+	}
+
+	userGroupIdentifier := c.userGroupAuditIdentifier(ctx, userGroupID)
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeUserGroupMembership, userGroupIdentifier, space),
+		enum.AuditActionDeleted,
+		audit.WithOldObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for delete user group membership operation")
+	}
+
+	return nil
+}
+
+func (c *Controller) findUserGroupOfAncestor(ctx context.Context,
+	space *types.Space,
+	userGroupID int64,
+) (*types.UserGroup, error) {
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User group with id %d not found", userGroupID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	userGroupSpace, err := c.spaceStore.Find(ctx, userGroup.SpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of user group: %w", err)
+	}
+
+	if !paths.IsAncesterOf(userGroupSpace.Path, space.Path) {
+		return nil, usererror.BadRequest("The user group has to belong to the space or one of its parent spaces.")
+	}
+
+	return userGroup, nil
+}
+
+// userGroupAuditIdentifier returns the identifier of the user group used in the audit log.
+// The ID of the user group is used if the user group can't be found.
+func (c *Controller) userGroupAuditIdentifier(ctx context.Context, userGroupID int64) string {
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find user group for audit log")
+		return strconv.FormatInt(userGroupID, 10)
+	}
+
+	return userGroup.Identifier
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UserGroupUpdateInput struct {
+	Identifier  *string `json:"identifier"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (in *UserGroupUpdateInput) sanitize() error {
+	if in.Identifier != nil {
+		*in.Identifier = strings.TrimSpace(*in.Identifier)
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.Name != nil {
+		*in.Name = strings.TrimSpace(*in.Name)
+		if err := check.DisplayName(*in.Name); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UserGroupUpdate updates the identifier, the name and the description of a user group.
+func (c *Controller) UserGroupUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UserGroupUpdateInput,
+) (*types.UserGroup, error) {
+	space, userGroup, err := c.getUserGroupCheckAccess(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.Identifier != nil && !strings.EqualFold(*in.Identifier, userGroup.Identifier) &&
+		userGroup.Source != enum.UserGroupSourceGitness {
+		return nil, errUserGroupManagedExternally
+	}
+
+	oldUserGroup := *userGroup
+
+	if in.Identifier != nil {
+		userGroup.Identifier = *in.Identifier
+	}
+	if in.Name != nil {
+		userGroup.Name = *in.Name
+	}
+	if in.Description != nil {
+		userGroup.Description = *in.Description
+	}
+
+	userGroup.Updated = time.Now().UnixMilli()
+
+	err = c.userGroupStore.Update(ctx, userGroup)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("A user group with identifier '%s' already exists.",
+			userGroup.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user group: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeUserGroup, userGroup.Identifier, space),
+		enum.AuditActionUpdated,
+		audit.WithOldObject(oldUserGroup),
+		audit.WithNewObject(userGroup),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for update user group operation")
+	}
+
+	return userGroup, nil
+}
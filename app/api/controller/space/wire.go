@@ -18,8 +18,10 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -42,10 +44,17 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
 	repoCtrl *repo.Controller, membershipStore store.MembershipStore, importer *importer.Repository,
 	exporter *exporter.Repository, limiter limiter.ResourceLimiter,
+	userGroupStore store.UserGroupStore, userGroupMembershipStore store.UserGroupMembershipStore,
+	customRoleStore store.CustomRoleStore,
+	auditLogStore store.AuditLogStore, auditService *audit.Service,
+	labelService *label.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, identifierCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
 		spaceStore, repoStore, principalStore,
-		repoCtrl, membershipStore, importer, exporter, limiter)
+		repoCtrl, membershipStore, importer, exporter, limiter,
+		userGroupStore, userGroupMembershipStore, customRoleStore,
+		auditLogStore, auditService,
+		labelService)
 }
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AuditLogList lists the audit log entries of the whole system.
+func (c *Controller) AuditLogList(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, int64, error) {
+	// Ensure principal has required permissions (audit log is global, no explicit resource)
+	scope := &types.Scope{}
+	resource := &types.Resource{
+		Type: enum.ResourceTypeUser,
+	}
+	if err := apiauth.Check(ctx, c.authorizer, session, scope, resource, enum.PermissionUserView); err != nil {
+		return nil, 0, err
+	}
+
+	var auditLogs []*types.AuditLog
+	var count int64
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		auditLogs, err = c.auditLogStore.List(ctx, 0, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit logs: %w", err)
+		}
+
+		if filter.Page == 1 && len(auditLogs) < filter.Size {
+			count = int64(len(auditLogs))
+			return nil
+		}
+
+		count, err = c.auditLogStore.Count(ctx, 0, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count audit logs: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, count, nil
+}
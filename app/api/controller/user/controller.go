@@ -18,6 +18,10 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/twofactor"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -28,12 +32,25 @@ import (
 )
 
 type Controller struct {
-	tx                dbtx.Transactor
-	principalUIDCheck check.PrincipalUID
-	authorizer        authz.Authorizer
-	principalStore    store.PrincipalStore
-	tokenStore        store.TokenStore
-	membershipStore   store.MembershipStore
+	tx                       dbtx.Transactor
+	principalUIDCheck        check.PrincipalUID
+	authorizer               authz.Authorizer
+	principalStore           store.PrincipalStore
+	tokenStore               store.TokenStore
+	membershipStore          store.MembershipStore
+	userGroupMembershipStore store.UserGroupMembershipStore
+	repoMembershipStore      store.RepoMembershipStore
+	publicKeyStore           store.PublicKeyStore
+	spaceStore               store.SpaceStore
+	oidcIdentityStore        store.OIDCIdentityStore
+	oidcProvider             *oidc.Provider
+	ldapAuthenticator        *ldap.Authenticator
+	ldapIdentityStore        store.LDAPIdentityStore
+	repoStore                store.RepoStore
+	twoFactorStore           store.TwoFactorStore
+	twoFactor                *twofactor.Authenticator
+	auditLogStore            store.AuditLogStore
+	auditService             *audit.Service
 }
 
 func NewController(
@@ -43,14 +60,40 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	spaceStore store.SpaceStore,
+	oidcIdentityStore store.OIDCIdentityStore,
+	oidcProvider *oidc.Provider,
+	ldapAuthenticator *ldap.Authenticator,
+	ldapIdentityStore store.LDAPIdentityStore,
+	repoStore store.RepoStore,
+	twoFactorStore store.TwoFactorStore,
+	twoFactor *twofactor.Authenticator,
+	auditLogStore store.AuditLogStore,
+	auditService *audit.Service,
 ) *Controller {
 	return &Controller{
-		tx:                tx,
-		principalUIDCheck: principalUIDCheck,
-		authorizer:        authorizer,
-		principalStore:    principalStore,
-		tokenStore:        tokenStore,
-		membershipStore:   membershipStore,
+		tx:                       tx,
+		principalUIDCheck:        principalUIDCheck,
+		authorizer:               authorizer,
+		principalStore:           principalStore,
+		tokenStore:               tokenStore,
+		membershipStore:          membershipStore,
+		userGroupMembershipStore: userGroupMembershipStore,
+		repoMembershipStore:      repoMembershipStore,
+		publicKeyStore:           publicKeyStore,
+		spaceStore:               spaceStore,
+		oidcIdentityStore:        oidcIdentityStore,
+		oidcProvider:             oidcProvider,
+		ldapAuthenticator:        ldapAuthenticator,
+		ldapIdentityStore:        ldapIdentityStore,
+		repoStore:                repoStore,
+		twoFactorStore:           twoFactorStore,
+		twoFactor:                twoFactor,
+		auditLogStore:            auditLogStore,
+		auditService:             auditService,
 	}
 }
 
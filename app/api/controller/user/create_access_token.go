@@ -16,15 +16,21 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreateTokenInput struct {
@@ -32,6 +38,12 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+
+	// Scopes optionally limits the permissions granted by the token.
+	Scopes []enum.Permission `json:"scopes"`
+	// Spaces and Repos optionally restrict the token to the provided spaces and repositories.
+	Spaces []string `json:"spaces"`
+	Repos  []string `json:"repos"`
 }
 
 /*
@@ -57,6 +69,11 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	restrictions, err := c.getTokenRestrictions(ctx, session, in)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,11 +81,23 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		restrictions,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewResource(enum.AuditResourceTypeToken, token.Identifier),
+		enum.AuditActionCreated,
+		audit.WithNewObject(token),
+		audit.WithData("principal", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create token operation")
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -87,5 +116,59 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
+	for i := range in.Scopes {
+		scope, ok := in.Scopes[i].Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid token scope '%s'.", in.Scopes[i])
+		}
+		in.Scopes[i] = scope
+	}
+
 	return nil
 }
+
+// getTokenRestrictions resolves the spaces and repos the token should be restricted to.
+// The caller is required to have access to all of them.
+func (c *Controller) getTokenRestrictions(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateTokenInput,
+) (token.Restrictions, error) {
+	restrictions := token.Restrictions{
+		Scopes: in.Scopes,
+	}
+
+	for _, spaceRef := range in.Spaces {
+		space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return token.Restrictions{}, usererror.BadRequestf("Space '%s' not found.", spaceRef)
+		}
+		if err != nil {
+			return token.Restrictions{}, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+			return token.Restrictions{}, err
+		}
+
+		restrictions.SpaceIDs = append(restrictions.SpaceIDs, space.ID)
+	}
+
+	for _, repoRef := range in.Repos {
+		repo, err := c.repoStore.FindByRef(ctx, repoRef)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return token.Restrictions{}, usererror.BadRequestf("Repository '%s' not found.", repoRef)
+		}
+		if err != nil {
+			return token.Restrictions{}, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, false); err != nil {
+			return token.Restrictions{}, err
+		}
+
+		restrictions.RepoIDs = append(restrictions.RepoIDs, repo.ID)
+	}
+
+	return restrictions, nil
+}
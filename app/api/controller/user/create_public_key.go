@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+type CreatePublicKeyInput struct {
+	Identifier string               `json:"identifier"`
+	Usage      enum.PublicKeyUsage  `json:"usage"`
+	Scheme     enum.PublicKeyScheme `json:"scheme"`
+	Content    string               `json:"content"`
+}
+
+// parsedPublicKey contains the information extracted from the content of a public key.
+type parsedPublicKey struct {
+	fingerprint string
+	comment     string
+	keyType     string
+}
+
+// CreatePublicKey adds a new public key to the user.
+func (c *Controller) CreatePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	key, err := sanitizeCreatePublicKeyInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	// a key used for authentication or signing has to identify a single user.
+	existingKeys, err := c.publicKeyStore.ListByFingerprint(ctx, key.fingerprint,
+		[]enum.PublicKeyUsage{in.Usage})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys by fingerprint: %w", err)
+	}
+
+	if len(existingKeys) > 0 {
+		return nil, usererror.Conflict("The public key is already in use")
+	}
+
+	now := time.Now().UnixMilli()
+
+	publicKey := &types.PublicKey{
+		PrincipalID: user.ID,
+		Created:     now,
+		Verified:    nil,
+		Identifier:  in.Identifier,
+		Usage:       in.Usage,
+		Scheme:      in.Scheme,
+		Fingerprint: key.fingerprint,
+		Content:     in.Content,
+		Comment:     key.comment,
+		Type:        key.keyType,
+	}
+
+	err = c.publicKeyStore.Create(ctx, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert public key: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewResource(enum.AuditResourceTypePublicKey, publicKey.Identifier),
+		enum.AuditActionCreated,
+		audit.WithNewObject(publicKey),
+		audit.WithData("principal", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for create public key operation")
+	}
+
+	return publicKey, nil
+}
+
+func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) (parsedPublicKey, error) {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return parsedPublicKey{}, err
+	}
+
+	scheme, ok := in.Scheme.Sanitize()
+	if !ok {
+		return parsedPublicKey{}, usererror.BadRequest("invalid value for public key scheme")
+	}
+	in.Scheme = scheme
+
+	// pgp keys can only be used to verify signatures.
+	if in.Scheme == enum.PublicKeySchemePGP && in.Usage == "" {
+		in.Usage = enum.PublicKeyUsageSign
+	}
+
+	usage, ok := in.Usage.Sanitize()
+	if !ok {
+		return parsedPublicKey{}, usererror.BadRequest("invalid value for public key usage")
+	}
+	in.Usage = usage
+
+	if in.Scheme == enum.PublicKeySchemePGP && in.Usage != enum.PublicKeyUsageSign {
+		return parsedPublicKey{}, usererror.BadRequest("pgp keys can only be used for signing")
+	}
+
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" {
+		return parsedPublicKey{}, usererror.BadRequest("public key not provided")
+	}
+
+	if in.Scheme == enum.PublicKeySchemePGP {
+		return parsePGPPublicKey(in)
+	}
+
+	return parseSSHPublicKey(in)
+}
+
+func parseSSHPublicKey(in *CreatePublicKeyInput) (parsedPublicKey, error) {
+	key, comment, _, rest, err := ssh.ParseAuthorizedKey([]byte(in.Content))
+	if err != nil {
+		return parsedPublicKey{}, usererror.BadRequestf("invalid public key format: %s", err.Error())
+	}
+
+	if len(rest) > 0 {
+		return parsedPublicKey{}, usererror.BadRequest("only one public key can be provided")
+	}
+
+	// store the key in the canonical authorized keys format
+	in.Content = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+
+	return parsedPublicKey{
+		fingerprint: ssh.FingerprintSHA256(key),
+		comment:     comment,
+		keyType:     key.Type(),
+	}, nil
+}
+
+func parsePGPPublicKey(in *CreatePublicKeyInput) (parsedPublicKey, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(in.Content))
+	if err != nil {
+		return parsedPublicKey{}, usererror.BadRequestf("invalid pgp public key format: %s", err.Error())
+	}
+
+	if len(entities) != 1 {
+		return parsedPublicKey{}, usererror.BadRequest("only one public key can be provided")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return parsedPublicKey{}, usererror.BadRequest("private keys must not be provided")
+	}
+
+	var comment string
+	if identity := entity.PrimaryIdentity(); identity != nil {
+		comment = identity.Name
+	}
+
+	return parsedPublicKey{
+		fingerprint: gitsignature.PGPFingerprint(entity.PrimaryKey),
+		comment:     comment,
+		keyType:     gitsignature.PGPKeyType(entity.PrimaryKey),
+	}, nil
+}
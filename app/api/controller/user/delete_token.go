@@ -20,6 +20,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -63,5 +64,20 @@ func (c *Controller) DeleteToken(
 		return usererror.ErrNotFound
 	}
 
-	return c.tokenStore.Delete(ctx, token.ID)
+	if err = c.tokenStore.Delete(ctx, token.ID); err != nil {
+		return err
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewResource(enum.AuditResourceTypeToken, token.Identifier),
+		enum.AuditActionDeleted,
+		audit.WithOldObject(token),
+		audit.WithData("principal", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for delete token operation")
+	}
+
+	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/ldap"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -38,13 +39,38 @@ type LoginInput struct {
 
 /*
  * Login attempts to login as a specific user - returns the session token if successful.
+ * In case the user has to provide a second factor, the challenge to complete the login with is returned instead.
  */
 func (c *Controller) Login(
 	ctx context.Context,
 	in *LoginInput,
-) (*types.TokenResponse, error) {
+) (*types.LoginResponse, error) {
 	// no auth check required, password is used for it.
 
+	if c.ldapAuthenticator != nil {
+		user, err := c.loginLDAP(ctx, in)
+		switch {
+		case err == nil:
+			return c.completeLogin(ctx, user)
+		case errors.Is(err, ldap.ErrInvalidCredentials):
+			log.Ctx(ctx).Debug().
+				Str("user_uid", in.LoginIdentifier).
+				Msg("invalid LDAP credentials")
+			return nil, usererror.ErrNotFound
+		case errors.Is(err, ldap.ErrUserNotFound):
+			// users that don't exist in the directory (e.g. the admin) login with their local password.
+		default:
+			var userErr *usererror.Error
+			if errors.As(err, &userErr) {
+				return nil, err
+			}
+			// the local password is still validated, so an unavailable directory doesn't lock out local users.
+			log.Ctx(ctx).Warn().Err(err).
+				Str("user_uid", in.LoginIdentifier).
+				Msg("failed to login via LDAP, falling back to the local password")
+		}
+	}
+
 	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
@@ -70,6 +96,10 @@ func (c *Controller) Login(
 		return nil, usererror.ErrNotFound
 	}
 
+	return c.completeLogin(ctx, user)
+}
+
+func (c *Controller) createSession(ctx context.Context, user *types.User) (*types.TokenResponse, error) {
 	tokenIdentifier, err := generateSessionTokenIdentifier()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/rs/zerolog/log"
+)
+
+const maxUserUIDAttempts = 10
+
+var illegalUIDCharacters = regexp.MustCompile(`[^a-zA-Z0-9\-_.]+`)
+
+// loginLDAP validates the credentials against the LDAP directory and returns the user linked to the LDAP user.
+// On the first login, a new user is created if auto provisioning is enabled. The LDAP user is only linked
+// to an existing user with the same uid or email if the admin explicitly allowed it.
+// ldap.ErrUserNotFound is returned if the user doesn't exist in the directory.
+func (c *Controller) loginLDAP(ctx context.Context, in *LoginInput) (*types.User, error) {
+	entry, err := c.ldapAuthenticator.Authenticate(ctx, in.LoginIdentifier, in.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	identity, err := c.ldapIdentityStore.Find(ctx, entry.ExternalID)
+	if err == nil {
+		if err = c.ldapIdentityStore.UpdateLastLogin(ctx, identity.ID, entry.DN, now); err != nil {
+			return nil, fmt.Errorf("failed to update last login of LDAP identity: %w", err)
+		}
+
+		user, err := c.principalStore.FindUser(ctx, identity.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user of LDAP identity: %w", err)
+		}
+
+		return user, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find LDAP identity: %w", err)
+	}
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = c.findExistingLDAPUser(ctx, entry)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			user, err = c.provisionLDAPUser(ctx, entry)
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.ldapIdentityStore.Create(ctx, &types.LDAPIdentity{
+			PrincipalID: user.ID,
+			ExternalID:  entry.ExternalID,
+			DN:          entry.DN,
+			Created:     now,
+			LastLogin:   now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("user_uid", user.UID).
+		Str("ldap_dn", entry.DN).
+		Msg("linked LDAP identity to user")
+
+	return user, nil
+}
+
+// findExistingLDAPUser returns the existing user with the same uid or email as the LDAP user.
+// Existing users are only linked if it's allowed by the admin, as otherwise any directory user
+// with a matching uid or email could take over a local account.
+func (c *Controller) findExistingLDAPUser(ctx context.Context, entry *ldap.Entry) (*types.User, error) {
+	user, err := c.findUserFromLDAPEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.ldapAuthenticator.LinkExistingUsers() {
+		return nil, usererror.Forbidden(
+			"A user with the uid or email of the LDAP user already exists and can't be linked to the LDAP user.")
+	}
+
+	return user, nil
+}
+
+func (c *Controller) findUserFromLDAPEntry(ctx context.Context, entry *ldap.Entry) (*types.User, error) {
+	if entry.UID != "" {
+		user, err := findUserFromUID(ctx, c.principalStore, entry.UID)
+		if !errors.Is(err, store.ErrResourceNotFound) {
+			return user, err
+		}
+	}
+
+	if entry.Email != "" {
+		return findUserFromEmail(ctx, c.principalStore, entry.Email)
+	}
+
+	return nil, store.ErrResourceNotFound
+}
+
+// provisionLDAPUser creates a new user for an LDAP user.
+// The user gets a random password, so the login is only possible via the LDAP directory.
+func (c *Controller) provisionLDAPUser(ctx context.Context, entry *ldap.Entry) (*types.User, error) {
+	if !c.ldapAuthenticator.AutoProvision() {
+		return nil, usererror.Forbidden("There is no user linked to the LDAP user.")
+	}
+
+	if entry.Email == "" {
+		return nil, usererror.Forbidden("The LDAP directory didn't provide an email of the user.")
+	}
+
+	base := entry.UID
+	if base == "" {
+		base, _, _ = strings.Cut(entry.Email, "@")
+	}
+
+	uid, err := c.availableUserUID(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := oidc.GenerateRandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := entry.DisplayName
+	if displayName == "" {
+		displayName = uid
+	}
+
+	user, err := c.CreateNoAuth(ctx, &CreateInput{
+		UID:         uid,
+		Email:       entry.Email,
+		DisplayName: displayName,
+		Password:    password,
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user for LDAP user: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("user_uid", user.UID).
+		Str("ldap_dn", entry.DN).
+		Msg("provisioned user for LDAP user")
+
+	return user, nil
+}
+
+// availableUserUID returns an unused user UID derived from the provided name.
+func (c *Controller) availableUserUID(ctx context.Context, name string) (string, error) {
+	base := strings.Trim(illegalUIDCharacters.ReplaceAllString(name, "-"), "-.")
+	if base == "" {
+		base = "user"
+	}
+	if len(base) > check.MaxIdentifierLength-4 {
+		base = base[:check.MaxIdentifierLength-4]
+	}
+
+	for i := 1; i <= maxUserUIDAttempts; i++ {
+		uid := base
+		if i > 1 {
+			uid = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		if err := c.principalUIDCheck(uid); err != nil {
+			return "", fmt.Errorf("invalid user uid '%s' derived from '%s': %w", uid, name, err)
+		}
+
+		_, err := c.principalStore.FindUserByUID(ctx, uid)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return uid, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to find user: %w", err)
+		}
+	}
+
+	return "", usererror.Conflict("Failed to find an available user uid.")
+}
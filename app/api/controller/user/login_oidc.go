@@ -0,0 +1,329 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// oidcTwoFactorLoginPath is the path of the UI login page users are redirected to if they
+// have to complete the OIDC login with a second factor.
+const oidcTwoFactorLoginPath = "/signin"
+
+var (
+	errOIDCLoginDisabled = usererror.BadRequest("OIDC login is disabled.")
+	errOIDCLoginFailed   = usererror.New(http.StatusUnauthorized, "OIDC login failed.")
+)
+
+// OIDCLoginState is the state of an OIDC login that's kept by the client
+// between the start of the login and the callback of the identity provider.
+type OIDCLoginState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	Redirect     string `json:"redirect"`
+}
+
+type OIDCCallbackInput struct {
+	Code             string
+	State            string
+	Error            string
+	ErrorDescription string
+}
+
+// OIDCLoginStart starts the login via the OIDC identity provider.
+// It returns the state of the login and the URL of the identity provider the user has to be redirected to.
+func (c *Controller) OIDCLoginStart(ctx context.Context, redirect string) (*OIDCLoginState, string, error) {
+	if c.oidcProvider == nil {
+		return nil, "", errOIDCLoginDisabled
+	}
+
+	loginState := &OIDCLoginState{
+		Redirect: sanitizeLoginRedirect(redirect),
+	}
+
+	var err error
+	for _, v := range []*string{&loginState.State, &loginState.Nonce, &loginState.CodeVerifier} {
+		if *v, err = oidc.GenerateRandomString(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	authURL, err := c.oidcProvider.AuthCodeURL(ctx, loginState.State, loginState.Nonce, loginState.CodeVerifier)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate OIDC authorization URL: %w", err)
+	}
+
+	return loginState, authURL, nil
+}
+
+// OIDCLoginCallback completes the login via the OIDC identity provider - returns the session token if successful,
+// together with the URL of the UI the user is redirected to.
+// In case the user has to provide a second factor, no session token is returned and the user is redirected
+// to the login page of the UI instead, with the two-factor challenge in the fragment of the URL.
+// On the first login, the identity is linked to the user with the same verified email,
+// or a new user is created if auto provisioning is enabled.
+func (c *Controller) OIDCLoginCallback(
+	ctx context.Context,
+	loginState *OIDCLoginState,
+	in *OIDCCallbackInput,
+) (*types.LoginResponse, string, error) {
+	if c.oidcProvider == nil {
+		return nil, "", errOIDCLoginDisabled
+	}
+
+	if in.Error != "" {
+		log.Ctx(ctx).Info().Msgf("OIDC login failed at the identity provider: %s: %s", in.Error, in.ErrorDescription)
+		return nil, "", errOIDCLoginFailed
+	}
+
+	if loginState == nil || in.State == "" ||
+		subtle.ConstantTimeCompare([]byte(in.State), []byte(loginState.State)) != 1 {
+		return nil, "", usererror.BadRequest("Invalid OIDC login state.")
+	}
+
+	claims, err := c.oidcProvider.Exchange(ctx, in.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to complete OIDC login")
+		return nil, "", errOIDCLoginFailed
+	}
+
+	user, err := c.findOrCreateOIDCUser(ctx, claims)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if user.Blocked {
+		return nil, "", usererror.Forbidden("The user is blocked.")
+	}
+
+	c.syncOIDCGroupMemberships(ctx, user, claims.Groups)
+
+	loginResponse, err := c.completeLogin(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if loginResponse.TwoFactor != nil {
+		return loginResponse,
+			c.oidcProvider.UIRedirectURL(oidcTwoFactorRedirect(loginResponse.TwoFactor, loginState.Redirect)), nil
+	}
+
+	return loginResponse, c.oidcProvider.UIRedirectURL(loginState.Redirect), nil
+}
+
+// oidcTwoFactorRedirect returns the path of the UI login page the user completes the login with the second factor on.
+// The challenge is passed in the fragment, so it's neither sent to the server nor logged as part of the URL.
+func oidcTwoFactorRedirect(challenge *types.TwoFactorChallenge, redirect string) string {
+	methods := make([]string, len(challenge.Methods))
+	for i, method := range challenge.Methods {
+		methods[i] = string(method)
+	}
+
+	fragment := url.Values{
+		"challenge_token":     {challenge.ChallengeToken},
+		"methods":             {strings.Join(methods, ",")},
+		"enrollment_required": {strconv.FormatBool(challenge.EnrollmentRequired)},
+		"redirect":            {redirect},
+	}
+
+	return oidcTwoFactorLoginPath + "#" + fragment.Encode()
+}
+
+func (c *Controller) findOrCreateOIDCUser(ctx context.Context, claims *oidc.Claims) (*types.User, error) {
+	now := time.Now().UnixMilli()
+
+	identity, err := c.oidcIdentityStore.Find(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		if err = c.oidcIdentityStore.UpdateLastLogin(ctx, identity.ID, now); err != nil {
+			return nil, fmt.Errorf("failed to update last login of OIDC identity: %w", err)
+		}
+
+		user, err := c.principalStore.FindUser(ctx, identity.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user of OIDC identity: %w", err)
+		}
+
+		return user, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find OIDC identity: %w", err)
+	}
+
+	// unverified emails could be used to take over the account of another user.
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, usererror.Forbidden("The identity provider didn't provide a verified email of the user.")
+	}
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = findUserFromEmail(ctx, c.principalStore, claims.Email)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			if !c.oidcProvider.AutoProvision() {
+				return usererror.Forbidden("There is no user with the email of the identity provider account.")
+			}
+
+			user, err = c.provisionOIDCUser(ctx, claims)
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.oidcIdentityStore.Create(ctx, &types.OIDCIdentity{
+			PrincipalID: user.ID,
+			Issuer:      claims.Issuer,
+			Subject:     claims.Subject,
+			Created:     now,
+			LastLogin:   now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("user_uid", user.UID).
+		Str("oidc_subject", claims.Subject).
+		Msg("linked OIDC identity to user")
+
+	return user, nil
+}
+
+// provisionOIDCUser creates a new user for an OIDC identity.
+// The user gets a random password, so the login is only possible via the identity provider.
+func (c *Controller) provisionOIDCUser(ctx context.Context, claims *oidc.Claims) (*types.User, error) {
+	uid, err := c.availableOIDCUserUID(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := oidc.GenerateRandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := claims.Name
+	if displayName == "" {
+		displayName = uid
+	}
+
+	user, err := c.CreateNoAuth(ctx, &CreateInput{
+		UID:         uid,
+		Email:       claims.Email,
+		DisplayName: displayName,
+		Password:    password,
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user for OIDC identity: %w", err)
+	}
+
+	return user, nil
+}
+
+// availableOIDCUserUID returns an unused user UID derived from the preferred username or the email of the user.
+func (c *Controller) availableOIDCUserUID(ctx context.Context, claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	return c.availableUserUID(ctx, base)
+}
+
+// syncOIDCGroupMemberships adds the user as member of the spaces mapped to its groups.
+// Existing memberships of mapped spaces are updated to the mapped role. Memberships are never removed.
+func (c *Controller) syncOIDCGroupMemberships(ctx context.Context, user *types.User, groups []string) {
+	memberships := oidc.MembershipsForGroups(c.oidcProvider.GroupMappings(), groups)
+
+	for spacePath, role := range memberships {
+		err := c.ensureMembership(ctx, user, spacePath, role)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("user_uid", user.UID).
+				Str("space_path", spacePath).
+				Msg("failed to sync space membership of OIDC group")
+		}
+	}
+}
+
+func (c *Controller) ensureMembership(
+	ctx context.Context,
+	user *types.User,
+	spacePath string,
+	role enum.MembershipRole,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spacePath)
+	if err != nil {
+		return fmt.Errorf("failed to find space: %w", err)
+	}
+
+	key := types.MembershipKey{
+		SpaceID:     space.ID,
+		PrincipalID: user.ID,
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership, err := c.membershipStore.Find(ctx, key)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the membership is created on behalf of the user logging in.
+		return c.membershipStore.Create(ctx, &types.Membership{
+			MembershipKey: key,
+			CreatedBy:     user.ID,
+			Created:       now,
+			Updated:       now,
+			Role:          role,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	if membership.Role == role {
+		return nil
+	}
+
+	membership.Role = role
+	membership.Updated = now
+
+	return c.membershipStore.Update(ctx, membership)
+}
+
+// sanitizeLoginRedirect only allows relative redirects to avoid redirecting users to other sites after login.
+func sanitizeLoginRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") ||
+		strings.HasPrefix(redirect, "//") ||
+		strings.Contains(redirect, "\\") {
+		return "/"
+	}
+
+	return redirect
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestOIDCTwoFactorRedirect(t *testing.T) {
+	challenge := &types.TwoFactorChallenge{
+		ChallengeToken: "token",
+		Methods:        []enum.TwoFactorMethod{enum.TwoFactorMethodTOTP, enum.TwoFactorMethodWebAuthn},
+	}
+
+	redirect := oidcTwoFactorRedirect(challenge, "/spaces/a&b")
+
+	path, fragment, ok := strings.Cut(redirect, "#")
+	if !ok || path != oidcTwoFactorLoginPath {
+		t.Fatalf("unexpected redirect %q", redirect)
+	}
+
+	values, err := url.ParseQuery(fragment)
+	if err != nil {
+		t.Fatalf("failed to parse fragment %q: %v", fragment, err)
+	}
+
+	want := map[string]string{
+		"challenge_token":     "token",
+		"methods":             "totp,webauthn",
+		"enrollment_required": "false",
+		"redirect":            "/spaces/a&b",
+	}
+	for key, value := range want {
+		if got := values.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
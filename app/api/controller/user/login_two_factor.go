@@ -0,0 +1,425 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/twofactor"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
+)
+
+var errInvalidTwoFactorChallenge = usererror.New(http.StatusUnauthorized,
+	"The two-factor authentication challenge is invalid or expired, please login again.")
+
+var errTwoFactorLockedOut = usererror.New(http.StatusTooManyRequests,
+	"Too many failed two-factor authentication attempts, please try again later.")
+
+type LoginChallengeInput struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type LoginTwoFactorInput struct {
+	ChallengeToken string               `json:"challenge_token"`
+	Method         enum.TwoFactorMethod `json:"method"`
+	// Code is the TOTP code or the recovery code, depending on the method.
+	Code string `json:"code"`
+	// WebAuthn is the response of the browser to the WebAuthn login.
+	WebAuthn json.RawMessage `json:"webauthn"`
+}
+
+// WebAuthnChallenge contains the options to start a WebAuthn ceremony in the browser with,
+// and the token to complete the ceremony with.
+type WebAuthnChallenge struct {
+	ChallengeToken string          `json:"challenge_token"`
+	Options        json.RawMessage `json:"options"`
+}
+
+// completeLogin completes the login of a user whose first factor was verified.
+// If the user has second factors configured or is required to use them, a challenge is returned
+// instead of the session token.
+func (c *Controller) completeLogin(ctx context.Context, user *types.User) (*types.LoginResponse, error) {
+	status, err := c.getTwoFactorStatus(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.IsEnabled() && !status.Required {
+		tokenResponse, err := c.createSession(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.LoginResponse{TokenResponse: tokenResponse}, nil
+	}
+
+	challengeToken, err := jwt.GenerateForTwoFactor(user.ID, &jwt.SubClaimsTwoFactor{
+		Purpose: jwt.TwoFactorPurposeLogin,
+	}, c.twoFactor.ChallengeLifetime(), user.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate two-factor challenge token: %w", err)
+	}
+
+	challenge := &types.TwoFactorChallenge{
+		ChallengeToken: challengeToken,
+		Methods:        status.Methods(),
+	}
+
+	if !status.IsEnabled() {
+		challenge.EnrollmentRequired = true
+		challenge.Methods = []enum.TwoFactorMethod{enum.TwoFactorMethodTOTP}
+	}
+
+	return &types.LoginResponse{TwoFactor: challenge}, nil
+}
+
+// LoginTwoFactor completes a login with the second factor of the user - returns the session token if successful.
+func (c *Controller) LoginTwoFactor(
+	ctx context.Context,
+	in *LoginTwoFactorInput,
+) (*types.LoginResponse, error) {
+	// no auth check required, the challenge token and the second factor are used for it.
+
+	user, claims, err := c.parseTwoFactorToken(ctx, in.ChallengeToken, jwt.TwoFactorPurposeLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return nil, usererror.BadRequestf("Two-factor authentication method '%s' is not supported", in.Method)
+	}
+
+	now := time.Now()
+	var recoveryCodes []string
+
+	switch method {
+	case enum.TwoFactorMethodTOTP:
+		recoveryCodes, err = c.verifyLoginTOTP(ctx, user, in.Code, now)
+	case enum.TwoFactorMethodRecoveryCode:
+		err = c.verifyRecoveryCode(ctx, user, in.Code, now)
+	case enum.TwoFactorMethodWebAuthn:
+		err = c.verifyWebAuthnLogin(ctx, user, claims.WebAuthnSession, in.WebAuthn, now)
+	}
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("user_uid", user.UID).
+			Str("method", string(method)).
+			Msg("two-factor authentication failed")
+
+		if errors.Is(err, usererror.ErrUnauthorized) {
+			return nil, c.recordFailedTwoFactorAttempt(ctx, user, now)
+		}
+
+		return nil, err
+	}
+
+	err = c.twoFactorStore.ResetFailedAttempts(ctx, user.ID, now.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset failed two-factor attempts: %w", err)
+	}
+
+	tokenResponse, err := c.createSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LoginResponse{
+		TokenResponse: tokenResponse,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// LoginTOTPEnroll generates a TOTP secret for a user that has to enroll a second factor to complete the login.
+func (c *Controller) LoginTOTPEnroll(
+	ctx context.Context,
+	in *LoginChallengeInput,
+) (*types.TOTPEnrollment, error) {
+	user, _, err := c.parseTwoFactorToken(ctx, in.ChallengeToken, jwt.TwoFactorPurposeLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := c.getTwoFactorStatus(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// users with a second factor have to use it, otherwise the enrollment would circumvent it.
+	if status.IsEnabled() {
+		return nil, usererror.Conflict("Two-factor authentication is already enabled")
+	}
+
+	return c.enrollTOTP(ctx, user)
+}
+
+// LoginWebAuthnBegin starts a WebAuthn login for the user of the challenge.
+// The returned challenge token has to be used to complete the login.
+func (c *Controller) LoginWebAuthnBegin(
+	ctx context.Context,
+	in *LoginChallengeInput,
+) (*WebAuthnChallenge, error) {
+	user, _, err := c.parseTwoFactorToken(ctx, in.ChallengeToken, jwt.TwoFactorPurposeLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	if len(credentials) == 0 {
+		return nil, usererror.BadRequest("No WebAuthn credentials are registered")
+	}
+
+	ceremony, err := c.twoFactor.BeginWebAuthnLogin(user, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newWebAuthnChallenge(user, jwt.TwoFactorPurposeLogin, ceremony)
+}
+
+// verifyLoginTOTP verifies the TOTP code of a login. If the user enrolled TOTP as part of the login,
+// the secret gets confirmed by the code and the generated recovery codes are returned.
+func (c *Controller) verifyLoginTOTP(
+	ctx context.Context,
+	user *types.User,
+	code string,
+	now time.Time,
+) ([]string, error) {
+	status, err := c.getTwoFactorStatus(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// unconfirmed secrets are only accepted for an enrollment, never in place of a configured second factor.
+	enrollment := !status.IsEnabled()
+
+	if err = c.verifyTOTP(ctx, user, code, now, enrollment); err != nil {
+		return nil, err
+	}
+
+	if !enrollment {
+		return nil, nil
+	}
+
+	return c.ensureRecoveryCodes(ctx, user, now)
+}
+
+// verifyTOTP verifies the TOTP code of the user and confirms the secret if it wasn't confirmed before.
+func (c *Controller) verifyTOTP(
+	ctx context.Context,
+	user *types.User,
+	code string,
+	now time.Time,
+	allowUnconfirmed bool,
+) error {
+	secret, err := c.findTOTP(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil || (!secret.Confirmed && !allowUnconfirmed) {
+		return usererror.BadRequest("TOTP is not enabled")
+	}
+
+	step, err := c.twoFactor.ValidateTOTP(secret.Secret, code, now)
+	if errors.Is(err, twofactor.ErrInvalidCode) {
+		return usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return fmt.Errorf("failed to validate TOTP code: %w", err)
+	}
+
+	ok, err := c.twoFactorStore.UseTOTPStep(ctx, user.ID, step, now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to mark TOTP code as used: %w", err)
+	}
+
+	// the code (or a later one) was used already.
+	if !ok {
+		return usererror.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func (c *Controller) verifyRecoveryCode(ctx context.Context, user *types.User, code string, now time.Time) error {
+	ok, err := c.twoFactorStore.UseRecoveryCode(ctx, user.ID, twofactor.HashRecoveryCode(code), now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	if !ok {
+		return usererror.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func (c *Controller) verifyWebAuthnLogin(
+	ctx context.Context,
+	user *types.User,
+	session json.RawMessage,
+	response json.RawMessage,
+	now time.Time,
+) error {
+	if len(session) == 0 {
+		return usererror.BadRequest("The WebAuthn login has to be started first")
+	}
+
+	credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	credential, err := c.twoFactor.FinishWebAuthnLogin(user, credentials, session, response)
+	if errors.Is(err, twofactor.ErrInvalidWebAuthnResponse) {
+		return usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return fmt.Errorf("failed to finish WebAuthn login: %w", err)
+	}
+
+	credential.LastUsed = now.UnixMilli()
+
+	if err = c.twoFactorStore.UpdateWebAuthnCredential(ctx, credential); err != nil {
+		return fmt.Errorf("failed to update WebAuthn credential: %w", err)
+	}
+
+	return nil
+}
+
+// parseTwoFactorToken verifies the token of an operation pending a second factor
+// and returns the user it was issued for.
+func (c *Controller) parseTwoFactorToken(
+	ctx context.Context,
+	str string,
+	purpose jwt.TwoFactorPurpose,
+) (*types.User, *jwt.SubClaimsTwoFactor, error) {
+	var user *types.User
+	claims := &jwt.Claims{}
+	parsed, err := gojwt.ParseWithClaims(str, claims, func(token *gojwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*gojwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid HMAC signature for JWT")
+		}
+
+		var err error
+		user, err = c.principalStore.FindUser(ctx, claims.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user for token: %w", err)
+		}
+
+		return []byte(user.Salt), nil
+	})
+	if err != nil || !parsed.Valid || claims.TwoFactor == nil || claims.TwoFactor.Purpose != purpose {
+		log.Ctx(ctx).Debug().Err(err).Msg("invalid two-factor challenge token")
+		return nil, nil, errInvalidTwoFactorChallenge
+	}
+
+	if purpose == jwt.TwoFactorPurposeLogin {
+		if err = c.checkTwoFactorLockout(ctx, user, claims.IssuedAt, time.Now()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return user, claims.TwoFactor, nil
+}
+
+// checkTwoFactorLockout rejects the login challenges of users that are locked out
+// and the challenges issued before the last lockout of the user.
+func (c *Controller) checkTwoFactorLockout(
+	ctx context.Context,
+	user *types.User,
+	issuedAt int64,
+	now time.Time,
+) error {
+	lockout, err := c.twoFactorStore.FindLockout(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find two-factor lockout: %w", err)
+	}
+
+	if lockout.IsLocked(now.UnixMilli()) {
+		return errTwoFactorLockedOut
+	}
+
+	// the issue time of the token is in seconds, the lockout time in milliseconds.
+	if issuedAt*1000 <= lockout.InvalidBefore {
+		return errInvalidTwoFactorChallenge
+	}
+
+	return nil
+}
+
+// recordFailedTwoFactorAttempt records a failed second factor attempt of a user and locks the user out
+// once the maximum number of failed attempts is reached. It returns the error to respond with.
+func (c *Controller) recordFailedTwoFactorAttempt(ctx context.Context, user *types.User, now time.Time) error {
+	failedAttempts, err := c.twoFactorStore.RecordFailedAttempt(ctx, user.ID, now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to record failed two-factor attempt: %w", err)
+	}
+
+	maxFailedAttempts := c.twoFactor.MaxFailedAttempts()
+	if maxFailedAttempts <= 0 || failedAttempts < maxFailedAttempts {
+		return usererror.ErrUnauthorized
+	}
+
+	lockedUntil := now.Add(c.twoFactor.LockoutDuration())
+
+	err = c.twoFactorStore.LockOut(ctx, user.ID, lockedUntil.UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to lock out two-factor login: %w", err)
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("user_uid", user.UID).
+		Int("failed_attempts", failedAttempts).
+		Time("locked_until", lockedUntil).
+		Msg("user locked out after too many failed two-factor attempts")
+
+	return errTwoFactorLockedOut
+}
+
+func (c *Controller) newWebAuthnChallenge(
+	user *types.User,
+	purpose jwt.TwoFactorPurpose,
+	ceremony *twofactor.WebAuthnCeremony,
+) (*WebAuthnChallenge, error) {
+	challengeToken, err := jwt.GenerateForTwoFactor(user.ID, &jwt.SubClaimsTwoFactor{
+		Purpose:         purpose,
+		WebAuthnSession: ceremony.Session,
+	}, c.twoFactor.ChallengeLifetime(), user.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate WebAuthn challenge token: %w", err)
+	}
+
+	return &WebAuthnChallenge{
+		ChallengeToken: challengeToken,
+		Options:        ceremony.Options,
+	}, nil
+}
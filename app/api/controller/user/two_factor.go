@@ -0,0 +1,286 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errTwoFactorRequired = usererror.BadRequest(
+	"Two-factor authentication is required, the last second factor can't be removed")
+
+// TwoFactorStatus returns the second factors the user has configured.
+func (c *Controller) TwoFactorStatus(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.TwoFactorStatus, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.getTwoFactorStatus(ctx, user)
+}
+
+// TwoFactorReset removes all second factors of the user, e.g. if the user lost access to all of them.
+func (c *Controller) TwoFactorReset(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.twoFactorStore.DeleteTOTP(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete TOTP secret: %w", err)
+		}
+
+		if err := c.twoFactorStore.DeleteRecoveryCodes(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+
+		credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+		}
+
+		for _, credential := range credentials {
+			if err := c.twoFactorStore.DeleteWebAuthnCredential(ctx, user.ID, credential.ID); err != nil {
+				return fmt.Errorf("failed to delete WebAuthn credential: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewResource(enum.AuditResourceTypeUser, user.UID),
+		enum.AuditActionUpdated,
+		audit.WithData("two_factor", "reset"),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for two-factor reset operation")
+	}
+
+	return nil
+}
+
+func (c *Controller) getTwoFactorStatus(ctx context.Context, user *types.User) (*types.TwoFactorStatus, error) {
+	status := &types.TwoFactorStatus{}
+
+	secret, err := c.findTOTP(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	status.TOTPEnabled = secret != nil && secret.Confirmed
+
+	status.WebAuthnCredentials, err = c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	status.RecoveryCodesLeft, err = c.twoFactorStore.CountRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	status.Required, err = c.isTwoFactorRequired(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// isTwoFactorRequired returns true if the user has to login with a second factor,
+// either because it's required for all users or because the user is a member of a space that requires it.
+// Memberships in ancestors of the space and in spaces or repos within it count as well,
+// no matter if the user is a member directly or via a user group.
+func (c *Controller) isTwoFactorRequired(ctx context.Context, user *types.User) (bool, error) {
+	if c.twoFactor.IsRequiredForAll() {
+		return true, nil
+	}
+
+	requiredPaths, err := c.listTwoFactorRequiredPaths(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if len(requiredPaths) == 0 {
+		return false, nil
+	}
+
+	spacePaths, repoPaths, err := c.listMembershipPaths(ctx, user)
+	if err != nil {
+		return false, err
+	}
+
+	for _, requiredPath := range requiredPaths {
+		for _, spacePath := range spacePaths {
+			if isSameOrWithinPath(spacePath, requiredPath) || isSameOrWithinPath(requiredPath, spacePath) {
+				return true, nil
+			}
+		}
+
+		for _, repoPath := range repoPaths {
+			if isSameOrWithinPath(repoPath, requiredPath) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// listTwoFactorRequiredPaths returns the lower case paths of all spaces that require
+// two-factor authentication, either configured by an admin or in the system config.
+func (c *Controller) listTwoFactorRequiredPaths(ctx context.Context) ([]string, error) {
+	requiredSpaces, err := c.twoFactorStore.ListRequiredSpaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list two-factor required spaces: %w", err)
+	}
+
+	paths := make([]string, 0, len(requiredSpaces)+len(c.twoFactor.RequiredSpaces()))
+
+	for _, requiredSpace := range requiredSpaces {
+		space, err := c.spaceStore.Find(ctx, requiredSpace.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", requiredSpace.SpaceID, err)
+		}
+
+		paths = append(paths, strings.ToLower(space.Path))
+	}
+
+	for _, spacePath := range c.twoFactor.RequiredSpaces() {
+		space, err := c.spaceStore.FindByRef(ctx, spacePath)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().
+				Str("space_path", spacePath).
+				Msg("space that requires two-factor authentication doesn't exist")
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space '%s': %w", spacePath, err)
+		}
+
+		paths = append(paths, strings.ToLower(space.Path))
+	}
+
+	return paths, nil
+}
+
+// listMembershipPaths returns the lower case paths of all spaces and repos the user is a member of,
+// either directly or via a user group.
+func (c *Controller) listMembershipPaths(
+	ctx context.Context,
+	user *types.User,
+) ([]string, []string, error) {
+	spaceIDs, err := c.membershipStore.ListSpaceIDs(ctx, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list space memberships: %w", err)
+	}
+
+	groupSpaceIDs, err := c.userGroupMembershipStore.ListSpaceIDs(ctx, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list user group space memberships: %w", err)
+	}
+
+	repoIDs, err := c.repoMembershipStore.ListRepoIDs(ctx, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list repo memberships: %w", err)
+	}
+
+	spaceIDs = append(spaceIDs, groupSpaceIDs...)
+
+	spacePaths := make([]string, 0, len(spaceIDs))
+	for _, spaceID := range spaceIDs {
+		space, err := c.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spacePaths = append(spacePaths, strings.ToLower(space.Path))
+	}
+
+	repoPaths := make([]string, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		repo, err := c.repoStore.Find(ctx, repoID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			// the repo is soft deleted.
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find repo %d: %w", repoID, err)
+		}
+
+		repoPaths = append(repoPaths, strings.ToLower(repo.Path))
+	}
+
+	return spacePaths, repoPaths, nil
+}
+
+// isSameOrWithinPath returns true if the path is equal to or nested below the parent path.
+func isSameOrWithinPath(path string, parentPath string) bool {
+	return path == parentPath || strings.HasPrefix(path, parentPath+"/")
+}
+
+// checkSecondFactorRemovable ensures a user that's required to use two-factor authentication
+// keeps at least one second factor.
+func (c *Controller) checkSecondFactorRemovable(ctx context.Context, user *types.User, remaining int) error {
+	if remaining > 0 {
+		return nil
+	}
+
+	required, err := c.isTwoFactorRequired(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		return errTwoFactorRequired
+	}
+
+	return nil
+}
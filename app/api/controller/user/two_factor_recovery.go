@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/twofactor"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RecoveryCodesGenerate generates a new set of recovery codes for the user, invalidating all existing ones.
+func (c *Controller) RecoveryCodesGenerate(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.RecoveryCodes, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	status, err := c.getTwoFactorStatus(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.IsEnabled() {
+		return nil, usererror.BadRequest("Two-factor authentication is not enabled")
+	}
+
+	codes, err := c.generateRecoveryCodes(ctx, user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RecoveryCodes{Codes: codes}, nil
+}
+
+// ensureRecoveryCodes generates recovery codes for the user in case the user has none left.
+// It returns the generated codes, or nil if the user still has unused codes.
+func (c *Controller) ensureRecoveryCodes(ctx context.Context, user *types.User, now time.Time) ([]string, error) {
+	count, err := c.twoFactorStore.CountRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	if count > 0 {
+		return nil, nil
+	}
+
+	return c.generateRecoveryCodes(ctx, user, now)
+}
+
+func (c *Controller) generateRecoveryCodes(ctx context.Context, user *types.User, now time.Time) ([]string, error) {
+	codes, hashes, err := twofactor.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.twoFactorStore.ReplaceRecoveryCodes(ctx, user.ID, hashes, now.UnixMilli())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
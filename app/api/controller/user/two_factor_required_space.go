@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TwoFactorRequiredSpaceList lists the spaces whose members have to login with a second factor.
+// Spaces configured in the system config aren't included.
+func (c *Controller) TwoFactorRequiredSpaceList(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.TwoFactorRequiredSpace, error) {
+	if err := c.checkTwoFactorRequiredSpaceAccess(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	requiredSpaces, err := c.twoFactorStore.ListRequiredSpaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list two-factor required spaces: %w", err)
+	}
+
+	for _, requiredSpace := range requiredSpaces {
+		space, err := c.spaceStore.Find(ctx, requiredSpace.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", requiredSpace.SpaceID, err)
+		}
+
+		requiredSpace.Path = space.Path
+	}
+
+	return requiredSpaces, nil
+}
+
+// TwoFactorRequiredSpaceAdd requires the members of the space to login with a second factor.
+func (c *Controller) TwoFactorRequiredSpaceAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.TwoFactorRequiredSpace, error) {
+	if err := c.checkTwoFactorRequiredSpaceAccess(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	requiredSpace := &types.TwoFactorRequiredSpace{
+		SpaceID:   space.ID,
+		Path:      space.Path,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	if err = c.twoFactorStore.AddRequiredSpace(ctx, requiredSpace); err != nil {
+		return nil, fmt.Errorf("failed to add two-factor required space: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeTwoFactorRequirement, space.Identifier, space),
+		enum.AuditActionCreated,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for two-factor requirement create operation")
+	}
+
+	return requiredSpace, nil
+}
+
+// TwoFactorRequiredSpaceDelete removes the second factor requirement of the space.
+func (c *Controller) TwoFactorRequiredSpaceDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	if err := c.checkTwoFactorRequiredSpaceAccess(ctx, session, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return fmt.Errorf("failed to find space: %w", err)
+	}
+
+	err = c.twoFactorStore.DeleteRequiredSpace(ctx, space.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.NotFound("Two-factor authentication isn't required for the space")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete two-factor required space: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		&session.Principal,
+		audit.NewSpaceResource(enum.AuditResourceTypeTwoFactorRequirement, space.Identifier, space),
+		enum.AuditActionDeleted,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to insert audit log for two-factor requirement delete operation")
+	}
+
+	return nil
+}
+
+// checkTwoFactorRequiredSpaceAccess ensures the principal can manage the two-factor requirement,
+// which is a system wide setting (no explicit resource).
+func (c *Controller) checkTwoFactorRequiredSpaceAccess(
+	ctx context.Context,
+	session *auth.Session,
+	permission enum.Permission,
+) error {
+	scope := &types.Scope{}
+	resource := &types.Resource{
+		Type: enum.ResourceTypeUser,
+	}
+
+	return apiauth.Check(ctx, c.authorizer, session, scope, resource, permission)
+}
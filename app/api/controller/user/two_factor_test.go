@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import "testing"
+
+func TestIsSameOrWithinPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		parentPath string
+		want       bool
+	}{
+		{name: "same", path: "org/team", parentPath: "org/team", want: true},
+		{name: "child space", path: "org/team/sub", parentPath: "org/team", want: true},
+		{name: "repo", path: "org/team/repo", parentPath: "org", want: true},
+		{name: "ancestor", path: "org", parentPath: "org/team", want: false},
+		{name: "sibling with common prefix", path: "org/team2", parentPath: "org/team", want: false},
+		{name: "unrelated", path: "other/team", parentPath: "org/team", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isSameOrWithinPath(test.path, test.parentPath); got != test.want {
+				t.Errorf("isSameOrWithinPath(%q, %q) = %t, want %t", test.path, test.parentPath, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type TOTPConfirmInput struct {
+	Code string `json:"code"`
+}
+
+// TOTPEnroll generates a new TOTP secret for the user.
+// The secret has to be confirmed with a code before it can be used for the login.
+func (c *Controller) TOTPEnroll(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.TOTPEnrollment, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	secret, err := c.findTOTP(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret != nil && secret.Confirmed {
+		return nil, usererror.Conflict("TOTP is already enabled")
+	}
+
+	return c.enrollTOTP(ctx, user)
+}
+
+// TOTPConfirm confirms the TOTP secret of the user with a code generated by the authenticator app.
+// If the user has no recovery codes yet, they are generated and returned.
+func (c *Controller) TOTPConfirm(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *TOTPConfirmInput,
+) (*types.RecoveryCodes, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if err = c.verifyTOTP(ctx, user, in.Code, now, true); err != nil {
+		return nil, err
+	}
+
+	codes, err := c.ensureRecoveryCodes(ctx, user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RecoveryCodes{Codes: codes}, nil
+}
+
+// TOTPDelete disables TOTP for the user.
+func (c *Controller) TOTPDelete(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	secret, err := c.findTOTP(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil {
+		return nil
+	}
+
+	credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	if secret.Confirmed {
+		if err = c.checkSecondFactorRemovable(ctx, user, len(credentials)); err != nil {
+			return err
+		}
+	}
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.twoFactorStore.DeleteTOTP(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete TOTP secret: %w", err)
+		}
+
+		// recovery codes are only kept as long as the user has any other second factor.
+		if len(credentials) == 0 {
+			if err := c.twoFactorStore.DeleteRecoveryCodes(ctx, user.ID); err != nil {
+				return fmt.Errorf("failed to delete recovery codes: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// findTOTP returns the TOTP secret of the user, or nil if the user has none.
+func (c *Controller) findTOTP(ctx context.Context, user *types.User) (*types.TOTPSecret, error) {
+	secret, err := c.twoFactorStore.FindTOTP(ctx, user.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find TOTP secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+// enrollTOTP generates a new (unconfirmed) TOTP secret for the user, replacing any unconfirmed one.
+func (c *Controller) enrollTOTP(ctx context.Context, user *types.User) (*types.TOTPEnrollment, error) {
+	enrollment, encryptedSecret, err := c.twoFactor.GenerateTOTP(user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	err = c.twoFactorStore.UpsertTOTP(ctx, &types.TOTPSecret{
+		PrincipalID:  user.ID,
+		Secret:       encryptedSecret,
+		Confirmed:    false,
+		LastUsedStep: 0,
+		Created:      now,
+		Updated:      now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store TOTP secret: %w", err)
+	}
+
+	return enrollment, nil
+}
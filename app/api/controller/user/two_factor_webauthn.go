@@ -0,0 +1,231 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/twofactor"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type WebAuthnRegisterInput struct {
+	ChallengeToken string `json:"challenge_token"`
+	Name           string `json:"name"`
+	// Credential is the response of the browser to the WebAuthn registration.
+	Credential json.RawMessage `json:"credential"`
+}
+
+type WebAuthnRegisterOutput struct {
+	Credential *types.WebAuthnCredential `json:"credential"`
+	// RecoveryCodes are returned if the credential is the first second factor of the user.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+func (in *WebAuthnRegisterInput) sanitize() error {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		in.Name = "Security key"
+	}
+
+	if err := check.DisplayName(in.Name); err != nil {
+		return err
+	}
+
+	if len(in.Credential) == 0 {
+		return usererror.BadRequest("Credential must be provided")
+	}
+
+	return nil
+}
+
+// WebAuthnRegisterBegin starts the registration of a new WebAuthn credential (e.g. a security key).
+// The returned challenge token has to be used to complete the registration.
+func (c *Controller) WebAuthnRegisterBegin(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*WebAuthnChallenge, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	ceremony, err := c.twoFactor.BeginWebAuthnRegistration(user, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newWebAuthnChallenge(user, jwt.TwoFactorPurposeWebAuthnRegistration, ceremony)
+}
+
+// WebAuthnRegisterFinish completes the registration of a new WebAuthn credential.
+func (c *Controller) WebAuthnRegisterFinish(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *WebAuthnRegisterInput,
+) (*WebAuthnRegisterOutput, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	challengeUser, claims, err := c.parseTwoFactorToken(ctx, in.ChallengeToken,
+		jwt.TwoFactorPurposeWebAuthnRegistration)
+	if err != nil {
+		return nil, err
+	}
+
+	if challengeUser.ID != user.ID {
+		return nil, errInvalidTwoFactorChallenge
+	}
+
+	credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	credential, err := c.twoFactor.FinishWebAuthnRegistration(user, credentials, claims.WebAuthnSession,
+		in.Credential)
+	if errors.Is(err, twofactor.ErrInvalidWebAuthnResponse) {
+		return nil, usererror.BadRequestf("Failed to register WebAuthn credential: %s", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to finish WebAuthn registration: %w", err)
+	}
+
+	now := time.Now()
+
+	credential.Name = in.Name
+	credential.Created = now.UnixMilli()
+	credential.LastUsed = now.UnixMilli()
+
+	err = c.twoFactorStore.CreateWebAuthnCredential(ctx, credential)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The WebAuthn credential is already registered")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebAuthn credential: %w", err)
+	}
+
+	codes, err := c.ensureRecoveryCodes(ctx, user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRegisterOutput{
+		Credential:    credential,
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// WebAuthnCredentialList lists the WebAuthn credentials of the user.
+func (c *Controller) WebAuthnCredentialList(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.WebAuthnCredential, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	credentials, err := c.twoFactorStore.ListWebAuthnCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// WebAuthnCredentialDelete deletes a WebAuthn credential of the user.
+func (c *Controller) WebAuthnCredentialDelete(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	status, err := c.getTwoFactorStatus(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	remaining := len(status.WebAuthnCredentials) - 1
+	if status.TOTPEnabled {
+		remaining++
+	}
+
+	if err = c.checkSecondFactorRemovable(ctx, user, remaining); err != nil {
+		return err
+	}
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.twoFactorStore.DeleteWebAuthnCredential(ctx, user.ID, id); err != nil {
+			return fmt.Errorf("failed to delete WebAuthn credential: %w", err)
+		}
+
+		// recovery codes are only kept as long as the user has any other second factor.
+		if remaining == 0 {
+			if err := c.twoFactorStore.DeleteRecoveryCodes(ctx, user.ID); err != nil {
+				return fmt.Errorf("failed to delete recovery codes: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -16,6 +16,10 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/twofactor"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -35,6 +39,19 @@ func ProvideController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	spaceStore store.SpaceStore,
+	oidcIdentityStore store.OIDCIdentityStore,
+	oidcProvider *oidc.Provider,
+	ldapAuthenticator *ldap.Authenticator,
+	ldapIdentityStore store.LDAPIdentityStore,
+	repoStore store.RepoStore,
+	twoFactorStore store.TwoFactorStore,
+	twoFactor *twofactor.Authenticator,
+	auditLogStore store.AuditLogStore,
+	auditService *audit.Service,
 ) *Controller {
 	return NewController(
 		tx,
@@ -42,5 +59,18 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		userGroupMembershipStore,
+		repoMembershipStore,
+		publicKeyStore,
+		spaceStore,
+		oidcIdentityStore,
+		oidcProvider,
+		ldapAuthenticator,
+		ldapIdentityStore,
+		repoStore,
+		twoFactorStore,
+		twoFactor,
+		auditLogStore,
+		auditService)
 }
@@ -33,6 +33,7 @@ func createRPCWriteParams(
 	session *auth.Session,
 	repo *types.Repository,
 	isInternal bool,
+	scanSecrets bool,
 ) (git.WriteParams, error) {
 	// generate envars (add everything githook CLI needs for execution)
 	envVars, err := githook.GenerateEnvironmentVariables(
@@ -42,6 +43,7 @@ func createRPCWriteParams(
 		session.Principal.ID,
 		false,
 		isInternal,
+		scanSecrets,
 	)
 	if err != nil {
 		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
@@ -65,7 +67,7 @@ func CreateRPCExternalWriteParams(
 	session *auth.Session,
 	repo *types.Repository,
 ) (git.WriteParams, error) {
-	return createRPCWriteParams(ctx, urlProvider, session, repo, false)
+	return createRPCWriteParams(ctx, urlProvider, session, repo, false, false)
 }
 
 // CreateRPCInternalWriteParams creates base write parameters for git internal write operations.
@@ -76,7 +78,7 @@ func CreateRPCInternalWriteParams(
 	session *auth.Session,
 	repo *types.Repository,
 ) (git.WriteParams, error) {
-	return createRPCWriteParams(ctx, urlProvider, session, repo, true)
+	return createRPCWriteParams(ctx, urlProvider, session, repo, true, false)
 }
 
 func MapCommit(c *git.Commit) (*types.Commit, error) {
@@ -134,3 +136,15 @@ func MapSignature(s *git.Signature) (*types.Signature, error) {
 		When: s.When,
 	}, nil
 }
+
+// CreateRPCInternalCommitWriteParams creates base write parameters for git internal write operations
+// that push commits with user provided content (e.g. commits created via the API).
+// Unlike other internal write operations, the pushed commits are scanned for secrets.
+func CreateRPCInternalCommitWriteParams(
+	ctx context.Context,
+	urlProvider url.Provider,
+	session *auth.Session,
+	repo *types.Repository,
+) (git.WriteParams, error) {
+	return createRPCWriteParams(ctx, urlProvider, session, repo, true, true)
+}
@@ -35,16 +35,16 @@ func HandleLogin(userCtrl *user.Controller, cookieName string) http.HandlerFunc
 			return
 		}
 
-		tokenResponse, err := userCtrl.Login(ctx, in)
+		loginResponse, err := userCtrl.Login(ctx, in)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
 		}
 
-		if cookieName != "" {
-			includeTokenCookie(r, w, tokenResponse, cookieName)
+		if cookieName != "" && loginResponse.TokenResponse != nil {
+			includeTokenCookie(r, w, loginResponse.TokenResponse, cookieName)
 		}
 
-		render.JSON(w, http.StatusOK, tokenResponse)
+		render.JSON(w, http.StatusOK, loginResponse)
 	}
 }
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+)
+
+// HandleLoginTwoFactor returns an http.HandlerFunc that completes a login
+// with the second factor of the user and returns an authentication token on success.
+func HandleLoginTwoFactor(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := new(user.LoginTwoFactorInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		loginResponse, err := userCtrl.LoginTwoFactor(ctx, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if cookieName != "" && loginResponse.TokenResponse != nil {
+			includeTokenCookie(r, w, loginResponse.TokenResponse, cookieName)
+		}
+
+		render.JSON(w, http.StatusOK, loginResponse)
+	}
+}
+
+// HandleLoginTOTPEnroll returns an http.HandlerFunc that generates a TOTP secret
+// for a user that has to enroll a second factor to complete the login.
+func HandleLoginTOTPEnroll(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := new(user.LoginChallengeInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		enrollment, err := userCtrl.LoginTOTPEnroll(ctx, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, enrollment)
+	}
+}
+
+// HandleLoginWebAuthnBegin returns an http.HandlerFunc that starts
+// the WebAuthn login of a user.
+func HandleLoginWebAuthnBegin(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := new(user.LoginChallengeInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		challenge, err := userCtrl.LoginWebAuthnBegin(ctx, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, challenge)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	oidcLoginStateCookieSuffix = "_oidc_login"
+	oidcLoginStateMaxAge       = 10 * time.Minute
+)
+
+// HandleOIDCLogin returns an http.HandlerFunc that starts the login via the OIDC identity provider.
+// The state of the login is stored in a cookie and the user is redirected to the identity provider.
+func HandleOIDCLogin(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		loginState, authURL, err := userCtrl.OIDCLoginStart(ctx, r.URL.Query().Get("redirect"))
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		data, err := json.Marshal(loginState)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		cookie := newOIDCLoginStateCookie(r, cookieName)
+		cookie.Value = base64.RawURLEncoding.EncodeToString(data)
+		cookie.Expires = time.Now().Add(oidcLoginStateMaxAge)
+		http.SetCookie(w, cookie)
+
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// HandleOIDCCallback returns an http.HandlerFunc that completes the login via the OIDC identity provider.
+// On success, the token cookie is set and the user is redirected to the UI.
+// If the user has to provide a second factor, no cookie is set and the user is redirected
+// to the login page of the UI to complete the login.
+func HandleOIDCCallback(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		loginState, err := readOIDCLoginState(r, cookieName)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to read OIDC login state")
+		}
+
+		// the login state is single use.
+		deleteOIDCLoginStateCookie(r, w, cookieName)
+
+		query := r.URL.Query()
+		in := &user.OIDCCallbackInput{
+			Code:             query.Get("code"),
+			State:            query.Get("state"),
+			Error:            query.Get("error"),
+			ErrorDescription: query.Get("error_description"),
+		}
+
+		loginResponse, redirectURL, err := userCtrl.OIDCLoginCallback(ctx, loginState, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if cookieName != "" && loginResponse.TokenResponse != nil {
+			includeTokenCookie(r, w, loginResponse.TokenResponse, cookieName)
+		}
+
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+	}
+}
+
+func readOIDCLoginState(r *http.Request, cookieName string) (*user.OIDCLoginState, error) {
+	cookie, err := r.Cookie(cookieName + oidcLoginStateCookieSuffix)
+	if errors.Is(err, http.ErrNoCookie) {
+		return nil, nil //nolint:nilnil // the missing login state is handled by the controller
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := &user.OIDCLoginState{}
+	if err = json.Unmarshal(data, loginState); err != nil {
+		return nil, err
+	}
+
+	return loginState, nil
+}
+
+func deleteOIDCLoginStateCookie(r *http.Request, w http.ResponseWriter, cookieName string) {
+	cookie := newOIDCLoginStateCookie(r, cookieName)
+	cookie.Expires = time.UnixMilli(0)
+	http.SetCookie(w, cookie)
+}
+
+func newOIDCLoginStateCookie(r *http.Request, cookieName string) *http.Cookie {
+	return &http.Cookie{
+		Name: cookieName + oidcLoginStateCookieSuffix,
+		// the cookie has to be sent on the redirect from the identity provider.
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
@@ -43,16 +43,16 @@ func HandleRegister(userCtrl *user.Controller, sysCtrl *system.Controller, cooki
 			return
 		}
 
-		tokenResponse, err := userCtrl.Register(ctx, sysCtrl, in)
+		loginResponse, err := userCtrl.Register(ctx, sysCtrl, in)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
 		}
 
-		if includeCookie {
-			includeTokenCookie(r, w, tokenResponse, cookieName)
+		if includeCookie && loginResponse.TokenResponse != nil {
+			includeTokenCookie(r, w, loginResponse.TokenResponse, cookieName)
 		}
 
-		render.JSON(w, http.StatusOK, tokenResponse)
+		render.JSON(w, http.StatusOK, loginResponse)
 	}
 }
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/url"
+)
+
+// HandleListLocks handles the listing of lfs locks.
+func HandleListLocks(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		limit, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamLimit, 0)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		in := &lfs.ListLocksInput{
+			Path:   request.QueryParamOrDefault(r, request.QueryParamPath, ""),
+			ID:     request.GetLFSLockIDFromQuery(r),
+			Cursor: request.GetLFSLockCursorFromQuery(r),
+			Limit:  int(limit),
+		}
+
+		out, err := lfsCtrl.ListLocks(ctx, session, repoRef, in)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	gitenum "github.com/harness/gitness/git/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleArchive streams an archive (zip, tar.gz) of the repository at the provided git ref.
+func HandleArchive(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		name, err := request.GetRemainderFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		gitRef, format, ok := gitenum.ParseArchiveFormatFromName(name)
+		if !ok {
+			render.BadRequestf(w, "Archive name '%s' has to end with one of %v.", name, gitenum.ArchiveFormats)
+			return
+		}
+
+		out, err := repoCtrl.Archive(ctx, session, repoRef, &repo.ArchiveInput{
+			GitRef: gitRef,
+			Format: format,
+			Paths:  request.GetArchivePathsFromQuery(r),
+		})
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		defer func() {
+			if err := out.Content.Close(); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to close archive reader.")
+			}
+		}()
+
+		w.Header().Set("Content-Type", archiveContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", out.Name))
+
+		render.Reader(ctx, w, http.StatusOK, out.Content)
+	}
+}
+
+func archiveContentType(format gitenum.ArchiveFormat) string {
+	switch format {
+	case gitenum.ArchiveFormatZip:
+		return "application/zip"
+	case gitenum.ArchiveFormatTarGz:
+		return "application/gzip"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -25,6 +25,7 @@ import (
 type ConfigOutput struct {
 	UserSignupAllowed             bool `json:"user_signup_allowed"`
 	PublicResourceCreationEnabled bool `json:"public_resource_creation_enabled"`
+	OIDCLoginEnabled              bool `json:"oidc_login_enabled"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -42,6 +43,7 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 		render.JSON(w, http.StatusOK, ConfigOutput{
 			UserSignupAllowed:             userSignupAllowed,
 			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+			OIDCLoginEnabled:              config.OIDC.Enabled,
 		})
 	}
 }
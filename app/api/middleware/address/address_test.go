@@ -13,3 +13,94 @@
 // limitations under the License.
 
 package address
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	trustedProxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        string
+		xRealIP    string
+		want       string
+	}{
+		{
+			name:       "no proxy",
+			remoteAddr: "203.0.113.7:4321",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed header from untrusted client",
+			remoteAddr: "203.0.113.7:4321",
+			xff:        "1.2.3.4",
+			xRealIP:    "1.2.3.4",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.1.2.3:4321",
+			xff:        "203.0.113.7",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy chain with spoofed entry",
+			remoteAddr: "10.1.2.3:4321",
+			xff:        "1.2.3.4, 203.0.113.7, 192.168.1.1",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "only trusted proxies",
+			remoteAddr: "10.1.2.3:4321",
+			xff:        "10.0.0.5, 192.168.1.1",
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "trusted proxy with x-real-ip",
+			remoteAddr: "192.168.1.1:4321",
+			xRealIP:    "203.0.113.7",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy without headers",
+			remoteAddr: "192.168.1.1:4321",
+			want:       "192.168.1.1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = test.remoteAddr
+			if test.xff != "" {
+				r.Header.Set("X-Forwarded-For", test.xff)
+			}
+			if test.xRealIP != "" {
+				r.Header.Set("X-Real-IP", test.xRealIP)
+			}
+
+			if got := resolveClientIP(r, trustedProxies); got != test.want {
+				t.Errorf("resolveClientIP() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	if _, err := ParseTrustedProxies([]string{"10.0.0.0/8", "::1", " 192.168.1.1 ", ""}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, value := range []string{"not-an-ip", "10.0.0.0/33"} {
+		if _, err := ParseTrustedProxies([]string{value}); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
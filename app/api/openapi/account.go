@@ -51,6 +51,29 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// request to complete a login with the second factor of the user.
+type loginTwoFactorRequest struct {
+	user.LoginTwoFactorInput
+}
+
+// request with the challenge of a login pending the second factor of the user.
+type loginChallengeRequest struct {
+	user.LoginChallengeInput
+}
+
+// request to start the login via the OIDC identity provider.
+type oidcLoginRequest struct {
+	Redirect string `query:"redirect" description:"The path of the UI the user is redirected to after the login."`
+}
+
+// callback of the OIDC identity provider.
+type oidcCallbackRequest struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -59,12 +82,42 @@ func buildAccount(reflector *openapi3.Reflector) {
 	onLogin.WithParameters(queryParameterIncludeCookie)
 	onLogin.WithMapOfAnything(map[string]interface{}{"operationId": "onLogin"})
 	_ = reflector.SetRequest(&onLogin, new(loginRequest), http.MethodPost)
-	_ = reflector.SetJSONResponse(&onLogin, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLogin, new(types.LoginResponse), http.StatusOK)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
+	onLoginTwoFactor := openapi3.Operation{}
+	onLoginTwoFactor.WithTags("account")
+	onLoginTwoFactor.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginTwoFactor"})
+	_ = reflector.SetRequest(&onLoginTwoFactor, new(loginTwoFactorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginTwoFactor, new(types.LoginResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginTwoFactor, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginTwoFactor, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginTwoFactor, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/2fa", onLoginTwoFactor)
+
+	onLoginTOTPEnroll := openapi3.Operation{}
+	onLoginTOTPEnroll.WithTags("account")
+	onLoginTOTPEnroll.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginTOTPEnroll"})
+	_ = reflector.SetRequest(&onLoginTOTPEnroll, new(loginChallengeRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(types.TOTPEnrollment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/2fa/totp", onLoginTOTPEnroll)
+
+	onLoginWebAuthnBegin := openapi3.Operation{}
+	onLoginWebAuthnBegin.WithTags("account")
+	onLoginWebAuthnBegin.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginWebAuthnBegin"})
+	_ = reflector.SetRequest(&onLoginWebAuthnBegin, new(loginChallengeRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginWebAuthnBegin, new(user.WebAuthnChallenge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginWebAuthnBegin, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginWebAuthnBegin, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginWebAuthnBegin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/2fa/webauthn", onLoginWebAuthnBegin)
+
 	opLogout := openapi3.Operation{}
 	opLogout.WithTags("account")
 	opLogout.WithMapOfAnything(map[string]interface{}{"operationId": "opLogout"})
@@ -80,8 +133,28 @@ func buildAccount(reflector *openapi3.Reflector) {
 	onRegister.WithParameters(queryParameterIncludeCookie)
 	onRegister.WithMapOfAnything(map[string]interface{}{"operationId": "onRegister"})
 	_ = reflector.SetRequest(&onRegister, new(registerRequest), http.MethodPost)
-	_ = reflector.SetJSONResponse(&onRegister, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onRegister, new(types.LoginResponse), http.StatusOK)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	opOIDCLogin := openapi3.Operation{}
+	opOIDCLogin.WithTags("account")
+	opOIDCLogin.WithMapOfAnything(map[string]interface{}{"operationId": "oidcLogin"})
+	_ = reflector.SetRequest(&opOIDCLogin, new(oidcLoginRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opOIDCLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opOIDCLogin, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opOIDCLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/login", opOIDCLogin)
+
+	opOIDCCallback := openapi3.Operation{}
+	opOIDCCallback.WithTags("account")
+	opOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "oidcCallback"})
+	_ = reflector.SetRequest(&opOIDCCallback, new(oidcCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/callback", opOIDCCallback)
 }
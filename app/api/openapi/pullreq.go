@@ -66,6 +66,21 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type autoMergeEnablePullReqRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeEnableInput
+}
+
+type labelAssignPullReqRequest struct {
+	pullReqRequest
+	pullreq.LabelAssignInput
+}
+
+type labelUnassignPullReqRequest struct {
+	pullReqRequest
+	LabelID int64 `path:"label_id"`
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -104,6 +119,11 @@ type reviewerAddPullReqRequest struct {
 	pullreq.ReviewerAddInput
 }
 
+type reviewerAddUserGroupPullReqRequest struct {
+	pullReqRequest
+	pullreq.ReviewerAddUserGroupInput
+}
+
 type reviewSubmitPullReqRequest struct {
 	pullreq.ReviewSubmitInput
 	pullReqRequest
@@ -207,6 +227,25 @@ var queryParameterCreatedByPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterLabelIDPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLabelID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The result should contain only pull requests with all of the provided labels."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterStatePullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamState,
@@ -319,6 +358,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		queryParameterStatePullRequest, queryParameterSourceRepoRefPullRequest,
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
 		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
+		queryParameterLabelIDPullRequest,
 		queryParameterOrder, queryParameterSortPullRequest,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
@@ -437,6 +477,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers", reviewerAdd)
 
+	reviewerAddUserGroup := openapi3.Operation{}
+	reviewerAddUserGroup.WithTags("pullreq")
+	reviewerAddUserGroup.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerAddUserGroupPullReq"})
+	_ = reflector.SetRequest(&reviewerAddUserGroup, new(reviewerAddUserGroupPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&reviewerAddUserGroup, new([]*types.PullReqReviewer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reviewerAddUserGroup, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewerAddUserGroup, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewerAddUserGroup, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewerAddUserGroup, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/usergroups", reviewerAddUserGroup)
+
 	reviewerList := openapi3.Operation{}
 	reviewerList.WithTags("pullreq")
 	reviewerList.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerListPullReq"})
@@ -488,6 +540,104 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	opMergeQueueFind := openapi3.Operation{}
+	opMergeQueueFind.WithTags("pullreq")
+	opMergeQueueFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqMergeQueueEntry"})
+	_ = reflector.SetRequest(&opMergeQueueFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeQueueFind, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueFind)
+
+	opMergeQueueDelete := openapi3.Operation{}
+	opMergeQueueDelete.WithTags("pullreq")
+	opMergeQueueDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePullReqMergeQueueEntry"})
+	_ = reflector.SetRequest(&opMergeQueueDelete, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMergeQueueDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMergeQueueDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueDelete)
+
+	opAutoMergeFind := openapi3.Operation{}
+	opAutoMergeFind.WithTags("pullreq")
+	opAutoMergeFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeFind)
+
+	opAutoMergeEnable := openapi3.Operation{}
+	opAutoMergeEnable.WithTags("pullreq")
+	opAutoMergeEnable.WithMapOfAnything(map[string]interface{}{"operationId": "enablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeEnable, new(autoMergeEnablePullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeEnable)
+
+	opAutoMergeDisable := openapi3.Operation{}
+	opAutoMergeDisable.WithTags("pullreq")
+	opAutoMergeDisable.WithMapOfAnything(map[string]interface{}{"operationId": "disablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeDisable, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeDisable)
+
+	opLabelList := openapi3.Operation{}
+	opLabelList.WithTags("pullreq")
+	opLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqLabels"})
+	_ = reflector.SetRequest(&opLabelList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLabelList, []types.Label{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", opLabelList)
+
+	opLabelAssign := openapi3.Operation{}
+	opLabelAssign.WithTags("pullreq")
+	opLabelAssign.WithMapOfAnything(map[string]interface{}{"operationId": "assignPullReqLabel"})
+	_ = reflector.SetRequest(&opLabelAssign, new(labelAssignPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLabelAssign, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelAssign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelAssign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelAssign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelAssign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelAssign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", opLabelAssign)
+
+	opLabelUnassign := openapi3.Operation{}
+	opLabelUnassign.WithTags("pullreq")
+	opLabelUnassign.WithMapOfAnything(map[string]interface{}{"operationId": "unassignPullReqLabel"})
+	_ = reflector.SetRequest(&opLabelUnassign, new(labelUnassignPullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opLabelUnassign, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opLabelUnassign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelUnassign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelUnassign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelUnassign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels/{label_id}", opLabelUnassign)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
 	gittypes "github.com/harness/gitness/git/types"
@@ -44,6 +45,11 @@ type repoRequest struct {
 	Ref string `path:"repo_ref"`
 }
 
+type repoLabelRequest struct {
+	repoRequest
+	ID int64 `path:"label_id"`
+}
+
 type updateRepoRequest struct {
 	repoRequest
 	repo.UpdateInput
@@ -54,11 +60,41 @@ type moveRepoRequest struct {
 	repo.MoveInput
 }
 
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
+type updateMirrorRequest struct {
+	repoRequest
+	repo.MirrorInput
+}
+
+type createPushMirrorRequest struct {
+	repoRequest
+	repo.PushMirrorCreateInput
+}
+
+type pushMirrorRequest struct {
+	repoRequest
+	Identifier string `path:"push_mirror_identifier"`
+}
+
+type updatePushMirrorRequest struct {
+	pushMirrorRequest
+	repo.PushMirrorUpdateInput
+}
+
 type getContentRequest struct {
 	repoRequest
 	Path string `path:"path"`
 }
 
+type archiveRequest struct {
+	repoRequest
+	Name string `path:"name"`
+}
+
 type pathsDetailsRequest struct {
 	repoRequest
 	repo.PathsDetailsInput
@@ -173,14 +209,14 @@ type codeOwnersValidate struct {
 type ruleType string
 
 func (ruleType) Enum() []interface{} {
-	return []interface{}{protection.TypeBranch}
+	return []interface{}{protection.TypeBranch, protection.TypeTag, protection.TypePush}
 }
 
 // ruleDefinition is a plugin for types.Rule Definition to allow using oneof.
 type ruleDefinition struct{}
 
 func (ruleDefinition) JSONSchemaOneOf() []interface{} {
-	return []interface{}{protection.Branch{}}
+	return []interface{}{protection.Branch{}, protection.Tag{}, protection.Push{}}
 }
 
 type rule struct {
@@ -215,6 +251,25 @@ var queryParameterGitRef = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterArchivePath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The paths the archive is restricted to. If no value is provided all files are archived."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterPath = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamPath,
@@ -443,6 +498,48 @@ var queryParameterSortRuleList = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQuerySecretFindings = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring of the file path by which the secret findings are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSecretFindingRule = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSecretFindingRule,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The identifier of the rule that detected the secret."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSecretFindingCommitSHA = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSecretFindingCommitSHA,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The SHA of the commit that contains the secret."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterBypassRules = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamBypassRules,
@@ -498,6 +595,17 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/import", importRepository)
 
+	opMirrorCreate := openapi3.Operation{}
+	opMirrorCreate.WithTags("repository")
+	opMirrorCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createMirrorRepository"})
+	_ = reflector.SetRequest(&opMirrorCreate, &struct{ repo.MirrorCreateInput }{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/mirror", opMirrorCreate)
+
 	opFind := openapi3.Operation{}
 	opFind.WithTags("repository")
 	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepository"})
@@ -568,6 +676,304 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/move", opMove)
 
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opMirrorFind := openapi3.Operation{}
+	opMirrorFind.WithTags("repository")
+	opMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepositoryMirror"})
+	_ = reflector.SetRequest(&opMirrorFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opMirrorFind)
+
+	opMirrorUpdate := openapi3.Operation{}
+	opMirrorUpdate.WithTags("repository")
+	opMirrorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepositoryMirror"})
+	_ = reflector.SetRequest(&opMirrorUpdate, new(updateMirrorRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/mirror", opMirrorUpdate)
+
+	opMirrorDelete := openapi3.Operation{}
+	opMirrorDelete.WithTags("repository")
+	opMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepositoryMirror"})
+	_ = reflector.SetRequest(&opMirrorDelete, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/mirror", opMirrorDelete)
+
+	opPushMirrorList := openapi3.Operation{}
+	opPushMirrorList.WithTags("repository")
+	opPushMirrorList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryPushMirrors"})
+	_ = reflector.SetRequest(&opPushMirrorList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new([]types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors", opPushMirrorList)
+
+	opPushMirrorCreate := openapi3.Operation{}
+	opPushMirrorCreate.WithTags("repository")
+	opPushMirrorCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepositoryPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorCreate, new(createPushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(types.PushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors", opPushMirrorCreate)
+
+	opPushMirrorFind := openapi3.Operation{}
+	opPushMirrorFind.WithTags("repository")
+	opPushMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepositoryPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorFind, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opPushMirrorFind)
+
+	opPushMirrorUpdate := openapi3.Operation{}
+	opPushMirrorUpdate.WithTags("repository")
+	opPushMirrorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepositoryPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorUpdate, new(updatePushMirrorRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opPushMirrorUpdate)
+
+	opPushMirrorDelete := openapi3.Operation{}
+	opPushMirrorDelete.WithTags("repository")
+	opPushMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepositoryPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorDelete, new(pushMirrorRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opPushMirrorDelete)
+
+	opPushMirrorSync := openapi3.Operation{}
+	opPushMirrorSync.WithTags("repository")
+	opPushMirrorSync.WithMapOfAnything(map[string]interface{}{"operationId": "syncRepositoryPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorSync, new(pushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushMirrorSync, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorSync, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorSync, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorSync, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorSync, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}/sync", opPushMirrorSync)
+
+	opSecretScanningFind := openapi3.Operation{}
+	opSecretScanningFind.WithTags("repository")
+	opSecretScanningFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepositorySecretScanning"})
+	_ = reflector.SetRequest(&opSecretScanningFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSecretScanningFind, new(types.SecretScanningSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSecretScanningFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSecretScanningFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSecretScanningFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSecretScanningFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secret-scanning", opSecretScanningFind)
+
+	opSecretScanningUpdate := openapi3.Operation{}
+	opSecretScanningUpdate.WithTags("repository")
+	opSecretScanningUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepositorySecretScanning"})
+	_ = reflector.SetRequest(&opSecretScanningUpdate, struct {
+		repoRequest
+		repo.SecretScanningUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSecretScanningUpdate, new(types.SecretScanningSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSecretScanningUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSecretScanningUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSecretScanningUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSecretScanningUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSecretScanningUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/secret-scanning", opSecretScanningUpdate)
+
+	opSecretFindingList := openapi3.Operation{}
+	opSecretFindingList.WithTags("repository")
+	opSecretFindingList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositorySecretFindings"})
+	opSecretFindingList.WithParameters(queryParameterPage, queryParameterLimit, queryParameterQuerySecretFindings,
+		queryParameterSecretFindingRule, queryParameterSecretFindingCommitSHA)
+	_ = reflector.SetRequest(&opSecretFindingList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSecretFindingList, new([]types.SecretFinding), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSecretFindingList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSecretFindingList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSecretFindingList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSecretFindingList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secret-scanning/findings", opSecretFindingList)
+
+	opMergeTemplatesFind := openapi3.Operation{}
+	opMergeTemplatesFind.WithTags("repository")
+	opMergeTemplatesFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepositoryMergeTemplates"})
+	_ = reflector.SetRequest(&opMergeTemplatesFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesFind, new(types.MergeTemplates), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/merge-templates", opMergeTemplatesFind)
+
+	opMergeTemplatesUpdate := openapi3.Operation{}
+	opMergeTemplatesUpdate.WithTags("repository")
+	opMergeTemplatesUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepositoryMergeTemplates"})
+	_ = reflector.SetRequest(&opMergeTemplatesUpdate, struct {
+		repoRequest
+		repo.MergeTemplatesUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesUpdate, new(types.MergeTemplates), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeTemplatesUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/merge-templates", opMergeTemplatesUpdate)
+
+	opLabelDefine := openapi3.Operation{}
+	opLabelDefine.WithTags("repository")
+	opLabelDefine.WithMapOfAnything(map[string]interface{}{"operationId": "defineRepositoryLabel"})
+	_ = reflector.SetRequest(&opLabelDefine, struct {
+		repoRequest
+		label.DefineInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/labels", opLabelDefine)
+
+	opLabelList := openapi3.Operation{}
+	opLabelList.WithTags("repository")
+	opLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryLabels"})
+	opLabelList.WithParameters(queryParameterQueryLabel, queryParameterInheritedLabel)
+	_ = reflector.SetRequest(&opLabelList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLabelList, []types.Label{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/labels", opLabelList)
+
+	opLabelUpdate := openapi3.Operation{}
+	opLabelUpdate.WithTags("repository")
+	opLabelUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepositoryLabel"})
+	_ = reflector.SetRequest(&opLabelUpdate, struct {
+		repoLabelRequest
+		label.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/labels/{label_id}", opLabelUpdate)
+
+	opLabelDelete := openapi3.Operation{}
+	opLabelDelete.WithTags("repository")
+	opLabelDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepositoryLabel"})
+	_ = reflector.SetRequest(&opLabelDelete, new(repoLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opLabelDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/labels/{label_id}", opLabelDelete)
+
+	opMembershipAdd := openapi3.Operation{}
+	opMembershipAdd.WithTags("repository")
+	opMembershipAdd.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipAdd"})
+	_ = reflector.SetRequest(&opMembershipAdd, struct {
+		repoRequest
+		repo.MembershipAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, &types.RepoMembershipUser{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/members", opMembershipAdd)
+
+	opMembershipDelete := openapi3.Operation{}
+	opMembershipDelete.WithTags("repository")
+	opMembershipDelete.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipDelete"})
+	_ = reflector.SetRequest(&opMembershipDelete, struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/members/{user_uid}", opMembershipDelete)
+
+	opMembershipUpdate := openapi3.Operation{}
+	opMembershipUpdate.WithTags("repository")
+	opMembershipUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipUpdate"})
+	_ = reflector.SetRequest(&opMembershipUpdate, &struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+		repo.MembershipUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, &types.RepoMembershipUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/members/{user_uid}", opMembershipUpdate)
+
+	opMembershipList := openapi3.Operation{}
+	opMembershipList.WithTags("repository")
+	opMembershipList.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipList"})
+	opMembershipList.WithParameters(
+		queryParameterMembershipUsers,
+		queryParameterOrder, queryParameterSortMembershipUsers,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opMembershipList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMembershipList, []types.RepoMembershipUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/members", opMembershipList)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -616,6 +1022,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opArchive := openapi3.Operation{}
+	opArchive.WithTags("repository")
+	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archive"})
+	opArchive.WithParameters(queryParameterArchivePath)
+	_ = reflector.SetRequest(&opArchive, new(archiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opArchive, http.StatusOK, "")
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{name}", opArchive)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -40,6 +41,21 @@ type updateSpaceRequest struct {
 	space.UpdateInput
 }
 
+type userGroupRequest struct {
+	spaceRequest
+	Identifier string `path:"user_group_identifier"`
+}
+
+type customRoleRequest struct {
+	spaceRequest
+	Identifier string `path:"custom_role_identifier"`
+}
+
+type spaceLabelRequest struct {
+	spaceRequest
+	ID int64 `path:"label_id"`
+}
+
 type moveSpaceRequest struct {
 	spaceRequest
 	space.MoveInput
@@ -397,4 +413,302 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opUserGroupCreate := openapi3.Operation{}
+	opUserGroupCreate.WithTags("space")
+	opUserGroupCreate.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupCreate"})
+	_ = reflector.SetRequest(&opUserGroupCreate, struct {
+		spaceRequest
+		space.UserGroupCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUserGroupCreate, &types.UserGroup{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUserGroupCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroups", opUserGroupCreate)
+
+	opUserGroupList := openapi3.Operation{}
+	opUserGroupList.WithTags("space")
+	opUserGroupList.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupList"})
+	_ = reflector.SetRequest(&opUserGroupList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUserGroupList, []types.UserGroup{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroupList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups", opUserGroupList)
+
+	opUserGroupFind := openapi3.Operation{}
+	opUserGroupFind.WithTags("space")
+	opUserGroupFind.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupFind"})
+	_ = reflector.SetRequest(&opUserGroupFind, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUserGroupFind, &types.UserGroup{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroupFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/usergroups/{user_group_identifier}", opUserGroupFind)
+
+	opUserGroupUpdate := openapi3.Operation{}
+	opUserGroupUpdate.WithTags("space")
+	opUserGroupUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupUpdate"})
+	_ = reflector.SetRequest(&opUserGroupUpdate, struct {
+		userGroupRequest
+		space.UserGroupUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUserGroupUpdate, &types.UserGroup{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroupUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/usergroups/{user_group_identifier}", opUserGroupUpdate)
+
+	opUserGroupDelete := openapi3.Operation{}
+	opUserGroupDelete.WithTags("space")
+	opUserGroupDelete.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupDelete"})
+	_ = reflector.SetRequest(&opUserGroupDelete, new(userGroupRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUserGroupDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUserGroupDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroups/{user_group_identifier}", opUserGroupDelete)
+
+	opUserGroupMemberList := openapi3.Operation{}
+	opUserGroupMemberList.WithTags("space")
+	opUserGroupMemberList.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMemberList"})
+	_ = reflector.SetRequest(&opUserGroupMemberList, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberList, []types.PrincipalInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/usergroups/{user_group_identifier}/members", opUserGroupMemberList)
+
+	opUserGroupMemberAdd := openapi3.Operation{}
+	opUserGroupMemberAdd.WithTags("space")
+	opUserGroupMemberAdd.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMemberAdd"})
+	_ = reflector.SetRequest(&opUserGroupMemberAdd, struct {
+		userGroupRequest
+		space.UserGroupMemberAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberAdd, &types.PrincipalInfo{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/usergroups/{user_group_identifier}/members", opUserGroupMemberAdd)
+
+	opUserGroupMemberDelete := openapi3.Operation{}
+	opUserGroupMemberDelete.WithTags("space")
+	opUserGroupMemberDelete.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMemberDelete"})
+	_ = reflector.SetRequest(&opUserGroupMemberDelete, struct {
+		userGroupRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMemberDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroups/{user_group_identifier}/members/{user_uid}", opUserGroupMemberDelete)
+
+	opUserGroupMembershipList := openapi3.Operation{}
+	opUserGroupMembershipList.WithTags("space")
+	opUserGroupMembershipList.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMembershipList"})
+	_ = reflector.SetRequest(&opUserGroupMembershipList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipList, []types.UserGroupMembershipInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroup-members", opUserGroupMembershipList)
+
+	opUserGroupMembershipAdd := openapi3.Operation{}
+	opUserGroupMembershipAdd.WithTags("space")
+	opUserGroupMembershipAdd.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMembershipAdd"})
+	_ = reflector.SetRequest(&opUserGroupMembershipAdd, struct {
+		spaceRequest
+		space.UserGroupMembershipAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipAdd, &types.UserGroupMembershipInfo{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroup-members", opUserGroupMembershipAdd)
+
+	opUserGroupMembershipUpdate := openapi3.Operation{}
+	opUserGroupMembershipUpdate.WithTags("space")
+	opUserGroupMembershipUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMembershipUpdate"})
+	_ = reflector.SetRequest(&opUserGroupMembershipUpdate, struct {
+		spaceRequest
+		UserGroupID int64 `path:"user_group_id"`
+		space.MembershipUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipUpdate, &types.UserGroupMembership{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/usergroup-members/{user_group_id}", opUserGroupMembershipUpdate)
+
+	opUserGroupMembershipDelete := openapi3.Operation{}
+	opUserGroupMembershipDelete.WithTags("space")
+	opUserGroupMembershipDelete.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupMembershipDelete"})
+	_ = reflector.SetRequest(&opUserGroupMembershipDelete, struct {
+		spaceRequest
+		UserGroupID int64 `path:"user_group_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroupMembershipDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroup-members/{user_group_id}", opUserGroupMembershipDelete)
+
+	opCustomRoleCreate := openapi3.Operation{}
+	opCustomRoleCreate.WithTags("space")
+	opCustomRoleCreate.WithMapOfAnything(map[string]interface{}{"operationId": "customRoleCreate"})
+	_ = reflector.SetRequest(&opCustomRoleCreate, struct {
+		spaceRequest
+		space.CustomRoleCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, &types.CustomRole{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/roles", opCustomRoleCreate)
+
+	opCustomRoleList := openapi3.Operation{}
+	opCustomRoleList.WithTags("space")
+	opCustomRoleList.WithMapOfAnything(map[string]interface{}{"operationId": "customRoleList"})
+	_ = reflector.SetRequest(&opCustomRoleList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, []types.CustomRole{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/roles", opCustomRoleList)
+
+	opCustomRoleFind := openapi3.Operation{}
+	opCustomRoleFind.WithTags("space")
+	opCustomRoleFind.WithMapOfAnything(map[string]interface{}{"operationId": "customRoleFind"})
+	_ = reflector.SetRequest(&opCustomRoleFind, new(customRoleRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, &types.CustomRole{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/roles/{custom_role_identifier}", opCustomRoleFind)
+
+	opCustomRoleUpdate := openapi3.Operation{}
+	opCustomRoleUpdate.WithTags("space")
+	opCustomRoleUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "customRoleUpdate"})
+	_ = reflector.SetRequest(&opCustomRoleUpdate, struct {
+		customRoleRequest
+		space.CustomRoleUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, &types.CustomRole{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/roles/{custom_role_identifier}", opCustomRoleUpdate)
+
+	opCustomRoleDelete := openapi3.Operation{}
+	opCustomRoleDelete.WithTags("space")
+	opCustomRoleDelete.WithMapOfAnything(map[string]interface{}{"operationId": "customRoleDelete"})
+	_ = reflector.SetRequest(&opCustomRoleDelete, new(customRoleRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCustomRoleDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/roles/{custom_role_identifier}", opCustomRoleDelete)
+
+	opLabelDefine := openapi3.Operation{}
+	opLabelDefine.WithTags("space")
+	opLabelDefine.WithMapOfAnything(map[string]interface{}{"operationId": "defineSpaceLabel"})
+	_ = reflector.SetRequest(&opLabelDefine, struct {
+		spaceRequest
+		label.DefineInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/labels", opLabelDefine)
+
+	opLabelList := openapi3.Operation{}
+	opLabelList.WithTags("space")
+	opLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceLabels"})
+	opLabelList.WithParameters(queryParameterQueryLabel, queryParameterInheritedLabel)
+	_ = reflector.SetRequest(&opLabelList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLabelList, []types.Label{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/labels", opLabelList)
+
+	opLabelUpdate := openapi3.Operation{}
+	opLabelUpdate.WithTags("space")
+	opLabelUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceLabel"})
+	_ = reflector.SetRequest(&opLabelUpdate, struct {
+		spaceLabelRequest
+		label.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/labels/{label_id}", opLabelUpdate)
+
+	opLabelDelete := openapi3.Operation{}
+	opLabelDelete.WithTags("space")
+	opLabelDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceLabel"})
+	_ = reflector.SetRequest(&opLabelDelete, new(spaceLabelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opLabelDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/labels/{label_id}", opLabelDelete)
+
+	opAuditLogList := openapi3.Operation{}
+	opAuditLogList.WithTags("space")
+	opAuditLogList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceAuditLogs"})
+	opAuditLogList.WithParameters(
+		queryParameterAuditAction, queryParameterAuditResourceType, queryParameterAuditPrincipalID,
+		queryParameterAuditCreatedGt, queryParameterAuditCreatedLt,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opAuditLogList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new([]types.AuditLog), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-logs", opAuditLogList)
 }
@@ -61,6 +61,96 @@ var queryParameterSortMembershipSpaces = openapi3.ParameterOrRef{
 	},
 }
 
+type createPublicKeyRequest struct {
+	user.CreatePublicKeyInput
+}
+
+type deletePublicKeyRequest struct {
+	ID string `path:"public_key_identifier"`
+}
+
+type totpConfirmRequest struct {
+	user.TOTPConfirmInput
+}
+
+type webAuthnRegisterRequest struct {
+	user.WebAuthnRegisterInput
+}
+
+type deleteWebAuthnCredentialRequest struct {
+	ID int64 `path:"webauthn_credential_id"`
+}
+
+var queryParameterQueryPublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the public keys are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSortPublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The field by which the public keys are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.PublicKeySortCreated),
+				Enum:    enum.PublicKeySort("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterUsagePublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPublicKeyUsage,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The public key usage to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.PublicKeyUsage("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterSchemePublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPublicKeyScheme,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The public key scheme to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.PublicKeyScheme("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
 // helper function that constructs the openapi specification
 // for user account resources.
 func buildUser(reflector *openapi3.Reflector) {
@@ -99,4 +189,118 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opKeyCreate := openapi3.Operation{}
+	opKeyCreate.WithTags("user")
+	opKeyCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opKeyCreate, new(createPublicKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opKeyCreate, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opKeyCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opKeyCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opKeyCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opKeyCreate)
+
+	opKeyList := openapi3.Operation{}
+	opKeyList.WithTags("user")
+	opKeyList.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKey"})
+	opKeyList.WithParameters(
+		queryParameterQueryPublicKey,
+		queryParameterUsagePublicKey, queryParameterSchemePublicKey,
+		queryParameterOrder, queryParameterSortPublicKey,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opKeyList, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opKeyList, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opKeyList)
+
+	opKeyDelete := openapi3.Operation{}
+	opKeyDelete.WithTags("user")
+	opKeyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opKeyDelete, new(deletePublicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opKeyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opKeyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opKeyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_identifier}", opKeyDelete)
+
+	opTwoFactorStatus := openapi3.Operation{}
+	opTwoFactorStatus.WithTags("user")
+	opTwoFactorStatus.WithMapOfAnything(map[string]interface{}{"operationId": "getTwoFactorStatus"})
+	_ = reflector.SetRequest(&opTwoFactorStatus, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTwoFactorStatus, new(types.TwoFactorStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTwoFactorStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/2fa", opTwoFactorStatus)
+
+	opRecoveryCodes := openapi3.Operation{}
+	opRecoveryCodes.WithTags("user")
+	opRecoveryCodes.WithMapOfAnything(map[string]interface{}{"operationId": "generateRecoveryCodes"})
+	_ = reflector.SetRequest(&opRecoveryCodes, struct{}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRecoveryCodes, new(types.RecoveryCodes), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRecoveryCodes, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRecoveryCodes, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/recovery-codes", opRecoveryCodes)
+
+	opTOTPEnroll := openapi3.Operation{}
+	opTOTPEnroll.WithTags("user")
+	opTOTPEnroll.WithMapOfAnything(map[string]interface{}{"operationId": "enrollTOTP"})
+	_ = reflector.SetRequest(&opTOTPEnroll, struct{}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTOTPEnroll, new(types.TOTPEnrollment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTOTPEnroll, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opTOTPEnroll, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/totp", opTOTPEnroll)
+
+	opTOTPConfirm := openapi3.Operation{}
+	opTOTPConfirm.WithTags("user")
+	opTOTPConfirm.WithMapOfAnything(map[string]interface{}{"operationId": "confirmTOTP"})
+	_ = reflector.SetRequest(&opTOTPConfirm, new(totpConfirmRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTOTPConfirm, new(types.RecoveryCodes), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTOTPConfirm, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTOTPConfirm, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTOTPConfirm, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/totp/confirm", opTOTPConfirm)
+
+	opTOTPDelete := openapi3.Operation{}
+	opTOTPDelete.WithTags("user")
+	opTOTPDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteTOTP"})
+	_ = reflector.SetRequest(&opTOTPDelete, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opTOTPDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTOTPDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTOTPDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/2fa/totp", opTOTPDelete)
+
+	opWebAuthnList := openapi3.Operation{}
+	opWebAuthnList.WithTags("user")
+	opWebAuthnList.WithMapOfAnything(map[string]interface{}{"operationId": "listWebAuthnCredentials"})
+	_ = reflector.SetRequest(&opWebAuthnList, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opWebAuthnList, new([]types.WebAuthnCredential), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWebAuthnList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/2fa/webauthn", opWebAuthnList)
+
+	opWebAuthnBegin := openapi3.Operation{}
+	opWebAuthnBegin.WithTags("user")
+	opWebAuthnBegin.WithMapOfAnything(map[string]interface{}{"operationId": "beginWebAuthnRegistration"})
+	_ = reflector.SetRequest(&opWebAuthnBegin, struct{}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opWebAuthnBegin, new(user.WebAuthnChallenge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWebAuthnBegin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/webauthn/register", opWebAuthnBegin)
+
+	opWebAuthnFinish := openapi3.Operation{}
+	opWebAuthnFinish.WithTags("user")
+	opWebAuthnFinish.WithMapOfAnything(map[string]interface{}{"operationId": "finishWebAuthnRegistration"})
+	_ = reflector.SetRequest(&opWebAuthnFinish, new(webAuthnRegisterRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opWebAuthnFinish, new(user.WebAuthnRegisterOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opWebAuthnFinish, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWebAuthnFinish, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWebAuthnFinish, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opWebAuthnFinish, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/2fa/webauthn", opWebAuthnFinish)
+
+	opWebAuthnDelete := openapi3.Operation{}
+	opWebAuthnDelete.WithTags("user")
+	opWebAuthnDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteWebAuthnCredential"})
+	_ = reflector.SetRequest(&opWebAuthnDelete, new(deleteWebAuthnCredentialRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opWebAuthnDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opWebAuthnDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWebAuthnDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opWebAuthnDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/2fa/webauthn/{webauthn_credential_id}", opWebAuthnDelete)
 }
@@ -117,4 +117,63 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opTwoFactorReset := openapi3.Operation{}
+	opTwoFactorReset.WithTags("admin")
+	opTwoFactorReset.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetTwoFactor"})
+	_ = reflector.SetRequest(&opTwoFactorReset, new(adminUsersRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opTwoFactorReset, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTwoFactorReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTwoFactorReset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}/2fa", opTwoFactorReset)
+
+	opAuditLogList := openapi3.Operation{}
+	opAuditLogList.WithTags("admin")
+	opAuditLogList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAuditLogs"})
+	opAuditLogList.WithParameters(
+		queryParameterAuditAction, queryParameterAuditResourceType, queryParameterAuditPrincipalID,
+		queryParameterAuditCreatedGt, queryParameterAuditCreatedLt,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opAuditLogList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new([]types.AuditLog), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuditLogList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-logs", opAuditLogList)
+
+	opTwoFactorRequiredSpaceList := openapi3.Operation{}
+	opTwoFactorRequiredSpaceList.WithTags("admin")
+	opTwoFactorRequiredSpaceList.WithMapOfAnything(
+		map[string]interface{}{"operationId": "adminListTwoFactorRequiredSpaces"})
+	_ = reflector.SetRequest(&opTwoFactorRequiredSpaceList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceList, new([]types.TwoFactorRequiredSpace), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/2fa/spaces", opTwoFactorRequiredSpaceList)
+
+	opTwoFactorRequiredSpaceAdd := openapi3.Operation{}
+	opTwoFactorRequiredSpaceAdd.WithTags("admin")
+	opTwoFactorRequiredSpaceAdd.WithMapOfAnything(
+		map[string]interface{}{"operationId": "adminAddTwoFactorRequiredSpace"})
+	_ = reflector.SetRequest(&opTwoFactorRequiredSpaceAdd, new(spaceRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceAdd, new(types.TwoFactorRequiredSpace), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/2fa/spaces/{space_ref}", opTwoFactorRequiredSpaceAdd)
+
+	opTwoFactorRequiredSpaceDelete := openapi3.Operation{}
+	opTwoFactorRequiredSpaceDelete.WithTags("admin")
+	opTwoFactorRequiredSpaceDelete.WithMapOfAnything(
+		map[string]interface{}{"operationId": "adminDeleteTwoFactorRequiredSpace"})
+	_ = reflector.SetRequest(&opTwoFactorRequiredSpaceDelete, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTwoFactorRequiredSpaceDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/2fa/spaces/{space_ref}", opTwoFactorRequiredSpaceDelete)
 }
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamAuditAction       = "action"
+	QueryParamAuditResourceType = "resource_type"
+	QueryParamAuditPrincipalID  = "principal_id"
+	QueryParamAuditCreatedGt    = "created_gt"
+	QueryParamAuditCreatedLt    = "created_lt"
+)
+
+// ParseAuditLogFilter extracts the audit log filter from the url.
+func ParseAuditLogFilter(r *http.Request) (*types.AuditLogFilter, error) {
+	// principal_id is optional, skipped if set to 0
+	principalID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditPrincipalID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// created_gt is optional, skipped if set to 0
+	createdGt, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditCreatedGt, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// created_lt is optional, skipped if set to 0
+	createdLt, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditCreatedLt, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AuditLogFilter{
+		Pagination:    ParsePaginationFromRequest(r),
+		Actions:       parseAuditActions(r),
+		ResourceTypes: parseAuditResourceTypes(r),
+		PrincipalID:   principalID,
+		CreatedGt:     createdGt,
+		CreatedLt:     createdLt,
+		Recursive:     recursive,
+	}, nil
+}
+
+// parseAuditActions extracts the audit log actions from the url.
+func parseAuditActions(r *http.Request) []enum.AuditAction {
+	strActions, _ := QueryParamList(r, QueryParamAuditAction)
+	m := make(map[enum.AuditAction]struct{}) // use map to eliminate duplicates
+	for _, s := range strActions {
+		if action, ok := enum.AuditAction(s).Sanitize(); ok {
+			m[action] = struct{}{}
+		}
+	}
+
+	actions := make([]enum.AuditAction, 0, len(m))
+	for a := range m {
+		actions = append(actions, a)
+	}
+
+	return actions
+}
+
+// parseAuditResourceTypes extracts the audit log resource types from the url.
+func parseAuditResourceTypes(r *http.Request) []enum.AuditResourceType {
+	strTypes, _ := QueryParamList(r, QueryParamAuditResourceType)
+	m := make(map[enum.AuditResourceType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.AuditResourceType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	resourceTypes := make([]enum.AuditResourceType, 0, len(m))
+	for t := range m {
+		resourceTypes = append(resourceTypes, t)
+	}
+
+	return resourceTypes
+}
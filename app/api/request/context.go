@@ -33,6 +33,7 @@ const (
 	spaceKey
 	repoKey
 	requestIDKey
+	clientIPKey
 )
 
 // WithAuthSession returns a copy of parent in which the principal
@@ -119,3 +120,15 @@ func RequestIDFrom(ctx context.Context) (string, bool) {
 	v, ok := ctx.Value(requestIDKey).(string)
 	return v, ok && v != ""
 }
+
+// WithClientIP returns a copy of parent in which the client ip value is set.
+func WithClientIP(parent context.Context, v string) context.Context {
+	return context.WithValue(parent, clientIPKey, v)
+}
+
+// ClientIPFrom returns the value of the client ip key on the
+// context - ok is true iff a non-empty value existed.
+func ClientIPFrom(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(clientIPKey).(string)
+	return v, ok && v != ""
+}
@@ -12,16 +12,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package pullreq
+package request
 
 import (
-	"github.com/harness/gitness/git"
-	"github.com/harness/gitness/types"
+	"net/http"
 )
 
-func identityFromPrincipalInfo(p types.PrincipalInfo) *git.Identity {
-	return &git.Identity{
-		Name:  p.DisplayName,
-		Email: p.Email,
-	}
+const (
+	PathParamCustomRoleIdentifier = "custom_role_identifier"
+)
+
+func GetCustomRoleIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCustomRoleIdentifier)
 }
@@ -49,6 +49,12 @@ func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, erro
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
 
+// GetArchivePathsFromQuery returns the paths an archive is restricted to (empty if not provided).
+func GetArchivePathsFromQuery(r *http.Request) []string {
+	paths, _ := QueryParamList(r, QueryParamPath)
+	return paths
+}
+
 func GetCommitSHAFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamCommitSHA)
 }
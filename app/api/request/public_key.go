@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamPublicKeyIdentifier = "public_key_identifier"
+
+	QueryParamPublicKeyUsage  = "usage"
+	QueryParamPublicKeyScheme = "scheme"
+)
+
+func GetPublicKeyIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamPublicKeyIdentifier)
+}
+
+// ParseListPublicKeyQueryFilterFromRequest parses query filter for public keys from the url.
+func ParseListPublicKeyQueryFilterFromRequest(r *http.Request) *types.PublicKeyFilter {
+	return &types.PublicKeyFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		Sort:            enum.ParsePublicKeySort(r.URL.Query().Get(QueryParamSort)),
+		Order:           ParseOrder(r),
+		Usages:          parsePublicKeyUsages(r),
+		Schemes:         parsePublicKeySchemes(r),
+	}
+}
+
+// parsePublicKeyUsages extracts the public key usages from the url.
+func parsePublicKeyUsages(r *http.Request) []enum.PublicKeyUsage {
+	strUsages := r.URL.Query()[QueryParamPublicKeyUsage]
+	m := make(map[enum.PublicKeyUsage]struct{}) // use map to eliminate duplicates
+	for _, s := range strUsages {
+		if u, ok := enum.PublicKeyUsage(s).Sanitize(); ok {
+			m[u] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	usages := make([]enum.PublicKeyUsage, 0, len(m))
+	for u := range m {
+		usages = append(usages, u)
+	}
+
+	return usages
+}
+
+// parsePublicKeySchemes extracts the public key schemes from the url.
+func parsePublicKeySchemes(r *http.Request) []enum.PublicKeyScheme {
+	strSchemes := r.URL.Query()[QueryParamPublicKeyScheme]
+	m := make(map[enum.PublicKeyScheme]struct{}) // use map to eliminate duplicates
+	for _, s := range strSchemes {
+		if scheme, ok := enum.PublicKeyScheme(s).Sanitize(); ok {
+			m[scheme] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	schemes := make([]enum.PublicKeyScheme, 0, len(m))
+	for scheme := range m {
+		schemes = append(schemes, scheme)
+	}
+
+	return schemes
+}
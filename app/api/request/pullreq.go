@@ -16,7 +16,9 @@ package request
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -63,6 +65,26 @@ func parsePullReqStates(r *http.Request) []enum.PullReqState {
 	return states
 }
 
+// parsePullReqLabelIDs extracts the IDs of the pull request labels from the url.
+func parsePullReqLabelIDs(r *http.Request) ([]int64, error) {
+	strLabelIDs, _ := QueryParamList(r, QueryParamLabelID)
+	m := make(map[int64]struct{}) // use map to eliminate duplicates
+	for _, s := range strLabelIDs {
+		labelID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || labelID <= 0 {
+			return nil, usererror.BadRequestf("Invalid label ID '%s'.", s)
+		}
+		m[labelID] = struct{}{}
+	}
+
+	labelIDs := make([]int64, 0, len(m))
+	for labelID := range m {
+		labelIDs = append(labelIDs, labelID)
+	}
+
+	return labelIDs, nil
+}
+
 // ParsePullReqFilter extracts the pull request query parameter from the url.
 func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	// created_by is optional, skipped if set to 0
@@ -70,6 +92,12 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	labelIDs, err := parsePullReqLabelIDs(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.PullReqFilter{
 		Page:          ParsePage(r),
 		Size:          ParseLimit(r),
@@ -79,6 +107,7 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		SourceBranch:  r.URL.Query().Get("source_branch"),
 		TargetBranch:  r.URL.Query().Get("target_branch"),
 		States:        parsePullReqStates(r),
+		LabelIDs:      labelIDs,
 		Sort:          ParseSortPullReq(r),
 		Order:         ParseOrder(r),
 	}, nil
@@ -67,9 +67,16 @@ var (
 	// ErrDefaultBranchCantBeDeleted is returned if the user tries to delete the default branch of a repository.
 	ErrDefaultBranchCantBeDeleted = New(http.StatusBadRequest, "The default branch of a repository can't be deleted")
 
+	// ErrBypassReasonRequired is returned if a user tries to bypass protection rules without providing a reason.
+	ErrBypassReasonRequired = New(http.StatusBadRequest, "A reason must be provided to bypass protection rules")
+
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrRepoIsPullMirror is returned if a user tries to update branches or tags of a pull mirror.
+	ErrRepoIsPullMirror = New(http.StatusForbidden,
+		"The repository is a mirror, its branches and tags can only be updated by syncing with the remote repository")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
@@ -83,6 +83,8 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 
 	var metadata auth.Metadata
 	switch {
+	case claims.TwoFactor != nil:
+		return nil, errors.New("jwt of an operation pending a second factor can't be used for authentication")
 	case claims.Token != nil:
 		metadata, err = a.metadataFromTokenClaims(ctx, principal, claims.Token)
 		if err != nil {
@@ -120,6 +122,9 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
+		Scopes:    tkn.Scopes,
+		SpaceIDs:  tkn.SpaceIDs,
+		RepoIDs:   tkn.RepoIDs,
 	}, nil
 }
 
@@ -16,15 +16,19 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 )
 
 var _ Authorizer = (*MembershipAuthorizer)(nil)
@@ -32,15 +36,18 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
+		repoStore:       repoStore,
 	}
 }
 
@@ -72,7 +79,14 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
-	if session.Principal.Admin {
+	// scopes of the token limit the permissions of any principal (including system admins)
+	tokenMetadata, _ := session.Metadata.(*auth.TokenMetadata)
+	if tokenMetadata != nil && !tokenMetadata.HasScope(permission) {
+		log.Ctx(ctx).Debug().Msgf("permission %s is outside of the scopes of token %d", permission, tokenMetadata.TokenID)
+		return false, nil
+	}
+
+	if session.Principal.Admin && (tokenMetadata == nil || !tokenMetadata.IsRestricted()) {
 		return true, nil // system admin can call any API
 	}
 
@@ -102,6 +116,11 @@ func (a *MembershipAuthorizer) Check(
 		spacePath = scope.SpacePath
 
 	case enum.ResourceTypeUser:
+		// tokens restricted to spaces or repos can't be used to manage users (e.g. to create unrestricted tokens)
+		if tokenMetadata != nil && tokenMetadata.IsRestricted() && permission != enum.PermissionUserView {
+			return false, nil
+		}
+
 		// a user is allowed to view / edit themselves
 		if resource.Identifier == session.Principal.UID &&
 			(permission == enum.PermissionUserView || permission == enum.PermissionUserEdit) {
@@ -119,23 +138,44 @@ func (a *MembershipAuthorizer) Check(
 		return false, nil
 	}
 
+	if tokenMetadata != nil && tokenMetadata.IsRestricted() {
+		allowed, err := a.checkTokenRestrictions(ctx, tokenMetadata, scope, resource, spacePath)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	if session.Principal.Admin {
+		return true, nil // system admin can call any API within the restrictions of the token
+	}
+
 	// ephemeral membership overrides any other space memberships of the principal
 	if membershipMetadata, ok := session.Metadata.(*auth.MembershipMetadata); ok {
 		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	if tokenMetadata == nil && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
 	return a.permissionCache.Get(ctx, PermissionCacheKey{
-		PrincipalID: session.Principal.ID,
-		SpaceRef:    spacePath,
-		Permission:  permission,
+		PrincipalID:    session.Principal.ID,
+		SpaceRef:       spacePath,
+		RepoIdentifier: getRepoIdentifier(scope, resource),
+		Permission:     permission,
 	})
 }
 
+// getRepoIdentifier returns the identifier of the repo the resource belongs to (empty if it's not within a repo).
+func getRepoIdentifier(scope *types.Scope, resource *types.Resource) string {
+	if resource.Type == enum.ResourceTypeRepo {
+		return resource.Identifier
+	}
+
+	return scope.Repo
+}
+
 func (a *MembershipAuthorizer) CheckAll(ctx context.Context, session *auth.Session,
 	permissionChecks ...types.PermissionCheck) (bool, error) {
 	for i := range permissionChecks {
@@ -179,3 +219,60 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	// access is granted by ephemeral membership
 	return true, nil
 }
+
+// checkTokenRestrictions checks whether the requested resource is within the spaces or repos of the token.
+func (a *MembershipAuthorizer) checkTokenRestrictions(
+	ctx context.Context,
+	tokenMetadata *auth.TokenMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	requestedSpacePath string,
+) (bool, error) {
+	for _, spaceID := range tokenMetadata.SpaceIDs {
+		space, err := a.spaceStore.Find(ctx, spaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the space got deleted after the token was created
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if isPathWithin(space.Path, requestedSpacePath) {
+			return true, nil
+		}
+	}
+
+	repoIdentifier := getRepoIdentifier(scope, resource)
+	if len(tokenMetadata.RepoIDs) == 0 || repoIdentifier == "" {
+		log.Ctx(ctx).Debug().Msgf("space '%s' is outside of the restrictions of token %d",
+			requestedSpacePath, tokenMetadata.TokenID)
+		return false, nil
+	}
+
+	repo, err := a.repoStore.FindByRef(ctx, paths.Concatinate(scope.SpacePath, repoIdentifier))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if !slices.Contains(tokenMetadata.RepoIDs, repo.ID) {
+		log.Ctx(ctx).Debug().Msgf("repo '%s' is outside of the restrictions of token %d",
+			repo.Path, tokenMetadata.TokenID)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// isPathWithin returns true if the path is the same as the root path or any of its descendants.
+func isPathWithin(root string, path string) bool {
+	root = strings.Trim(root, types.PathSeparator)
+	path = strings.Trim(path, types.PathSeparator)
+
+	return strings.EqualFold(root, path) ||
+		(len(path) > len(root) &&
+			strings.EqualFold(path[:len(root)+1], root+types.PathSeparator))
+}
@@ -33,30 +33,55 @@ import (
 type PermissionCacheKey struct {
 	PrincipalID int64
 	SpaceRef    string
-	Permission  enum.Permission
+	// RepoIdentifier is the identifier of the repo within the space in case the permission is for a repo resource.
+	RepoIdentifier string
+	Permission     enum.Permission
 }
 type PermissionCache cache.Cache[PermissionCacheKey, bool]
 
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
+	repoStore store.RepoStore,
+	repoMembershipStore store.RepoMembershipStore,
+	customRoleStore store.CustomRoleStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceStore:      spaceStore,
-		membershipStore: membershipStore,
+		spaceStore:               spaceStore,
+		membershipStore:          membershipStore,
+		userGroupMembershipStore: userGroupMembershipStore,
+		repoStore:                repoStore,
+		repoMembershipStore:      repoMembershipStore,
+		customRoleStore:          customRoleStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
-	spaceStore      store.SpaceStore
-	membershipStore store.MembershipStore
+	spaceStore               store.SpaceStore
+	membershipStore          store.MembershipStore
+	userGroupMembershipStore store.UserGroupMembershipStore
+	repoStore                store.RepoStore
+	repoMembershipStore      store.RepoMembershipStore
+	customRoleStore          store.CustomRoleStore
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
 	spaceRef := key.SpaceRef
 	principalID := key.PrincipalID
 
+	// A repo membership grants permissions in addition to the memberships of the ancestor spaces.
+	if key.RepoIdentifier != "" {
+		hasPermission, err := g.findRepoPermission(ctx, key)
+		if err != nil {
+			return false, err
+		}
+		if hasPermission {
+			return true, nil
+		}
+	}
+
 	// Find the starting space.
 	space, err := g.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
@@ -77,9 +102,30 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 		}
 
 		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
+		if membership != nil {
+			hasPermission, err := g.roleHasPermission(ctx, space.ID, membership.Role, key.Permission)
+			if err != nil {
+				return false, err
+			}
+			if hasPermission {
+				return true, nil
+			}
+		}
+
+		// The principal might also have the permission via the memberships of its user groups.
+		groupRoles, err := g.userGroupMembershipStore.ListRoles(ctx, space.ID, principalID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list user group membership roles: %w", err)
+		}
+
+		for _, role := range groupRoles {
+			hasPermission, err := g.roleHasPermission(ctx, space.ID, role, key.Permission)
+			if err != nil {
+				return false, err
+			}
+			if hasPermission {
+				return true, nil
+			}
 		}
 
 		// If membership with the requested permission has not been found in the current space,
@@ -98,6 +144,56 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	return false, nil
 }
 
+// findRepoPermission checks if the principal has the permission via its membership of the repo.
+func (g permissionCacheGetter) findRepoPermission(ctx context.Context, key PermissionCacheKey) (bool, error) {
+	repoRef := paths.Concatinate(key.SpaceRef, key.RepoIdentifier)
+
+	repo, err := g.repoStore.FindByRef(ctx, repoRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo '%s': %w", repoRef, err)
+	}
+
+	membership, err := g.repoMembershipStore.Find(ctx, types.RepoMembershipKey{
+		RepoID:      repo.ID,
+		PrincipalID: key.PrincipalID,
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo membership: %w", err)
+	}
+
+	return g.roleHasPermission(ctx, repo.ParentID, membership.Role, key.Permission)
+}
+
+// roleHasPermission checks if the role grants the permission.
+// Custom roles are resolved using the custom roles available in the space the membership belongs to.
+func (g permissionCacheGetter) roleHasPermission(
+	ctx context.Context,
+	spaceID int64,
+	role enum.MembershipRole,
+	permission enum.Permission,
+) (bool, error) {
+	if !role.IsCustom() {
+		return roleHasPermission(role, permission), nil
+	}
+
+	customRole, err := g.customRoleStore.FindInherited(ctx, spaceID, string(role))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the custom role got deleted - the membership doesn't grant any permissions anymore.
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find custom role '%s': %w", role, err)
+	}
+
+	return customRole.HasPermission(permission), nil
+}
+
 func roleHasPermission(role enum.MembershipRole, permission enum.Permission) bool {
 	_, hasRole := slices.BinarySearch(role.Permissions(), permission)
 	return hasRole
@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const testRoleDeployer = enum.MembershipRole("deployer")
+
+type fakeMembershipStore struct {
+	store.MembershipStore
+	roles map[types.MembershipKey]enum.MembershipRole
+}
+
+func (s fakeMembershipStore) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	role, ok := s.roles[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Membership{MembershipKey: key, Role: role}, nil
+}
+
+type fakeUserGroupMembershipStore struct {
+	store.UserGroupMembershipStore
+	roles map[types.MembershipKey][]enum.MembershipRole
+}
+
+func (s fakeUserGroupMembershipStore) ListRoles(
+	_ context.Context,
+	spaceID int64,
+	principalID int64,
+) ([]enum.MembershipRole, error) {
+	return s.roles[types.MembershipKey{SpaceID: spaceID, PrincipalID: principalID}], nil
+}
+
+type fakeRepoMembershipStore struct {
+	store.RepoMembershipStore
+	roles map[types.RepoMembershipKey]enum.MembershipRole
+}
+
+func (s fakeRepoMembershipStore) Find(_ context.Context, key types.RepoMembershipKey) (*types.RepoMembership, error) {
+	role, ok := s.roles[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.RepoMembership{RepoMembershipKey: key, Role: role}, nil
+}
+
+type fakeCustomRoleStore struct {
+	store.CustomRoleStore
+	roles []*types.CustomRole
+}
+
+// FindInherited finds the custom role in the space or the closest of its ancestors.
+func (s fakeCustomRoleStore) FindInherited(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.CustomRole, error) {
+	for spaceID != 0 {
+		for _, role := range s.roles {
+			if role.SpaceID == spaceID && role.Identifier == identifier {
+				return role, nil
+			}
+		}
+
+		space, err := fakeSpaceStore{}.Find(ctx, spaceID)
+		if err != nil {
+			return nil, err
+		}
+		spaceID = space.ParentID
+	}
+
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestPermissionCacheGetter_Find(t *testing.T) {
+	const (
+		spaceReader      = int64(1)
+		spaceDeployer    = int64(2)
+		repoReader       = int64(3)
+		repoDeployer     = int64(4)
+		groupContributor = int64(5)
+		deletedRole      = int64(6)
+		otherSpaceOwner  = int64(7)
+		noMembership     = int64(8)
+	)
+
+	getter := permissionCacheGetter{
+		spaceStore: fakeSpaceStore{},
+		membershipStore: fakeMembershipStore{roles: map[types.MembershipKey]enum.MembershipRole{
+			{SpaceID: testSpaceRootID, PrincipalID: spaceReader}:      enum.MembershipRoleReader,
+			{SpaceID: testSpaceChildID, PrincipalID: spaceDeployer}:   testRoleDeployer,
+			{SpaceID: testSpaceChildID, PrincipalID: deletedRole}:     "deleted",
+			{SpaceID: testSpaceOtherID, PrincipalID: otherSpaceOwner}: enum.MembershipRoleSpaceOwner,
+		}},
+		userGroupMembershipStore: fakeUserGroupMembershipStore{roles: map[types.MembershipKey][]enum.MembershipRole{
+			{SpaceID: testSpaceRootID, PrincipalID: groupContributor}: {enum.MembershipRoleContributor},
+		}},
+		repoStore: fakeRepoStore{},
+		repoMembershipStore: fakeRepoMembershipStore{roles: map[types.RepoMembershipKey]enum.MembershipRole{
+			{RepoID: testRepoID, PrincipalID: repoReader}:   enum.MembershipRoleReader,
+			{RepoID: testRepoID, PrincipalID: repoDeployer}: testRoleDeployer,
+		}},
+		customRoleStore: fakeCustomRoleStore{roles: []*types.CustomRole{
+			{
+				SpaceID:     testSpaceRootID,
+				Identifier:  string(testRoleDeployer),
+				Permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionPipelineExecute},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		key      PermissionCacheKey
+		expected bool
+	}{
+		{
+			name: "space membership",
+			key: PermissionCacheKey{PrincipalID: spaceReader, SpaceRef: "root",
+				Permission: enum.PermissionSpaceView},
+			expected: true,
+		},
+		{
+			name: "space membership inherited by child space",
+			key: PermissionCacheKey{PrincipalID: spaceReader, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoView},
+			expected: true,
+		},
+		{
+			name: "space membership without permission",
+			key: PermissionCacheKey{PrincipalID: spaceReader, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoPush},
+			expected: false,
+		},
+		{
+			name: "space membership of other space",
+			key: PermissionCacheKey{PrincipalID: otherSpaceOwner, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoView},
+			expected: false,
+		},
+		{
+			name: "no membership",
+			key: PermissionCacheKey{PrincipalID: noMembership, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoView},
+			expected: false,
+		},
+		{
+			name: "user group membership inherited by child space",
+			key: PermissionCacheKey{PrincipalID: groupContributor, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoPush},
+			expected: true,
+		},
+		{
+			name: "repo membership",
+			key: PermissionCacheKey{PrincipalID: repoReader, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoView},
+			expected: true,
+		},
+		{
+			name: "repo membership without permission",
+			key: PermissionCacheKey{PrincipalID: repoReader, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoPush},
+			expected: false,
+		},
+		{
+			name: "repo membership doesn't grant access to the space",
+			key: PermissionCacheKey{PrincipalID: repoReader, SpaceRef: "root/child",
+				Permission: enum.PermissionSpaceView},
+			expected: false,
+		},
+		{
+			name: "repo membership doesn't grant access to other repos",
+			key: PermissionCacheKey{PrincipalID: repoReader, SpaceRef: "other", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoView},
+			expected: false,
+		},
+		{
+			name: "custom role inherited from parent space",
+			key: PermissionCacheKey{PrincipalID: spaceDeployer, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionPipelineExecute},
+			expected: true,
+		},
+		{
+			name: "custom role without permission",
+			key: PermissionCacheKey{PrincipalID: spaceDeployer, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoPush},
+			expected: false,
+		},
+		{
+			name: "custom role of repo membership",
+			key: PermissionCacheKey{PrincipalID: repoDeployer, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionPipelineExecute},
+			expected: true,
+		},
+		{
+			name: "deleted custom role",
+			key: PermissionCacheKey{PrincipalID: deletedRole, SpaceRef: "root/child", RepoIdentifier: "repo",
+				Permission: enum.PermissionRepoView},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hasPermission, err := getter.Find(context.Background(), test.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if hasPermission != test.expected {
+				t.Errorf("expected hasPermission=%t, got %t", test.expected, hasPermission)
+			}
+		})
+	}
+}
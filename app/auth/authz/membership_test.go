@@ -0,0 +1,460 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSpaceRootID  = int64(1)
+	testSpaceChildID = int64(2)
+	testSpaceOtherID = int64(3)
+	testRepoID       = int64(10)
+	testRepoOtherID  = int64(11)
+)
+
+// testSpaces contains the spaces root, root/child and other.
+var testSpaces = []*types.Space{
+	{ID: testSpaceRootID, Path: "root", Identifier: "root"},
+	{ID: testSpaceChildID, ParentID: testSpaceRootID, Path: "root/child", Identifier: "child"},
+	{ID: testSpaceOtherID, Path: "other", Identifier: "other"},
+}
+
+// testRepos contains the repos root/child/repo and other/repo.
+var testRepos = []*types.Repository{
+	{ID: testRepoID, ParentID: testSpaceChildID, Path: "root/child/repo", Identifier: "repo"},
+	{ID: testRepoOtherID, ParentID: testSpaceOtherID, Path: "other/repo", Identifier: "repo"},
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	for _, space := range testSpaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (fakeSpaceStore) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range testSpaces {
+		if strings.EqualFold(space.Path, spaceRef) {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	for _, repo := range testRepos {
+		if strings.EqualFold(repo.Path, repoRef) {
+			return repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakePermissionCache struct {
+	allowed bool
+	keys    []PermissionCacheKey
+}
+
+func (c *fakePermissionCache) Stats() (int64, int64) {
+	return 0, 0
+}
+
+func (c *fakePermissionCache) Get(_ context.Context, key PermissionCacheKey) (bool, error) {
+	c.keys = append(c.keys, key)
+	return c.allowed, nil
+}
+
+func TestMembershipAuthorizer_Check(t *testing.T) {
+	user := types.Principal{ID: 100, UID: "user", Type: enum.PrincipalTypeUser}
+	admin := types.Principal{ID: 101, UID: "admin", Type: enum.PrincipalTypeUser, Admin: true}
+
+	repoInChild := func() (*types.Scope, *types.Resource) {
+		return &types.Scope{SpacePath: "root/child"},
+			&types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"}
+	}
+	repoInOther := func() (*types.Scope, *types.Resource) {
+		return &types.Scope{SpacePath: "other"},
+			&types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"}
+	}
+	space := func(parent, identifier string) func() (*types.Scope, *types.Resource) {
+		return func() (*types.Scope, *types.Resource) {
+			return &types.Scope{SpacePath: parent},
+				&types.Resource{Type: enum.ResourceTypeSpace, Identifier: identifier}
+		}
+	}
+	userResource := func(uid string) func() (*types.Scope, *types.Resource) {
+		return func() (*types.Scope, *types.Resource) {
+			return &types.Scope{}, &types.Resource{Type: enum.ResourceTypeUser, Identifier: uid}
+		}
+	}
+
+	tests := []struct {
+		name       string
+		principal  types.Principal
+		metadata   auth.Metadata
+		resource   func() (*types.Scope, *types.Resource)
+		permission enum.Permission
+		cache      bool
+		expected   bool
+		// expectedCacheKey is the key the permission cache is expected to be called with (nil if not called).
+		expectedCacheKey *PermissionCacheKey
+	}{
+		{
+			name:       "no token - permission from membership",
+			principal:  user,
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoPush,
+			},
+		},
+		{
+			name:       "no token - no permission from membership",
+			principal:  user,
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			cache:      false,
+			expected:   false,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoPush,
+			},
+		},
+		{
+			name:       "token without scopes",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoPush,
+			},
+		},
+		{
+			name:      "token with scope",
+			principal: user,
+			metadata: &auth.TokenMetadata{
+				TokenID: 1,
+				Scopes:  []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush},
+			},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoPush,
+			},
+		},
+		{
+			name:       "token without required scope",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, Scopes: []enum.Permission{enum.PermissionRepoView}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			cache:      true,
+			expected:   false,
+		},
+		{
+			name:       "admin token without required scope",
+			principal:  admin,
+			metadata:   &auth.TokenMetadata{TokenID: 1, Scopes: []enum.Permission{enum.PermissionRepoView}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			expected:   false,
+		},
+		{
+			name:       "admin without restrictions",
+			principal:  admin,
+			metadata:   &auth.TokenMetadata{TokenID: 1},
+			resource:   repoInOther,
+			permission: enum.PermissionRepoPush,
+			expected:   true,
+		},
+		{
+			name:       "admin token restricted to space - within space",
+			principal:  admin,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceChildID}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			expected:   true,
+		},
+		{
+			name:       "admin token restricted to space - outside of space",
+			principal:  admin,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceChildID}},
+			resource:   repoInOther,
+			permission: enum.PermissionRepoPush,
+			expected:   false,
+		},
+		{
+			name:       "token restricted to space - descendant of space",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceRootID}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoView,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoView,
+			},
+		},
+		{
+			name:       "token restricted to space - restriction doesn't grant permissions",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceRootID}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoView,
+			cache:      false,
+			expected:   false,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoView,
+			},
+		},
+		{
+			name:       "token restricted to space - ancestor of space",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceChildID}},
+			resource:   space("", "root"),
+			permission: enum.PermissionSpaceView,
+			cache:      true,
+			expected:   false,
+		},
+		{
+			name:       "token restricted to space - sibling space",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceChildID}},
+			resource:   space("", "other"),
+			permission: enum.PermissionSpaceView,
+			cache:      true,
+			expected:   false,
+		},
+		{
+			name:       "token restricted to deleted space",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{999}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoView,
+			cache:      true,
+			expected:   false,
+		},
+		{
+			name:       "token restricted to repo - same repo",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, RepoIDs: []int64{testRepoID}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoPush,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoPush,
+			},
+		},
+		{
+			name:      "token restricted to repo - resource within repo",
+			principal: user,
+			metadata:  &auth.TokenMetadata{TokenID: 1, RepoIDs: []int64{testRepoID}},
+			resource: func() (*types.Scope, *types.Resource) {
+				return &types.Scope{SpacePath: "root/child", Repo: "repo"},
+					&types.Resource{Type: enum.ResourceTypePipeline, Identifier: "pipeline"}
+			},
+			permission: enum.PermissionPipelineExecute,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionPipelineExecute,
+			},
+		},
+		{
+			name:       "token restricted to repo - other repo",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, RepoIDs: []int64{testRepoID}},
+			resource:   repoInOther,
+			permission: enum.PermissionRepoView,
+			cache:      true,
+			expected:   false,
+		},
+		{
+			name:       "token restricted to repo - parent space",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, RepoIDs: []int64{testRepoID}},
+			resource:   space("root", "child"),
+			permission: enum.PermissionSpaceView,
+			cache:      true,
+			expected:   false,
+		},
+		{
+			name:       "token restricted to space and repo - repo outside of space",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceOtherID}, RepoIDs: []int64{testRepoID}},
+			resource:   repoInChild,
+			permission: enum.PermissionRepoView,
+			cache:      true,
+			expected:   true,
+			expectedCacheKey: &PermissionCacheKey{
+				PrincipalID:    user.ID,
+				SpaceRef:       "root/child",
+				RepoIdentifier: "repo",
+				Permission:     enum.PermissionRepoView,
+			},
+		},
+		{
+			name:       "user views self",
+			principal:  user,
+			resource:   userResource(user.UID),
+			permission: enum.PermissionUserView,
+			expected:   true,
+		},
+		{
+			name:       "user edits self",
+			principal:  user,
+			resource:   userResource(user.UID),
+			permission: enum.PermissionUserEdit,
+			expected:   true,
+		},
+		{
+			name:       "user edits other user",
+			principal:  user,
+			resource:   userResource(admin.UID),
+			permission: enum.PermissionUserEdit,
+			expected:   false,
+		},
+		{
+			name:       "restricted token views self",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceRootID}},
+			resource:   userResource(user.UID),
+			permission: enum.PermissionUserView,
+			expected:   true,
+		},
+		{
+			name:       "restricted token edits self",
+			principal:  user,
+			metadata:   &auth.TokenMetadata{TokenID: 1, SpaceIDs: []int64{testSpaceRootID}},
+			resource:   userResource(user.UID),
+			permission: enum.PermissionUserEdit,
+			expected:   false,
+		},
+		{
+			name:       "restricted admin token edits other user",
+			principal:  admin,
+			metadata:   &auth.TokenMetadata{TokenID: 1, RepoIDs: []int64{testRepoID}},
+			resource:   userResource(user.UID),
+			permission: enum.PermissionUserEdit,
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			permissionCache := &fakePermissionCache{allowed: test.cache}
+			authorizer := NewMembershipAuthorizer(permissionCache, fakeSpaceStore{}, fakeRepoStore{})
+
+			session := &auth.Session{Principal: test.principal, Metadata: test.metadata}
+			scope, resource := test.resource()
+
+			allowed, err := authorizer.Check(context.Background(), session, scope, resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if allowed != test.expected {
+				t.Errorf("expected allowed=%t, got %t", test.expected, allowed)
+			}
+
+			switch {
+			case test.expectedCacheKey == nil && len(permissionCache.keys) > 0:
+				t.Errorf("expected no permission cache lookup, got %+v", permissionCache.keys)
+			case test.expectedCacheKey != nil && len(permissionCache.keys) != 1:
+				t.Errorf("expected one permission cache lookup, got %+v", permissionCache.keys)
+			case test.expectedCacheKey != nil && permissionCache.keys[0] != *test.expectedCacheKey:
+				t.Errorf("expected permission cache key %+v, got %+v", *test.expectedCacheKey, permissionCache.keys[0])
+			}
+		})
+	}
+}
+
+func TestIsPathWithin(t *testing.T) {
+	tests := []struct {
+		root     string
+		path     string
+		expected bool
+	}{
+		{root: "root", path: "root", expected: true},
+		{root: "root", path: "ROOT", expected: true},
+		{root: "root", path: "/root/", expected: true},
+		{root: "root", path: "root/child", expected: true},
+		{root: "root", path: "root/child/grandchild", expected: true},
+		{root: "root/child", path: "root", expected: false},
+		{root: "root", path: "rootless", expected: false},
+		{root: "root", path: "rootless/child", expected: false},
+		{root: "root/child", path: "root/children", expected: false},
+		{root: "root", path: "other", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.root+"->"+test.path, func(t *testing.T) {
+			if got := isPathWithin(test.root, test.path); got != test.expected {
+				t.Errorf("isPathWithin(%q, %q) = %t, expected %t", test.root, test.path, got, test.expected)
+			}
+		})
+	}
+}
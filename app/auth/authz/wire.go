@@ -28,14 +28,26 @@ var WireSet = wire.NewSet(
 	ProvidePermissionCache,
 )
 
-func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceStore)
+func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore, repoStore store.RepoStore) Authorizer {
+	return NewMembershipAuthorizer(pCache, spaceStore, repoStore)
 }
 
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	userGroupMembershipStore store.UserGroupMembershipStore,
+	repoStore store.RepoStore,
+	repoMembershipStore store.RepoMembershipStore,
+	customRoleStore store.CustomRoleStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(
+		spaceStore,
+		membershipStore,
+		userGroupMembershipStore,
+		repoStore,
+		repoMembershipStore,
+		customRoleStore,
+		permissionCacheTimeout,
+	)
 }
@@ -0,0 +1,302 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	dialTimeout    = 10 * time.Second
+	requestTimeout = 30 * time.Second
+
+	// pageSize is the size of the pages used for searches that can return a large number of entries.
+	pageSize = 500
+)
+
+var (
+	ErrUserNotFound       = errors.New("user not found in LDAP directory")
+	ErrInvalidCredentials = errors.New("invalid LDAP credentials")
+)
+
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+
+	UserBaseDN string
+	// UserFilter is the filter used to find a user, %s is replaced with the (escaped) login of the user.
+	UserFilter string
+	// IDAttribute is the attribute that permanently identifies a user (e.g. objectGUID or entryUUID).
+	// The normalized DN is used if the attribute isn't set, which doesn't protect against recreated entries.
+	IDAttribute          string
+	UIDAttribute         string
+	EmailAttribute       string
+	DisplayNameAttribute string
+
+	// AutoProvision creates a new user on the first login if no user exists with the same uid or email.
+	AutoProvision bool
+
+	// LinkExistingUsers links an LDAP user to the existing user with the same uid or email on the first login.
+	// Otherwise, logins of LDAP users that conflict with an existing user are rejected.
+	LinkExistingUsers bool
+
+	GroupBaseDN          string
+	GroupFilter          string
+	GroupNameAttribute   string
+	GroupMemberAttribute string
+}
+
+// Entry is a user of the LDAP directory.
+type Entry struct {
+	// ExternalID permanently identifies the user in the directory, see Config.IDAttribute.
+	ExternalID  string
+	DN          string
+	UID         string
+	Email       string
+	DisplayName string
+}
+
+// Group is a group of the LDAP directory.
+type Group struct {
+	DN        string
+	Name      string
+	MemberDNs []string
+}
+
+// Authenticator authenticates users against an LDAP directory and provides its users and groups.
+type Authenticator struct {
+	config Config
+}
+
+func NewAuthenticator(config Config) *Authenticator {
+	return &Authenticator{
+		config: config,
+	}
+}
+
+func (a *Authenticator) AutoProvision() bool {
+	return a.config.AutoProvision
+}
+
+// LinkExistingUsers returns true if LDAP users can be linked to existing users with the same uid or email.
+func (a *Authenticator) LinkExistingUsers() bool {
+	return a.config.LinkExistingUsers
+}
+
+// GroupSyncEnabled returns true if the groups of the directory should be synced.
+func (a *Authenticator) GroupSyncEnabled() bool {
+	return a.config.GroupBaseDN != ""
+}
+
+// Authenticate finds the user with the provided login (using the service account),
+// and validates the password by binding as the user.
+func (a *Authenticator) Authenticate(ctx context.Context, login string, password string) (*Entry, error) {
+	// an empty password results in an unauthenticated bind, which most servers accept.
+	if password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := a.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf(a.config.UserFilter, ldapv3.EscapeFilter(login))
+	res, err := conn.Search(ldapv3.NewSearchRequest(
+		a.config.UserBaseDN,
+		ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 2, int(requestTimeout.Seconds()), false,
+		filter,
+		a.userAttributes(),
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for user: %w", err)
+	}
+
+	switch len(res.Entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+	default:
+		return nil, fmt.Errorf("found %d users with login '%s' in LDAP directory", len(res.Entries), login)
+	}
+
+	entry := a.toEntry(res.Entries[0])
+
+	err = conn.Bind(entry.DN, password)
+	if ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind as user: %w", err)
+	}
+
+	return entry, nil
+}
+
+// ListUsers returns all users of the directory that match the user filter.
+func (a *Authenticator) ListUsers(ctx context.Context) ([]*Entry, error) {
+	conn, err := a.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := conn.SearchWithPaging(ldapv3.NewSearchRequest(
+		a.config.UserBaseDN,
+		ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(a.config.UserFilter, "*"),
+		a.userAttributes(),
+		nil,
+	), pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for users: %w", err)
+	}
+
+	entries := make([]*Entry, len(res.Entries))
+	for i, e := range res.Entries {
+		entries[i] = a.toEntry(e)
+	}
+
+	return entries, nil
+}
+
+// ListGroups returns all groups of the directory that match the group filter.
+func (a *Authenticator) ListGroups(ctx context.Context) ([]*Group, error) {
+	conn, err := a.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := conn.SearchWithPaging(ldapv3.NewSearchRequest(
+		a.config.GroupBaseDN,
+		ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 0, 0, false,
+		a.config.GroupFilter,
+		[]string{a.config.GroupNameAttribute, a.config.GroupMemberAttribute},
+		nil,
+	), pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for groups: %w", err)
+	}
+
+	groups := make([]*Group, len(res.Entries))
+	for i, e := range res.Entries {
+		groups[i] = &Group{
+			DN:        e.DN,
+			Name:      e.GetEqualFoldAttributeValue(a.config.GroupNameAttribute),
+			MemberDNs: e.GetEqualFoldAttributeValues(a.config.GroupMemberAttribute),
+		}
+	}
+
+	return groups, nil
+}
+
+// connect opens a connection to the directory server that's bound with the service account.
+func (a *Authenticator) connect(ctx context.Context) (*ldapv3.Conn, error) {
+	//nolint:gosec // skipping the verification is an explicit choice of the admin.
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: a.config.InsecureSkipVerify,
+	}
+
+	conn, err := ldapv3.DialURL(a.config.URL,
+		ldapv3.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+		ldapv3.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+
+	timeout := requestTimeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	conn.SetTimeout(timeout)
+
+	if a.config.StartTLS && !strings.HasPrefix(strings.ToLower(a.config.URL), "ldaps://") {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if a.config.BindDN != "" {
+		if err = conn.Bind(a.config.BindDN, a.config.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to bind with service account: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+func (a *Authenticator) userAttributes() []string {
+	attributes := []string{a.config.UIDAttribute, a.config.EmailAttribute, a.config.DisplayNameAttribute}
+	if a.config.IDAttribute != "" {
+		attributes = append(attributes, a.config.IDAttribute)
+	}
+
+	return attributes
+}
+
+func (a *Authenticator) toEntry(e *ldapv3.Entry) *Entry {
+	// the value is hex encoded, as identifiers like objectGUID are binary.
+	externalID := "dn:" + NormalizeDN(e.DN)
+	if a.config.IDAttribute != "" {
+		if raw := e.GetEqualFoldRawAttributeValue(a.config.IDAttribute); len(raw) > 0 {
+			externalID = a.config.IDAttribute + ":" + hex.EncodeToString(raw)
+		}
+	}
+
+	return &Entry{
+		ExternalID:  externalID,
+		DN:          e.DN,
+		UID:         e.GetEqualFoldAttributeValue(a.config.UIDAttribute),
+		Email:       e.GetEqualFoldAttributeValue(a.config.EmailAttribute),
+		DisplayName: e.GetEqualFoldAttributeValue(a.config.DisplayNameAttribute),
+	}
+}
+
+// NormalizeDN returns the normalized form of a DN, allowing to compare DNs
+// that differ in case or formatting (e.g. the member attribute of a group and the DN of a user).
+func NormalizeDN(dn string) string {
+	parsed, err := ldapv3.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+
+	rdns := make([]string, len(parsed.RDNs))
+	for i, rdn := range parsed.RDNs {
+		attrs := make([]string, len(rdn.Attributes))
+		for j, attr := range rdn.Attributes {
+			attrs[j] = strings.ToLower(attr.Type) + "=" + strings.ToLower(attr.Value)
+		}
+		rdns[i] = strings.Join(attrs, "+")
+	}
+
+	return strings.Join(rdns, ",")
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"testing"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+)
+
+func TestNormalizeDN(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		same bool
+	}{
+		{
+			name: "case-and-spaces",
+			a:    "CN=John Doe, OU=People,DC=Example,DC=com",
+			b:    "cn=john doe,ou=people,dc=example,dc=com",
+			same: true,
+		},
+		{
+			name: "escaped",
+			a:    `cn=Doe\, John,ou=people,dc=example,dc=com`,
+			b:    `cn=doe\2C john,ou=people,dc=example,dc=com`,
+			same: true,
+		},
+		{
+			name: "different",
+			a:    "cn=john,ou=people,dc=example,dc=com",
+			b:    "cn=jane,ou=people,dc=example,dc=com",
+			same: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := NormalizeDN(test.a), NormalizeDN(test.b)
+			if (a == b) != test.same {
+				t.Errorf("expected same=%t, got %q and %q", test.same, a, b)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_toEntry_ExternalID(t *testing.T) {
+	guid := []byte{0x01, 0x02, 0xab, 0xff}
+
+	tests := []struct {
+		name        string
+		idAttribute string
+		attributes  map[string][]string
+		expected    string
+	}{
+		{
+			name:        "id-attribute",
+			idAttribute: "objectGUID",
+			attributes:  map[string][]string{"objectGUID": {string(guid)}},
+			expected:    "objectGUID:0102abff",
+		},
+		{
+			name:        "id-attribute-missing",
+			idAttribute: "objectGUID",
+			expected:    "dn:cn=john,dc=example,dc=com",
+		},
+		{
+			name:     "no-id-attribute",
+			expected: "dn:cn=john,dc=example,dc=com",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAuthenticator(Config{IDAttribute: test.idAttribute, UIDAttribute: "uid"})
+
+			entry := a.toEntry(ldapv3.NewEntry("CN=John,DC=Example,DC=com", test.attributes))
+			if entry.ExternalID != test.expected {
+				t.Errorf("expected external ID %q, got %q", test.expected, entry.ExternalID)
+			}
+		})
+	}
+}
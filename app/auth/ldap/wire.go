@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"errors"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideAuthenticator,
+)
+
+// ProvideAuthenticator provides the LDAP authenticator. It returns nil in case LDAP login is disabled.
+func ProvideAuthenticator(config *types.Config) (*Authenticator, error) {
+	if !config.LDAP.Enabled {
+		return nil, nil //nolint:nilnil // LDAP login is optional
+	}
+
+	if config.LDAP.URL == "" || config.LDAP.UserBaseDN == "" {
+		return nil, errors.New("LDAP url and user base DN are required if LDAP login is enabled")
+	}
+
+	if config.LDAP.GroupBaseDN != "" && config.LDAP.GroupSyncSpace == "" {
+		return nil, errors.New("LDAP group sync space is required if the LDAP group base DN is set")
+	}
+
+	return NewAuthenticator(Config{
+		URL:                config.LDAP.URL,
+		StartTLS:           config.LDAP.StartTLS,
+		InsecureSkipVerify: config.LDAP.InsecureSkipVerify,
+		BindDN:             config.LDAP.BindDN,
+		BindPassword:       config.LDAP.BindPassword,
+
+		UserBaseDN:           config.LDAP.UserBaseDN,
+		UserFilter:           config.LDAP.UserFilter,
+		IDAttribute:          config.LDAP.IDAttribute,
+		UIDAttribute:         config.LDAP.UIDAttribute,
+		EmailAttribute:       config.LDAP.EmailAttribute,
+		DisplayNameAttribute: config.LDAP.DisplayNameAttribute,
+
+		AutoProvision:     config.LDAP.AutoProvision,
+		LinkExistingUsers: config.LDAP.LinkExistingUsers,
+
+		GroupBaseDN:          config.LDAP.GroupBaseDN,
+		GroupFilter:          config.LDAP.GroupFilter,
+		GroupNameAttribute:   config.LDAP.GroupNameAttribute,
+		GroupMemberAttribute: config.LDAP.GroupMemberAttribute,
+	}), nil
+}
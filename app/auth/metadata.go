@@ -31,12 +31,37 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+
+	// Scopes, SpaceIDs and RepoIDs optionally restrict the access granted by the token.
+	Scopes   []enum.Permission
+	SpaceIDs []int64
+	RepoIDs  []int64
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
+	return len(m.Scopes) > 0 || m.IsRestricted()
+}
+
+// HasScope returns true if the token grants the provided permission.
+func (m *TokenMetadata) HasScope(permission enum.Permission) bool {
+	if len(m.Scopes) == 0 {
+		return true
+	}
+
+	for _, scope := range m.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+
 	return false
 }
 
+// IsRestricted returns true in case the token is restricted to specific spaces or repositories.
+func (m *TokenMetadata) IsRestricted() bool {
+	return len(m.SpaceIDs) > 0 || len(m.RepoIDs) > 0
+}
+
 // MembershipMetadata contains information about an ephemeral membership grant.
 type MembershipMetadata struct {
 	SpaceID int64
@@ -46,3 +71,12 @@ type MembershipMetadata struct {
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// PublicKeyMetadata contains information about the public key that was used during auth (e.g. via ssh).
+type PublicKeyMetadata struct {
+	PublicKeyID int64
+}
+
+func (m *PublicKeyMetadata) ImpactsAuthorization() bool {
+	return false
+}
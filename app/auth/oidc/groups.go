@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// GroupMapping maps a group of the identity provider to a space membership.
+type GroupMapping struct {
+	Group     string
+	SpacePath string
+	Role      enum.MembershipRole
+}
+
+// ParseGroupMappings parses group mappings in the format "<group>=<space_path>:<role>".
+func ParseGroupMappings(raw []string) ([]GroupMapping, error) {
+	mappings := make([]GroupMapping, 0, len(raw))
+	for _, s := range raw {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		group, target, ok := strings.Cut(s, "=")
+		if !ok {
+			return nil, fmt.Errorf("group mapping '%s' must have the format '<group>=<space_path>:<role>'", s)
+		}
+
+		idx := strings.LastIndex(target, ":")
+		if idx < 0 {
+			return nil, fmt.Errorf("group mapping '%s' must have the format '<group>=<space_path>:<role>'", s)
+		}
+
+		mapping := GroupMapping{
+			Group:     strings.TrimSpace(group),
+			SpacePath: strings.Trim(strings.TrimSpace(target[:idx]), "/"),
+			Role:      enum.MembershipRole(strings.TrimSpace(target[idx+1:])),
+		}
+
+		if mapping.Group == "" || mapping.SpacePath == "" {
+			return nil, fmt.Errorf("group mapping '%s' must have the format '<group>=<space_path>:<role>'", s)
+		}
+
+		if _, ok := mapping.Role.Sanitize(); !ok {
+			return nil, fmt.Errorf("group mapping '%s' has an unknown membership role '%s'", s, mapping.Role)
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, nil
+}
+
+// MembershipsForGroups returns the space memberships (space path to role) of a user with the provided groups.
+// If multiple groups of the user are mapped to the same space, the first mapping is used.
+func MembershipsForGroups(mappings []GroupMapping, groups []string) map[string]enum.MembershipRole {
+	groupSet := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		groupSet[group] = struct{}{}
+	}
+
+	memberships := map[string]enum.MembershipRole{}
+	for _, mapping := range mappings {
+		if _, ok := groupSet[mapping.Group]; !ok {
+			continue
+		}
+
+		if _, ok := memberships[mapping.SpacePath]; ok {
+			continue
+		}
+
+		memberships[mapping.SpacePath] = mapping.Role
+	}
+
+	return memberships
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseGroupMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []string
+		want    []GroupMapping
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			raw:  []string{"developers=acme/backend:contributor", " ", "admins = /acme/ : space_owner"},
+			want: []GroupMapping{
+				{Group: "developers", SpacePath: "acme/backend", Role: enum.MembershipRoleContributor},
+				{Group: "admins", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner},
+			},
+		},
+		{
+			name:    "missing-space",
+			raw:     []string{"developers"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-role",
+			raw:     []string{"developers=acme"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-role",
+			raw:     []string{"developers=acme:owner"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseGroupMappings(test.raw)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got mappings %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v, got=%v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMembershipsForGroups(t *testing.T) {
+	mappings := []GroupMapping{
+		{Group: "developers", SpacePath: "acme", Role: enum.MembershipRoleContributor},
+		{Group: "admins", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner},
+		{Group: "readers", SpacePath: "other", Role: enum.MembershipRoleReader},
+	}
+
+	got := MembershipsForGroups(mappings, []string{"admins", "developers", "unknown"})
+	want := map[string]enum.MembershipRole{"acme": enum.MembershipRoleContributor}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%v, got=%v", want, got)
+	}
+}
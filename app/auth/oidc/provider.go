@@ -0,0 +1,353 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// CallbackPath is the path of the rest api the identity provider redirects to after the login.
+	CallbackPath = "/v1/oidc/callback"
+
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// clockSkew is the allowed difference between the clocks of the identity provider and the server.
+	clockSkew = time.Minute
+
+	requestTimeout = 30 * time.Second
+)
+
+var ErrInvalidToken = errors.New("invalid ID token")
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// UIURL is the base URL of the UI users are redirected to after the login.
+	UIURL       string
+	Scopes      []string
+	GroupsClaim string
+
+	// AutoProvision creates a new user on the first login if no user can be linked via its verified email.
+	AutoProvision bool
+
+	// GroupMappings map the groups of the user to space memberships.
+	GroupMappings []GroupMapping
+}
+
+// Claims are the claims of the authenticated user.
+type Claims struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Groups            []string
+}
+
+// discovery is the metadata of the identity provider returned by its discovery endpoint.
+type discovery struct {
+	Issuer           string `json:"issuer"`
+	AuthEndpoint     string `json:"authorization_endpoint"`
+	TokenEndpoint    string `json:"token_endpoint"`
+	UserInfoEndpoint string `json:"userinfo_endpoint"`
+}
+
+// Provider authenticates users via the authorization code flow with PKCE of an OpenID Connect identity provider.
+type Provider struct {
+	config     Config
+	httpClient *http.Client
+
+	mx        sync.Mutex
+	discovery *discovery
+}
+
+func NewProvider(config Config) *Provider {
+	config.Issuer = strings.TrimRight(config.Issuer, "/")
+
+	return &Provider{
+		config:     config,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// AutoProvision returns true if new users are created on their first login.
+func (p *Provider) AutoProvision() bool {
+	return p.config.AutoProvision
+}
+
+// GroupMappings returns the mappings of groups of the identity provider to space memberships.
+func (p *Provider) GroupMappings() []GroupMapping {
+	return p.config.GroupMappings
+}
+
+// UIRedirectURL returns the URL of the UI the user is redirected to after the login.
+func (p *Provider) UIRedirectURL(path string) string {
+	return strings.TrimRight(p.config.UIURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// AuthCodeURL returns the URL of the identity provider the user is redirected to for the login.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	oauthConfig, _, err := p.oauthConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthConfig.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallengeS256(codeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	), nil
+}
+
+// Exchange exchanges the authorization code for the tokens of the user and returns the claims of the user.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error) {
+	oauthConfig, disc, err := p.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+
+	token, err := oauthConfig.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response doesn't contain an ID token", ErrInvalidToken)
+	}
+
+	// The ID token is received directly from the token endpoint via TLS, which authenticates the issuer,
+	// so the signature of the token doesn't have to be verified (OpenID Connect Core 1.0, section 3.1.3.7).
+	// All other claims are validated.
+	mapClaims := jwt.MapClaims{}
+	_, _, err = new(jwt.Parser).ParseUnverified(rawIDToken, mapClaims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	err = p.validateIDTokenClaims(mapClaims, disc.Issuer, nonce, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	claims := p.claimsFromMap(mapClaims)
+	claims.Issuer = disc.Issuer
+
+	// the email might not be part of the ID token, it's always available via the user info endpoint.
+	if claims.Email == "" && disc.UserInfoEndpoint != "" {
+		userInfo, err := p.userInfo(ctx, disc.UserInfoEndpoint, token)
+		if err != nil {
+			return nil, err
+		}
+
+		// the subject of the user info response must match the subject of the ID token.
+		if sub, _ := userInfo["sub"].(string); sub != claims.Subject {
+			return nil, errors.New("subject of the user info doesn't match the subject of the ID token")
+		}
+
+		p.mergeClaims(claims, p.claimsFromMap(userInfo))
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) validateIDTokenClaims(claims jwt.MapClaims, issuer, nonce string, now time.Time) error {
+	if iss, _ := claims["iss"].(string); iss != issuer {
+		return fmt.Errorf("%w: unexpected issuer '%s'", ErrInvalidToken, iss)
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return fmt.Errorf("%w: token wasn't issued for this client", ErrInvalidToken)
+	}
+
+	if !claims.VerifyExpiresAt(now.Add(-clockSkew).Unix(), true) {
+		return fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return fmt.Errorf("%w: nonce doesn't match", ErrInvalidToken)
+	}
+
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return fmt.Errorf("%w: subject is missing", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+func (p *Provider) claimsFromMap(m map[string]any) *Claims {
+	claims := &Claims{}
+	claims.Subject, _ = m["sub"].(string)
+	claims.Email, _ = m["email"].(string)
+	claims.Name, _ = m["name"].(string)
+	claims.PreferredUsername, _ = m["preferred_username"].(string)
+
+	// some identity providers return email_verified as a string.
+	switch v := m["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		claims.EmailVerified = v == "true"
+	}
+
+	if p.config.GroupsClaim != "" {
+		switch v := m[p.config.GroupsClaim].(type) {
+		case []any:
+			for _, group := range v {
+				if s, ok := group.(string); ok {
+					claims.Groups = append(claims.Groups, s)
+				}
+			}
+		case string:
+			claims.Groups = []string{v}
+		}
+	}
+
+	return claims
+}
+
+func (p *Provider) mergeClaims(claims *Claims, userInfo *Claims) {
+	claims.Email = userInfo.Email
+	claims.EmailVerified = userInfo.EmailVerified
+	if claims.Name == "" {
+		claims.Name = userInfo.Name
+	}
+	if claims.PreferredUsername == "" {
+		claims.PreferredUsername = userInfo.PreferredUsername
+	}
+	if len(claims.Groups) == 0 {
+		claims.Groups = userInfo.Groups
+	}
+}
+
+func (p *Provider) userInfo(ctx context.Context, endpoint string, token *oauth2.Token) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user info request: %w", err)
+	}
+
+	token.SetAuthHeader(req)
+
+	userInfo := map[string]any{}
+	if err := p.getJSON(req, &userInfo); err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	return userInfo, nil
+}
+
+func (p *Provider) oauthConfig(ctx context.Context) (*oauth2.Config, *discovery, error) {
+	disc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  disc.AuthEndpoint,
+			TokenURL: disc.TokenEndpoint,
+		},
+		RedirectURL: p.config.RedirectURL,
+		Scopes:      p.config.Scopes,
+	}, disc, nil
+}
+
+// getDiscovery returns the metadata of the identity provider. It's fetched once and cached afterwards.
+func (p *Provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.Issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+
+	disc := &discovery{}
+	if err := p.getJSON(req, disc); err != nil {
+		return nil, fmt.Errorf("failed to get OIDC discovery document: %w", err)
+	}
+
+	if disc.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("issuer of the discovery document '%s' doesn't match the configured issuer '%s'",
+			disc.Issuer, p.config.Issuer)
+	}
+
+	if disc.AuthEndpoint == "" || disc.TokenEndpoint == "" {
+		return nil, errors.New("discovery document doesn't contain the authorization and token endpoints")
+	}
+
+	p.discovery = disc
+
+	return disc, nil
+}
+
+func (p *Provider) getJSON(req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// GenerateRandomString returns a cryptographically random string
+// usable as the state, the nonce or the PKCE code verifier of a login.
+func GenerateRandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func codeChallengeS256(codeVerifier string) string {
+	h := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideProvider,
+)
+
+// ProvideProvider provides the OIDC login provider. It returns nil in case OIDC login is disabled.
+func ProvideProvider(config *types.Config, urlProvider url.Provider) (*Provider, error) {
+	if !config.OIDC.Enabled {
+		return nil, nil //nolint:nilnil // OIDC login is optional
+	}
+
+	if config.OIDC.Issuer == "" || config.OIDC.ClientID == "" {
+		return nil, errors.New("OIDC issuer and client id are required if OIDC login is enabled")
+	}
+
+	groupMappings, err := ParseGroupMappings(config.OIDC.GroupMappings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OIDC group mappings: %w", err)
+	}
+
+	return NewProvider(Config{
+		Issuer:       config.OIDC.Issuer,
+		ClientID:     config.OIDC.ClientID,
+		ClientSecret: config.OIDC.ClientSecret,
+		RedirectURL:  urlProvider.GenerateAPIURL(CallbackPath),
+		UIURL:        urlProvider.GenerateUIURL("/"),
+		Scopes:       config.OIDC.Scopes,
+		GroupsClaim:  config.OIDC.GroupsClaim,
+
+		AutoProvision: config.OIDC.AutoProvision,
+		GroupMappings: groupMappings,
+	}), nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/encrypt"
+
+	"github.com/duo-labs/webauthn/webauthn"
+)
+
+var (
+	ErrInvalidCode             = errors.New("invalid two-factor authentication code")
+	ErrInvalidWebAuthnResponse = errors.New("invalid WebAuthn response")
+)
+
+type Config struct {
+	// Issuer is the name the TOTP secrets are labeled with in authenticator apps.
+	Issuer string
+
+	// Required requires all users to login with a second factor.
+	Required bool
+	// RequiredSpaces are the paths of the spaces whose members have to login with a second factor.
+	RequiredSpaces []string
+
+	// ChallengeLifetime is the time a user has to provide the second factor after the password was verified.
+	ChallengeLifetime time.Duration
+
+	// MaxFailedAttempts is the number of failed second factor attempts after which a user is locked out.
+	MaxFailedAttempts int
+	// LockoutDuration is the time a user can't login with a second factor after too many failed attempts.
+	LockoutDuration time.Duration
+
+	// WebAuthnRPID is the ID of the relying party (the domain of the UI) security keys are registered for.
+	WebAuthnRPID string
+	// WebAuthnRPOrigin is the origin of the UI the WebAuthn ceremonies are performed in.
+	WebAuthnRPOrigin string
+}
+
+// Authenticator verifies the second factors of users: TOTP codes, recovery codes and WebAuthn credentials.
+type Authenticator struct {
+	config    Config
+	encrypter encrypt.Encrypter
+	webAuthn  *webauthn.WebAuthn
+}
+
+func NewAuthenticator(config Config, encrypter encrypt.Encrypter) (*Authenticator, error) {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPDisplayName: config.Issuer,
+		RPID:          config.WebAuthnRPID,
+		RPOrigin:      config.WebAuthnRPOrigin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebAuthn relying party: %w", err)
+	}
+
+	return &Authenticator{
+		config:    config,
+		encrypter: encrypter,
+		webAuthn:  webAuthn,
+	}, nil
+}
+
+// ChallengeLifetime returns the time a user has to provide the second factor of a login.
+func (a *Authenticator) ChallengeLifetime() time.Duration {
+	return a.config.ChallengeLifetime
+}
+
+// MaxFailedAttempts returns the number of failed second factor attempts after which a user is locked out.
+func (a *Authenticator) MaxFailedAttempts() int {
+	return a.config.MaxFailedAttempts
+}
+
+// LockoutDuration returns the time a user can't login with a second factor after too many failed attempts.
+func (a *Authenticator) LockoutDuration() time.Duration {
+	return a.config.LockoutDuration
+}
+
+// IsRequiredForAll returns true if all users have to login with a second factor.
+func (a *Authenticator) IsRequiredForAll() bool {
+	return a.config.Required
+}
+
+// RequiredSpaces returns the paths of the spaces whose members have to login with a second factor.
+func (a *Authenticator) RequiredSpaces() []string {
+	return a.config.RequiredSpaces
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("expected %d codes and hashes, got %d and %d", recoveryCodeCount, len(codes), len(hashes))
+	}
+
+	seen := map[string]struct{}{}
+	for i, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("unexpected format of code %q", code)
+		}
+
+		if hashes[i] != HashRecoveryCode(code) {
+			t.Errorf("hash of code %q doesn't match", code)
+		}
+
+		if _, ok := seen[code]; ok {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = struct{}{}
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	const code = "abcde-fghij"
+
+	tests := []string{
+		"abcde-fghij",
+		"ABCDE-FGHIJ",
+		"abcdefghij",
+		" abcde fghij ",
+	}
+	for _, test := range tests {
+		if got, want := HashRecoveryCode(test), HashRecoveryCode(code); got != want {
+			t.Errorf("hash of %q doesn't match the hash of %q", test, code)
+		}
+	}
+
+	if HashRecoveryCode(code) == HashRecoveryCode(strings.Replace(code, "a", "b", 1)) {
+		t.Error("hashes of different codes must not match")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpPeriod = 30
+	// totpSkew is the number of periods before and after the current one codes are accepted for,
+	// to allow for clock drift between the server and the authenticator app.
+	totpSkew = 1
+)
+
+var totpValidateOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// GenerateTOTP generates a new TOTP secret for the user.
+// It returns the enrollment information for the authenticator app and the encrypted secret.
+func (a *Authenticator) GenerateTOTP(user *types.User) (*types.TOTPEnrollment, []byte, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      a.config.Issuer,
+		AccountName: user.UID,
+		Period:      totpPeriod,
+		Digits:      totpValidateOpts.Digits,
+		Algorithm:   totpValidateOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate TOTP key: %w", err)
+	}
+
+	encrypted, err := a.encrypter.Encrypt(key.Secret())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+
+	return &types.TOTPEnrollment{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+	}, encrypted, nil
+}
+
+// ValidateTOTP validates the code against the encrypted TOTP secret.
+// It returns the time step the code was generated for, which is used to prevent replays of the code.
+func (a *Authenticator) ValidateTOTP(encryptedSecret []byte, code string, now time.Time) (int64, error) {
+	secret, err := a.encrypter.Decrypt(encryptedSecret)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+
+	step, ok := validateTOTPCode(secret, code, now)
+	if !ok {
+		return 0, ErrInvalidCode
+	}
+
+	return step, nil
+}
+
+func validateTOTPCode(secret string, code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpValidateOpts.Digits.Length() {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0).UTC(), totpValidateOpts)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
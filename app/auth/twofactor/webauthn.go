@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+)
+
+// webAuthnUser adapts a user and its credentials to the interface required by the WebAuthn library.
+type webAuthnUser struct {
+	user        *types.User
+	credentials []webauthn.Credential
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte                         { return []byte(strconv.FormatInt(u.user.ID, 10)) }
+func (u *webAuthnUser) WebAuthnName() string                       { return u.user.UID }
+func (u *webAuthnUser) WebAuthnDisplayName() string                { return u.user.DisplayName }
+func (u *webAuthnUser) WebAuthnIcon() string                       { return "" }
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential { return u.credentials }
+
+// WebAuthnCeremony contains the options passed to the browser to start a WebAuthn registration or login,
+// and the session data that's required to verify the response of the browser.
+type WebAuthnCeremony struct {
+	Options json.RawMessage
+	Session json.RawMessage
+}
+
+// BeginWebAuthnRegistration starts the registration of a new WebAuthn credential for the user.
+func (a *Authenticator) BeginWebAuthnRegistration(
+	user *types.User,
+	existing []*types.WebAuthnCredential,
+) (*WebAuthnCeremony, error) {
+	waUser, err := newWebAuthnUser(user, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	options, session, err := a.webAuthn.BeginRegistration(waUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin WebAuthn registration: %w", err)
+	}
+
+	return newWebAuthnCeremony(options, session)
+}
+
+// FinishWebAuthnRegistration verifies the response of the browser to the registration and returns
+// the new credential.
+func (a *Authenticator) FinishWebAuthnRegistration(
+	user *types.User,
+	existing []*types.WebAuthnCredential,
+	session json.RawMessage,
+	response json.RawMessage,
+) (*types.WebAuthnCredential, error) {
+	waUser, err := newWebAuthnUser(user, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionData := webauthn.SessionData{}
+	if err = json.Unmarshal(session, &sessionData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal WebAuthn session: %w", err)
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWebAuthnResponse, err)
+	}
+
+	credential, err := a.webAuthn.CreateCredential(waUser, sessionData, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWebAuthnResponse, err)
+	}
+
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WebAuthn credential: %w", err)
+	}
+
+	return &types.WebAuthnCredential{
+		PrincipalID: user.ID,
+		Key:         base64.RawURLEncoding.EncodeToString(credential.ID),
+		Data:        data,
+	}, nil
+}
+
+// BeginWebAuthnLogin starts the login with any of the WebAuthn credentials of the user.
+func (a *Authenticator) BeginWebAuthnLogin(
+	user *types.User,
+	credentials []*types.WebAuthnCredential,
+) (*WebAuthnCeremony, error) {
+	waUser, err := newWebAuthnUser(user, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	options, session, err := a.webAuthn.BeginLogin(waUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin WebAuthn login: %w", err)
+	}
+
+	return newWebAuthnCeremony(options, session)
+}
+
+// FinishWebAuthnLogin verifies the response of the browser to the login.
+// It returns the credential that was used, with its data updated (e.g. the signature counter).
+func (a *Authenticator) FinishWebAuthnLogin(
+	user *types.User,
+	credentials []*types.WebAuthnCredential,
+	session json.RawMessage,
+	response json.RawMessage,
+) (*types.WebAuthnCredential, error) {
+	waUser, err := newWebAuthnUser(user, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionData := webauthn.SessionData{}
+	if err = json.Unmarshal(session, &sessionData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal WebAuthn session: %w", err)
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWebAuthnResponse, err)
+	}
+
+	used, err := a.webAuthn.ValidateLogin(waUser, sessionData, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWebAuthnResponse, err)
+	}
+
+	if used.Authenticator.CloneWarning {
+		return nil, fmt.Errorf("%w: the signature counter indicates a cloned authenticator",
+			ErrInvalidWebAuthnResponse)
+	}
+
+	data, err := json.Marshal(used)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WebAuthn credential: %w", err)
+	}
+
+	key := base64.RawURLEncoding.EncodeToString(used.ID)
+	for _, credential := range credentials {
+		if credential.Key == key {
+			credential.Data = data
+			return credential, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unknown credential", ErrInvalidWebAuthnResponse)
+}
+
+func newWebAuthnUser(user *types.User, credentials []*types.WebAuthnCredential) (*webAuthnUser, error) {
+	waCredentials := make([]webauthn.Credential, len(credentials))
+	for i, credential := range credentials {
+		if err := json.Unmarshal(credential.Data, &waCredentials[i]); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal WebAuthn credential %d: %w", credential.ID, err)
+		}
+	}
+
+	return &webAuthnUser{
+		user:        user,
+		credentials: waCredentials,
+	}, nil
+}
+
+func newWebAuthnCeremony(options interface{}, session *webauthn.SessionData) (*WebAuthnCeremony, error) {
+	optionsRaw, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WebAuthn options: %w", err)
+	}
+
+	sessionRaw, err := json.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WebAuthn session: %w", err)
+	}
+
+	return &WebAuthnCeremony{
+		Options: optionsRaw,
+		Session: sessionRaw,
+	}, nil
+}
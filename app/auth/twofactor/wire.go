@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twofactor
+
+import (
+	"fmt"
+	"net/url"
+
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideAuthenticator,
+)
+
+// ProvideAuthenticator provides the authenticator of second factors.
+// The WebAuthn relying party is derived from the public URL of the UI.
+func ProvideAuthenticator(
+	config *types.Config,
+	urlProvider gitnessurl.Provider,
+	encrypter encrypt.Encrypter,
+) (*Authenticator, error) {
+	uiURL, err := url.Parse(urlProvider.GenerateUIURL("/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse UI url: %w", err)
+	}
+
+	return NewAuthenticator(Config{
+		Issuer:            config.TwoFactor.Issuer,
+		Required:          config.TwoFactor.Required,
+		RequiredSpaces:    config.TwoFactor.RequiredSpaces,
+		ChallengeLifetime: config.TwoFactor.ChallengeLifetime,
+		MaxFailedAttempts: config.TwoFactor.MaxFailedAttempts,
+		LockoutDuration:   config.TwoFactor.LockoutDuration,
+		WebAuthnRPID:      uiURL.Hostname(),
+		WebAuthnRPOrigin:  uiURL.Scheme + "://" + uiURL.Host,
+	}, encrypter)
+}
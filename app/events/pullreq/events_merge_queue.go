@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueEnqueuedEvent events.EventType = "merge-queue-enqueued"
+
+type MergeQueueEnqueuedPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+	SourceSHA    string `json:"source_sha"`
+}
+
+func (r *Reporter) MergeQueueEnqueued(ctx context.Context, payload *MergeQueueEnqueuedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueEnqueuedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue enqueued event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue enqueued event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueEnqueued(fn events.HandlerFunc[*MergeQueueEnqueuedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueEnqueuedEvent, fn, opts...)
+}
+
+const MergeQueueBuiltEvent events.EventType = "merge-queue-built"
+
+type MergeQueueBuiltPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+	SourceSHA    string `json:"source_sha"`
+	BaseSHA      string `json:"base_sha"`
+	MergeSHA     string `json:"merge_sha"`
+}
+
+func (r *Reporter) MergeQueueBuilt(ctx context.Context, payload *MergeQueueBuiltPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueBuiltEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue built event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue built event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueBuilt(fn events.HandlerFunc[*MergeQueueBuiltPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueBuiltEvent, fn, opts...)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const RuleBypassedEvent events.EventType = "rule-bypassed"
+
+// RuleBypassedPayload describes protection rules that got bypassed by a merge or a push.
+type RuleBypassedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+	// PullReqID is the ID of the merged pull request, it's zero if the rules were bypassed by a push.
+	PullReqID int64            `json:"pullreq_id,omitempty"`
+	Refs      []string         `json:"refs"`
+	Rules     []types.RuleInfo `json:"rules"`
+	Reason    string           `json:"reason"`
+}
+
+func (r *Reporter) RuleBypassed(ctx context.Context, payload *RuleBypassedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RuleBypassedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo rule bypassed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo rule bypassed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRuleBypassed(fn events.HandlerFunc[*RuleBypassedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, RuleBypassedEvent, fn, opts...)
+}
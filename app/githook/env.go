@@ -42,6 +42,7 @@ func GenerateEnvironmentVariables(
 	principalID int64,
 	disabled bool,
 	internal bool,
+	scanSecrets bool,
 ) (map[string]string, error) {
 	// best effort retrieving of requestID - log in case we can't find it but don't fail operation.
 	requestID, ok := request.RequestIDFrom(ctx)
@@ -49,6 +50,9 @@ func GenerateEnvironmentVariables(
 		log.Ctx(ctx).Warn().Msg("operation doesn't have a requestID in the context - generate githook payload without")
 	}
 
+	// the client ip is only available for operations triggered by a client (e.g. git push).
+	clientIP, _ := request.ClientIPFrom(ctx)
+
 	// generate githook base url
 	baseURL := strings.TrimLeft(apiBaseURL, "/") + "/v1/internal/git-hooks"
 
@@ -57,8 +61,10 @@ func GenerateEnvironmentVariables(
 		RepoID:      repoID,
 		PrincipalID: principalID,
 		RequestID:   requestID,
+		ClientIP:    clientIP,
 		Disabled:    disabled,
 		Internal:    internal,
+		ScanSecrets: scanSecrets,
 	}
 
 	if err := payload.Validate(); err != nil {
@@ -26,8 +26,10 @@ type Payload struct {
 	RepoID      int64
 	PrincipalID int64
 	RequestID   string
+	ClientIP    string
 	Disabled    bool
 	Internal    bool // Internal calls originate from Gitness, and external calls are direct git pushes.
+	ScanSecrets bool // ScanSecrets is set for internal calls that push commits with user provided content.
 }
 
 func (p Payload) Validate() error {
@@ -53,6 +55,8 @@ func getInputBaseFromPayload(p Payload) types.GithookInputBase {
 	return types.GithookInputBase{
 		RepoID:      p.RepoID,
 		PrincipalID: p.PrincipalID,
+		ClientIP:    p.ClientIP,
 		Internal:    p.Internal,
+		ScanSecrets: p.ScanSecrets,
 	}
 }
@@ -19,7 +19,11 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -52,6 +56,11 @@ func ProvideController(
 	protectionManager *protection.Manager,
 	githookFactory hook.ClientFactory,
 	limiter limiter.ResourceLimiter,
+	signatureVerifier *gitsignature.Verifier,
+	pullMirrorStore store.PullMirrorStore,
+	auditService *audit.Service,
+	repoReporter *repoevents.Reporter,
+	secretScanService *secretscan.Service,
 ) *githook.Controller {
 	ctrl := githook.NewController(
 		authorizer,
@@ -62,7 +71,12 @@ func ProvideController(
 		pullreqStore,
 		urlProvider,
 		protectionManager,
-		limiter)
+		limiter,
+		signatureVerifier,
+		pullMirrorStore,
+		auditService,
+		repoReporter,
+		secretScanService)
 
 	// TODO: improve wiring if possible
 	if fct, ok := githookFactory.(*ControllerClientFactory); ok {
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	hostKeyDirMode  = 0o700
+	hostKeyFileMode = 0o600
+)
+
+// loadOrGenerateHostKey loads the private host key from the provided path.
+// In case the file doesn't exist, a new ed25519 key is generated and stored at the path.
+func loadOrGenerateHostKey(path string) (ssh.Signer, error) {
+	if path == "" {
+		return nil, errors.New("host key path is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = generateHostKey(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key: %w", err)
+	}
+
+	return signer, nil
+}
+
+func generateHostKey(path string) ([]byte, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate host key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal host key: %w", err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	})
+
+	if err = os.MkdirAll(filepath.Dir(path), hostKeyDirMode); err != nil {
+		return nil, fmt.Errorf("failed to create host key directory: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, hostKeyFileMode); err != nil {
+		return nil, fmt.Errorf("failed to write host key: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitssh implements an ssh server that serves git clone, fetch and push operations.
+package gitssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	serverVersion = "SSH-2.0-Gitness"
+
+	// extensionPrincipalID and extensionPublicKeyID are used to pass
+	// the authentication result from the public key callback to the connection handler.
+	extensionPrincipalID = "gitness-principal-id"
+	extensionPublicKeyID = "gitness-public-key-id"
+)
+
+// ShutdownFunction defines a function that is called to shutdown the server.
+type ShutdownFunction func(context.Context) error
+
+// Config defines the config of the ssh server.
+type Config struct {
+	Port        int
+	HostKeyPath string
+}
+
+// Server is an ssh server that serves git operations of users authenticated via their public keys.
+type Server struct {
+	config         Config
+	principalStore store.PrincipalStore
+	publicKeyStore store.PublicKeyStore
+	repoCtrl       *repo.Controller
+
+	mx       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closing  bool
+	wg       sync.WaitGroup
+}
+
+func NewServer(
+	config Config,
+	principalStore store.PrincipalStore,
+	publicKeyStore store.PublicKeyStore,
+	repoCtrl *repo.Controller,
+) *Server {
+	return &Server{
+		config:         config,
+		principalStore: principalStore,
+		publicKeyStore: publicKeyStore,
+		repoCtrl:       repoCtrl,
+		conns:          make(map[net.Conn]struct{}),
+	}
+}
+
+// ListenAndServe starts accepting ssh connections.
+// The returned error group finishes once the server is shut down using the returned ShutdownFunction.
+func (s *Server) ListenAndServe(ctx context.Context) (*errgroup.Group, ShutdownFunction) {
+	// connections are using their own context that's only canceled in case the shutdown times out.
+	ctx, cancel := context.WithCancel(log.Ctx(ctx).WithContext(context.Background()))
+
+	var g errgroup.Group
+	g.Go(func() error {
+		defer cancel()
+
+		sshConfig, err := s.setupSSHConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+		if err != nil {
+			return fmt.Errorf("failed to listen on ssh port: %w", err)
+		}
+
+		if err = s.setListener(listener); err != nil {
+			return err
+		}
+
+		return s.serve(ctx, listener, sshConfig)
+	})
+
+	return &g, func(shutdownCtx context.Context) error {
+		defer cancel()
+		return s.shutdown(shutdownCtx)
+	}
+}
+
+func (s *Server) setupSSHConfig(ctx context.Context) (*ssh.ServerConfig, error) {
+	hostKey, err := loadOrGenerateHostKey(s.config.HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup host key: %w", err)
+	}
+
+	sshConfig := &ssh.ServerConfig{
+		ServerVersion: serverVersion,
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			return s.authenticate(ctx, conn, key)
+		},
+	}
+	sshConfig.AddHostKey(hostKey)
+
+	return sshConfig, nil
+}
+
+func (s *Server) setListener(listener net.Listener) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.closing {
+		_ = listener.Close()
+		return nil
+	}
+
+	s.listener = listener
+
+	return nil
+}
+
+func (s *Server) serve(ctx context.Context, listener net.Listener, sshConfig *ssh.ServerConfig) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if s.isClosing() {
+				return nil
+			}
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to accept ssh connection")
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+
+			return fmt.Errorf("failed to accept ssh connection: %w", err)
+		}
+
+		if !s.trackConn(conn) {
+			_ = conn.Close()
+			return nil
+		}
+
+		go func() {
+			defer s.untrackConn(conn)
+			s.handleConn(ctx, conn, sshConfig)
+		}()
+	}
+}
+
+func (s *Server) trackConn(conn net.Conn) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.closing {
+		return false
+	}
+
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+
+	return true
+}
+
+func (s *Server) untrackConn(conn net.Conn) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	delete(s.conns, conn)
+	s.wg.Done()
+}
+
+func (s *Server) isClosing() bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.closing
+}
+
+// shutdown stops accepting new connections and waits for all active connections to finish.
+// If the context is done before that, all remaining connections are closed.
+func (s *Server) shutdown(ctx context.Context) error {
+	s.mx.Lock()
+	s.closing = true
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	s.mx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.mx.Lock()
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mx.Unlock()
+
+		return ctx.Err()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to close ssh listener: %w", err)
+	}
+
+	return nil
+}
+
+// authenticate finds the principal that owns the provided public key.
+func (s *Server) authenticate(
+	ctx context.Context,
+	conn ssh.ConnMetadata,
+	key ssh.PublicKey,
+) (*ssh.Permissions, error) {
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	keys, err := s.publicKeyStore.ListByFingerprint(ctx, fingerprint,
+		[]enum.PublicKeyUsage{enum.PublicKeyUsageAuth})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find public key by fingerprint")
+		return nil, fmt.Errorf("failed to find public key: %w", err)
+	}
+
+	var publicKey *types.PublicKey
+	for i := range keys {
+		// a fingerprint collision is practically impossible, but compare the whole key to be safe.
+		parsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(keys[i].Content))
+		if err == nil && string(parsedKey.Marshal()) == string(key.Marshal()) {
+			publicKey = &keys[i]
+			break
+		}
+	}
+
+	if publicKey == nil {
+		return nil, errors.New("unknown public key")
+	}
+
+	principal, err := s.principalStore.Find(ctx, publicKey.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal of public key: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, errors.New("principal is blocked")
+	}
+
+	err = s.publicKeyStore.MarkAsVerified(ctx, publicKey.ID, time.Now().UnixMilli())
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to mark public key as verified")
+	}
+
+	log.Ctx(ctx).Debug().
+		Str("ssh.remote_addr", conn.RemoteAddr().String()).
+		Str("principal_uid", principal.UID).
+		Str("public_key_identifier", publicKey.Identifier).
+		Msg("ssh client authenticated")
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			extensionPrincipalID: strconv.FormatInt(principal.ID, 10),
+			extensionPublicKeyID: strconv.FormatInt(publicKey.ID, 10),
+		},
+	}, nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitssh
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	envGitProtocol = "GIT_PROTOCOL"
+
+	commandUploadPack  = "git-upload-pack"
+	commandReceivePack = "git-receive-pack"
+)
+
+type envRequest struct {
+	Name  string
+	Value string
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatusRequest struct {
+	Status uint32
+}
+
+// handleConn executes the ssh handshake and serves all sessions of the connection.
+func (s *Server) handleConn(ctx context.Context, conn net.Conn, sshConfig *ssh.ServerConfig) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, sshConfig)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("ssh.remote_addr", conn.RemoteAddr().String()).
+			Msg("ssh handshake failed")
+		return
+	}
+	defer func() {
+		_ = sshConn.Close()
+	}()
+
+	go ssh.DiscardRequests(requests)
+
+	session, err := s.sessionFromPermissions(ctx, sshConn.Permissions)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create auth session for ssh connection")
+		return
+	}
+
+	if host, _, err := net.SplitHostPort(sshConn.RemoteAddr().String()); err == nil {
+		ctx = request.WithClientIP(ctx, host)
+	}
+
+	ctx = log.Ctx(ctx).With().
+		Str("ssh.remote_addr", sshConn.RemoteAddr().String()).
+		Str("principal_uid", session.Principal.UID).
+		Logger().WithContext(ctx)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to accept ssh channel")
+			continue
+		}
+
+		go s.handleSession(ctx, session, channel, channelRequests)
+	}
+}
+
+func (s *Server) sessionFromPermissions(ctx context.Context, permissions *ssh.Permissions) (*auth.Session, error) {
+	if permissions == nil {
+		return nil, fmt.Errorf("connection has no permissions")
+	}
+
+	principalID, err := strconv.ParseInt(permissions.Extensions[extensionPrincipalID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse principal id: %w", err)
+	}
+
+	publicKeyID, err := strconv.ParseInt(permissions.Extensions[extensionPublicKeyID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key id: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	return &auth.Session{
+		Principal: *principal,
+		Metadata: &auth.PublicKeyMetadata{
+			PublicKeyID: publicKeyID,
+		},
+	}, nil
+}
+
+// handleSession serves a single ssh session. Only the execution of git commands is supported.
+func (s *Server) handleSession(
+	ctx context.Context,
+	session *auth.Session,
+	channel ssh.Channel,
+	requests <-chan *ssh.Request,
+) {
+	defer func() {
+		_ = channel.Close()
+	}()
+
+	gitProtocol := ""
+
+	for req := range requests {
+		switch req.Type {
+		case "env":
+			var env envRequest
+			if err := ssh.Unmarshal(req.Payload, &env); err != nil {
+				replyToRequest(req, false)
+				continue
+			}
+
+			// only the git protocol is taken from the client, all other env variables are ignored.
+			if env.Name == envGitProtocol {
+				gitProtocol = env.Value
+			}
+
+			replyToRequest(req, true)
+
+		case "exec":
+			var execReq execRequest
+			if err := ssh.Unmarshal(req.Payload, &execReq); err != nil {
+				replyToRequest(req, false)
+				continue
+			}
+
+			replyToRequest(req, true)
+
+			go ssh.DiscardRequests(requests)
+
+			status := s.execute(ctx, session, channel, execReq.Command, gitProtocol)
+			sendExitStatus(ctx, channel, status)
+
+			return
+
+		case "shell":
+			replyToRequest(req, true)
+
+			_, _ = fmt.Fprintf(channel.Stderr(),
+				"Hi %s! You've successfully authenticated, but shell access is not supported.\n",
+				session.Principal.DisplayName)
+			sendExitStatus(ctx, channel, 1)
+
+			return
+
+		default:
+			replyToRequest(req, false)
+		}
+	}
+}
+
+// execute runs the git command requested by the client and returns the exit status.
+func (s *Server) execute(
+	ctx context.Context,
+	session *auth.Session,
+	channel ssh.Channel,
+	command string,
+	gitProtocol string,
+) uint32 {
+	service, repoRef, err := parseCommand(command)
+	if err != nil {
+		writeError(channel, err.Error())
+		return 1
+	}
+
+	log.Ctx(ctx).Debug().
+		Str("git.service", string(service)).
+		Str("repo_ref", repoRef).
+		Msg("ssh git command started")
+
+	err = s.repoCtrl.GitSSHServicePack(ctx, session, repoRef, service, gitProtocol, channel, channel)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("git.service", string(service)).
+			Str("repo_ref", repoRef).
+			Msg("ssh git command failed")
+
+		writeError(channel, usererror.Translate(err).Message)
+
+		return 1
+	}
+
+	return 0
+}
+
+// parseCommand parses the git command executed via ssh (e.g. git-upload-pack '/space/repo.git')
+// and returns the git service and the repo reference.
+func parseCommand(command string) (enum.GitServiceType, string, error) {
+	verb, arg, _ := strings.Cut(strings.TrimSpace(command), " ")
+
+	var service enum.GitServiceType
+	switch verb {
+	case commandUploadPack:
+		service = enum.GitServiceTypeUploadPack
+	case commandReceivePack:
+		service = enum.GitServiceTypeReceivePack
+	default:
+		return "", "", fmt.Errorf("unsupported command %q", verb)
+	}
+
+	repoRef := strings.TrimSpace(arg)
+	repoRef = strings.Trim(repoRef, "'\"")
+	repoRef = strings.Trim(repoRef, "/")
+	repoRef = strings.TrimSuffix(repoRef, url.GITSuffix)
+
+	if repoRef == "" {
+		return "", "", fmt.Errorf("a repository has to be provided")
+	}
+
+	return service, repoRef, nil
+}
+
+func writeError(channel ssh.Channel, msg string) {
+	_, _ = fmt.Fprintf(channel.Stderr(), "ERROR: %s\n", msg)
+}
+
+func replyToRequest(req *ssh.Request, ok bool) {
+	if req.WantReply {
+		_ = req.Reply(ok, nil)
+	}
+}
+
+func sendExitStatus(ctx context.Context, channel ssh.Channel, status uint32) {
+	_, err := channel.SendRequest("exit-status", false, ssh.Marshal(&exitStatusRequest{Status: status}))
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to send exit status to ssh client")
+	}
+}
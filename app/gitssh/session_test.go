@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitssh
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		command     string
+		wantService enum.GitServiceType
+		wantRepoRef string
+		wantErr     bool
+	}{
+		{"git-upload-pack 'space/repo.git'", enum.GitServiceTypeUploadPack, "space/repo", false},
+		{"git-upload-pack '/space/repo.git'", enum.GitServiceTypeUploadPack, "space/repo", false},
+		{"git-receive-pack '/space/sub/repo.git'", enum.GitServiceTypeReceivePack, "space/sub/repo", false},
+		{"git-receive-pack 'space/repo'", enum.GitServiceTypeReceivePack, "space/repo", false},
+		{"git-upload-pack \"space/repo.git\"", enum.GitServiceTypeUploadPack, "space/repo", false},
+		{"git-upload-pack ''", "", "", true},
+		{"git-upload-pack", "", "", true},
+		{"git-upload-archive 'space/repo.git'", "", "", true},
+		{"ls -la", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, test := range tests {
+		service, repoRef, err := parseCommand(test.command)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Want command %q to fail, got service %q and repo %q", test.command, service, repoRef)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Want command %q to succeed, got error: %v", test.command, err)
+			continue
+		}
+
+		if service != test.wantService || repoRef != test.wantRepoRef {
+			t.Errorf("Want command %q parsed as %q %q, got %q %q",
+				test.command, test.wantService, test.wantRepoRef, service, repoRef)
+		}
+	}
+}
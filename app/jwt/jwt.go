@@ -15,6 +15,7 @@
 package jwt
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/harness/gitness/types"
@@ -36,6 +37,7 @@ type Claims struct {
 
 	Token      *SubClaimsToken      `json:"tkn,omitempty"`
 	Membership *SubClaimsMembership `json:"ms,omitempty"`
+	TwoFactor  *SubClaimsTwoFactor  `json:"2fa,omitempty"`
 }
 
 // SubClaimsToken contains information about the token the JWT was created for.
@@ -50,6 +52,24 @@ type SubClaimsMembership struct {
 	SpaceID int64               `json:"sid,omitempty"`
 }
 
+// TwoFactorPurpose is the purpose a JWT with two-factor sub-claims was created for.
+type TwoFactorPurpose string
+
+const (
+	// TwoFactorPurposeLogin is used for logins that wait for the second factor of the user.
+	TwoFactorPurposeLogin TwoFactorPurpose = "login"
+	// TwoFactorPurposeWebAuthnRegistration is used for pending registrations of WebAuthn credentials.
+	TwoFactorPurposeWebAuthnRegistration TwoFactorPurpose = "webauthn_registration"
+)
+
+// SubClaimsTwoFactor contains the state of an operation that's pending a second factor.
+// NOTE: JWTs with these sub-claims can't be used for authentication.
+type SubClaimsTwoFactor struct {
+	Purpose TwoFactorPurpose `json:"pur,omitempty"`
+	// WebAuthnSession is the session data of a pending WebAuthn ceremony.
+	WebAuthnSession json.RawMessage `json:"was,omitempty"`
+}
+
 // GenerateForToken generates a jwt for a given token.
 func GenerateForToken(token *types.Token, secret string) (string, error) {
 	var expiresAt int64
@@ -111,3 +131,32 @@ func GenerateWithMembership(
 
 	return res, nil
 }
+
+// GenerateForTwoFactor generates a short-lived jwt for an operation that's pending a second factor.
+func GenerateForTwoFactor(
+	principalID int64,
+	twoFactor *SubClaimsTwoFactor,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		TwoFactor:   twoFactor,
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
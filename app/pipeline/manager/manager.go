@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -133,6 +134,7 @@ type Manager struct {
 	Pipelines        store.PipelineStore
 	urlProvider      urlprovider.Provider
 	Checks           store.CheckStore
+	CheckReporter    *checkevents.Reporter
 	// Converter  store.ConvertService
 	SSEStreamer sse.Streamer
 	// Globals    store.GlobalSecretStore
@@ -167,6 +169,7 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	checkReporter *checkevents.Reporter,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -185,6 +188,7 @@ func New(
 		Stages:           stageStore,
 		Steps:            stepStore,
 		Users:            userStore,
+		CheckReporter:    checkReporter,
 	}
 }
 
@@ -459,6 +463,7 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Executions:  m.Executions,
 		Pipelines:   m.Pipelines,
 		Checks:      m.Checks,
+		Reporter:    m.CheckReporter,
 		SSEStreamer: m.SSEStreamer,
 		Logs:        m.Logz,
 		Repos:       m.Repos,
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -36,6 +37,7 @@ import (
 type teardown struct {
 	Executions  store.ExecutionStore
 	Checks      store.CheckStore
+	Reporter    *checkevents.Reporter
 	Pipelines   store.PipelineStore
 	SSEStreamer sse.Streamer
 	Logs        livelog.LogStream
@@ -183,8 +185,17 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 	err = checks.Write(ctx, t.Checks, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
+		return nil
 	}
 
+	t.Reporter.Reported(ctx, &checkevents.ReportedPayload{
+		RepoID:      execution.RepoID,
+		PrincipalID: execution.CreatedBy,
+		CommitSHA:   execution.After,
+		Identifier:  pipeline.Identifier,
+		Status:      execution.Status.ConvertToCheckStatus(),
+	})
+
 	return nil
 }
 
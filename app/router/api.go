@@ -17,6 +17,7 @@ package router
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/check"
@@ -94,6 +95,7 @@ var (
 func NewAPIHandler(
 	appCtx context.Context,
 	config *types.Config,
+	trustedProxies []*net.IPNet,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
 	executionCtrl *execution.Controller,
@@ -129,6 +131,7 @@ func NewAPIHandler(
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
 	r.Use(address.Handler("", ""))
+	r.Use(address.ClientIPHandler(trustedProxies))
 
 	// configure cors middleware
 	r.Use(corsHandler(config))
@@ -237,6 +240,52 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Route("/usergroups", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleUserGroupList(spaceCtrl))
+				r.Post("/", handlerspace.HandleUserGroupCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupIdentifier), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleUserGroupFind(spaceCtrl))
+					r.Patch("/", handlerspace.HandleUserGroupUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleUserGroupDelete(spaceCtrl))
+					r.Route("/members", func(r chi.Router) {
+						r.Get("/", handlerspace.HandleUserGroupMemberList(spaceCtrl))
+						r.Post("/", handlerspace.HandleUserGroupMemberAdd(spaceCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamUserUID),
+							handlerspace.HandleUserGroupMemberDelete(spaceCtrl))
+					})
+				})
+			})
+
+			r.Route("/usergroup-members", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleUserGroupMembershipList(spaceCtrl))
+				r.Post("/", handlerspace.HandleUserGroupMembershipAdd(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleUserGroupMembershipUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleUserGroupMembershipDelete(spaceCtrl))
+				})
+			})
+
+			r.Route("/roles", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleCustomRoleList(spaceCtrl))
+				r.Post("/", handlerspace.HandleCustomRoleCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamCustomRoleIdentifier), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleCustomRoleFind(spaceCtrl))
+					r.Patch("/", handlerspace.HandleCustomRoleUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleCustomRoleDelete(spaceCtrl))
+				})
+			})
+
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleLabelList(spaceCtrl))
+				r.Post("/", handlerspace.HandleLabelDefine(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleLabelUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleLabelDelete(spaceCtrl))
+				})
+			})
+
+			r.Get("/audit-logs", handlerspace.HandleAuditLogList(spaceCtrl))
 		})
 	})
 }
@@ -256,6 +305,7 @@ func setupRepos(r chi.Router,
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerrepo.HandleCreate(repoCtrl))
 		r.Post("/import", handlerrepo.HandleImport(repoCtrl))
+		r.Post("/mirror", handlerrepo.HandleMirrorCreate(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 			// repo level operations
 			r.Get("/", handlerrepo.HandleFind(repoCtrl))
@@ -265,10 +315,58 @@ func setupRepos(r chi.Router,
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
+			r.Route("/mirror", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMirrorFind(repoCtrl))
+				r.Patch("/", handlerrepo.HandleMirrorUpdate(repoCtrl))
+				r.Delete("/", handlerrepo.HandleMirrorDelete(repoCtrl))
+			})
+
+			r.Route("/push-mirrors", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandlePushMirrorList(repoCtrl))
+				r.Post("/", handlerrepo.HandlePushMirrorCreate(repoCtrl))
+
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorIdentifier), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandlePushMirrorFind(repoCtrl))
+					r.Patch("/", handlerrepo.HandlePushMirrorUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandlePushMirrorDelete(repoCtrl))
+					r.Post("/sync", handlerrepo.HandlePushMirrorSync(repoCtrl))
+				})
+			})
+
+			r.Route("/secret-scanning", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleSecretScanningFind(repoCtrl))
+				r.Patch("/", handlerrepo.HandleSecretScanningUpdate(repoCtrl))
+				r.Get("/findings", handlerrepo.HandleSecretFindingList(repoCtrl))
+			})
+
+			r.Route("/merge-templates", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMergeTemplatesFind(repoCtrl))
+				r.Patch("/", handlerrepo.HandleMergeTemplatesUpdate(repoCtrl))
+			})
+
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleLabelList(repoCtrl))
+				r.Post("/", handlerrepo.HandleLabelDefine(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Patch("/", handlerrepo.HandleLabelUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandleLabelDelete(repoCtrl))
+				})
+			})
+
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMembershipList(repoCtrl))
+				r.Post("/", handlerrepo.HandleMembershipAdd(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+					r.Delete("/", handlerrepo.HandleMembershipDelete(repoCtrl))
+					r.Patch("/", handlerrepo.HandleMembershipUpdate(repoCtrl))
+				})
+			})
+
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
 			// content operations
@@ -288,6 +386,10 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleArchive(repoCtrl))
+			})
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
@@ -503,6 +605,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
+				r.Put("/usergroups", handlerpullreq.HandleReviewerAddUserGroup(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
 				})
@@ -511,6 +614,22 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleMergeQueueFind(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueDelete(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleLabelList(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleLabelAssign(pullreqCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Delete("/", handlerpullreq.HandleLabelUnassign(pullreqCtrl))
+				})
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -599,6 +718,40 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypeSession))
 			})
 		})
+
+		// Public keys
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+
+			// per public key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePublicKey(userCtrl))
+			})
+		})
+
+		// Two-factor authentication
+		r.Route("/2fa", func(r chi.Router) {
+			r.Get("/", handleruser.HandleTwoFactorStatus(userCtrl))
+			r.Post("/recovery-codes", handleruser.HandleRecoveryCodesGenerate(userCtrl))
+
+			r.Route("/totp", func(r chi.Router) {
+				r.Post("/", handleruser.HandleTOTPEnroll(userCtrl))
+				r.Delete("/", handleruser.HandleTOTPDelete(userCtrl))
+				r.Post("/confirm", handleruser.HandleTOTPConfirm(userCtrl))
+			})
+
+			r.Route("/webauthn", func(r chi.Router) {
+				r.Get("/", handleruser.HandleWebAuthnCredentialList(userCtrl))
+				r.Post("/", handleruser.HandleWebAuthnRegisterFinish(userCtrl))
+				r.Post("/register", handleruser.HandleWebAuthnRegisterBegin(userCtrl))
+
+				// per credential operations
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebAuthnCredentialID), func(r chi.Router) {
+					r.Delete("/", handleruser.HandleWebAuthnCredentialDelete(userCtrl))
+				})
+			})
+		})
 	})
 }
 
@@ -662,14 +815,33 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+				r.Delete("/2fa", users.HandleTwoFactorReset(userCtrl))
 			})
 		})
+
+		r.Get("/audit-logs", users.HandleAuditLogList(userCtrl))
+
+		r.Route("/2fa/spaces", func(r chi.Router) {
+			r.Get("/", users.HandleTwoFactorRequiredSpaceList(userCtrl))
+			r.Put(fmt.Sprintf("/{%s}", request.PathParamSpaceRef), users.HandleTwoFactorRequiredSpaceAdd(userCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamSpaceRef), users.HandleTwoFactorRequiredSpaceDelete(userCtrl))
+		})
 	})
 }
 
 func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, config *types.Config) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+	r.Route("/login/2fa", func(r chi.Router) {
+		r.Post("/", account.HandleLoginTwoFactor(userCtrl, cookieName))
+		r.Post("/totp", account.HandleLoginTOTPEnroll(userCtrl))
+		r.Post("/webauthn", account.HandleLoginWebAuthnBegin(userCtrl))
+	})
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 	r.Post("/logout", account.HandleLogout(userCtrl, cookieName))
+
+	r.Route("/oidc", func(r chi.Router) {
+		r.Get("/login", account.HandleOIDCLogin(userCtrl, cookieName))
+		r.Get("/callback", account.HandleOIDCCallback(userCtrl, cookieName))
+	})
 }
@@ -16,10 +16,14 @@ package router
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
+	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
@@ -42,8 +46,10 @@ type GitHandler interface {
 // NewGitHandler returns a new GitHandler.
 func NewGitHandler(
 	urlProvider url.Provider,
+	trustedProxies []*net.IPNet,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -57,6 +63,7 @@ func NewGitHandler(
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
+	r.Use(address.ClientIPHandler(trustedProxies))
 
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
@@ -79,6 +86,24 @@ func NewGitHandler(
 				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
 			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
 
+			// large file storage
+			r.Route("/info/lfs", func(r chi.Router) {
+				r.Post("/objects/batch", handlerlfs.HandleBatch(lfsCtrl, urlProvider))
+				r.Route(fmt.Sprintf("/objects/{%s}", request.PathParamLFSObjectID), func(r chi.Router) {
+					r.Put("/", handlerlfs.HandleUpload(lfsCtrl, urlProvider))
+					r.Get("/", handlerlfs.HandleDownload(lfsCtrl, urlProvider))
+				})
+				r.Post("/verify", handlerlfs.HandleVerify(lfsCtrl, urlProvider))
+
+				r.Route("/locks", func(r chi.Router) {
+					r.Get("/", handlerlfs.HandleListLocks(lfsCtrl, urlProvider))
+					r.Post("/", handlerlfs.HandleCreateLock(lfsCtrl, urlProvider))
+					r.Post("/verify", handlerlfs.HandleVerifyLocks(lfsCtrl, urlProvider))
+					r.Post(fmt.Sprintf("/{%s}/unlock", request.PathParamLFSLockID),
+						handlerlfs.HandleUnlock(lfsCtrl, urlProvider))
+				})
+			})
+
 			// dumb protocol
 			r.Get("/HEAD", stubGitHandler())
 			r.Get("/objects/info/alternates", stubGitHandler())
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
@@ -38,6 +39,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/middleware/address"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/url"
@@ -75,15 +77,24 @@ func ProvideRouter(
 }
 
 func ProvideGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
-) GitHandler {
+	lfsCtrl *lfs.Controller,
+) (GitHandler, error) {
+	trustedProxies, err := address.ParseTrustedProxies(config.Server.HTTP.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	return NewGitHandler(
 		urlProvider,
+		trustedProxies,
 		authenticator,
 		repoCtrl,
-	)
+		lfsCtrl,
+	), nil
 }
 
 func ProvideAPIHandler(
@@ -110,11 +121,16 @@ func ProvideAPIHandler(
 	sysCtrl *system.Controller,
 	blobCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
-) APIHandler {
-	return NewAPIHandler(appCtx, config,
+) (APIHandler, error) {
+	trustedProxies, err := address.ParseTrustedProxies(config.Server.HTTP.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAPIHandler(appCtx, config, trustedProxies,
 		authenticator, repoCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl)
+		githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl), nil
 }
 
 func ProvideWebHandler(config *types.Config, openapi openapi.Service) WebHandler {
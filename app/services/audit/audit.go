@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Resource identifies the resource an audited action was performed on.
+type Resource struct {
+	Type       enum.AuditResourceType
+	Identifier string
+
+	// SpaceID is the ID of the space the resource belongs to (zero for system-wide resources).
+	SpaceID int64
+	// Path is the path of the space or repository the resource belongs to.
+	Path string
+}
+
+// NewResource returns a system-wide resource (e.g. a user).
+func NewResource(resourceType enum.AuditResourceType, identifier string) Resource {
+	return Resource{
+		Type:       resourceType,
+		Identifier: identifier,
+	}
+}
+
+// NewRepoResource returns a resource that belongs to the provided repository.
+func NewRepoResource(resourceType enum.AuditResourceType, identifier string, repo *types.Repository) Resource {
+	return Resource{
+		Type:       resourceType,
+		Identifier: identifier,
+		SpaceID:    repo.ParentID,
+		Path:       repo.Path,
+	}
+}
+
+// NewSpaceResource returns a resource that belongs to the provided space.
+func NewSpaceResource(resourceType enum.AuditResourceType, identifier string, space *types.Space) Resource {
+	return Resource{
+		Type:       resourceType,
+		Identifier: identifier,
+		SpaceID:    space.ID,
+		Path:       space.Path,
+	}
+}
+
+type options struct {
+	oldObject interface{}
+	newObject interface{}
+	data      map[string]string
+	clientIP  string
+}
+
+// Option configures an audit log entry.
+type Option func(*options)
+
+// WithOldObject sets the state of the resource before the action.
+func WithOldObject(v interface{}) Option {
+	return func(o *options) {
+		o.oldObject = v
+	}
+}
+
+// WithNewObject sets the state of the resource after the action.
+func WithNewObject(v interface{}) Option {
+	return func(o *options) {
+		o.newObject = v
+	}
+}
+
+// WithData adds additional information about the action to the audit log entry.
+func WithData(key, value string) Option {
+	return func(o *options) {
+		if o.data == nil {
+			o.data = make(map[string]string)
+		}
+		o.data[key] = value
+	}
+}
+
+// WithClientIP overrides the client IP that is otherwise taken from the request context.
+func WithClientIP(ip string) Option {
+	return func(o *options) {
+		o.clientIP = ip
+	}
+}
+
+// Service records security-relevant actions in the audit log.
+type Service struct {
+	auditLogStore store.AuditLogStore
+}
+
+func NewService(auditLogStore store.AuditLogStore) *Service {
+	return &Service{
+		auditLogStore: auditLogStore,
+	}
+}
+
+// Log records the action that the actor performed on the resource.
+func (s *Service) Log(
+	ctx context.Context,
+	actor *types.Principal,
+	resource Resource,
+	action enum.AuditAction,
+	opts ...Option,
+) error {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	diff, err := Diff(o.oldObject, o.newObject)
+	if err != nil {
+		return fmt.Errorf("failed to calculate audit log diff: %w", err)
+	}
+
+	clientIP := o.clientIP
+	if clientIP == "" {
+		clientIP, _ = request.ClientIPFrom(ctx)
+	}
+
+	var spaceID *int64
+	if resource.SpaceID > 0 {
+		spaceID = &resource.SpaceID
+	}
+
+	err = s.auditLogStore.Create(ctx, &types.AuditLog{
+		SpaceID:            spaceID,
+		Action:             action,
+		ResourceType:       resource.Type,
+		ResourceIdentifier: resource.Identifier,
+		ResourcePath:       resource.Path,
+		PrincipalID:        actor.ID,
+		ClientIP:           clientIP,
+		Diff:               diff,
+		Data:               o.data,
+		Created:            time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// diffValueKey is used for objects that aren't serialized as a JSON object.
+const diffValueKey = "value"
+
+// Diff returns the top-level fields of the JSON representation of the two objects that differ.
+// A nil object is treated as not existing, so all fields of the other object are returned.
+func Diff(oldObject, newObject interface{}) (map[string]types.AuditLogChange, error) {
+	oldFields, err := jsonFields(oldObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize old object: %w", err)
+	}
+
+	newFields, err := jsonFields(newObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize new object: %w", err)
+	}
+
+	diff := make(map[string]types.AuditLogChange)
+
+	for key, oldValue := range oldFields {
+		newValue := newFields[key]
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+
+		diff[key] = types.AuditLogChange{Before: oldValue, After: newValue}
+	}
+
+	for key, newValue := range newFields {
+		if _, ok := oldFields[key]; ok {
+			continue
+		}
+
+		diff[key] = types.AuditLogChange{After: newValue}
+	}
+
+	if len(diff) == 0 {
+		return nil, nil
+	}
+
+	return diff, nil
+}
+
+func jsonFields(v interface{}) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return map[string]json.RawMessage{diffValueKey: data}, nil //nolint:nilerr // not a JSON object
+	}
+
+	return fields, nil
+}
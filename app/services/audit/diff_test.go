@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDiff(t *testing.T) {
+	type object struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}
+
+	tests := []struct {
+		name      string
+		oldObject interface{}
+		newObject interface{}
+		want      map[string]types.AuditLogChange
+	}{
+		{
+			name:      "no-objects",
+			oldObject: nil,
+			newObject: nil,
+			want:      nil,
+		},
+		{
+			name:      "equal",
+			oldObject: object{Name: "rule", State: "active"},
+			newObject: object{Name: "rule", State: "active"},
+			want:      nil,
+		},
+		{
+			name:      "created",
+			oldObject: nil,
+			newObject: &object{Name: "rule", State: "active"},
+			want: map[string]types.AuditLogChange{
+				"name":  {After: []byte(`"rule"`)},
+				"state": {After: []byte(`"active"`)},
+			},
+		},
+		{
+			name:      "deleted",
+			oldObject: &object{Name: "rule", State: "active"},
+			newObject: (*object)(nil),
+			want: map[string]types.AuditLogChange{
+				"name":  {Before: []byte(`"rule"`)},
+				"state": {Before: []byte(`"active"`)},
+			},
+		},
+		{
+			name:      "updated",
+			oldObject: object{Name: "rule", State: "active"},
+			newObject: object{Name: "rule", State: "disabled"},
+			want: map[string]types.AuditLogChange{
+				"state": {Before: []byte(`"active"`), After: []byte(`"disabled"`)},
+			},
+		},
+		{
+			name:      "not-an-object",
+			oldObject: "old",
+			newObject: "new",
+			want: map[string]types.AuditLogChange{
+				diffValueKey: {Before: []byte(`"old"`), After: []byte(`"new"`)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Diff(test.oldObject, test.newObject)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/rs/zerolog/log"
+)
+
+// PGPFingerprint returns the fingerprint of a pgp key in the format used by gpg (upper case hex).
+func PGPFingerprint(key *packet.PublicKey) string {
+	return strings.ToUpper(hex.EncodeToString(key.Fingerprint))
+}
+
+// PGPKeyType returns the algorithm name of a pgp key.
+func PGPKeyType(key *packet.PublicKey) string {
+	switch key.PubKeyAlgo {
+	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSASignOnly, packet.PubKeyAlgoRSAEncryptOnly:
+		return "rsa"
+	case packet.PubKeyAlgoDSA:
+		return "dsa"
+	case packet.PubKeyAlgoECDSA:
+		return "ecdsa"
+	case packet.PubKeyAlgoEdDSA:
+		return "eddsa"
+	case packet.PubKeyAlgoElGamal:
+		return "elgamal"
+	case packet.PubKeyAlgoECDH:
+		return "ecdh"
+	default:
+		return "unknown"
+	}
+}
+
+// pgpKeyRing reads the pgp keys of a principal into a key ring.
+// Keys that can't be parsed anymore are skipped.
+func pgpKeyRing(keys []types.PublicKey) openpgp.EntityList {
+	keyRing := make(openpgp.EntityList, 0, len(keys))
+	for _, key := range keys {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.Content))
+		if err != nil {
+			log.Warn().Err(err).Int64("public_key_id", key.ID).Msg("failed to read pgp public key")
+			continue
+		}
+
+		keyRing = append(keyRing, entities...)
+	}
+
+	return keyRing
+}
+
+// verifyPGPSignature verifies the armored pgp signature of the content using the provided key ring.
+func verifyPGPSignature(
+	keyRing openpgp.EntityList,
+	signature []byte,
+	content []byte,
+) *types.GitSignatureVerification {
+	verification := &types.GitSignatureVerification{
+		Scheme: enum.PublicKeySchemePGP,
+	}
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyRing, bytes.NewReader(content),
+		bytes.NewReader(signature), nil)
+	switch {
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		verification.Status = enum.GitSignatureStatusUnknownKey
+	case err != nil:
+		verification.Status = enum.GitSignatureStatusBadSignature
+	default:
+		verification.Status = enum.GitSignatureStatusVerified
+		verification.KeyFingerprint = PGPFingerprint(signer.PrimaryKey)
+	}
+
+	return verification
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignaturePEMType   = "SSH SIGNATURE"
+	sshSignatureNamespace = "git"
+)
+
+var errSSHSignatureInvalid = errors.New("invalid ssh signature")
+
+// sshSignature is the ssh signature format as defined in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data that is signed to create an ssh signature (without the magic preamble).
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// parseSSHSignature parses an armored ssh signature and returns the public key that was used to create it.
+func parseSSHSignature(armored []byte) (*sshSignature, ssh.PublicKey, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != sshSignaturePEMType {
+		return nil, nil, fmt.Errorf("%w: failed to decode armored signature", errSSHSignatureInvalid)
+	}
+
+	data, ok := bytes.CutPrefix(block.Bytes, []byte(sshSignatureMagic))
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: missing magic preamble", errSSHSignatureInvalid)
+	}
+
+	sig := &sshSignature{}
+	if err := ssh.Unmarshal(data, sig); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errSSHSignatureInvalid, err)
+	}
+
+	if sig.Version != sshSignatureVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported version %d", errSSHSignatureInvalid, sig.Version)
+	}
+
+	publicKey, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to parse public key: %s", errSSHSignatureInvalid, err)
+	}
+
+	return sig, publicKey, nil
+}
+
+// verifySSHSignature verifies that the signature was created by the public key for the provided content.
+func verifySSHSignature(sig *sshSignature, publicKey ssh.PublicKey, content []byte) error {
+	if sig.Namespace != sshSignatureNamespace {
+		return fmt.Errorf("%w: unexpected namespace %q", errSSHSignatureInvalid, sig.Namespace)
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("%w: unsupported hash algorithm %q", errSSHSignatureInvalid, sig.HashAlgorithm)
+	}
+
+	_, _ = h.Write(content)
+
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	signature := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, signature); err != nil {
+		return fmt.Errorf("%w: %s", errSSHSignatureInvalid, err)
+	}
+
+	return publicKey.Verify(signedData, signature)
+}
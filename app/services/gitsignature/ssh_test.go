@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHSignature(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %s", err)
+	}
+
+	content := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ntitle\n")
+	armored := createSSHSignature(t, signer, sshSignatureNamespace, content)
+
+	sig, publicKey, err := parseSSHSignature(armored)
+	if err != nil {
+		t.Fatalf("failed to parse signature: %s", err)
+	}
+
+	if want, got := ssh.FingerprintSHA256(signer.PublicKey()), ssh.FingerprintSHA256(publicKey); want != got {
+		t.Errorf("fingerprint mismatch: want=%s got=%s", want, got)
+	}
+
+	if err = verifySSHSignature(sig, publicKey, content); err != nil {
+		t.Errorf("expected valid signature, got: %s", err)
+	}
+
+	if err = verifySSHSignature(sig, publicKey, append(content, '!')); err == nil {
+		t.Errorf("expected invalid signature for modified content")
+	}
+
+	sig, publicKey, err = parseSSHSignature(createSSHSignature(t, signer, "file", content))
+	if err != nil {
+		t.Fatalf("failed to parse signature: %s", err)
+	}
+
+	if err = verifySSHSignature(sig, publicKey, content); err == nil {
+		t.Errorf("expected invalid signature for wrong namespace")
+	}
+
+	pgpSignature := []byte("-----BEGIN PGP SIGNATURE-----\n\n-----END PGP SIGNATURE-----\n")
+	if _, _, err = parseSSHSignature(pgpSignature); err == nil {
+		t.Errorf("expected error for pgp signature")
+	}
+}
+
+// createSSHSignature creates an armored ssh signature the same way as "ssh-keygen -Y sign".
+func createSSHSignature(t *testing.T, signer ssh.Signer, namespace string, content []byte) []byte {
+	h := sha512.Sum512(content)
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Hash:          h[:],
+	})...)
+
+	signature, err := signer.Sign(rand.Reader, signedData)
+	if err != nil {
+		t.Fatalf("failed to sign: %s", err)
+	}
+
+	blob := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignature{
+		Version:       sshSignatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})...)
+
+	return pem.EncodeToMemory(&pem.Block{Type: sshSignaturePEMType, Bytes: blob})
+}
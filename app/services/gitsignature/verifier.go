@@ -0,0 +1,276 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsignature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+// signingKeysLimit is the maximum number of signing keys of a principal that are considered for verification.
+const signingKeysLimit = 100
+
+// Verifier verifies the signatures of git commits and tags using the signing keys uploaded by the signers.
+type Verifier struct {
+	git            git.Interface
+	principalStore store.PrincipalStore
+	publicKeyStore store.PublicKeyStore
+}
+
+func NewVerifier(
+	git git.Interface,
+	principalStore store.PrincipalStore,
+	publicKeyStore store.PublicKeyStore,
+) *Verifier {
+	return &Verifier{
+		git:            git,
+		principalStore: principalStore,
+		publicKeyStore: publicKeyStore,
+	}
+}
+
+// signer contains the principal that claims a signature together with its signing keys.
+type signer struct {
+	principal  *types.Principal
+	pgpKeyRing openpgp.EntityList
+	sshKeys    []types.PublicKey
+}
+
+// VerifyObjects verifies the signatures of the provided commits and annotated tags.
+// The returned map contains the verification result for each of the provided SHAs.
+func (v *Verifier) VerifyObjects(
+	ctx context.Context,
+	readParams git.ReadParams,
+	shas []string,
+) (map[string]*types.GitSignatureVerification, error) {
+	if len(shas) == 0 {
+		return map[string]*types.GitSignatureVerification{}, nil
+	}
+
+	out, err := v.git.GetObjectSignatures(ctx, &git.GetObjectSignaturesParams{
+		ReadParams: readParams,
+		SHAs:       shas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object signatures: %w", err)
+	}
+
+	signers := make(map[string]*signer)
+	result := make(map[string]*types.GitSignatureVerification, len(out.Signatures))
+
+	for _, sig := range out.Signatures {
+		verification, err := v.verify(ctx, signers, sig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of object %s: %w", sig.SHA, err)
+		}
+
+		result[sig.SHA] = verification
+	}
+
+	return result, nil
+}
+
+// ListUnverifiedCommits returns the SHAs of all new commits of the git ref that don't carry a verified signature.
+// New commits are all commits that aren't reachable from the base ref, or, if no base ref is provided,
+// from any of the existing references of the repository.
+func (v *Verifier) ListUnverifiedCommits(
+	ctx context.Context,
+	readParams git.ReadParams,
+	baseRef string,
+	gitRef string,
+) ([]string, error) {
+	out, err := v.git.ListNewCommitSHAs(ctx, &git.ListNewCommitSHAsParams{
+		ReadParams: readParams,
+		GitRef:     gitRef,
+		BaseRef:    baseRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	verifications, err := v.VerifyObjects(ctx, readParams, out.SHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	var unverified []string
+	for _, sha := range out.SHAs {
+		if verification := verifications[sha]; verification == nil ||
+			verification.Status != enum.GitSignatureStatusVerified {
+			unverified = append(unverified, sha)
+		}
+	}
+
+	return unverified, nil
+}
+
+// PullReqUnverifiedCommitsFn returns a function that lists the commits of the pull request that don't carry
+// a verified signature, i.e. the commits of the source branch that aren't part of the target branch.
+// The commits of pull requests from forks are available in the target repository via the head ref of the pull request.
+func (v *Verifier) PullReqUnverifiedCommitsFn(
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) func(ctx context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		return v.ListUnverifiedCommits(ctx, git.CreateReadParams(targetRepo),
+			"refs/heads/"+pr.TargetBranch, pr.SourceSHA)
+	}
+}
+
+func (v *Verifier) verify(
+	ctx context.Context,
+	signers map[string]*signer,
+	sig git.ObjectSignature,
+) (*types.GitSignatureVerification, error) {
+	var scheme enum.PublicKeyScheme
+	switch sig.Scheme {
+	case gitenum.SignatureSchemePGP:
+		scheme = enum.PublicKeySchemePGP
+	case gitenum.SignatureSchemeSSH:
+		scheme = enum.PublicKeySchemeSSH
+	case gitenum.SignatureSchemeUnknown:
+		// unsigned objects and signatures we don't support (e.g. x509) can't be verified.
+		return &types.GitSignatureVerification{Status: enum.GitSignatureStatusUnverified}, nil
+	}
+
+	s, err := v.findSigner(ctx, signers, sig.Signer.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		return &types.GitSignatureVerification{
+			Status: enum.GitSignatureStatusUnknownKey,
+			Scheme: scheme,
+		}, nil
+	}
+
+	var verification *types.GitSignatureVerification
+	if scheme == enum.PublicKeySchemePGP {
+		verification = verifyPGPSignature(s.pgpKeyRing, sig.Signature, sig.SignedContent)
+	} else {
+		verification = verifySSHSignatureOfSigner(s.sshKeys, sig.Signature, sig.SignedContent)
+	}
+
+	if verification.Status == enum.GitSignatureStatusVerified {
+		verification.Signer = s.principal.ToPrincipalInfo()
+	}
+
+	return verification, nil
+}
+
+func verifySSHSignatureOfSigner(
+	keys []types.PublicKey,
+	signature []byte,
+	content []byte,
+) *types.GitSignatureVerification {
+	verification := &types.GitSignatureVerification{
+		Scheme: enum.PublicKeySchemeSSH,
+	}
+
+	sig, publicKey, err := parseSSHSignature(signature)
+	if err != nil {
+		verification.Status = enum.GitSignatureStatusBadSignature
+		return verification
+	}
+
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+	verification.KeyFingerprint = fingerprint
+
+	var known bool
+	for _, key := range keys {
+		if key.Fingerprint == fingerprint {
+			known = true
+			break
+		}
+	}
+
+	switch {
+	case !known:
+		verification.Status = enum.GitSignatureStatusUnknownKey
+	case verifySSHSignature(sig, publicKey, content) != nil:
+		verification.Status = enum.GitSignatureStatusBadSignature
+	default:
+		verification.Status = enum.GitSignatureStatusVerified
+	}
+
+	return verification
+}
+
+// findSigner returns the principal with the provided email and its signing keys, or nil if there's none.
+func (v *Verifier) findSigner(
+	ctx context.Context,
+	signers map[string]*signer,
+	email string,
+) (*signer, error) {
+	email = strings.ToLower(email)
+	if s, ok := signers[email]; ok {
+		return s, nil
+	}
+
+	principal, err := v.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// no principal is using the email, remember it to avoid repeated lookups.
+		signers[email] = nil
+		return nil, nil //nolint:nilnil // on purpose
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	keys, err := v.publicKeyStore.List(ctx, principal.ID, &types.PublicKeyFilter{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Page: 1, Size: signingKeysLimit},
+		},
+		Sort:   enum.PublicKeySortCreated,
+		Order:  enum.OrderAsc,
+		Usages: []enum.PublicKeyUsage{enum.PublicKeyUsageSign},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys of principal: %w", err)
+	}
+
+	s := &signer{
+		principal: principal,
+	}
+
+	var pgpKeys []types.PublicKey
+	for _, key := range keys {
+		switch key.Scheme {
+		case enum.PublicKeySchemePGP:
+			pgpKeys = append(pgpKeys, key)
+		case enum.PublicKeySchemeSSH:
+			s.sshKeys = append(s.sshKeys, key)
+		}
+	}
+
+	s.pgpKeyRing = pgpKeyRing(pgpKeys)
+
+	signers[email] = s
+
+	return s, nil
+}
@@ -499,6 +499,7 @@ func (r *Repository) createEnvVars(ctx context.Context,
 		principal.ID,
 		false,
 		true,
+		false,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
@@ -0,0 +1,288 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// localIndexVersion is the version of the on-disk format of the index.
+	// Indexes written with a different version are ignored and rebuilt on the next index run.
+	localIndexVersion = 1
+
+	// trigramLen is the length of the tokens the content gets split into.
+	trigramLen = 3
+
+	// binarySniffLen is the number of leading bytes that are inspected to detect binary files.
+	binarySniffLen = 8000
+)
+
+// localIndex is the keyword search index of the default branch of a single repository.
+// Content of all text files is split into lower-cased trigrams, and each trigram points to the files containing it.
+// The lines of the files are kept as well, so that matches can be verified and returned with their context.
+type localIndex struct {
+	Version   int
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+	Files     []localIndexFile
+
+	// Trigrams maps each trigram to the sorted list of indices (in Files) of files containing it.
+	Trigrams map[string][]int
+}
+
+type localIndexFile struct {
+	Path  string
+	Lines []string
+}
+
+func newLocalIndex(repoID int64, branch, commitSHA string) *localIndex {
+	return &localIndex{
+		Version:   localIndexVersion,
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Files:     []localIndexFile{},
+		Trigrams:  map[string][]int{},
+	}
+}
+
+// isBinary returns true if the content looks like binary data.
+func isBinary(content []byte) bool {
+	if len(content) > binarySniffLen {
+		content = content[:binarySniffLen]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// add adds the file to the index. Binary files are ignored.
+func (idx *localIndex) add(filePath string, content []byte) {
+	if len(content) == 0 || isBinary(content) {
+		return
+	}
+
+	text := strings.ToValidUTF8(string(content), string(utf8.RuneError))
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	fileIdx := len(idx.Files)
+	idx.Files = append(idx.Files, localIndexFile{
+		Path:  filePath,
+		Lines: strings.Split(strings.TrimSuffix(text, "\n"), "\n"),
+	})
+
+	for trigram := range trigrams(strings.ToLower(text)) {
+		idx.Trigrams[trigram] = append(idx.Trigrams[trigram], fileIdx)
+	}
+}
+
+// trigrams returns the set of all trigrams of the provided text. Trigrams spanning multiple lines are skipped.
+func trigrams(text string) map[string]struct{} {
+	set := map[string]struct{}{}
+
+	runes := []rune(text)
+	for i := 0; i+trigramLen <= len(runes); i++ {
+		trigram := runes[i : i+trigramLen]
+		if trigram[0] == '\n' || trigram[1] == '\n' || trigram[2] == '\n' {
+			continue
+		}
+		set[string(trigram)] = struct{}{}
+	}
+
+	return set
+}
+
+// candidates returns the indices of the files that might contain the query.
+// For queries shorter than a trigram all files are candidates.
+func (idx *localIndex) candidates(query string) []int {
+	queryTrigrams := trigrams(query)
+	if len(queryTrigrams) == 0 {
+		result := make([]int, len(idx.Files))
+		for i := range result {
+			result[i] = i
+		}
+		return result
+	}
+
+	var result []int
+	first := true
+	for trigram := range queryTrigrams {
+		postings, ok := idx.Trigrams[trigram]
+		if !ok {
+			return nil
+		}
+
+		if first {
+			result = append(result, postings...)
+			first = false
+			continue
+		}
+
+		result = intersect(result, postings)
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// intersect returns the intersection of two sorted lists.
+func intersect(a, b []int) []int {
+	result := a[:0]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// search returns up to maxFiles files that contain the query (case-insensitive).
+func (idx *localIndex) search(query string, maxFiles int) []types.FileMatch {
+	query = strings.ToLower(query)
+
+	var fileMatches []types.FileMatch
+	for _, fileIdx := range idx.candidates(query) {
+		if len(fileMatches) >= maxFiles {
+			break
+		}
+
+		file := idx.Files[fileIdx]
+
+		var matches []types.Match
+		for lineIdx, line := range file.Lines {
+			fragments := matchLine(line, query)
+			if len(fragments) == 0 {
+				continue
+			}
+
+			match := types.Match{
+				LineNum:   lineIdx + 1,
+				Fragments: fragments,
+			}
+			if lineIdx > 0 {
+				match.Before = file.Lines[lineIdx-1]
+			}
+			if lineIdx < len(file.Lines)-1 {
+				match.After = file.Lines[lineIdx+1]
+			}
+
+			matches = append(matches, match)
+		}
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		fileMatches = append(fileMatches, types.FileMatch{
+			FileName:   file.Path,
+			RepoID:     idx.RepoID,
+			RepoBranch: idx.Branch,
+			Language:   languageFromPath(file.Path),
+			Matches:    matches,
+		})
+	}
+
+	return fileMatches
+}
+
+// matchLine returns all non-overlapping occurrences of the lower-cased query in the line.
+func matchLine(line string, query string) []types.Fragment {
+	lowerLine := strings.ToLower(line)
+	if len(lowerLine) != len(line) {
+		// lower-casing changed the byte length of the line (rare unicode case) - offsets can't be mapped back.
+		lowerLine = line
+	}
+
+	var fragments []types.Fragment
+	offset := 0
+	for {
+		pos := strings.Index(lowerLine[offset:], query)
+		if pos < 0 {
+			break
+		}
+
+		start := offset + pos
+		end := start + len(query)
+		fragments = append(fragments, types.Fragment{
+			Pre:   line[offset:start],
+			Match: line[start:end],
+		})
+		offset = end
+	}
+
+	if len(fragments) > 0 {
+		fragments[len(fragments)-1].Post = line[offset:]
+	}
+
+	return fragments
+}
+
+var extensionLanguages = map[string]string{
+	".c":     "c",
+	".cpp":   "cpp",
+	".cs":    "csharp",
+	".css":   "css",
+	".go":    "go",
+	".h":     "c",
+	".html":  "html",
+	".java":  "java",
+	".js":    "javascript",
+	".json":  "json",
+	".jsx":   "javascript",
+	".kt":    "kotlin",
+	".md":    "markdown",
+	".php":   "php",
+	".py":    "python",
+	".rb":    "ruby",
+	".rs":    "rust",
+	".scala": "scala",
+	".sh":    "shell",
+	".sql":   "sql",
+	".swift": "swift",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".xml":   "xml",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+}
+
+func languageFromPath(filePath string) string {
+	return extensionLanguages[strings.ToLower(path.Ext(filePath))]
+}
+
+// sortFileMatches sorts file matches by repository and file name to get a stable output across searches.
+func sortFileMatches(fileMatches []types.FileMatch) {
+	sort.Slice(fileMatches, func(i, j int) bool {
+		if fileMatches[i].RepoID != fileMatches[j].RepoID {
+			return fileMatches[i].RepoID < fileMatches[j].RepoID
+		}
+		return fileMatches[i].FileName < fileMatches[j].FileName
+	})
+}
@@ -15,28 +15,269 @@
 package keywordsearch
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/gob"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	localIndexFileSuffix = ".idx"
+
+	// defaultMaxResultCount is used in case the caller doesn't limit the number of results.
+	defaultMaxResultCount = 50
 )
 
+// LocalIndexSearcher maintains a keyword search index of the default branch of each repository on the local disk.
 type LocalIndexSearcher struct {
+	git         git.Interface
+	indexDir    string
+	maxFileSize int64
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(
+	config Config,
+	git git.Interface,
+) (*LocalIndexSearcher, error) {
+	if config.IndexDir == "" {
+		return nil, errors.New("config.IndexDir is required")
+	}
+	if config.MaxFileSize <= 0 {
+		return nil, errors.New("config.MaxFileSize has to be a positive number")
+	}
+
+	err := os.MkdirAll(config.IndexDir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keyword search index directory: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		git:         git,
+		indexDir:    config.IndexDir,
+		maxFileSize: config.MaxFileSize,
+	}, nil
 }
 
+// Search searches the indexes of the provided repositories for the query.
+// Repositories that haven't been indexed yet are skipped.
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, fmt.Errorf("not implemented")
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		remaining := maxResultCount - len(result.FileMatches)
+		if remaining <= 0 {
+			break
+		}
+
+		idx, err := s.readIndex(repoID)
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Ctx(ctx).Debug().Msgf("keyword search index for repo %d doesn't exist", repoID)
+			continue
+		}
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to read keyword search index of repo %d: %w", repoID, err)
+		}
+
+		result.FileMatches = append(result.FileMatches, idx.search(query, remaining)...)
+	}
+
+	sortFileMatches(result.FileMatches)
+
+	result.Stats.TotalFiles = len(result.FileMatches)
+	for _, fileMatch := range result.FileMatches {
+		result.Stats.TotalMatches += len(fileMatch.Matches)
+	}
+
+	return result, nil
+}
+
+// Index (re)builds the index of the default branch of the repository.
+// Nothing is done if the index is already up-to-date.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	readParams := git.CreateReadParams(repo)
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the repository is empty or the default branch got deleted - nothing to search in.
+		return s.deleteIndex(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branchOut.Branch.SHA
+
+	existing, err := s.readIndex(repo.ID)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to read existing keyword search index of repo %d", repo.ID)
+	}
+	if err == nil && existing.Branch == repo.DefaultBranch && existing.CommitSHA == commitSHA {
+		return nil
+	}
+
+	treeOut, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+		Path:       "",
+		Recursive:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	idx := newLocalIndex(repo.ID, repo.DefaultBranch, commitSHA)
+
+	for _, node := range treeOut.Nodes {
+		if node.Type != git.TreeNodeTypeBlob || node.Mode == git.TreeNodeModeSymlink {
+			continue
+		}
+
+		content, err := s.readBlob(ctx, readParams, node.SHA)
+		if err != nil {
+			return fmt.Errorf("failed to read file %q: %w", node.Path, err)
+		}
+
+		idx.add(node.Path, content)
+	}
+
+	err = s.writeIndex(idx)
+	if err != nil {
+		return fmt.Errorf("failed to write keyword search index: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("indexed %d files of repo %d at commit %s", len(idx.Files), repo.ID, commitSHA)
+
+	return nil
+}
+
+// readBlob returns the content of the blob, or nil if the blob is larger than the max file size.
+func (s *LocalIndexSearcher) readBlob(ctx context.Context, readParams git.ReadParams, sha string) ([]byte, error) {
+	blobOut, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        sha,
+		SizeLimit:  s.maxFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	defer func() {
+		if err := blobOut.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader")
+		}
+	}()
+
+	if blobOut.Size > s.maxFileSize {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(blobOut.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	return content, nil
+}
+
+func (s *LocalIndexSearcher) indexPath(repoID int64) string {
+	return filepath.Join(s.indexDir, strconv.FormatInt(repoID, 10)+localIndexFileSuffix)
+}
+
+func (s *LocalIndexSearcher) readIndex(repoID int64) (*localIndex, error) {
+	f, err := os.Open(s.indexPath(repoID))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip reader: %w", err)
+	}
+	defer r.Close()
+
+	idx := &localIndex{}
+	if err = gob.NewDecoder(r).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+
+	if idx.Version != localIndexVersion {
+		return nil, fmt.Errorf("unsupported index version %d: %w", idx.Version, fs.ErrNotExist)
+	}
+
+	return idx, nil
+}
+
+// writeIndex writes the index to a temporary file first and then renames it,
+// so concurrent searches never see a partially written index.
+func (s *LocalIndexSearcher) writeIndex(idx *localIndex) (err error) {
+	f, err := os.CreateTemp(s.indexDir, strconv.FormatInt(idx.RepoID, 10)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	w := gzip.NewWriter(f)
+
+	if err = gob.NewEncoder(w).Encode(idx); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	if err = w.Close(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to flush gzip writer: %w", err)
+	}
+
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	if err = os.Rename(f.Name(), s.indexPath(idx.RepoID)); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
-	return fmt.Errorf("not implemented")
+func (s *LocalIndexSearcher) deleteIndex(repoID int64) error {
+	err := os.Remove(s.indexPath(repoID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete keyword search index: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestLocalIndex_Search(t *testing.T) {
+	idx := newLocalIndex(42, "main", "abc")
+	idx.add("main.go", []byte("package main\n\nfunc main() {\n\tprintln(\"Hello, World\")\n}\n"))
+	idx.add("README.md", []byte("# Hello\r\nhello hello\r\n"))
+	idx.add("image.png", []byte("PNG\x00\x01hello"))
+
+	tests := []struct {
+		name  string
+		query string
+		max   int
+		want  []types.FileMatch
+	}{
+		{
+			name:  "no-match",
+			query: "goodbye",
+			max:   10,
+			want:  nil,
+		},
+		{
+			name:  "case-insensitive-multiple-fragments",
+			query: "HELLO",
+			max:   10,
+			want: []types.FileMatch{
+				{
+					FileName:   "main.go",
+					RepoID:     42,
+					RepoBranch: "main",
+					Language:   "go",
+					Matches: []types.Match{
+						{
+							LineNum: 4,
+							Fragments: []types.Fragment{
+								{Pre: "\tprintln(\"", Match: "Hello", Post: ", World\")"},
+							},
+							Before: "func main() {",
+							After:  "}",
+						},
+					},
+				},
+				{
+					FileName:   "README.md",
+					RepoID:     42,
+					RepoBranch: "main",
+					Language:   "markdown",
+					Matches: []types.Match{
+						{
+							LineNum:   1,
+							Fragments: []types.Fragment{{Pre: "# ", Match: "Hello", Post: ""}},
+							After:     "hello hello",
+						},
+						{
+							LineNum: 2,
+							Fragments: []types.Fragment{
+								{Pre: "", Match: "hello", Post: ""},
+								{Pre: " ", Match: "hello", Post: ""},
+							},
+							Before: "# Hello",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "max-files",
+			query: "hello",
+			max:   1,
+			want: []types.FileMatch{
+				{
+					FileName:   "main.go",
+					RepoID:     42,
+					RepoBranch: "main",
+					Language:   "go",
+					Matches: []types.Match{
+						{
+							LineNum: 4,
+							Fragments: []types.Fragment{
+								{Pre: "\tprintln(\"", Match: "Hello", Post: ", World\")"},
+							},
+							Before: "func main() {",
+							After:  "}",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "short-query",
+			query: "{",
+			max:   10,
+			want: []types.FileMatch{
+				{
+					FileName:   "main.go",
+					RepoID:     42,
+					RepoBranch: "main",
+					Language:   "go",
+					Matches: []types.Match{
+						{
+							LineNum:   3,
+							Fragments: []types.Fragment{{Pre: "func main() ", Match: "{", Post: ""}},
+							Before:    "",
+							After:     "\tprintln(\"Hello, World\")",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := idx.search(test.query, test.max)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got=%+v\nwant=%+v", got, test.want)
+			}
+		})
+	}
+}
@@ -34,6 +34,11 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexDir is the directory in which the local keyword search indexes are stored.
+	IndexDir string
+	// MaxFileSize is the maximum size of a file to be indexed, bigger files are skipped.
+	MaxFileSize int64
 }
 
 func (c *Config) Prepare() error {
@@ -20,6 +20,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -45,8 +46,11 @@ func ProvideService(ctx context.Context,
 		indexer)
 }
 
-func ProvideLocalIndexSearcher() *LocalIndexSearcher {
-	return NewLocalIndexSearcher()
+func ProvideLocalIndexSearcher(
+	config Config,
+	git git.Interface,
+) (*LocalIndexSearcher, error) {
+	return NewLocalIndexSearcher(config, git)
 }
 
 func ProvideIndexer(l *LocalIndexSearcher) Indexer {
@@ -0,0 +1,453 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxKeyLength   = 50
+	maxValueLength = 50
+)
+
+type Service struct {
+	labelStore        store.LabelStore
+	pullReqLabelStore store.PullReqLabelStore
+	spaceStore        store.SpaceStore
+}
+
+func NewService(
+	labelStore store.LabelStore,
+	pullReqLabelStore store.PullReqLabelStore,
+	spaceStore store.SpaceStore,
+) *Service {
+	return &Service{
+		labelStore:        labelStore,
+		pullReqLabelStore: pullReqLabelStore,
+		spaceStore:        spaceStore,
+	}
+}
+
+type DefineInput struct {
+	Key         string          `json:"key"`
+	Value       string          `json:"value"`
+	Description string          `json:"description"`
+	Color       enum.LabelColor `json:"color"`
+}
+
+func (in *DefineInput) Sanitize() error {
+	var err error
+
+	if in.Key, err = sanitizeKey(in.Key); err != nil {
+		return err
+	}
+
+	if in.Value, err = sanitizeValue(in.Value); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err = check.Description(in.Description); err != nil {
+		return err
+	}
+
+	if in.Color, err = sanitizeColor(in.Color); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type UpdateInput struct {
+	Key         *string          `json:"key"`
+	Value       *string          `json:"value"`
+	Description *string          `json:"description"`
+	Color       *enum.LabelColor `json:"color"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	if in.Key != nil {
+		key, err := sanitizeKey(*in.Key)
+		if err != nil {
+			return err
+		}
+		in.Key = &key
+	}
+
+	if in.Value != nil {
+		value, err := sanitizeValue(*in.Value)
+		if err != nil {
+			return err
+		}
+		in.Value = &value
+	}
+
+	if in.Description != nil {
+		description := strings.TrimSpace(*in.Description)
+		if err := check.Description(description); err != nil {
+			return err
+		}
+		in.Description = &description
+	}
+
+	if in.Color != nil {
+		color, err := sanitizeColor(*in.Color)
+		if err != nil {
+			return err
+		}
+		in.Color = &color
+	}
+
+	return nil
+}
+
+func sanitizeKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", usererror.BadRequest("Label key must be provided.")
+	}
+	if utf8.RuneCountInString(key) > maxKeyLength {
+		return "", usererror.BadRequestf("Label key can have at most %d characters.", maxKeyLength)
+	}
+	if strings.Contains(key, "=") {
+		return "", usererror.BadRequest("Label key can't contain the '=' character.")
+	}
+
+	return key, nil
+}
+
+func sanitizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > maxValueLength {
+		return "", usererror.BadRequestf("Label value can have at most %d characters.", maxValueLength)
+	}
+	if strings.Contains(value, "=") {
+		return "", usererror.BadRequest("Label value can't contain the '=' character.")
+	}
+
+	return value, nil
+}
+
+func sanitizeColor(color enum.LabelColor) (enum.LabelColor, error) {
+	sanitized, ok := color.Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Label color '%s' is not supported.", color)
+	}
+
+	return sanitized, nil
+}
+
+// Define creates a new label either in a space or in a repository.
+func (s *Service) Define(
+	ctx context.Context,
+	principalID int64,
+	spaceID *int64,
+	repoID *int64,
+	in *DefineInput,
+) (*types.Label, error) {
+	now := time.Now().UnixMilli()
+
+	label := &types.Label{
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Key:         in.Key,
+		Value:       in.Value,
+		Description: in.Description,
+		Color:       in.Color,
+		CreatedBy:   principalID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err := s.labelStore.Create(ctx, label)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Label '%s' already exists.", label.Name()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	return label, nil
+}
+
+// Update applies the changes to a label. Nil fields of the input are left unchanged.
+func (s *Service) Update(ctx context.Context, label *types.Label, in *UpdateInput) (*types.Label, error) {
+	updated := *label
+	label = &updated
+
+	if in.Key != nil {
+		label.Key = *in.Key
+	}
+	if in.Value != nil {
+		label.Value = *in.Value
+	}
+	if in.Description != nil {
+		label.Description = *in.Description
+	}
+	if in.Color != nil {
+		label.Color = *in.Color
+	}
+
+	label.Updated = time.Now().UnixMilli()
+
+	err := s.labelStore.Update(ctx, label)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Label '%s' already exists.", label.Name()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	return label, nil
+}
+
+// FindSpaceLabel returns a label defined directly in the space.
+func (s *Service) FindSpaceLabel(ctx context.Context, spaceID, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if label.SpaceID == nil || *label.SpaceID != spaceID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return label, nil
+}
+
+// FindRepoLabel returns a label defined directly in the repository.
+func (s *Service) FindRepoLabel(ctx context.Context, repoID, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if label.RepoID == nil || *label.RepoID != repoID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return label, nil
+}
+
+// Delete deletes a label. The label gets removed from all pull requests it was assigned to.
+func (s *Service) Delete(ctx context.Context, labelID int64) error {
+	if err := s.labelStore.Delete(ctx, labelID); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	return nil
+}
+
+// ListSpaceLabels returns labels defined in the space, and optionally the labels of all its ancestor spaces.
+func (s *Service) ListSpaceLabels(
+	ctx context.Context,
+	space *types.Space,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	spaceIDs := []int64{space.ID}
+	if filter.Inherited {
+		ancestorIDs, err := s.ancestorSpaceIDs(ctx, space.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		spaceIDs = append(spaceIDs, ancestorIDs...)
+	}
+
+	labels, err := s.labelStore.List(ctx, spaceIDs, nil, filter.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// ListRepoLabels returns labels defined in the repository, and optionally the labels of all its ancestor spaces.
+func (s *Service) ListRepoLabels(
+	ctx context.Context,
+	repo *types.Repository,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	var spaceIDs []int64
+	if filter.Inherited {
+		var err error
+		spaceIDs, err = s.ancestorSpaceIDs(ctx, repo.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := s.labelStore.List(ctx, spaceIDs, &repo.ID, filter.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// ancestorSpaceIDs returns the ID of the space and the IDs of all its ancestors.
+func (s *Service) ancestorSpaceIDs(ctx context.Context, spaceID int64) ([]int64, error) {
+	var spaceIDs []int64
+	for spaceID > 0 {
+		spaceIDs = append(spaceIDs, spaceID)
+
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return spaceIDs, nil
+}
+
+// AssignResult holds the outcome of a label assignment.
+type AssignResult struct {
+	Label *types.Label
+	// Replaced is the scoped label with the same key that was removed from the pull request, if any.
+	Replaced *types.Label
+	// Assigned is false if the label was already assigned to the pull request.
+	Assigned bool
+}
+
+// AssignToPullReq assigns a label to a pull request. The label must be defined
+// in the target repository of the pull request or in any of its ancestor spaces.
+// A pull request can have only one value of a scoped label (label with a value),
+// so assigning a scoped label replaces the other label with the same key.
+func (s *Service) AssignToPullReq(
+	ctx context.Context,
+	principalID int64,
+	pr *types.PullReq,
+	repo *types.Repository,
+	labelID int64,
+) (*AssignResult, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if err = s.checkAvailable(ctx, label, repo); err != nil {
+		return nil, err
+	}
+
+	assigned, err := s.ListPullReqLabels(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AssignResult{Label: label}
+
+	for _, l := range assigned {
+		if l.ID == label.ID {
+			return result, nil
+		}
+	}
+
+	if label.Value != "" {
+		for _, l := range assigned {
+			if l.Value == "" || !strings.EqualFold(l.Key, label.Key) {
+				continue
+			}
+
+			if err = s.pullReqLabelStore.Unassign(ctx, pr.ID, l.ID); err != nil {
+				return nil, fmt.Errorf("failed to unassign replaced label: %w", err)
+			}
+
+			result.Replaced = l
+
+			break
+		}
+	}
+
+	err = s.pullReqLabelStore.Assign(ctx, &types.PullReqLabel{
+		PullReqID:  pr.ID,
+		LabelID:    label.ID,
+		AssignedBy: principalID,
+		Assigned:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign label: %w", err)
+	}
+
+	result.Assigned = true
+
+	return result, nil
+}
+
+// UnassignFromPullReq removes a label from a pull request.
+func (s *Service) UnassignFromPullReq(ctx context.Context, prID, labelID int64) (*types.Label, error) {
+	label, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if err = s.pullReqLabelStore.Unassign(ctx, prID, labelID); err != nil {
+		return nil, fmt.Errorf("failed to unassign label: %w", err)
+	}
+
+	return label, nil
+}
+
+// ListPullReqLabels returns all labels assigned to a pull request.
+func (s *Service) ListPullReqLabels(ctx context.Context, prID int64) ([]*types.Label, error) {
+	labels, err := s.pullReqLabelStore.ListLabels(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// checkAvailable returns an error if the label is not defined
+// in the repository or in any of the repository's ancestor spaces.
+func (s *Service) checkAvailable(ctx context.Context, label *types.Label, repo *types.Repository) error {
+	if label.RepoID != nil {
+		if *label.RepoID == repo.ID {
+			return nil
+		}
+		return usererror.BadRequest("The label is not available in the repository.")
+	}
+
+	if label.SpaceID == nil {
+		return usererror.BadRequest("The label is not available in the repository.")
+	}
+
+	spaceIDs, err := s.ancestorSpaceIDs(ctx, repo.ParentID)
+	if err != nil {
+		return err
+	}
+
+	for _, spaceID := range spaceIDs {
+		if spaceID == *label.SpaceID {
+			return nil
+		}
+	}
+
+	return usererror.BadRequest("The label is not available in the repository.")
+}
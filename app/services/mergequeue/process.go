@@ -0,0 +1,579 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) processForPullReq(ctx context.Context, pullReqID int64) error {
+	entry, err := s.mergeQueueStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return s.Process(ctx, entry.RepoID, entry.TargetBranch)
+}
+
+// Process advances the merge queue of a branch:
+// Pull requests that can't be merged anymore are removed from the queue,
+// speculative merge commits are built for the pull requests in the front of the queue
+// and the target branch is fast-forwarded to the merge commits whose required status checks succeeded.
+//
+// The speculative merge commit of an entry is built on top of the merge commit of the previous entry,
+// so a merge commit is tested together with all pull requests ahead of it in the queue.
+//
+//nolint:gocognit // refactor if needed.
+func (s *Service) Process(ctx context.Context, repoID int64, branch string) error {
+	unlock, err := s.lockQueue(ctx, repoID, branch)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := s.mergeQueueStore.ListForBranch(ctx, repoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	ref, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       branch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if errors.IsNotFound(err) {
+		for _, entry := range entries {
+			s.removeForPullReq(ctx, repo, entry, "The target branch doesn't exist anymore.")
+		}
+		s.publishQueue(ctx, repo, branch)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get target branch commit SHA: %w", err)
+	}
+
+	targetSHA := ref.SHA
+	baseSHA := targetSHA
+	changed := false
+	built := 0
+
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateOpen {
+			// the pull request has been closed or merged directly.
+			s.remove(ctx, repo, pr, entry, entry.EnqueuedBy, "")
+			changed = true
+			continue
+		}
+
+		if pr.SourceSHA != entry.SourceSHA {
+			s.remove(ctx, repo, pr, entry, entry.EnqueuedBy,
+				"The source branch has been updated. Merge the pull request again to add it back to the queue.")
+			changed = true
+			continue
+		}
+
+		if entry.MergeSHA == "" || entry.BaseSHA != baseSHA {
+			if built >= s.config.MaxSpeculativeMerges {
+				break
+			}
+
+			reason, err := s.build(ctx, repo, pr, entry, baseSHA)
+			if err != nil {
+				return fmt.Errorf("failed to build speculative merge commit for pull request %d: %w", pr.Number, err)
+			}
+
+			changed = true
+
+			if reason != "" {
+				s.remove(ctx, repo, pr, entry, entry.EnqueuedBy, reason)
+				continue
+			}
+		}
+
+		built++
+
+		failedChecks, succeeded, err := s.checkStatus(ctx, repo, pr, entry.MergeSHA)
+		if err != nil {
+			return fmt.Errorf("failed to get status checks of pull request %d: %w", pr.Number, err)
+		}
+
+		if len(failedChecks) > 0 {
+			s.remove(ctx, repo, pr, entry, entry.EnqueuedBy, fmt.Sprintf(
+				"The following required status checks of the merge commit failed: %s",
+				strings.Join(failedChecks, ", ")))
+			changed = true
+			continue
+		}
+
+		if succeeded && entry.BaseSHA == targetSHA {
+			merged, err := s.merge(ctx, repo, pr, entry)
+			if err != nil {
+				return fmt.Errorf("failed to merge pull request %d: %w", pr.Number, err)
+			}
+
+			changed = true
+
+			if merged {
+				targetSHA = entry.MergeSHA
+				baseSHA = entry.MergeSHA
+				built--
+			}
+
+			continue
+		}
+
+		baseSHA = entry.MergeSHA
+	}
+
+	if changed {
+		s.publishQueue(ctx, repo, branch)
+	}
+
+	return nil
+}
+
+func (s *Service) removeForPullReq(
+	ctx context.Context,
+	repo *types.Repository,
+	entry *types.MergeQueueEntry,
+	reason string,
+) {
+	pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find pull request of merge queue entry")
+		return
+	}
+
+	s.remove(ctx, repo, pr, entry, entry.EnqueuedBy, reason)
+}
+
+// build creates the speculative merge commit of the pull request on top of the base commit
+// and stores it in the pull request's merge queue reference.
+// If the merge commit can't be created, the function returns the reason for removing the pull request from the queue.
+func (s *Service) build(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA string,
+) (string, error) {
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	writeParams, err := s.createSystemRPCWriteParams(ctx, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	author, committer, title, message, err := s.commitDetails(ctx, repo, sourceRepo, pr, entry)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      baseSHA,
+		HeadRepoUID:     sourceRepo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		Title:           title,
+		Message:         message,
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypePullReqQueue,
+		RefName:         strconv.FormatInt(pr.Number, 10),
+		HeadExpectedSHA: entry.SourceSHA,
+		Force:           true,
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if errors.IsPreconditionFailed(err) || errors.IsInvalidArgument(err) {
+		return "Failed to create the merge commit: " + errors.Message(err), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if mergeOutput.MergeSHA == "" || len(mergeOutput.ConflictFiles) > 0 {
+		return "The pull request has merge conflicts with the target branch " +
+			"or with the pull requests ahead of it in the merge queue.", nil
+	}
+
+	entry.State = enum.MergeQueueEntryStateTesting
+	entry.BaseSHA = baseSHA
+	entry.MergeSHA = mergeOutput.MergeSHA
+	entry.Updated = time.Now().UnixMilli()
+
+	if err = s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return "", fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	s.eventReporter.MergeQueueBuilt(ctx, &pullreqevents.MergeQueueBuiltPayload{
+		Base:         eventBase(pr, entry.EnqueuedBy),
+		TargetBranch: entry.TargetBranch,
+		SourceSHA:    entry.SourceSHA,
+		BaseSHA:      entry.BaseSHA,
+		MergeSHA:     entry.MergeSHA,
+	})
+
+	return "", nil
+}
+
+// commitDetails returns the author, the committer, the title and the message of the merge commit.
+// They are the same as if the user that added the pull request to the queue merged it directly.
+func (s *Service) commitDetails(
+	ctx context.Context,
+	repo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (*git.Identity, *git.Identity, string, string, error) {
+	if _, ok := entry.Method.Sanitize(); !ok {
+		return nil, nil, "", "", fmt.Errorf("unsupported merge method: %s", entry.Method)
+	}
+
+	principal, err := s.principalInfoCache.Get(ctx, entry.EnqueuedBy)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	author, committer := merger.CommitIdentities(entry.Method, principal, &pr.Author)
+
+	if entry.Method == enum.MergeMethodRebase || entry.Method == enum.MergeMethodFastForward {
+		// no merge commit is created.
+		return author, committer, "", "", nil
+	}
+
+	title, message, err := s.mergeTemplates.CommitMessage(ctx, &mergetemplate.CommitMessageInput{
+		TargetRepo: repo,
+		SourceRepo: sourceRepo,
+		PullReq:    pr,
+		Method:     entry.Method,
+		Title:      entry.Title,
+		Message:    entry.Message,
+	})
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to create merge commit message: %w", err)
+	}
+
+	return author, committer, title, message, nil
+}
+
+// checkStatus returns the identifiers of the required status checks of the merge commit that failed
+// and whether all required status checks succeeded.
+// The required status checks can't be bypassed for the merge queue.
+func (s *Service) checkStatus(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	mergeSHA string,
+) ([]string, bool, error) {
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	requiredChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Actor:       &bootstrap.NewSystemServiceSession().Principal,
+		IsRepoOwner: false,
+		Repo:        repo,
+		PullReq:     pr,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, mergeSHA)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(checkResults))
+	for _, result := range checkResults {
+		statuses[result.Identifier] = result.Status
+	}
+
+	var failed []string
+	succeeded := true
+
+	check := func(ids map[string]struct{}) {
+		for id := range ids {
+			switch statuses[id] {
+			case enum.CheckStatusSuccess:
+			case enum.CheckStatusFailure, enum.CheckStatusError:
+				failed = append(failed, id)
+			case enum.CheckStatusPending, enum.CheckStatusRunning:
+				succeeded = false
+			default:
+				succeeded = false // not reported yet
+			}
+		}
+	}
+
+	check(requiredChecks.RequiredIdentifiers)
+	check(requiredChecks.BypassableIdentifiers)
+
+	sort.Strings(failed)
+
+	return failed, succeeded && len(failed) == 0, nil
+}
+
+// merge fast-forwards the target branch to the speculative merge commit and marks the pull request as merged.
+// It returns false if the pull request has been removed from the queue in the meantime.
+func (s *Service) merge(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (bool, error) {
+	unlock, err := s.lockPullReqs(ctx, repo.ID)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	// the pull request could have been removed from the queue or updated while waiting for the lock.
+	if _, err = s.mergeQueueStore.Find(ctx, entry.PullReqID); errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	pr, err = s.pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen || pr.SourceSHA != entry.SourceSHA {
+		return false, nil
+	}
+
+	reason, err := s.verifyRules(ctx, repo, pr, entry)
+	if err != nil {
+		return false, err
+	}
+
+	if reason != "" {
+		s.remove(ctx, repo, pr, entry, entry.EnqueuedBy, reason)
+		return false, nil
+	}
+
+	writeParams, err := s.createSystemRPCWriteParams(ctx, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        entry.TargetBranch,
+		Type:        gitenum.RefTypeBranch,
+		OldValue:    entry.BaseSHA,
+		NewValue:    entry.MergeSHA,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to fast-forward target branch: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("merge queue fast-forwarded branch %q to %s", entry.TargetBranch, entry.MergeSHA)
+
+	if err = s.mergeQueueStore.Delete(ctx, entry.PullReqID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue entry of merged pull request")
+	}
+
+	s.deleteQueueRef(ctx, repo, pr.Number)
+
+	sourceWriteParams := writeParams
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, errFind := s.repoStore.Find(ctx, pr.SourceRepoID)
+		if errFind != nil {
+			return false, fmt.Errorf("failed to find source repository: %w", errFind)
+		}
+
+		sourceWriteParams, err = s.createSystemRPCWriteParams(ctx, sourceRepo)
+		if err != nil {
+			return false, fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+	}
+
+	_, _, err = s.merger.MarkMerged(ctx, &merger.MarkMergedInput{
+		PrincipalID:        entry.EnqueuedBy,
+		TargetRepo:         repo,
+		PullReq:            pr,
+		Method:             entry.Method,
+		Merged:             time.Now(),
+		MergeSHA:           entry.MergeSHA,
+		TargetSHA:          entry.BaseSHA,
+		SourceSHA:          entry.SourceSHA,
+		SourceWriteParams:  sourceWriteParams,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// verifyRules verifies the protection rules of the target branch again right before the pull request is merged,
+// because approvals could have been dismissed or changes requested since the pull request was added to the queue.
+// The rules are verified for the user who added the pull request to the queue and can't be bypassed.
+// If the rules aren't satisfied anymore, the function returns the reason for removing the pull request from the queue.
+func (s *Service) verifyRules(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (string, error) {
+	actor, err := s.principalStore.Find(ctx, entry.EnqueuedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal that added the pull request to the queue: %w", err)
+	}
+
+	session := &auth.Session{
+		Principal: *actor,
+		Metadata:  nil,
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, pr.SourceSHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return "", fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	labels, err := s.pullReqLabelStore.ListLabels(ctx, pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        actor,
+		AllowBypass:  false, // protection rules can't be bypassed for the merge queue
+		IsRepoOwner:  isRepoOwner,
+		TargetRepo:   repo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       entry.Method,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		Labels:       labels,
+
+		UnverifiedCommits: s.signatureVerifier.PullReqUnverifiedCommitsFn(repo, pr),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	var messages []string
+	for _, ruleViolation := range violations {
+		if !ruleViolation.IsCritical() {
+			continue
+		}
+		for _, violation := range ruleViolation.Violations {
+			messages = append(messages, violation.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return "", nil
+	}
+
+	return "The pull request doesn't satisfy the protection rules of the target branch anymore: " +
+		strings.Join(messages, " "), nil
+}
+
+func eventBase(pr *types.PullReq, principalID int64) pullreqevents.Base {
+	return pullreqevents.Base{
+		PullReqID:    pr.ID,
+		SourceRepoID: pr.SourceRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		PrincipalID:  principalID,
+		Number:       pr.Number,
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Find returns the merge queue entry of a pull request with its current position in the queue.
+func (s *Service) Find(ctx context.Context, pr *types.PullReq) (*types.MergeQueueEntry, error) {
+	entry, err := s.mergeQueueStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.ListForBranch(ctx, entry.RepoID, entry.TargetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.PullReqID == entry.PullReqID {
+			return e, nil
+		}
+	}
+
+	return entry, nil
+}
+
+// Enqueue adds the pull request to the end of the merge queue of its target branch.
+// The merge queue is processed asynchronously.
+func (s *Service) Enqueue(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	title string,
+	message string,
+	deleteSourceBranch bool,
+) (*types.MergeQueueEntry, error) {
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		PullReqID:          pr.ID,
+		RepoID:             repo.ID,
+		TargetBranch:       pr.TargetBranch,
+		Method:             method,
+		Title:              title,
+		Message:            message,
+		State:              enum.MergeQueueEntryStateQueued,
+		SourceSHA:          pr.SourceSHA,
+		DeleteSourceBranch: deleteSourceBranch,
+		EnqueuedBy:         principal.ID,
+		Created:            now,
+		Updated:            now,
+	}
+
+	if err := s.mergeQueueStore.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadMergeQueue{Enqueued: true})
+
+	s.eventReporter.MergeQueueEnqueued(ctx, &pullreqevents.MergeQueueEnqueuedPayload{
+		Base:         eventBase(pr, principal.ID),
+		TargetBranch: pr.TargetBranch,
+		SourceSHA:    pr.SourceSHA,
+	})
+
+	queue := s.publishQueue(ctx, repo, pr.TargetBranch)
+	for i := range queue.Entries {
+		if queue.Entries[i].PullReqID == pr.ID {
+			entry.Position = queue.Entries[i].Position
+		}
+	}
+
+	return entry, nil
+}
+
+// Dequeue removes the pull request from the merge queue.
+func (s *Service) Dequeue(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+) error {
+	// the lock prevents removing the pull request while it's being merged by the merge queue.
+	unlock, err := s.lockPullReqs(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entry, err := s.mergeQueueStore.Find(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	s.remove(ctx, repo, pr, entry, principal.ID, "Removed from the merge queue.")
+	s.publishQueue(ctx, repo, entry.TargetBranch)
+
+	return nil
+}
+
+// remove deletes the merge queue entry with its temporary reference
+// and writes a pull request activity with the reason. An empty reason skips the activity.
+func (s *Service) remove(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	principalID int64,
+	reason string,
+) {
+	if err := s.mergeQueueStore.Delete(ctx, entry.PullReqID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue entry")
+		return
+	}
+
+	if entry.MergeSHA != "" {
+		s.deleteQueueRef(ctx, repo, pr.Number)
+	}
+
+	if reason != "" {
+		s.writeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadMergeQueue{
+			Enqueued: false,
+			Reason:   reason,
+		})
+	}
+}
+
+func (s *Service) deleteQueueRef(ctx context.Context, repo *types.Repository, prNum int64) {
+	writeParams, err := s.createSystemRPCWriteParams(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params")
+		return
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.FormatInt(prNum, 10),
+		Type:        gitenum.RefTypePullReqQueue,
+		NewValue:    "", // when NewValue is empty will delete the ref.
+		OldValue:    "", // we don't care about the old value
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete PR merge queue ref")
+	}
+}
+
+// writeActivity writes a pull request activity. Failures are only logged.
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) {
+	err := func() error {
+		prUpd, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, prUpd, principalID, payload)
+		return err
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request merge queue activity")
+	}
+}
+
+// publishQueue sends the current merge queue of the branch to the event stream.
+func (s *Service) publishQueue(ctx context.Context, repo *types.Repository, branch string) *types.MergeQueue {
+	queue := &types.MergeQueue{
+		RepoID:       repo.ID,
+		TargetBranch: branch,
+		Entries:      []types.MergeQueueEntry{},
+	}
+
+	entries, err := s.mergeQueueStore.ListForBranch(ctx, repo.ID, branch)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list merge queue entries")
+		return queue
+	}
+
+	for _, entry := range entries {
+		queue.Entries = append(queue.Entries, *entry)
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestMergeQueueUpdated, queue); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish merge queue updated event")
+	}
+
+	return queue
+}
+
+// createSystemRPCWriteParams creates base write parameters for write operations.
+// The git hooks are executed as internal, so the merge queue isn't blocked by the protection rules.
+func (s *Service) createSystemRPCWriteParams(ctx context.Context, repo *types.Repository) (git.WriteParams, error) {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		principal.ID,
+		false,
+		true,
+		false,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+// lockQueue serializes the processing of the merge queue of a branch.
+func (s *Service) lockQueue(ctx context.Context, repoID int64, branch string) (func(), error) {
+	const expiry = 10 * time.Minute
+	return s.lock(ctx, fmt.Sprintf("%d/merge-queue/%s", repoID, branch), expiry)
+}
+
+// lockPullReqs acquires the same lock the pull request merge API uses
+// to serialize the fast-forward of the target branch with direct merges.
+func (s *Service) lockPullReqs(ctx context.Context, repoID int64) (func(), error) {
+	const expiry = 3*time.Minute + 30*time.Second
+	return s.lock(ctx, fmt.Sprintf("%d/pulls", repoID), expiry)
+}
+
+func (s *Service) lock(ctx context.Context, key string, expiry time.Duration) (func(), error) {
+	mutex, err := s.mtxManager.NewMutex(
+		key,
+		lock.WithNamespace("repo"),
+		lock.WithExpiry(expiry),
+		lock.WithTimeoutFactor(4/expiry.Seconds()), // 4s
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new mutex %q: %w", key, err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to lock mutex %q: %w", key, err)
+	}
+
+	return func() {
+		// always unlock independent of whether source context got canceled or not
+		ctx, cancel := context.WithTimeout(
+			contextutil.WithNewValues(context.Background(), ctx),
+			30*time.Second,
+		)
+		defer cancel()
+
+		if err := mutex.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to unlock mutex %q", key)
+		}
+	}, nil
+}
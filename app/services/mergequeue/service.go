@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeMergeQueue = "merge-queue-process"
+
+	eventsReaderGroupName = "gitness:mergequeue"
+
+	checkEventsReaderGroupName = "gitness:mergequeue:check"
+)
+
+type Config struct {
+	Cron                 string
+	MaxDuration          time.Duration
+	MaxSpeculativeMerges int
+	EventReaderName      string
+}
+
+// Service maintains the merge queues of protected branches.
+// For the queued pull requests it builds speculative merge commits on temporary references,
+// waits for the required status checks of the merge commits and fast-forwards the target branch.
+type Service struct {
+	config             Config
+	urlProvider        url.Provider
+	git                git.Interface
+	mtxManager         lock.MutexManager
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	pullreqStore       store.PullReqStore
+	activityStore      store.PullReqActivityStore
+	reviewerStore      store.PullReqReviewerStore
+	pullReqLabelStore  store.PullReqLabelStore
+	checkStore         store.CheckStore
+	mergeQueueStore    store.MergeQueueStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	protectionManager  *protection.Manager
+	codeOwners         *codeowners.Service
+	eventReporter      *pullreqevents.Reporter
+	sseStreamer        sse.Streamer
+	scheduler          *job.Scheduler
+	mergeTemplates     *mergetemplate.Service
+	merger             *merger.Service
+	signatureVerifier  *gitsignature.Verifier
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	urlProvider url.Provider,
+	git git.Interface,
+	mtxManager lock.MutexManager,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	pullReqLabelStore store.PullReqLabelStore,
+	checkStore store.CheckStore,
+	mergeQueueStore store.MergeQueueStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	eventReporter *pullreqevents.Reporter,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	sseStreamer sse.Streamer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	mergeTemplates *mergetemplate.Service,
+	merger *merger.Service,
+	signatureVerifier *gitsignature.Verifier,
+) (*Service, error) {
+	service := &Service{
+		config:             config,
+		urlProvider:        urlProvider,
+		git:                git,
+		mtxManager:         mtxManager,
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		pullreqStore:       pullreqStore,
+		activityStore:      activityStore,
+		reviewerStore:      reviewerStore,
+		pullReqLabelStore:  pullReqLabelStore,
+		checkStore:         checkStore,
+		mergeQueueStore:    mergeQueueStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		protectionManager:  protectionManager,
+		codeOwners:         codeOwners,
+		eventReporter:      eventReporter,
+		sseStreamer:        sseStreamer,
+		scheduler:          scheduler,
+		mergeTemplates:     mergeTemplates,
+		merger:             merger,
+		signatureVerifier:  signatureVerifier,
+	}
+
+	err := executor.Register(jobTypeMergeQueue, &mergeQueueJob{service: service})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					// merge queues are processed periodically as well, no need for retries.
+					stream.WithMaxRetries(0),
+				))
+
+			_ = r.RegisterMergeQueueEnqueued(service.handleEventMergeQueueEnqueued)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterClosed(service.handleEventClosed)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request events reader: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, checkEventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					// merge queues are processed periodically as well, no need for retries.
+					stream.WithMaxRetries(0),
+				))
+
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeMergeQueue, jobTypeMergeQueue,
+		s.config.Cron, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for merge queue processing: %w", err)
+	}
+
+	return nil
+}
+
+type mergeQueueJob struct {
+	service *Service
+}
+
+// Handle processes all non-empty merge queues.
+// The merge queues are processed on events, this is a fallback in case an event got lost.
+func (j *mergeQueueJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	branches, err := j.service.mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queue branches: %w", err)
+	}
+
+	for _, branch := range branches {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// errors are logged, a single failing merge queue shouldn't fail the whole job.
+		err = j.service.Process(ctx, branch.RepoID, branch.TargetBranch)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", branch.RepoID).
+				Str("branch", branch.TargetBranch).
+				Msg("failed to process merge queue")
+		}
+	}
+
+	return "", nil
+}
+
+func (s *Service) handleEventMergeQueueEnqueued(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueEnqueuedPayload],
+) error {
+	return s.Process(ctx, event.Payload.TargetRepoID, event.Payload.TargetBranch)
+}
+
+// handleEventCheckReported processes the merge queue a speculative merge commit belongs to
+// once a status check of the merge commit completed.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	entry, err := s.mergeQueueStore.FindByMergeSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry by merge commit: %w", err)
+	}
+
+	return s.Process(ctx, entry.RepoID, entry.TargetBranch)
+}
+
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	urlProvider url.Provider,
+	git git.Interface,
+	mtxManager lock.MutexManager,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	pullReqLabelStore store.PullReqLabelStore,
+	checkStore store.CheckStore,
+	mergeQueueStore store.MergeQueueStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	eventReporter *pullreqevents.Reporter,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	sseStreamer sse.Streamer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	mergeTemplates *mergetemplate.Service,
+	merger *merger.Service,
+	signatureVerifier *gitsignature.Verifier,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		Config{
+			Cron:                 config.MergeQueue.CRON,
+			MaxDuration:          config.MergeQueue.MaxDuration,
+			MaxSpeculativeMerges: config.MergeQueue.MaxSpeculativeMerges,
+			EventReaderName:      config.InstanceID,
+		},
+		urlProvider,
+		git,
+		mtxManager,
+		authorizer,
+		repoStore,
+		pullreqStore,
+		activityStore,
+		reviewerStore,
+		pullReqLabelStore,
+		checkStore,
+		mergeQueueStore,
+		principalStore,
+		principalInfoCache,
+		protectionManager,
+		codeOwners,
+		eventReporter,
+		pullreqEvReaderFactory,
+		checkEvReaderFactory,
+		sseStreamer,
+		scheduler,
+		executor,
+		mergeTemplates,
+		merger,
+		signatureVerifier,
+	)
+}
@@ -0,0 +1,391 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Service merges pull requests. It's shared by the merge API, auto-merge and the merge queue,
+// so a pull request ends up in the same state no matter how it was merged.
+type Service struct {
+	git            git.Interface
+	pullreqStore   store.PullReqStore
+	activityStore  store.PullReqActivityStore
+	eventReporter  *pullreqevents.Reporter
+	sseStreamer    sse.Streamer
+	mergeTemplates *mergetemplate.Service
+}
+
+func NewService(
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	eventReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	mergeTemplates *mergetemplate.Service,
+) *Service {
+	return &Service{
+		git:            git,
+		pullreqStore:   pullreqStore,
+		activityStore:  activityStore,
+		eventReporter:  eventReporter,
+		sseStreamer:    sseStreamer,
+		mergeTemplates: mergeTemplates,
+	}
+}
+
+// MergeInput describes a merge of a pull request into its target branch.
+type MergeInput struct {
+	// Actor is the principal on whose behalf the pull request is merged.
+	Actor      *types.Principal
+	TargetRepo *types.Repository
+	SourceRepo *types.Repository
+	PullReq    *types.PullReq
+	Method     enum.MergeMethod
+	// Title and Message of the merge commit, the merge commit templates are used if they are empty.
+	Title   string
+	Message string
+
+	TargetWriteParams git.WriteParams
+	SourceWriteParams git.WriteParams
+
+	DeleteSourceBranch bool
+
+	RulesBypassed []types.RuleInfo
+	BypassReason  string
+}
+
+// MergeOutput is the result of a merge of a pull request.
+type MergeOutput struct {
+	PullReq  *types.PullReq
+	MergeSHA string
+	// ConflictFiles are set if the pull request couldn't be merged because of merge conflicts.
+	ConflictFiles []string
+	BranchDeleted bool
+}
+
+// Merge merges the pull request into its target branch and marks it as merged.
+// If the pull request has merge conflicts, the target branch isn't updated, the pull request is
+// updated with the conflicts and the conflicting files are returned.
+func (s *Service) Merge(ctx context.Context, in *MergeInput) (*MergeOutput, error) {
+	pr := in.PullReq
+
+	author, committer := CommitIdentities(in.Method, in.Actor.ToPrincipalInfo(), &pr.Author)
+
+	title, message, err := s.mergeTemplates.CommitMessage(ctx, &mergetemplate.CommitMessageInput{
+		TargetRepo: in.TargetRepo,
+		SourceRepo: in.SourceRepo,
+		PullReq:    pr,
+		Method:     in.Method,
+		Title:      in.Title,
+		Message:    in.Message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge commit message: %w", err)
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     in.TargetWriteParams,
+		BaseBranch:      pr.TargetBranch,
+		HeadRepoUID:     in.SourceRepo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		Title:           title,
+		Message:         message,
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypeBranch,
+		RefName:         pr.TargetBranch,
+		HeadExpectedSHA: pr.SourceSHA,
+		Method:          gitenum.MergeMethod(in.Method),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("merge execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA == "" || len(mergeOutput.ConflictFiles) > 0 {
+		pr = s.markConflicts(ctx, in.TargetRepo, pr, mergeOutput)
+
+		return &MergeOutput{
+			PullReq:       pr,
+			ConflictFiles: mergeOutput.ConflictFiles,
+		}, nil
+	}
+
+	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
+
+	diffStats := types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
+
+	pr, branchDeleted, err := s.MarkMerged(ctx, &MarkMergedInput{
+		PrincipalID:        in.Actor.ID,
+		TargetRepo:         in.TargetRepo,
+		PullReq:            pr,
+		Method:             in.Method,
+		Merged:             now,
+		MergeSHA:           mergeOutput.MergeSHA,
+		TargetSHA:          mergeOutput.BaseSHA,
+		SourceSHA:          mergeOutput.HeadSHA,
+		MergeBaseSHA:       mergeOutput.MergeBaseSHA,
+		DiffStats:          &diffStats,
+		SourceWriteParams:  in.SourceWriteParams,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		RulesBypassed:      in.RulesBypassed,
+		BypassReason:       in.BypassReason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MergeOutput{
+		PullReq:       pr,
+		MergeSHA:      mergeOutput.MergeSHA,
+		BranchDeleted: branchDeleted,
+	}, nil
+}
+
+// markConflicts updates the pull request with the merge conflicts. Failures are only logged.
+func (s *Service) markConflicts(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	mergeOutput git.MergeOutput,
+) *types.PullReq {
+	prUpdated, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.SourceSHA != mergeOutput.HeadSHA {
+			return errors.New("source SHA has changed")
+		}
+
+		// update all Merge specific information
+		pr.MergeCheckStatus = enum.MergeCheckStatusConflict
+		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA
+		pr.MergeTargetSHA = &mergeOutput.BaseSHA
+		pr.MergeSHA = nil
+		pr.MergeConflicts = mergeOutput.ConflictFiles
+		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
+		return nil
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request with conflict files")
+		return pr
+	}
+
+	if err = s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, prUpdated); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return prUpdated
+}
+
+// MarkMergedInput describes a pull request whose changes have been merged into the target branch.
+type MarkMergedInput struct {
+	// PrincipalID is the principal on whose behalf the pull request has been merged.
+	PrincipalID int64
+	TargetRepo  *types.Repository
+	PullReq     *types.PullReq
+	Method      enum.MergeMethod
+	Merged      time.Time
+
+	MergeSHA  string
+	TargetSHA string
+	SourceSHA string
+	// MergeBaseSHA and DiffStats are optional, the values of the pull request are kept if they aren't provided.
+	MergeBaseSHA string
+	DiffStats    *types.DiffStats
+
+	// SourceWriteParams are used to delete the source branch.
+	SourceWriteParams  git.WriteParams
+	DeleteSourceBranch bool
+
+	RulesBypassed []types.RuleInfo
+	BypassReason  string
+}
+
+// MarkMerged marks the pull request as merged once its changes are in the target branch:
+// It updates the pull request, writes the merge activity, reports the merged event
+// and deletes the source branch if requested. It returns the updated pull request and
+// whether the source branch has been deleted.
+func (s *Service) MarkMerged(ctx context.Context, in *MarkMergedInput) (*types.PullReq, bool, error) {
+	var activitySeqMerge, activitySeqBranchDeleted int64
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, in.PullReq, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := in.Merged.UnixMilli()
+		pr.Merged = &nowMilli
+		pr.MergedBy = &in.PrincipalID
+		pr.MergeMethod = &in.Method
+
+		// update all Merge specific information (might be empty if previous merge check failed)
+		// since this is the final operation on the PR, we update any sha that might've changed by now.
+		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
+		pr.SourceSHA = in.SourceSHA
+		pr.MergeTargetSHA = &in.TargetSHA
+		pr.MergeSHA = &in.MergeSHA
+		pr.MergeConflicts = nil
+
+		if in.MergeBaseSHA != "" {
+			pr.MergeBaseSHA = in.MergeBaseSHA
+		}
+		if in.DiffStats != nil {
+			pr.Stats.DiffStats = *in.DiffStats
+		}
+
+		// update sequence for PR activities
+		pr.ActivitySeq++
+		activitySeqMerge = pr.ActivitySeq
+
+		if in.DeleteSourceBranch {
+			pr.ActivitySeq++
+			activitySeqBranchDeleted = pr.ActivitySeq
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	pr.ActivitySeq = activitySeqMerge
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod: in.Method,
+		MergeSHA:    in.MergeSHA,
+		TargetSHA:   in.TargetSHA,
+		SourceSHA:   in.SourceSHA,
+	}
+	if len(in.RulesBypassed) > 0 {
+		activityPayload.RulesBypassed = in.RulesBypassed
+		activityPayload.BypassReason = in.BypassReason
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, in.PrincipalID, activityPayload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	s.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  in.PrincipalID,
+			Number:       pr.Number,
+		},
+		MergeMethod: in.Method,
+		MergeSHA:    in.MergeSHA,
+		TargetSHA:   in.TargetSHA,
+		SourceSHA:   in.SourceSHA,
+	})
+
+	var branchDeleted bool
+	if in.DeleteSourceBranch {
+		branchDeleted = s.deleteSourceBranch(ctx, in, pr, activitySeqBranchDeleted)
+	}
+
+	if err = s.sseStreamer.Publish(ctx, in.TargetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, branchDeleted, nil
+}
+
+// deleteSourceBranch deletes the source branch of a merged pull request. Failures are only logged.
+func (s *Service) deleteSourceBranch(
+	ctx context.Context,
+	in *MarkMergedInput,
+	pr *types.PullReq,
+	activitySeq int64,
+) bool {
+	err := s.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+		WriteParams: in.SourceWriteParams,
+		BranchName:  pr.SourceBranch,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to delete source branch after merging")
+		return false
+	}
+
+	// NOTE: there is a chance someone pushed on the branch between merge and delete.
+	// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
+	pr.ActivitySeq = activitySeq
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, in.PrincipalID,
+		&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).
+			Msgf("failed to write pull request activity for successful automatic branch delete")
+	}
+
+	return true
+}
+
+// CommitIdentities returns the author and the committer of the commits created by a merge
+// with the provided method. The merger is the principal on whose behalf the pull request is merged.
+func CommitIdentities(
+	method enum.MergeMethod,
+	merger *types.PrincipalInfo,
+	prAuthor *types.PrincipalInfo,
+) (*git.Identity, *git.Identity) {
+	system := bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()
+	return commitIdentities(method, merger, prAuthor, system)
+}
+
+func commitIdentities(
+	method enum.MergeMethod,
+	merger *types.PrincipalInfo,
+	prAuthor *types.PrincipalInfo,
+	system *types.PrincipalInfo,
+) (*git.Identity, *git.Identity) {
+	switch method {
+	case enum.MergeMethodMerge:
+		return identityFromPrincipalInfo(merger), identityFromPrincipalInfo(system)
+	case enum.MergeMethodSquash:
+		return identityFromPrincipalInfo(prAuthor), identityFromPrincipalInfo(system)
+	case enum.MergeMethodRebase:
+		// the author info in the commits will be preserved.
+		return nil, identityFromPrincipalInfo(merger)
+	case enum.MergeMethodRebaseMerge:
+		// the rebase-merge uses the same committer for the rebased commits and the merge commit.
+		return identityFromPrincipalInfo(merger), identityFromPrincipalInfo(merger)
+	case enum.MergeMethodFastForward:
+		// no commit is created.
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+func identityFromPrincipalInfo(p *types.PrincipalInfo) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCommitIdentities(t *testing.T) {
+	merger := &types.PrincipalInfo{DisplayName: "Merger", Email: "merger@example.com"}
+	author := &types.PrincipalInfo{DisplayName: "Author", Email: "author@example.com"}
+	system := &types.PrincipalInfo{DisplayName: "Gitness", Email: "system@example.com"}
+
+	mergerIdentity := &git.Identity{Name: "Merger", Email: "merger@example.com"}
+	authorIdentity := &git.Identity{Name: "Author", Email: "author@example.com"}
+	systemIdentity := &git.Identity{Name: "Gitness", Email: "system@example.com"}
+
+	tests := []struct {
+		method        enum.MergeMethod
+		wantAuthor    *git.Identity
+		wantCommitter *git.Identity
+	}{
+		{method: enum.MergeMethodMerge, wantAuthor: mergerIdentity, wantCommitter: systemIdentity},
+		{method: enum.MergeMethodSquash, wantAuthor: authorIdentity, wantCommitter: systemIdentity},
+		{method: enum.MergeMethodRebase, wantAuthor: nil, wantCommitter: mergerIdentity},
+		{method: enum.MergeMethodRebaseMerge, wantAuthor: mergerIdentity, wantCommitter: mergerIdentity},
+		{method: enum.MergeMethodFastForward, wantAuthor: nil, wantCommitter: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.method), func(t *testing.T) {
+			gotAuthor, gotCommitter := commitIdentities(test.method, merger, author, system)
+			if !equalIdentity(gotAuthor, test.wantAuthor) {
+				t.Errorf("author = %v, want %v", gotAuthor, test.wantAuthor)
+			}
+			if !equalIdentity(gotCommitter, test.wantCommitter) {
+				t.Errorf("committer = %v, want %v", gotCommitter, test.wantCommitter)
+			}
+		})
+	}
+}
+
+func equalIdentity(a, b *git.Identity) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,276 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergetemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PlaceholderPullReqTitle       = "{pr_title}"
+	PlaceholderPullReqNumber      = "{pr_number}"
+	PlaceholderPullReqDescription = "{pr_description}"
+	PlaceholderSourceBranch       = "{source_branch}"
+	PlaceholderTargetBranch       = "{target_branch}"
+	PlaceholderSourceRepo         = "{source_repo}"
+	PlaceholderCoAuthors          = "{co_authors}"
+	PlaceholderReviewers          = "{reviewers}"
+
+	// maxCoAuthorCommits limits the number of pull request commits that are inspected for co-authors.
+	maxCoAuthorCommits = 1000
+)
+
+var trailerRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*: \S`)
+
+type Service struct {
+	git           git.Interface
+	templateStore store.MergeTemplateStore
+	reviewerStore store.PullReqReviewerStore
+}
+
+func NewService(
+	git git.Interface,
+	templateStore store.MergeTemplateStore,
+	reviewerStore store.PullReqReviewerStore,
+) *Service {
+	return &Service{
+		git:           git,
+		templateStore: templateStore,
+		reviewerStore: reviewerStore,
+	}
+}
+
+// FindTemplates returns the merge commit templates of a repository.
+// If the templates were never updated, empty templates are returned.
+func (s *Service) FindTemplates(ctx context.Context, repoID int64) (*types.MergeTemplates, error) {
+	templates, err := s.templateStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.MergeTemplates{RepoID: repoID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// UpdateTemplates stores the merge commit templates of a repository.
+func (s *Service) UpdateTemplates(ctx context.Context, templates *types.MergeTemplates) error {
+	if err := s.templateStore.Upsert(ctx, templates); err != nil {
+		return fmt.Errorf("failed to store merge templates: %w", err)
+	}
+
+	return nil
+}
+
+type CommitMessageInput struct {
+	TargetRepo *types.Repository
+	SourceRepo *types.Repository
+	PullReq    *types.PullReq
+	Method     enum.MergeMethod
+
+	// Title and Message, if provided, are used instead of the repository templates.
+	Title   string
+	Message string
+}
+
+// CommitMessage returns the title and the message of the commit that is created by merging the pull request.
+// The title and the message are created from the input overrides, the repository templates
+// or the defaults, in that order. The squash commit message always ends with
+// a "Co-authored-by" trailer for each author of the squashed commits.
+// The rebase and the fast-forward methods don't create a merge commit so the function returns empty strings.
+func (s *Service) CommitMessage(ctx context.Context, in *CommitMessageInput) (string, string, error) {
+	if in.Method != enum.MergeMethodMerge &&
+		in.Method != enum.MergeMethodRebaseMerge &&
+		in.Method != enum.MergeMethodSquash {
+		return "", "", nil
+	}
+
+	templates, err := s.FindTemplates(ctx, in.TargetRepo.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	pr := in.PullReq
+
+	var defaultTitle, titleTemplate, messageTemplate string
+	if in.Method == enum.MergeMethodSquash {
+		defaultTitle = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		titleTemplate = templates.SquashTitle
+		messageTemplate = templates.SquashMessage
+	} else {
+		defaultTitle = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, in.SourceRepo.Path, pr.Number)
+		titleTemplate = templates.MergeTitle
+		messageTemplate = templates.MergeMessage
+	}
+
+	title := in.Title
+	if title == "" {
+		title = titleTemplate
+	}
+
+	message := in.Message
+	if message == "" {
+		message = messageTemplate
+	}
+
+	var coAuthors []string
+	if in.Method == enum.MergeMethodSquash || containsPlaceholder(PlaceholderCoAuthors, title, message) {
+		coAuthors, err = s.coAuthors(ctx, in)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	var reviewers []string
+	if containsPlaceholder(PlaceholderReviewers, title, message) {
+		reviewers, err = s.reviewers(ctx, pr)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		PlaceholderPullReqTitle, pr.Title,
+		PlaceholderPullReqNumber, strconv.FormatInt(pr.Number, 10),
+		PlaceholderPullReqDescription, pr.Description,
+		PlaceholderSourceBranch, pr.SourceBranch,
+		PlaceholderTargetBranch, pr.TargetBranch,
+		PlaceholderSourceRepo, in.SourceRepo.Path,
+		PlaceholderCoAuthors, strings.Join(coAuthors, "\n"),
+		PlaceholderReviewers, strings.Join(reviewers, "\n"),
+	)
+
+	title = strings.TrimSpace(replacer.Replace(title))
+	if title == "" {
+		title = defaultTitle
+	}
+
+	message = strings.TrimSpace(replacer.Replace(message))
+	if in.Method == enum.MergeMethodSquash {
+		message = appendTrailers(message, coAuthors)
+	}
+
+	return title, message, nil
+}
+
+// coAuthors returns a "Co-authored-by" trailer for each author of the pull request commits,
+// except for the author of the pull request.
+func (s *Service) coAuthors(ctx context.Context, in *CommitMessageInput) ([]string, error) {
+	if in.PullReq.MergeBaseSHA == "" {
+		return nil, nil
+	}
+
+	output, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(in.TargetRepo),
+		GitREF:     in.PullReq.SourceSHA,
+		After:      in.PullReq.MergeBaseSHA,
+		Limit:      maxCoAuthorCommits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	return coAuthorTrailers(output.Commits, in.PullReq.Author.Email), nil
+}
+
+// reviewers returns a "Reviewed-by" trailer for each reviewer that approved the pull request.
+func (s *Service) reviewers(ctx context.Context, pr *types.PullReq) ([]string, error) {
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	trailers := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision != enum.PullReqReviewDecisionApproved {
+			continue
+		}
+
+		trailers = append(trailers,
+			fmt.Sprintf("Reviewed-by: %s <%s>", reviewer.Reviewer.DisplayName, reviewer.Reviewer.Email))
+	}
+
+	return trailers, nil
+}
+
+// coAuthorTrailers returns a "Co-authored-by" trailer for each distinct author of the commits,
+// ignoring the author with the excluded email address.
+func coAuthorTrailers(commits []git.Commit, excludeEmail string) []string {
+	seen := map[string]struct{}{
+		strings.ToLower(excludeEmail): {},
+	}
+
+	var trailers []string
+	for i := len(commits) - 1; i >= 0; i-- { // commits are listed newest first
+		author := commits[i].Author.Identity
+		email := strings.ToLower(author.Email)
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+
+		trailers = append(trailers, fmt.Sprintf("Co-authored-by: %s <%s>", author.Name, author.Email))
+	}
+
+	return trailers
+}
+
+// appendTrailers adds to the end of the message the trailers that the message doesn't already contain.
+func appendTrailers(message string, trailers []string) string {
+	var missing []string
+	for _, trailer := range trailers {
+		if !strings.Contains(message, trailer) {
+			missing = append(missing, trailer)
+		}
+	}
+
+	if len(missing) == 0 {
+		return message
+	}
+
+	if message == "" {
+		return strings.Join(missing, "\n")
+	}
+
+	// continue the trailer block if the message already ends with one.
+	separator := "\n\n"
+	if trailerRegex.MatchString(message[strings.LastIndex(message, "\n")+1:]) {
+		separator = "\n"
+	}
+
+	return message + separator + strings.Join(missing, "\n")
+}
+
+func containsPlaceholder(placeholder string, templates ...string) bool {
+	for _, template := range templates {
+		if strings.Contains(template, placeholder) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergetemplate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git"
+)
+
+func TestCoAuthorTrailers(t *testing.T) {
+	commit := func(name, email string) git.Commit {
+		return git.Commit{Author: git.Signature{Identity: git.Identity{Name: name, Email: email}}}
+	}
+
+	tests := []struct {
+		name         string
+		commits      []git.Commit
+		excludeEmail string
+		exp          []string
+	}{
+		{
+			name:         "no-commits",
+			excludeEmail: "author@example.com",
+		},
+		{
+			name: "only-author",
+			commits: []git.Commit{
+				commit("Author", "author@example.com"),
+				commit("Author", "Author@Example.com"),
+			},
+			excludeEmail: "author@example.com",
+		},
+		{
+			name: "distinct-oldest-first",
+			commits: []git.Commit{
+				commit("Second", "second@example.com"),
+				commit("First", "first@example.com"),
+				commit("Author", "author@example.com"),
+				commit("Second", "SECOND@example.com"),
+			},
+			excludeEmail: "author@example.com",
+			exp: []string{
+				"Co-authored-by: Second <SECOND@example.com>",
+				"Co-authored-by: First <first@example.com>",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trailers := coAuthorTrailers(test.commits, test.excludeEmail)
+			if !reflect.DeepEqual(trailers, test.exp) {
+				t.Errorf("expected %v, got %v", test.exp, trailers)
+			}
+		})
+	}
+}
+
+func TestAppendTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		trailers []string
+		exp      string
+	}{
+		{
+			name:    "no-trailers",
+			message: "Description",
+			exp:     "Description",
+		},
+		{
+			name:     "empty-message",
+			trailers: []string{"Co-authored-by: A <a@example.com>"},
+			exp:      "Co-authored-by: A <a@example.com>",
+		},
+		{
+			name:     "append",
+			message:  "Description",
+			trailers: []string{"Co-authored-by: A <a@example.com>", "Co-authored-by: B <b@example.com>"},
+			exp:      "Description\n\nCo-authored-by: A <a@example.com>\nCo-authored-by: B <b@example.com>",
+		},
+		{
+			name:     "already-present",
+			message:  "Description\n\nCo-authored-by: A <a@example.com>",
+			trailers: []string{"Co-authored-by: A <a@example.com>", "Co-authored-by: B <b@example.com>"},
+			exp:      "Description\n\nCo-authored-by: A <a@example.com>\nCo-authored-by: B <b@example.com>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message := appendTrailers(test.message, test.trailers)
+			if message != test.exp {
+				t.Errorf("expected %q, got %q", test.exp, message)
+			}
+		})
+	}
+}
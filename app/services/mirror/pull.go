@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pullMirrorRefSpecs are the references that are synced from the remote repository.
+// The references that don't exist on the remote repository anymore are deleted.
+var pullMirrorRefSpecs = []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"}
+
+type pullMirrorsJob struct {
+	service *Service
+}
+
+// Handle is the pull mirror sync background job handler.
+// It syncs all pull mirrors whose sync interval passed with their remote repositories.
+func (j *pullMirrorsJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !j.service.config.Enabled {
+		return "", nil
+	}
+
+	mirrors, err := j.service.pullMirrorStore.ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull mirrors: %w", err)
+	}
+
+	now := time.Now()
+	dueMirrors := make([]*types.PullMirror, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		if isPullMirrorSyncDue(mirror, j.service.config.PullInterval, now) {
+			dueMirrors = append(dueMirrors, mirror)
+		}
+	}
+
+	runWorkers(ctx, j.service.config.NumWorkers, dueMirrors, func(ctx context.Context, mirror *types.PullMirror) {
+		// errors are stored as the mirror status and logged, they shouldn't fail the whole job.
+		_ = j.service.SyncPullMirror(ctx, mirror)
+	})
+
+	return "", nil
+}
+
+// isPullMirrorSyncDue returns true if the sync interval of the pull mirror passed since its last sync.
+// The mirrors are checked periodically, so a mirror that is due shortly is synced as well,
+// otherwise it would be synced only by the next check.
+func isPullMirrorSyncDue(mirror *types.PullMirror, defaultInterval time.Duration, now time.Time) bool {
+	const tolerance = time.Minute
+
+	interval := defaultInterval
+	if mirror.SyncIntervalMinutes > 0 {
+		interval = time.Duration(mirror.SyncIntervalMinutes) * time.Minute
+	}
+
+	return now.Sub(time.UnixMilli(mirror.LastSync)) >= interval-tolerance
+}
+
+// SyncPullMirror syncs all branches and tags of the repository from its remote repository
+// and stores the outcome as the last sync status of the pull mirror.
+// The post-receive githook is executed for all updated references, so branch and tag events are triggered.
+func (s *Service) SyncPullMirror(ctx context.Context, mirror *types.PullMirror) error {
+	log := log.Ctx(ctx).With().Int64("repo.id", mirror.RepoID).Logger()
+
+	errSync := s.syncPullMirror(ctx, mirror)
+
+	mirror.LastSync = time.Now().UnixMilli()
+	mirror.LastSyncStatus = enum.MirrorSyncStatusSuccess
+	mirror.LastSyncError = ""
+	if errSync != nil {
+		log.Warn().Err(errSync).Msg("failed to sync pull mirror")
+
+		mirror.LastSyncStatus = enum.MirrorSyncStatusFailed
+		mirror.LastSyncError = errSync.Error()
+	}
+
+	err := s.pullMirrorStore.UpdateSyncStatus(ctx, mirror)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to update pull mirror sync status")
+		return fmt.Errorf("failed to update pull mirror sync status: %w", err)
+	}
+
+	return errSync
+}
+
+func (s *Service) syncPullMirror(ctx context.Context, mirror *types.PullMirror) error {
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.Importing {
+		return errors.New("repository is being imported")
+	}
+
+	sourceURL, password, err := s.sourceURLWithAuth(mirror)
+	if err != nil {
+		return err
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		systemPrincipal.ID,
+		false,
+		true,
+		false,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	syncOut, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams: git.WriteParams{
+			Actor: git.Identity{
+				Name:  systemPrincipal.DisplayName,
+				Email: systemPrincipal.Email,
+			},
+			RepoUID: repo.GitUID,
+			EnvVars: envVars,
+		},
+		Source:            sourceURL,
+		CreateIfNotExists: false,
+		RefSpecs:          pullMirrorRefSpecs,
+		RunPostReceive:    true,
+	})
+	if err != nil {
+		// git errors can contain the source URL, make sure the credentials don't leak into the sync status.
+		return errors.New(redactCredentials(err.Error(), sourceURL, mirror.URL, password))
+	}
+
+	if syncOut.DefaultBranch == "" || syncOut.DefaultBranch == repo.DefaultBranch {
+		return nil
+	}
+
+	_, err = s.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.DefaultBranch = syncOut.DefaultBranch
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update default branch of repository: %w", err)
+	}
+
+	return nil
+}
+
+// sourceURLWithAuth returns the URL of the remote repository with the credentials of the pull mirror.
+func (s *Service) sourceURLWithAuth(mirror *types.PullMirror) (string, string, error) {
+	if mirror.Username == "" && len(mirror.Password) == 0 {
+		return mirror.URL, "", nil
+	}
+
+	sourceURL, err := url.Parse(mirror.URL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse remote repository URL: %w", err)
+	}
+
+	var password string
+	if len(mirror.Password) > 0 {
+		password, err = s.encrypter.Decrypt(mirror.Password)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decrypt password: %w", err)
+		}
+	}
+
+	sourceURL.User = url.UserPassword(mirror.Username, password)
+
+	return sourceURL.String(), password, nil
+}
+
+func redactCredentials(s string, urlWithAuth string, urlWithoutAuth string, password string) string {
+	s = strings.ReplaceAll(s, urlWithAuth, urlWithoutAuth)
+	if password != "" {
+		escapedPassword := strings.TrimPrefix(url.UserPassword("", password).String(), ":")
+		s = strings.ReplaceAll(s, escapedPassword, "*****")
+		s = strings.ReplaceAll(s, password, "*****")
+	}
+
+	return s
+}
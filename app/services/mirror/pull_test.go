@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestIsPullMirrorSyncDue(t *testing.T) {
+	now := time.Now()
+	defaultInterval := 30 * time.Minute
+
+	tests := []struct {
+		name     string
+		mirror   *types.PullMirror
+		expected bool
+	}{
+		{
+			name:     "never synced",
+			mirror:   &types.PullMirror{},
+			expected: true,
+		},
+		{
+			name:     "default interval passed",
+			mirror:   &types.PullMirror{LastSync: now.Add(-31 * time.Minute).UnixMilli()},
+			expected: true,
+		},
+		{
+			name:     "default interval passes shortly",
+			mirror:   &types.PullMirror{LastSync: now.Add(-29*time.Minute - 30*time.Second).UnixMilli()},
+			expected: true,
+		},
+		{
+			name:     "default interval not passed",
+			mirror:   &types.PullMirror{LastSync: now.Add(-20 * time.Minute).UnixMilli()},
+			expected: false,
+		},
+		{
+			name: "mirror interval passed",
+			mirror: &types.PullMirror{
+				SyncIntervalMinutes: 10,
+				LastSync:            now.Add(-20 * time.Minute).UnixMilli(),
+			},
+			expected: true,
+		},
+		{
+			name: "mirror interval not passed",
+			mirror: &types.PullMirror{
+				SyncIntervalMinutes: 120,
+				LastSync:            now.Add(-60 * time.Minute).UnixMilli(),
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isPullMirrorSyncDue(test.mirror, defaultInterval, now); got != test.expected {
+				t.Errorf("expected due=%t, got %t", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	pushMirrorMinRetryDelay = time.Minute
+	pushMirrorMaxRetryDelay = 6 * time.Hour
+)
+
+// pushMirrorRefSpecs are the references that are pushed to the remote repository.
+// The references of the remote repository that don't exist in the repository anymore are deleted.
+var pushMirrorRefSpecs = []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"}
+
+type pushMirrorsJob struct {
+	service *Service
+}
+
+// Handle is the push mirror sync background job handler.
+// It pushes all repositories to their push mirrors, except for push mirrors that are waiting for a retry.
+func (j *pushMirrorsJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !j.service.config.Enabled {
+		return "", nil
+	}
+
+	mirrors, err := j.service.pushMirrorStore.ListDue(ctx, time.Now().UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	runWorkers(ctx, j.service.config.NumWorkers, mirrors, func(ctx context.Context, mirror *types.PushMirror) {
+		// errors are stored as the mirror status and logged, they shouldn't fail the whole job.
+		_ = j.service.SyncPushMirror(ctx, mirror)
+	})
+
+	return "", nil
+}
+
+// SyncPushMirror pushes all branches and tags of the repository to the remote repository of the push mirror
+// and stores the outcome as the last sync status of the push mirror.
+// Failed pushes are retried by the periodic sync job with an exponential backoff.
+func (s *Service) SyncPushMirror(ctx context.Context, mirror *types.PushMirror) error {
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", mirror.RepoID).
+		Str("push_mirror.identifier", mirror.Identifier).
+		Logger()
+
+	// the sync time is the start of the push, all changes before it are part of the push.
+	syncStarted := time.Now()
+
+	errSync := s.syncPushMirror(ctx, mirror)
+
+	mirror.LastSync = syncStarted.UnixMilli()
+	mirror.LastSyncStatus = enum.MirrorSyncStatusSuccess
+	mirror.LastSyncError = ""
+	mirror.FailedAttempts = 0
+	mirror.NextRetry = 0
+	if errSync != nil {
+		log.Warn().Err(errSync).Msg("failed to sync push mirror")
+
+		mirror.LastSyncStatus = enum.MirrorSyncStatusFailed
+		mirror.LastSyncError = errSync.Error()
+		mirror.FailedAttempts++
+		mirror.NextRetry = time.Now().Add(pushMirrorRetryDelay(mirror.FailedAttempts)).UnixMilli()
+	}
+
+	err := s.pushMirrorStore.UpdateSyncStatus(ctx, mirror)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to update push mirror sync status")
+		return fmt.Errorf("failed to update push mirror sync status: %w", err)
+	}
+
+	return errSync
+}
+
+func (s *Service) syncPushMirror(ctx context.Context, mirror *types.PushMirror) error {
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.Importing {
+		return errors.New("repository is being imported")
+	}
+
+	remoteURL, password, err := s.remoteURLWithAuth(ctx, repo, mirror)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		RemoteURL:  remoteURL,
+		RefSpecs:   pushMirrorRefSpecs,
+	})
+	if err != nil {
+		// git errors can contain the remote URL, make sure the credentials don't leak into the sync status.
+		return errors.New(redactCredentials(err.Error(), remoteURL, mirror.URL, password))
+	}
+
+	return nil
+}
+
+// remoteURLWithAuth returns the URL of the remote repository with the credentials of the push mirror.
+// The password is the data of the secret of the push mirror, which has to be in the parent space of the repository.
+func (s *Service) remoteURLWithAuth(
+	ctx context.Context,
+	repo *types.Repository,
+	mirror *types.PushMirror,
+) (string, string, error) {
+	if mirror.SecretIdentifier == "" {
+		return mirror.URL, "", nil
+	}
+
+	secret, err := s.secretStore.FindByIdentifier(ctx, repo.ParentID, mirror.SecretIdentifier)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find secret '%s': %w", mirror.SecretIdentifier, err)
+	}
+
+	password, err := s.encrypter.Decrypt([]byte(secret.Data))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt secret '%s': %w", mirror.SecretIdentifier, err)
+	}
+
+	remoteURL, err := url.Parse(mirror.URL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse remote repository URL: %w", err)
+	}
+
+	remoteURL.User = url.UserPassword(mirror.Username, password)
+
+	return remoteURL.String(), password, nil
+}
+
+// pushMirrorRetryDelay returns the delay before the next retry of a push mirror that failed the provided times.
+func pushMirrorRetryDelay(failedAttempts int) time.Duration {
+	delay := pushMirrorMinRetryDelay
+	for i := 1; i < failedAttempts && delay < pushMirrorMaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > pushMirrorMaxRetryDelay {
+		delay = pushMirrorMaxRetryDelay
+	}
+
+	return delay
+}
+
+// syncPushMirrorsAfterEvent pushes the repository to all its push mirrors after a change of a reference.
+// Push mirrors that are waiting for a retry and push mirrors that were synced after the event are skipped.
+func (s *Service) syncPushMirrorsAfterEvent(ctx context.Context, repoID int64, eventTime time.Time) error {
+	mirrors, err := s.pushMirrorStore.List(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list push mirrors of repository: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	for _, mirror := range mirrors {
+		if mirror.NextRetry > now {
+			continue
+		}
+
+		if mirror.LastSyncStatus == enum.MirrorSyncStatusSuccess && mirror.LastSync >= eventTime.UnixMilli() {
+			continue
+		}
+
+		// errors are stored as the mirror status and logged, the periodic sync job retries failed pushes.
+		_ = s.SyncPushMirror(ctx, mirror)
+	}
+
+	return nil
+}
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.syncPushMirrorsAfterEvent(ctx, event.Payload.RepoID, event.Timestamp)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.syncPushMirrorsAfterEvent(ctx, event.Payload.RepoID, event.Timestamp)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.syncPushMirrorsAfterEvent(ctx, event.Payload.RepoID, event.Timestamp)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	return s.syncPushMirrorsAfterEvent(ctx, event.Payload.RepoID, event.Timestamp)
+}
+
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	return s.syncPushMirrorsAfterEvent(ctx, event.Payload.RepoID, event.Timestamp)
+}
+
+func (s *Service) handleEventTagDeleted(ctx context.Context,
+	event *events.Event[*gitevents.TagDeletedPayload]) error {
+	return s.syncPushMirrorsAfterEvent(ctx, event.Payload.RepoID, event.Timestamp)
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/stream"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePullMirrorSync = "repo-pull-mirror-sync"
+	jobTypePushMirrorSync = "repo-push-mirror-sync"
+
+	eventsReaderGroupName = "gitness:mirror"
+)
+
+type Config struct {
+	Enabled  bool
+	PullCron string
+	// PullInterval is the sync interval of the pull mirrors that don't define their own sync interval.
+	PullInterval    time.Duration
+	PushCron        string
+	MaxDuration     time.Duration
+	NumWorkers      int
+	EventReaderName string
+}
+
+// Service keeps repository mirrors in sync with their remote repositories.
+type Service struct {
+	config          Config
+	urlProvider     gitnessurl.Provider
+	git             git.Interface
+	encrypter       encrypt.Encrypter
+	repoStore       store.RepoStore
+	secretStore     store.SecretStore
+	pullMirrorStore store.PullMirrorStore
+	pushMirrorStore store.PushMirrorStore
+	scheduler       *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	urlProvider gitnessurl.Provider,
+	git git.Interface,
+	encrypter encrypt.Encrypter,
+	repoStore store.RepoStore,
+	secretStore store.SecretStore,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	service := &Service{
+		config:          config,
+		urlProvider:     urlProvider,
+		git:             git,
+		encrypter:       encrypter,
+		repoStore:       repoStore,
+		secretStore:     secretStore,
+		pullMirrorStore: pullMirrorStore,
+		pushMirrorStore: pushMirrorStore,
+		scheduler:       scheduler,
+	}
+
+	err := executor.Register(jobTypePullMirrorSync, &pullMirrorsJob{service: service})
+	if err != nil {
+		return nil, err
+	}
+
+	err = executor.Register(jobTypePushMirrorSync, &pushMirrorsJob{service: service})
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.Enabled {
+		return service, nil
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.NumWorkers),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					// failed pushes are retried by the periodic push mirror sync.
+					stream.WithMaxRetries(0),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+			_ = r.RegisterTagDeleted(service.handleEventTagDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypePullMirrorSync, jobTypePullMirrorSync,
+		s.config.PullCron, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pull mirror sync: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypePushMirrorSync, jobTypePushMirrorSync,
+		s.config.PushCron, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for push mirror sync: %w", err)
+	}
+
+	return nil
+}
+
+// runWorkers calls the sync function for all provided mirrors using the configured number of workers.
+func runWorkers[T any](ctx context.Context, numWorkers int, mirrors []T, syncFn func(context.Context, T)) {
+	log.Ctx(ctx).Info().Msgf("start sync of %d mirrors", len(mirrors))
+
+	var wg sync.WaitGroup
+	taskCh := make(chan T)
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for mirror := range taskCh {
+				syncFn(ctx, mirror)
+			}
+		}()
+	}
+
+loop:
+	for _, mirror := range mirrors {
+		select {
+		case <-ctx.Done():
+			break loop
+		case taskCh <- mirror:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+}
@@ -51,6 +51,11 @@ func (v *Branch) MergeVerify(
 		violations[i].Bypassed = bypassed
 	}
 
+	// users that bypass the rule merge directly, without going through the merge queue.
+	if bypassed {
+		out.UseMergeQueue = false
+	}
+
 	return
 }
 
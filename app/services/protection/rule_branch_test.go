@@ -156,6 +156,42 @@ func TestBranch_MergeVerify(t *testing.T) {
 			},
 			expVs: []types.RuleViolations{},
 		},
+		{
+			name: "merge-queue",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{user.ID}},
+				PullReq: DefPullReq{
+					Merge: DefMerge{UseMergeQueue: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:       user,
+				AllowBypass: false,
+			},
+			expOut: MergeVerifyOutput{
+				UseMergeQueue:  true,
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "merge-queue-bypass",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{user.ID}},
+				PullReq: DefPullReq{
+					Merge: DefMerge{UseMergeQueue: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+			},
+			expOut: MergeVerifyOutput{
+				UseMergeQueue:  false,
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{},
+		},
 	}
 
 	ctx := context.Background()
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypePush types.RuleType = "push"
+
+// Push implements protection rules for the rule type TypePush.
+// Push rules verify the content of the commits that are pushed to matching branches and tags.
+type Push struct {
+	Bypass DefBypass `json:"bypass"`
+	Push   DefPush   `json:"push"`
+}
+
+var (
+	// ensures that the Push type implements Definition interface.
+	_ Definition = (*Push)(nil)
+)
+
+// MergeVerify doesn't restrict merging of pull requests, push rules only apply to pushed commits.
+func (*Push) MergeVerify(
+	context.Context,
+	MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	return MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}, nil, nil
+}
+
+// RequiredChecks doesn't require any status checks, push rules only apply to pushed commits.
+func (*Push) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *Push) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if in.RefAction == RefActionDelete || len(in.RefNames) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Push.RefChangeVerify(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return violations, nil
+}
+
+func (v *Push) UserIDs() ([]int64, error) {
+	return v.Bypass.UserIDs, nil
+}
+
+func (v *Push) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.Push.Sanitize(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	return nil
+}
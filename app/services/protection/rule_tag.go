@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypeTag types.RuleType = "tag"
+
+// Tag implements protection rules for the rule type TypeTag.
+type Tag struct {
+	Bypass    DefBypass       `json:"bypass"`
+	Lifecycle DefTagLifecycle `json:"lifecycle"`
+}
+
+var (
+	// ensures that the Tag type implements Definition interface.
+	_ Definition = (*Tag)(nil)
+)
+
+// MergeVerify doesn't restrict merging of pull requests, tag rules only apply to tags.
+func (*Tag) MergeVerify(
+	context.Context,
+	MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	return MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}, nil, nil
+}
+
+// RequiredChecks doesn't require any status checks, tag rules only apply to tags.
+func (*Tag) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *Tag) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if in.RefType != RefTypeTag || len(in.RefNames) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
+func (v *Tag) UserIDs() ([]int64, error) {
+	userIDs := slices.Clone(v.Bypass.UserIDs)
+	for _, userID := range v.Lifecycle.CreateUserIDs {
+		if !slices.Contains(userIDs, userID) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	return userIDs, nil
+}
+
+func (v *Tag) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.Lifecycle.Sanitize(); err != nil {
+		return fmt.Errorf("lifecycle: %w", err)
+	}
+
+	return nil
+}
@@ -69,6 +69,26 @@ func IsCritical(violations []types.RuleViolations) bool {
 	return false
 }
 
+// BypassedRules returns all rules whose violations have been bypassed.
+func BypassedRules(violations []types.RuleViolations) []types.RuleInfo {
+	var rules []types.RuleInfo
+	for i := range violations {
+		if violations[i].Bypassed && len(violations[i].Violations) > 0 {
+			rules = append(rules, violations[i].Rule)
+		}
+	}
+	return rules
+}
+
+// RuleIdentifiers returns identifiers of the provided rules.
+func RuleIdentifiers(rules []types.RuleInfo) []string {
+	identifiers := make([]string, len(rules))
+	for i := range rules {
+		identifiers[i] = rules[i].Identifier
+	}
+	return identifiers
+}
+
 // NewManager creates new protection Manager.
 func NewManager(ruleStore store.RuleStore) *Manager {
 	return &Manager{
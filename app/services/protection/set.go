@@ -52,6 +52,7 @@ func (s ruleSet) MergeVerify(
 
 			violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
 			out.DeleteSourceBranch = out.DeleteSourceBranch || rOut.DeleteSourceBranch
+			out.UseMergeQueue = out.UseMergeQueue || rOut.UseMergeQueue
 			out.AllowedMethods = intersectSorted(out.AllowedMethods, rOut.AllowedMethods)
 
 			return nil
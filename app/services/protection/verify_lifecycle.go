@@ -16,6 +16,7 @@ package protection
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/types"
 )
@@ -33,6 +34,14 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+
+		// UnverifiedCommits returns the new commits of the ref that don't carry a verified signature.
+		// It's optional and only provided if the ref change introduces new commits (e.g. a git push).
+		UnverifiedCommits func(ctx context.Context, refName string) ([]string, error)
+
+		// NewCommits returns the details of the new commits of the ref.
+		// It's optional and only provided if the ref change introduces new commits (e.g. a git push).
+		NewCommits func(ctx context.Context, refName string) ([]PushCommit, error)
 	}
 
 	RefType int
@@ -40,9 +49,10 @@ type (
 	RefAction int
 
 	DefLifecycle struct {
-		CreateForbidden bool `json:"create_forbidden,omitempty"`
-		DeleteForbidden bool `json:"delete_forbidden,omitempty"`
-		UpdateForbidden bool `json:"update_forbidden,omitempty"`
+		CreateForbidden      bool `json:"create_forbidden,omitempty"`
+		DeleteForbidden      bool `json:"delete_forbidden,omitempty"`
+		UpdateForbidden      bool `json:"update_forbidden,omitempty"`
+		RequireSignedCommits bool `json:"require_signed_commits,omitempty"`
 	}
 )
 
@@ -68,9 +78,11 @@ const (
 	codeLifecycleCreate = "lifecycle.create"
 	codeLifecycleDelete = "lifecycle.delete"
 	codeLifecycleUpdate = "lifecycle.update"
+
+	codeLifecycleSignedCommits = "lifecycle.signed_commits"
 )
 
-func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+func (v *DefLifecycle) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations types.RuleViolations
 
 	switch in.RefAction {
@@ -91,6 +103,21 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 		}
 	}
 
+	if v.RequireSignedCommits && in.RefAction != RefActionDelete && in.UnverifiedCommits != nil {
+		for _, refName := range in.RefNames {
+			unverified, err := in.UnverifiedCommits(ctx, refName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find unverified commits: %w", err)
+			}
+
+			if len(unverified) > 0 {
+				violations.Addf(codeLifecycleSignedCommits,
+					"Branch %q requires signed commits, but %d commit(s) don't have a verified signature (e.g. %s).",
+					refName, len(unverified), unverified[0])
+			}
+		}
+	}
+
 	if len(violations.Violations) > 0 {
 		return []types.RuleViolations{violations}, nil
 	}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+// DefTagLifecycle restricts creation, update (move) and deletion of tags.
+type DefTagLifecycle struct {
+	CreateForbidden bool `json:"create_forbidden,omitempty"`
+	DeleteForbidden bool `json:"delete_forbidden,omitempty"`
+	UpdateForbidden bool `json:"update_forbidden,omitempty"`
+
+	// CreateUserIDs, if not empty, restricts creation of tags to the listed users.
+	CreateUserIDs []int64 `json:"create_user_ids,omitempty"`
+}
+
+// ensures that the DefTagLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefTagLifecycle)(nil)
+	_ RefChangeVerifier = (*DefTagLifecycle)(nil)
+)
+
+const (
+	codeLifecycleCreateRestricted = "lifecycle.create_restricted"
+)
+
+func (v *DefTagLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		switch in.RefAction {
+		case RefActionCreate:
+			if v.CreateForbidden {
+				violations.Addf(codeLifecycleCreate,
+					"Creation of tag %q is not allowed.", refName)
+			} else if len(v.CreateUserIDs) > 0 && (in.Actor == nil || !slices.Contains(v.CreateUserIDs, in.Actor.ID)) {
+				violations.Addf(codeLifecycleCreateRestricted,
+					"Creation of tag %q is restricted to specific users.", refName)
+			}
+		case RefActionDelete:
+			if v.DeleteForbidden {
+				violations.Addf(codeLifecycleDelete,
+					"Delete of tag %q is not allowed.", refName)
+			}
+		case RefActionUpdate:
+			if v.UpdateForbidden {
+				violations.Addf(codeLifecycleUpdate,
+					"Moving tag %q is not allowed.", refName)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefTagLifecycle) Sanitize() error {
+	if err := validateIDSlice(v.CreateUserIDs); err != nil {
+		return fmt.Errorf("create user IDs error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDefTagLifecycle_RefChangeVerify(t *testing.T) {
+	const refName = "v1.0.0"
+	tests := []struct {
+		name      string
+		def       DefTagLifecycle
+		action    RefAction
+		actorID   int64
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "lifecycle.create-fail",
+			def:       DefTagLifecycle{CreateForbidden: true},
+			action:    RefActionCreate,
+			expCodes:  []string{"lifecycle.create"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:      "lifecycle.create_restricted-fail",
+			def:       DefTagLifecycle{CreateUserIDs: []int64{1, 2}},
+			action:    RefActionCreate,
+			actorID:   3,
+			expCodes:  []string{"lifecycle.create_restricted"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:    "lifecycle.create_restricted-success",
+			def:     DefTagLifecycle{CreateUserIDs: []int64{1, 2}},
+			action:  RefActionCreate,
+			actorID: 2,
+		},
+		{
+			name:      "lifecycle.delete-fail",
+			def:       DefTagLifecycle{DeleteForbidden: true},
+			action:    RefActionDelete,
+			expCodes:  []string{"lifecycle.delete"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:      "lifecycle.update-fail",
+			def:       DefTagLifecycle{UpdateForbidden: true},
+			action:    RefActionUpdate,
+			expCodes:  []string{"lifecycle.update"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:   "lifecycle.update-success",
+			def:    DefTagLifecycle{CreateForbidden: true, DeleteForbidden: true},
+			action: RefActionUpdate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				Actor:     &types.Principal{ID: test.actorID},
+				RefNames:  []string{refName},
+				RefAction: test.action,
+				RefType:   RefTypeTag,
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
@@ -26,11 +26,12 @@ import (
 func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 	const refName = "a"
 	tests := []struct {
-		name      string
-		def       DefLifecycle
-		action    RefAction
-		expCodes  []string
-		expParams [][]any
+		name       string
+		def        DefLifecycle
+		action     RefAction
+		unverified []string
+		expCodes   []string
+		expParams  [][]any
 	}{
 		{
 			name: "empty",
@@ -56,6 +57,25 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 			expCodes:  []string{"lifecycle.update"},
 			expParams: [][]any{{refName}},
 		},
+		{
+			name:       "lifecycle.signed_commits-fail",
+			def:        DefLifecycle{RequireSignedCommits: true},
+			action:     RefActionUpdate,
+			unverified: []string{"abc", "def"},
+			expCodes:   []string{"lifecycle.signed_commits"},
+			expParams:  [][]any{{refName, 2, "abc"}},
+		},
+		{
+			name:   "lifecycle.signed_commits-success",
+			def:    DefLifecycle{RequireSignedCommits: true},
+			action: RefActionCreate,
+		},
+		{
+			name:       "lifecycle.signed_commits-delete",
+			def:        DefLifecycle{RequireSignedCommits: true},
+			action:     RefActionDelete,
+			unverified: []string{"abc"},
+		},
 	}
 
 	for _, test := range tests {
@@ -64,6 +84,9 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 				RefNames:  []string{refName},
 				RefAction: test.action,
 				RefType:   RefTypeBranch,
+				UnverifiedCommits: func(context.Context, string) ([]string, error) {
+					return test.unverified, nil
+				},
 			}
 
 			if err := test.def.Sanitize(); err != nil {
@@ -45,11 +45,18 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+		Labels       []*types.Label
+
+		// UnverifiedCommits returns the commits of the pull request that don't carry a verified signature.
+		// It's optional and only called if a rule requires signed commits.
+		UnverifiedCommits func(ctx context.Context) ([]string, error)
 	}
 
 	MergeVerifyOutput struct {
 		DeleteSourceBranch bool
-		AllowedMethods     []enum.MergeMethod
+		// UseMergeQueue requires that the pull request is merged through the merge queue of the target branch.
+		UseMergeQueue  bool
+		AllowedMethods []enum.MergeMethod
 	}
 
 	RequiredChecksInput struct {
@@ -87,17 +94,28 @@ const (
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
+
+	codePullReqLabelsRequire = "pullreq.labels.require"
+	codePullReqLabelsForbid  = "pullreq.labels.forbid"
+
+	codePullReqCommitsRequireSigned       = "pullreq.commits.require_signed"
+	codePullReqCommitsRequireSignedMethod = "pullreq.commits.require_signed:merge_method"
 )
 
+// signedCommitsMergeMethods are the merge methods that keep the commits of the pull request as they are (sorted).
+// All other methods create new commits from them, which don't carry the signatures of the original commits.
+var signedCommitsMergeMethods = []enum.MergeMethod{enum.MergeMethodFastForward, enum.MergeMethodMerge}
+
 //nolint:gocognit // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
 	var violations types.RuleViolations
 
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
+	out.UseMergeQueue = v.Merge.UseMergeQueue
 
 	// pullreq.approvals
 
@@ -205,6 +223,43 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	// pullreq.labels
+
+	for _, name := range v.Labels.RequireLabels {
+		if !containsLabel(in.Labels, name) {
+			violations.Addf(codePullReqLabelsRequire,
+				"The pull request must have the label %q.", name)
+		}
+	}
+
+	for _, name := range v.Labels.ForbidLabels {
+		if containsLabel(in.Labels, name) {
+			violations.Addf(codePullReqLabelsForbid,
+				"The pull request must not have the label %q.", name)
+		}
+	}
+
+	// pullreq.commits
+
+	if v.Commits.RequireSigned && in.UnverifiedCommits != nil {
+		unverified, err := in.UnverifiedCommits(ctx)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to find unverified commits: %w", err)
+		}
+
+		if len(unverified) > 0 {
+			violations.Addf(codePullReqCommitsRequireSigned,
+				"All commits must have a verified signature, but %d commit(s) don't (e.g. %s).",
+				len(unverified), unverified[0])
+		}
+	}
+
+	if v.Commits.RequireSigned && in.Method != "" && !slices.Contains(signedCommitsMergeMethods, in.Method) {
+		violations.Addf(codePullReqCommitsRequireSignedMethod,
+			"The merge strategy %q creates commits without signatures. Allowed strategies are %v.",
+			in.Method, signedCommitsMergeMethods)
+	}
+
 	// pullreq.merge
 
 	if in.Method == "" {
@@ -226,6 +281,10 @@ func (v *DefPullReq) MergeVerify(
 		}
 	}
 
+	if v.Commits.RequireSigned && in.Method == "" {
+		out.AllowedMethods = intersectSorted(slices.Clone(out.AllowedMethods), signedCommitsMergeMethods)
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -323,6 +382,7 @@ func (c *DefStatusChecks) Sanitize() error {
 type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
+	UseMergeQueue     bool               `json:"use_merge_queue,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -344,19 +404,92 @@ func (v *DefMerge) Sanitize() error {
 	return nil
 }
 
-type DefPush struct {
-	Block bool `json:"block,omitempty"`
+// DefCommits contains the requirements for the commits of a pull request.
+type DefCommits struct {
+	// RequireSigned requires that all commits of the pull request carry a verified signature.
+	// Only merge strategies that keep the commits as they are can be used to merge the pull request.
+	RequireSigned bool `json:"require_signed,omitempty"`
+}
+
+func (DefCommits) Sanitize() error {
+	return nil
 }
 
-func (v *DefPush) Sanitize() error {
+// DefLabels contains label names that a pull request must or must not have to be merged.
+// A label name without a value ("priority") matches the label key with any value,
+// and a name with a value ("priority=high") matches only the exact scoped label.
+type DefLabels struct {
+	RequireLabels []string `json:"require_labels,omitempty"`
+	ForbidLabels  []string `json:"forbid_labels,omitempty"`
+}
+
+func (v *DefLabels) Sanitize() error {
+	var err error
+
+	if v.RequireLabels, err = sanitizeLabelNames(v.RequireLabels); err != nil {
+		return fmt.Errorf("required labels error: %w", err)
+	}
+
+	if v.ForbidLabels, err = sanitizeLabelNames(v.ForbidLabels); err != nil {
+		return fmt.Errorf("forbidden labels error: %w", err)
+	}
+
+	for _, required := range v.RequireLabels {
+		for _, forbidden := range v.ForbidLabels {
+			if strings.EqualFold(required, forbidden) {
+				return fmt.Errorf("label %q can't be both required and forbidden", required)
+			}
+		}
+	}
+
 	return nil
 }
 
+func sanitizeLabelNames(names []string) ([]string, error) {
+	m := make(map[string]struct{}, len(names))
+	for i := range names {
+		key, value, hasValue := strings.Cut(names[i], "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if key == "" || (hasValue && value == "") {
+			return nil, fmt.Errorf("invalid label name: %q", names[i])
+		}
+
+		names[i] = key
+		if hasValue {
+			names[i] = key + "=" + value
+		}
+
+		lower := strings.ToLower(names[i])
+		if _, ok := m[lower]; ok {
+			return nil, fmt.Errorf("duplicate entry in label list: %s", names[i])
+		}
+
+		m[lower] = struct{}{}
+	}
+
+	return names, nil
+}
+
+// containsLabel returns true if any of the labels matches the label name.
+func containsLabel(labels []*types.Label, name string) bool {
+	for _, label := range labels {
+		if label.Matches(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
 	StatusChecks DefStatusChecks `json:"status_checks"`
 	Merge        DefMerge        `json:"merge"`
+	Labels       DefLabels       `json:"labels"`
+	Commits      DefCommits      `json:"commits"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -376,6 +509,14 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.Labels.Sanitize(); err != nil {
+		return fmt.Errorf("labels: %w", err)
+	}
+
+	if err := v.Commits.Sanitize(); err != nil {
+		return fmt.Errorf("commits: %w", err)
+	}
+
 	return nil
 }
 
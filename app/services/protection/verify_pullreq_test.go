@@ -317,6 +317,36 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expOut: MergeVerifyOutput{},
 		},
+		{
+			name: codePullReqMergeStrategiesAllowed + "-fast-forward-fail",
+			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
+				enum.MergeMethodFastForward,
+				enum.MergeMethodRebaseMerge,
+			}}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodRebase,
+			},
+			expCodes: []string{codePullReqMergeStrategiesAllowed},
+			expParams: [][]any{{
+				enum.MergeMethodRebase,
+				[]enum.MergeMethod{
+					enum.MergeMethodFastForward,
+					enum.MergeMethodRebaseMerge,
+				}},
+			},
+			expOut: MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqMergeStrategiesAllowed + "-fast-forward-success",
+			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
+				enum.MergeMethodFastForward,
+				enum.MergeMethodRebaseMerge,
+			}}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodFastForward,
+			},
+			expOut: MergeVerifyOutput{},
+		},
 		{
 			name: codePullReqMergeDeleteBranch,
 			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
@@ -328,6 +358,17 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods:     nil,
 			},
 		},
+		{
+			name: "merge-queue",
+			def:  DefPullReq{Merge: DefMerge{UseMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				UseMergeQueue:  true,
+				AllowedMethods: nil,
+			},
+		},
 		{
 			name: codePullReqApprovalReqChangeRequested + "-true",
 			def: DefPullReq{
@@ -393,6 +434,101 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			expParams: [][]any{{"John"}},
 			expOut:    MergeVerifyOutput{},
 		},
+		{
+			name: codePullReqLabelsRequire + "-fail",
+			def:  DefPullReq{Labels: DefLabels{RequireLabels: []string{"reviewed", "priority=high"}}},
+			in: MergeVerifyInput{
+				Labels: []*types.Label{
+					{Key: "reviewed"},
+					{Key: "priority", Value: "low"},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqLabelsRequire},
+			expParams: [][]any{{"priority=high"}},
+			expOut:    MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqLabelsRequire + "-success",
+			def:  DefPullReq{Labels: DefLabels{RequireLabels: []string{"reviewed", "priority"}}},
+			in: MergeVerifyInput{
+				Labels: []*types.Label{
+					{Key: "Reviewed"},
+					{Key: "priority", Value: "low"},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqLabelsForbid + "-fail",
+			def:  DefPullReq{Labels: DefLabels{ForbidLabels: []string{"wip", "priority=low"}}},
+			in: MergeVerifyInput{
+				Labels: []*types.Label{
+					{Key: "priority", Value: "low"},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqLabelsForbid},
+			expParams: [][]any{{"priority=low"}},
+			expOut:    MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqLabelsForbid + "-success",
+			def:  DefPullReq{Labels: DefLabels{ForbidLabels: []string{"wip", "priority=low"}}},
+			in: MergeVerifyInput{
+				Labels: []*types.Label{
+					{Key: "priority", Value: "high"},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqCommitsRequireSigned + "-fail",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				UnverifiedCommits: func(context.Context) ([]string, error) {
+					return []string{"abc", "def"}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqCommitsRequireSigned},
+			expParams: [][]any{{2, "abc"}},
+			expOut:    MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqCommitsRequireSigned + "-success",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				UnverifiedCommits: func(context.Context) ([]string, error) {
+					return nil, nil
+				},
+				Method: enum.MergeMethodFastForward,
+			},
+			expOut: MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqCommitsRequireSignedMethod + "-fail",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				UnverifiedCommits: func(context.Context) ([]string, error) {
+					return nil, nil
+				},
+				Method: enum.MergeMethodSquash,
+			},
+			expCodes:  []string{codePullReqCommitsRequireSignedMethod},
+			expParams: [][]any{{enum.MergeMethodSquash, signedCommitsMergeMethods}},
+			expOut:    MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqCommitsRequireSigned + "-allowed-methods",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in:   MergeVerifyInput{},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: []enum.MergeMethod{enum.MergeMethodFastForward, enum.MergeMethodMerge},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+)
+
+type (
+	// PushCommit contains the details of a pushed commit that are verified by push rules.
+	PushCommit struct {
+		// SHA is empty for commits that are verified before they are created (e.g. commits created via the API).
+		SHA         string
+		AuthorEmail string
+		Message     string
+		Files       []PushCommitFile
+	}
+
+	// PushCommitFile contains the path and the size of a file added or modified by a pushed commit.
+	PushCommitFile struct {
+		Path string
+		Size int64
+	}
+
+	// DefPush restricts the content of the commits pushed to a branch or a tag.
+	DefPush struct {
+		// Block blocks all pushes that create or update a matching ref.
+		Block bool `json:"block,omitempty"`
+
+		// MaxBlobSize is the maximum size in bytes of a file added or modified by a commit.
+		MaxBlobSize int64 `json:"max_blob_size,omitempty"`
+
+		// ForbiddenPaths contains glob patterns of file paths that mustn't be added or modified.
+		// Patterns without a slash are matched against the file name only (e.g. "*.pem").
+		ForbiddenPaths []string `json:"forbidden_paths,omitempty"`
+
+		// CommitMessagePattern is a regular expression that all commit messages must match.
+		CommitMessagePattern string `json:"commit_message_pattern,omitempty"`
+
+		// AuthorEmailDomains, if not empty, restricts the commit author emails to the listed domains.
+		AuthorEmailDomains []string `json:"author_email_domains,omitempty"`
+
+		// RequireAuthorMatchesPusher requires the commit author email to match the email of the pusher.
+		RequireAuthorMatchesPusher bool `json:"require_author_matches_pusher,omitempty"`
+	}
+)
+
+// ensures that the DefPush type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefPush)(nil)
+	_ RefChangeVerifier = (*DefPush)(nil)
+)
+
+const (
+	codePushBlocked           = "push.blocked"
+	codePushMaxBlobSize       = "push.max_blob_size"
+	codePushForbiddenPath     = "push.forbidden_path"
+	codePushCommitMessage     = "push.commit_message"
+	codePushAuthorEmail       = "push.author_email_domain"
+	codePushAuthorNotPusher   = "push.author_not_pusher"
+	codePushTooManyViolations = "push.too_many_violations"
+)
+
+// maxPushViolations limits the number of reported violations so large pushes don't flood the git client.
+const maxPushViolations = 50
+
+func (v *DefPush) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if v.Block {
+		var violations types.RuleViolations
+		for _, refName := range in.RefNames {
+			violations.Addf(codePushBlocked, "Pushing to %q is not allowed.", refName)
+		}
+
+		return []types.RuleViolations{violations}, nil
+	}
+
+	if in.NewCommits == nil || v.isEmpty() {
+		return nil, nil
+	}
+
+	var messagePattern *regexp.Regexp
+	if v.CommitMessagePattern != "" {
+		var err error
+		if messagePattern, err = regexp.Compile(v.CommitMessagePattern); err != nil {
+			return nil, fmt.Errorf("failed to compile commit message pattern: %w", err)
+		}
+	}
+
+	var violations types.RuleViolations
+	var count int
+	addf := func(code, format string, params ...any) {
+		count++
+		if count <= maxPushViolations {
+			violations.Addf(code, format, params...)
+		}
+	}
+
+	for _, refName := range in.RefNames {
+		commits, err := in.NewCommits(ctx, refName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get new commits: %w", err)
+		}
+
+		for _, commit := range commits {
+			v.verifyCommit(commit, in.Actor, messagePattern, addf)
+		}
+	}
+
+	if count > maxPushViolations {
+		violations.Addf(codePushTooManyViolations,
+			"%d more push rule violation(s) are not shown.", count-maxPushViolations)
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefPush) verifyCommit(
+	commit PushCommit,
+	actor *types.Principal,
+	messagePattern *regexp.Regexp,
+	addf func(code, format string, params ...any),
+) {
+	if messagePattern != nil && !messagePattern.MatchString(strings.TrimSpace(commit.Message)) {
+		addf(codePushCommitMessage,
+			"%s: The commit message doesn't match the required pattern %q.",
+			commit.name(), v.CommitMessagePattern)
+	}
+
+	if len(v.AuthorEmailDomains) > 0 && !emailDomainAllowed(commit.AuthorEmail, v.AuthorEmailDomains) {
+		addf(codePushAuthorEmail,
+			"%s: The author email %q is not in one of the allowed domains: %s.",
+			commit.name(), commit.AuthorEmail, strings.Join(v.AuthorEmailDomains, ", "))
+	}
+
+	if v.RequireAuthorMatchesPusher && (actor == nil || !strings.EqualFold(commit.AuthorEmail, actor.Email)) {
+		addf(codePushAuthorNotPusher,
+			"%s: The author email %q doesn't match the email of the pusher.",
+			commit.name(), commit.AuthorEmail)
+	}
+
+	for _, file := range commit.Files {
+		if v.MaxBlobSize > 0 && file.Size > v.MaxBlobSize {
+			addf(codePushMaxBlobSize,
+				"%s: File %q has %d bytes, which exceeds the maximum allowed size of %d bytes.",
+				commit.name(), file.Path, file.Size, v.MaxBlobSize)
+		}
+
+		if pattern, forbidden := v.forbiddenPath(file.Path); forbidden {
+			addf(codePushForbiddenPath,
+				"%s: File %q matches the forbidden path pattern %q.",
+				commit.name(), file.Path, pattern)
+		}
+	}
+}
+
+// name returns the name of the commit used in violation messages.
+func (c PushCommit) name() string {
+	if c.SHA == "" {
+		return "The new commit"
+	}
+
+	return "Commit " + c.SHA
+}
+
+func (v *DefPush) isEmpty() bool {
+	return v.MaxBlobSize == 0 &&
+		len(v.ForbiddenPaths) == 0 &&
+		v.CommitMessagePattern == "" &&
+		len(v.AuthorEmailDomains) == 0 &&
+		!v.RequireAuthorMatchesPusher
+}
+
+// forbiddenPath returns the first forbidden path pattern that matches the provided file path.
+func (v *DefPush) forbiddenPath(filePath string) (string, bool) {
+	for _, pattern := range v.ForbiddenPaths {
+		name := filePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(filePath)
+		}
+
+		if patternMatches(pattern, name) {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+func emailDomainAllowed(email string, domains []string) bool {
+	idx := strings.LastIndexByte(email, '@')
+	if idx < 0 {
+		return false
+	}
+
+	emailDomain := email[idx+1:]
+	for _, domain := range domains {
+		if strings.EqualFold(emailDomain, domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *DefPush) Sanitize() error {
+	if v.MaxBlobSize < 0 {
+		return errors.New("max blob size must not be negative")
+	}
+
+	if len(v.ForbiddenPaths) > maxElements {
+		return errors.New("too many forbidden paths provided")
+	}
+
+	for _, pattern := range v.ForbiddenPaths {
+		if err := patternValidate(pattern); err != nil {
+			return fmt.Errorf("forbidden path %q: %w", pattern, err)
+		}
+	}
+
+	if v.CommitMessagePattern != "" {
+		if _, err := regexp.Compile(v.CommitMessagePattern); err != nil {
+			return fmt.Errorf("invalid commit message pattern: %w", err)
+		}
+	}
+
+	if len(v.AuthorEmailDomains) > maxElements {
+		return errors.New("too many author email domains provided")
+	}
+
+	for i, domain := range v.AuthorEmailDomains {
+		domain = strings.TrimPrefix(strings.TrimSpace(domain), "@")
+		if domain == "" || strings.Contains(domain, "@") {
+			return fmt.Errorf("invalid author email domain %q", v.AuthorEmailDomains[i])
+		}
+
+		v.AuthorEmailDomains[i] = domain
+	}
+
+	return nil
+}
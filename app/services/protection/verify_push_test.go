@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDefPush_RefChangeVerify(t *testing.T) {
+	const sha = "abc123"
+	const name = "Commit " + sha
+	tests := []struct {
+		name      string
+		def       DefPush
+		commit    PushCommit
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name: "empty",
+			commit: PushCommit{
+				SHA:         sha,
+				AuthorEmail: "other@example.org",
+				Files:       []PushCommitFile{{Path: "key.pem", Size: 1 << 20}},
+			},
+		},
+		{
+			name:      "push.blocked",
+			def:       DefPush{Block: true},
+			commit:    PushCommit{SHA: sha},
+			expCodes:  []string{"push.blocked"},
+			expParams: [][]any{{"main"}},
+		},
+		{
+			name: "push.max_blob_size-fail",
+			def:  DefPush{MaxBlobSize: 100},
+			commit: PushCommit{
+				SHA:   sha,
+				Files: []PushCommitFile{{Path: "a.txt", Size: 100}, {Path: "b.bin", Size: 101}},
+			},
+			expCodes:  []string{"push.max_blob_size"},
+			expParams: [][]any{{name, "b.bin", int64(101), int64(100)}},
+		},
+		{
+			name: "push.forbidden_path-fail",
+			def:  DefPush{ForbiddenPaths: []string{"*.pem", "secrets/**"}},
+			commit: PushCommit{
+				SHA: sha,
+				Files: []PushCommitFile{
+					{Path: "certs/server.pem"},
+					{Path: "secrets/prod/db.yaml"},
+					{Path: "docs/secrets/readme.md"},
+				},
+			},
+			expCodes: []string{"push.forbidden_path", "push.forbidden_path"},
+			expParams: [][]any{
+				{name, "certs/server.pem", "*.pem"},
+				{name, "secrets/prod/db.yaml", "secrets/**"},
+			},
+		},
+		{
+			name:      "push.commit_message-fail",
+			def:       DefPush{CommitMessagePattern: `^[A-Z]+-[0-9]+: `},
+			commit:    PushCommit{SHA: sha, Message: "fix the build\n"},
+			expCodes:  []string{"push.commit_message"},
+			expParams: [][]any{{name, `^[A-Z]+-[0-9]+: `}},
+		},
+		{
+			name:   "push.commit_message-success",
+			def:    DefPush{CommitMessagePattern: `^[A-Z]+-[0-9]+: `},
+			commit: PushCommit{SHA: sha, Message: "ABC-12: fix the build\n"},
+		},
+		{
+			name:      "push.author_email_domain-fail",
+			def:       DefPush{AuthorEmailDomains: []string{"@example.com"}},
+			commit:    PushCommit{SHA: sha, AuthorEmail: "user@example.org"},
+			expCodes:  []string{"push.author_email_domain"},
+			expParams: [][]any{{name, "user@example.org", "example.com"}},
+		},
+		{
+			name:   "push.author_email_domain-success",
+			def:    DefPush{AuthorEmailDomains: []string{"example.com"}},
+			commit: PushCommit{SHA: sha, AuthorEmail: "user@Example.COM"},
+		},
+		{
+			name:      "push.author_not_pusher-fail",
+			def:       DefPush{RequireAuthorMatchesPusher: true},
+			commit:    PushCommit{SHA: sha, AuthorEmail: "other@example.com"},
+			expCodes:  []string{"push.author_not_pusher"},
+			expParams: [][]any{{name, "other@example.com"}},
+		},
+		{
+			name:   "push.author_not_pusher-success",
+			def:    DefPush{RequireAuthorMatchesPusher: true},
+			commit: PushCommit{SHA: sha, AuthorEmail: "Pusher@example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				Actor:     &types.Principal{ID: 1, Email: "pusher@example.com"},
+				RefNames:  []string{"main"},
+				RefAction: RefActionUpdate,
+				RefType:   RefTypeBranch,
+				NewCommits: func(context.Context, string) ([]PushCommit, error) {
+					return []PushCommit{test.commit}, nil
+				},
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
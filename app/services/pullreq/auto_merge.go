@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FindAutoMerge returns the auto-merge of a pull request.
+func (s *Service) FindAutoMerge(ctx context.Context, pr *types.PullReq) (*types.AutoMerge, error) {
+	autoMerge, err := s.autoMergeStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	return autoMerge, nil
+}
+
+// EnableAutoMerge enables auto-merge for a pull request. The pull request gets merged with the provided
+// merge method on behalf of the principal as soon as it satisfies all protection rules of the target branch.
+func (s *Service) EnableAutoMerge(
+	ctx context.Context,
+	principal *types.Principal,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) (*types.AutoMerge, error) {
+	now := time.Now().UnixMilli()
+	autoMerge := &types.AutoMerge{
+		PullReqID: pr.ID,
+		RepoID:    pr.TargetRepoID,
+		Method:    method,
+		EnabledBy: principal.ID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	if err := s.autoMergeStore.Upsert(ctx, autoMerge); err != nil {
+		return nil, fmt.Errorf("failed to enable pull request auto-merge: %w", err)
+	}
+
+	s.writeAutoMergeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadAutoMerge{
+		Enabled: true,
+		Method:  method,
+	})
+
+	s.pullreqEvReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principal.ID,
+			Number:       pr.Number,
+		},
+		MergeMethod: method,
+	})
+
+	return autoMerge, nil
+}
+
+// DisableAutoMerge disables auto-merge for a pull request.
+func (s *Service) DisableAutoMerge(ctx context.Context, principal *types.Principal, pr *types.PullReq) error {
+	if _, err := s.autoMergeStore.Find(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	return s.cancelAutoMerge(ctx, pr, principal.ID, "")
+}
+
+// cancelAutoMerge disables auto-merge for a pull request and writes a pull request activity with the reason.
+func (s *Service) cancelAutoMerge(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	reason string,
+) error {
+	if err := s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to disable pull request auto-merge: %w", err)
+	}
+
+	s.writeAutoMergeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadAutoMerge{
+		Enabled: false,
+		Reason:  reason,
+	})
+
+	return nil
+}
+
+// writeAutoMergeActivity writes a pull request auto-merge activity. Failures are only logged.
+func (s *Service) writeAutoMergeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadAutoMerge,
+) {
+	err := func() error {
+		prUpd, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, prUpd, principalID, payload)
+		return err
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request auto-merge activity")
+	}
+}
+
+// lockPullReqs acquires the same lock the pull request merge API uses,
+// so a pull request is never merged by the API and the auto-merge at the same time.
+func (s *Service) lockPullReqs(ctx context.Context, repoID int64, expiry time.Duration) (func(), error) {
+	key := fmt.Sprintf("%d/pulls", repoID)
+
+	mutex, err := s.mtxManager.NewMutex(
+		key,
+		lock.WithNamespace("repo"),
+		lock.WithExpiry(expiry),
+		lock.WithTimeoutFactor(4/expiry.Seconds()), // 4s
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new mutex for pull requests in repo %d: %w", repoID, err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to lock mutex for pull requests in repo %d: %w", repoID, err)
+	}
+
+	return func() {
+		// always unlock independent of whether source context got canceled or not
+		ctx, cancel := context.WithTimeout(
+			contextutil.WithNewValues(context.Background(), ctx),
+			30*time.Second,
+		)
+		defer cancel()
+
+		if err := mutex.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to unlock mutex for pull requests in repo %d", repoID)
+		}
+	}, nil
+}
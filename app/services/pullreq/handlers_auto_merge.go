@@ -0,0 +1,373 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// autoMergeOnEnabled handles pull request AutoMergeEnabled events.
+// It merges the pull request right away if it already satisfies all protection rules.
+func (s *Service) autoMergeOnEnabled(ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.autoMerge(ctx, event.Payload.PullReqID)
+}
+
+// autoMergeOnReviewSubmitted handles pull request ReviewSubmitted events.
+func (s *Service) autoMergeOnReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.autoMerge(ctx, event.Payload.PullReqID)
+}
+
+// autoMergeOnLabelAssigned handles pull request LabelAssigned events.
+func (s *Service) autoMergeOnLabelAssigned(ctx context.Context,
+	event *events.Event[*pullreqevents.LabelAssignedPayload],
+) error {
+	return s.autoMerge(ctx, event.Payload.PullReqID)
+}
+
+// autoMergeOnLabelUnassigned handles pull request LabelUnassigned events.
+func (s *Service) autoMergeOnLabelUnassigned(ctx context.Context,
+	event *events.Event[*pullreqevents.LabelUnassignedPayload],
+) error {
+	return s.autoMerge(ctx, event.Payload.PullReqID)
+}
+
+// autoMergeOnBranchUpdate handles pull request BranchUpdated events.
+// Auto-merge gets canceled if the new commits were pushed by anyone other than the user who enabled it.
+func (s *Service) autoMergeOnBranchUpdate(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	autoMerge, err := s.autoMergeStore.Find(ctx, event.Payload.PullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	if event.Payload.PrincipalID == autoMerge.EnabledBy {
+		return s.autoMerge(ctx, event.Payload.PullReqID)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return s.cancelAutoMerge(ctx, pr, event.Payload.PrincipalID, "New commits were pushed by another user.")
+}
+
+// autoMergeOnClosed handles pull request Closed events. It disables auto-merge for the pull request.
+func (s *Service) autoMergeOnClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	if err := s.autoMergeStore.Delete(ctx, event.Payload.PullReqID); err != nil {
+		return fmt.Errorf("failed to disable auto-merge of closed pull request: %w", err)
+	}
+
+	return nil
+}
+
+// autoMergeOnMerged handles pull request Merged events. It disables auto-merge for the pull request.
+func (s *Service) autoMergeOnMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	if err := s.autoMergeStore.Delete(ctx, event.Payload.PullReqID); err != nil {
+		return fmt.Errorf("failed to disable auto-merge of merged pull request: %w", err)
+	}
+
+	return nil
+}
+
+// autoMergeOnCheckReported handles check Reported events. Every pull request with auto-merge enabled
+// whose latest commit got a completed status check is evaluated again.
+func (s *Service) autoMergeOnCheckReported(ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	autoMerges, err := s.autoMergeStore.ListForRepo(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request auto-merges: %w", err)
+	}
+
+	// a failure of one pull request mustn't prevent the others from being merged.
+	var errs []error
+	for _, autoMerge := range autoMerges {
+		pr, errFind := s.pullreqStore.Find(ctx, autoMerge.PullReqID)
+		if errFind != nil {
+			log.Ctx(ctx).Warn().Err(errFind).Int64("pullreq_id", autoMerge.PullReqID).
+				Msg("failed to find pull request with auto-merge enabled")
+			errs = append(errs, fmt.Errorf("failed to find pull request: %w", errFind))
+			continue
+		}
+
+		if pr.SourceSHA != event.Payload.CommitSHA {
+			continue
+		}
+
+		if errMerge := s.autoMerge(ctx, pr.ID); errMerge != nil {
+			log.Ctx(ctx).Warn().Err(errMerge).Int64("pullreq_id", pr.ID).
+				Msg("failed to auto-merge pull request")
+			errs = append(errs, errMerge)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// autoMerge merges the pull request if auto-merge is enabled for it and the pull request
+// satisfies all protection rules of the target branch. The rules are verified for the user who
+// enabled auto-merge and can never be bypassed. If the rules require the merge queue,
+// the pull request is added to the merge queue instead.
+//
+//nolint:gocognit,gocyclo,cyclop,funlen
+func (s *Service) autoMerge(ctx context.Context, pullReqID int64) error {
+	if _, err := s.autoMergeStore.Find(ctx, pullReqID); errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	// the max time we give a merge to succeed
+	const timeout = 3 * time.Minute
+
+	unlock, err := s.lockPullReqs(ctx, pr.TargetRepoID, timeout+30*time.Second)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// auto-merge could have been disabled or the pull request updated while waiting for the lock.
+	autoMerge, err := s.autoMergeStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	pr, err = s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		if err = s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+			return fmt.Errorf("failed to disable auto-merge of pull request that isn't open: %w", err)
+		}
+		return nil
+	}
+
+	if pr.IsDraft || pr.MergeCheckStatus == enum.MergeCheckStatusConflict {
+		return nil
+	}
+
+	if _, err = s.mergeQueue.Find(ctx, pr); err == nil {
+		return nil
+	} else if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to check merge queue: %w", err)
+	}
+
+	actor, err := s.principalStore.Find(ctx, autoMerge.EnabledBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal that enabled auto-merge: %w", err)
+	}
+
+	targetRepo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find source repository: %w", err)
+		}
+	}
+
+	// The pull request is merged on behalf of the user who enabled auto-merge,
+	// so we create a session object to verify the user's access.
+	session := &auth.Session{
+		Principal: *actor,
+		Metadata:  nil,
+	}
+
+	err = apiauth.CheckRepo(ctx, s.authorizer, session, targetRepo, enum.PermissionRepoPush, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return s.cancelAutoMerge(ctx, pr, bootstrap.NewSystemServiceSession().Principal.ID,
+			"The user who enabled auto-merge is no longer allowed to merge the pull request.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check access of principal that enabled auto-merge: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	labels, err := s.pullReqLabelStore.ListLabels(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        actor,
+		AllowBypass:  false, // auto-merge never bypasses protection rules
+		IsRepoOwner:  isRepoOwner,
+		TargetRepo:   targetRepo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       autoMerge.Method,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		Labels:       labels,
+
+		UnverifiedCommits: s.signatureVerifier.PullReqUnverifiedCommitsFn(targetRepo, pr),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if len(violations) > 0 {
+		log.Ctx(ctx).Debug().Msgf("pull request %d not auto-merged: protection rules not satisfied", pr.Number)
+		return nil
+	}
+
+	deleteSourceBranch := ruleOut.DeleteSourceBranch
+	if deleteSourceBranch && sourceRepo.ID != targetRepo.ID {
+		// the source branch is in a fork - only delete it if the user is allowed to push to the fork.
+		errAuth := apiauth.CheckRepo(ctx, s.authorizer, session, sourceRepo, enum.PermissionRepoPush, false)
+		deleteSourceBranch = errAuth == nil
+	}
+
+	if ruleOut.UseMergeQueue {
+		_, err = s.mergeQueue.Enqueue(ctx, actor, targetRepo, pr, autoMerge.Method, "", "", deleteSourceBranch)
+		if err != nil {
+			return fmt.Errorf("failed to add auto-merged pull request to the merge queue: %w", err)
+		}
+
+		if err = s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+			return fmt.Errorf("failed to disable auto-merge of enqueued pull request: %w", err)
+		}
+
+		return nil
+	}
+
+	return s.mergeAutoMerge(ctx, actor, targetRepo, sourceRepo, pr, autoMerge, deleteSourceBranch)
+}
+
+// mergeAutoMerge merges the pull request on behalf of the user who enabled auto-merge.
+func (s *Service) mergeAutoMerge(
+	ctx context.Context,
+	actor *types.Principal,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	autoMerge *types.AutoMerge,
+	deleteSourceBranch bool,
+) error {
+	targetWriteParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceWriteParams := targetWriteParams
+	if sourceRepo.ID != targetRepo.ID {
+		sourceWriteParams, err = createSystemRPCWriteParams(ctx, s.urlProvider, sourceRepo.ID, sourceRepo.GitUID)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+	}
+
+	mergeOut, err := s.merger.Merge(ctx, &merger.MergeInput{
+		Actor:              actor,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Method:             autoMerge.Method,
+		TargetWriteParams:  targetWriteParams,
+		SourceWriteParams:  sourceWriteParams,
+		DeleteSourceBranch: deleteSourceBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("auto-merge execution failed: %w", err)
+	}
+
+	if len(mergeOut.ConflictFiles) > 0 || mergeOut.MergeSHA == "" {
+		log.Ctx(ctx).Debug().Msgf("pull request %d not auto-merged: merge conflicts", pr.Number)
+		return nil
+	}
+
+	log.Ctx(ctx).Debug().Msgf("successfully auto-merged PR")
+
+	if err = s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to disable auto-merge of merged pull request")
+	}
+
+	return nil
+}
@@ -57,17 +57,27 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		}
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
-		// First check if the merge base has changed
-
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
 		if err != nil {
 			return fmt.Errorf("failed to get repo git info: %w", err)
 		}
 
+		// For pull requests from forks the new commits must be available in the target repository.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+			if err != nil {
+				return fmt.Errorf("failed to generate rpc write params: %w", err)
+			}
+
+			err = s.fetchSourceCommits(ctx, writeParams, pr.SourceRepoID, pr.TargetRepoID, event.Payload.NewSHA)
+			if err != nil {
+				return err
+			}
+		}
+
+		// First check if the merge base has changed
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -40,15 +40,11 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
-	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
-		WriteParams: writeParams,
-		Name:        strconv.Itoa(int(event.Payload.Number)),
-		Type:        gitenum.RefTypePullReqHead,
-		NewValue:    event.Payload.SourceSHA,
-		OldValue:    "", // this is a new pull request, so we expect that the ref doesn't exist
-	})
+	err = s.updateHeadRef(ctx, writeParams, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.Number,
+		event.Payload.SourceSHA,
+		"", // this is a new pull request, so we expect that the ref doesn't exist
+	)
 	if err != nil {
 		return fmt.Errorf("failed to update PR head ref: %w", err)
 	}
@@ -71,15 +67,11 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
-	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
-		WriteParams: writeParams,
-		Name:        strconv.Itoa(int(event.Payload.Number)),
-		Type:        gitenum.RefTypePullReqHead,
-		NewValue:    event.Payload.NewSHA,
-		OldValue:    event.Payload.OldSHA,
-	})
+	err = s.updateHeadRef(ctx, writeParams, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.Number,
+		event.Payload.NewSHA,
+		event.Payload.OldSHA,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to update PR head ref after new commit: %w", err)
 	}
@@ -102,17 +94,79 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
-	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+	err = s.updateHeadRef(ctx, writeParams, event.Payload.SourceRepoID, event.Payload.TargetRepoID,
+		event.Payload.Number,
+		event.Payload.SourceSHA,
+		"", // the request is re-opened, so anything can be the old value
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update PR head ref after pull request reopen: %w", err)
+	}
+
+	return nil
+}
+
+// updateHeadRef updates the PR head git ref to point to the provided SHA.
+// For pull requests from forks the commits are fetched from the source repository and the fetch writes the ref,
+// so the fetched commits are referenced as soon as they are available in the target repository.
+func (s *Service) updateHeadRef(ctx context.Context,
+	writeParams git.WriteParams,
+	sourceRepoID, targetRepoID int64,
+	number int64,
+	newSHA, oldSHA string,
+) error {
+	if sourceRepoID == targetRepoID {
+		return s.git.UpdateRef(ctx, git.UpdateRefParams{
+			WriteParams: writeParams,
+			Name:        strconv.Itoa(int(number)),
+			Type:        gitenum.RefTypePullReqHead,
+			NewValue:    newSHA,
+			OldValue:    oldSHA,
+		})
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
 		WriteParams: writeParams,
-		Name:        strconv.Itoa(int(event.Payload.Number)),
-		Type:        gitenum.RefTypePullReqHead,
-		NewValue:    event.Payload.SourceSHA,
-		OldValue:    "", // the request is re-opened, so anything can be the old value
+		Source:      sourceRepo.GitUID,
+		ObjectSHAs:  []string{newSHA},
+		RefName:     strconv.Itoa(int(number)),
+		RefType:     gitenum.RefTypePullReqHead,
+		RefOldValue: oldSHA,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update PR head ref after pull request reopen: %w", err)
+		return fmt.Errorf("failed to fetch source commits into the target repo: %w", err)
+	}
+
+	return nil
+}
+
+// fetchSourceCommits makes the commits of a pull request from a fork available in the target repository.
+func (s *Service) fetchSourceCommits(ctx context.Context,
+	writeParams git.WriteParams,
+	sourceRepoID, targetRepoID int64,
+	sha string,
+) error {
+	if sourceRepoID == targetRepoID {
+		return nil
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceRepo.GitUID,
+		ObjectSHAs:  []string{sha},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commits into the target repo: %w", err)
 	}
 
 	return nil
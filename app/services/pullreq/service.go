@@ -20,16 +20,24 @@ import (
 	"sync"
 	"time"
 
+	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
@@ -47,6 +55,18 @@ type Service struct {
 	fileViewStore       store.PullReqFileViewStore
 	sseStreamer         sse.Streamer
 	urlProvider         url.Provider
+	autoMergeStore      store.AutoMergeStore
+	reviewerStore       store.PullReqReviewerStore
+	principalStore      store.PrincipalStore
+	checkStore          store.CheckStore
+	protectionManager   *protection.Manager
+	codeOwners          *codeowners.Service
+	authorizer          authz.Authorizer
+	mtxManager          lock.MutexManager
+	mergeQueue          *mergequeue.Service
+	merger              *merger.Service
+	pullReqLabelStore   store.PullReqLabelStore
+	signatureVerifier   *gitsignature.Verifier
 
 	cancelMutex        sync.Mutex
 	cancelMergeability map[string]context.CancelFunc
@@ -71,6 +91,19 @@ func New(ctx context.Context,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	autoMergeStore store.AutoMergeStore,
+	reviewerStore store.PullReqReviewerStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	authorizer authz.Authorizer,
+	mtxManager lock.MutexManager,
+	mergeQueue *mergequeue.Service,
+	merger *merger.Service,
+	pullReqLabelStore store.PullReqLabelStore,
+	signatureVerifier *gitsignature.Verifier,
 ) (*Service, error) {
 	service := &Service{
 		pullreqEvReporter:   pullreqEvReporter,
@@ -86,6 +119,18 @@ func New(ctx context.Context,
 		cancelMergeability:  make(map[string]context.CancelFunc),
 		pubsub:              bus,
 		sseStreamer:         sseStreamer,
+		autoMergeStore:      autoMergeStore,
+		reviewerStore:       reviewerStore,
+		principalStore:      principalStore,
+		checkStore:          checkStore,
+		protectionManager:   protectionManager,
+		codeOwners:          codeOwners,
+		authorizer:          authorizer,
+		mtxManager:          mtxManager,
+		mergeQueue:          mergeQueue,
+		merger:              merger,
+		pullReqLabelStore:   pullReqLabelStore,
+		signatureVerifier:   signatureVerifier,
 	}
 
 	var err error
@@ -244,6 +289,51 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// auto-merge
+	const groupPullReqAutoMerge = "gitness:pullreq:automerge"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqAutoMerge, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterAutoMergeEnabled(service.autoMergeOnEnabled)
+			_ = r.RegisterReviewSubmitted(service.autoMergeOnReviewSubmitted)
+			_ = r.RegisterLabelAssigned(service.autoMergeOnLabelAssigned)
+			_ = r.RegisterLabelUnassigned(service.autoMergeOnLabelUnassigned)
+			_ = r.RegisterBranchUpdated(service.autoMergeOnBranchUpdate)
+			_ = r.RegisterClosed(service.autoMergeOnClosed)
+			_ = r.RegisterMerged(service.autoMergeOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupCheckAutoMerge = "gitness:pullreq:automerge:check"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheckAutoMerge, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.autoMergeOnCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	return service, nil
 }
 
@@ -264,6 +354,7 @@ func createSystemRPCWriteParams(
 		principal.ID,
 		false,
 		true,
+		false,
 	)
 	if err != nil {
 		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
@@ -17,14 +17,22 @@ package pullreq
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/gitsignature"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/types"
 
@@ -51,8 +59,23 @@ func ProvideService(ctx context.Context,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	checkEvFactory *events.ReaderFactory[*checkevents.Reader],
+	autoMergeStore store.AutoMergeStore,
+	reviewerStore store.PullReqReviewerStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	authorizer authz.Authorizer,
+	mtxManager lock.MutexManager,
+	mergeQueue *mergequeue.Service,
+	merger *merger.Service,
+	pullReqLabelStore store.PullReqLabelStore,
+	signatureVerifier *gitsignature.Verifier,
 ) (*Service, error) {
 	return New(ctx, config, gitReaderFactory, pullReqEvFactory, pullReqEvReporter, git,
 		repoGitInfoCache, repoStore, pullreqStore, activityStore,
-		codeCommentView, codeCommentMigrator, fileViewStore, pubsub, urlProvider, sseStreamer)
+		codeCommentView, codeCommentMigrator, fileViewStore, pubsub, urlProvider, sseStreamer,
+		checkEvFactory, autoMergeStore, reviewerStore, principalStore, checkStore, protectionManager,
+		codeOwners, authorizer, mtxManager, mergeQueue, merger, pullReqLabelStore, signatureVerifier)
 }
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	allowlistPrefixPath        = "path:"
+	allowlistPrefixFingerprint = "fingerprint:"
+	allowlistPrefixRegex       = "regex:"
+)
+
+// Allowlist contains the findings that are ignored by the secret scanner.
+// It's read from a file in the repository where every non-empty line that doesn't start with '#' is one of:
+//
+//	path:<glob>            ignores all findings in files matching the glob (e.g. "path:testdata/**")
+//	regex:<regex>          ignores all secrets matching the regex
+//	fingerprint:<value>    ignores a single finding, "fingerprint:" can be omitted
+type Allowlist struct {
+	paths        []string
+	regexes      []*regexp.Regexp
+	fingerprints map[string]struct{}
+}
+
+// ParseAllowlist parses the content of an allowlist file.
+func ParseAllowlist(content string) (*Allowlist, error) {
+	allowlist := &Allowlist{
+		fingerprints: make(map[string]struct{}),
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, allowlistPrefixPath):
+			pattern := strings.TrimSpace(line[len(allowlistPrefixPath):])
+			if !doublestar.ValidatePattern(pattern) {
+				return nil, fmt.Errorf("line %d: invalid path pattern %q", lineNumber, pattern)
+			}
+			allowlist.paths = append(allowlist.paths, pattern)
+		case strings.HasPrefix(line, allowlistPrefixRegex):
+			regex, err := regexp.Compile(strings.TrimSpace(line[len(allowlistPrefixRegex):]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid regex: %w", lineNumber, err)
+			}
+			allowlist.regexes = append(allowlist.regexes, regex)
+		default:
+			fingerprint := strings.TrimSpace(strings.TrimPrefix(line, allowlistPrefixFingerprint))
+			allowlist.fingerprints[fingerprint] = struct{}{}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read allowlist: %w", err)
+	}
+
+	return allowlist, nil
+}
+
+// Allows returns true if the finding of the provided commit is ignored by the allowlist.
+func (a *Allowlist) Allows(finding Finding, commitSHA string) bool {
+	if a == nil {
+		return false
+	}
+
+	if _, ok := a.fingerprints[finding.Fingerprint(commitSHA)]; ok {
+		return true
+	}
+
+	for _, pattern := range a.paths {
+		if ok, _ := doublestar.Match(pattern, finding.Path); ok {
+			return true
+		}
+	}
+
+	for _, regex := range a.regexes {
+		if regex.MatchString(finding.Secret) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import "github.com/harness/gitness/types"
+
+// DefaultRules is the built-in rule set. It detects well-known credential formats
+// and generic assignments of high entropy values to secret-like variables.
+var DefaultRules = []types.SecretScanningRule{
+	{
+		Identifier:  "aws-access-key-id",
+		Description: "AWS access key ID",
+		Regex:       `\b((?:A3T[A-Z0-9]|AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16})\b`,
+		SecretGroup: 1,
+		Entropy:     3,
+	},
+	{
+		Identifier:  "aws-secret-access-key",
+		Description: "AWS secret access key",
+		Regex:       `(?i)aws_?(?:secret)?_?(?:access)?_?key['"]?\s*[:=]+\s*['"]?([A-Za-z0-9/+=]{40})\b`,
+		SecretGroup: 1,
+		Entropy:     4,
+		Keywords:    []string{"aws"},
+	},
+	{
+		Identifier:  "github-token",
+		Description: "GitHub personal access, OAuth, app or refresh token",
+		Regex:       `\b((?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36})\b`,
+		SecretGroup: 1,
+		Keywords:    []string{"ghp_", "gho_", "ghu_", "ghs_", "ghr_"},
+	},
+	{
+		Identifier:  "github-fine-grained-token",
+		Description: "GitHub fine-grained personal access token",
+		Regex:       `\b(github_pat_[A-Za-z0-9_]{82})\b`,
+		SecretGroup: 1,
+		Keywords:    []string{"github_pat_"},
+	},
+	{
+		Identifier:  "gitlab-token",
+		Description: "GitLab personal access token",
+		Regex:       `\b(glpat-[A-Za-z0-9_-]{20})\b`,
+		SecretGroup: 1,
+		Keywords:    []string{"glpat-"},
+	},
+	{
+		Identifier:  "slack-token",
+		Description: "Slack bot, user or app token",
+		Regex:       `\b(xox[abposr]-[0-9A-Za-z-]{10,250})\b`,
+		SecretGroup: 1,
+		Keywords:    []string{"xox"},
+	},
+	{
+		Identifier:  "google-api-key",
+		Description: "Google API key",
+		Regex:       `\b(AIza[0-9A-Za-z_-]{35})\b`,
+		SecretGroup: 1,
+		Keywords:    []string{"aiza"},
+	},
+	{
+		Identifier:  "stripe-secret-key",
+		Description: "Stripe live secret or restricted key",
+		Regex:       `\b((?:sk|rk)_live_[0-9A-Za-z]{24,99})\b`,
+		SecretGroup: 1,
+		Keywords:    []string{"_live_"},
+	},
+	{
+		Identifier:  "private-key",
+		Description: "Private key in PEM format",
+		Regex:       `-----BEGIN[ A-Z0-9_-]{0,100}PRIVATE KEY(?: BLOCK)?-----`,
+		Keywords:    []string{"private key"},
+	},
+	{
+		Identifier:  "generic-secret",
+		Description: "High entropy value assigned to a secret-like variable",
+		Regex: `(?i)(?:api_?key|secret|token|passw(?:or)?d|credentials?)[\w.-]*['"]?\s*(?::|=|:=|=>)\s*` +
+			`['"]([^'"\s]{16,})['"]`,
+		SecretGroup: 1,
+		Entropy:     3.5,
+		Keywords:    []string{"key", "secret", "token", "passw", "credential"},
+	},
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	maxRules = 100
+
+	// redactedPrefixLength is the number of characters of a secret that remain visible after redaction.
+	redactedPrefixLength = 4
+)
+
+// Finding is a secret detected in a line of a file.
+type Finding struct {
+	RuleIdentifier string
+	Path           string
+	Line           int
+	Secret         string
+}
+
+// Redacted returns the secret with all but its first few characters masked.
+func (f Finding) Redacted() string {
+	if len(f.Secret) <= 2*redactedPrefixLength {
+		return strings.Repeat("*", len(f.Secret))
+	}
+
+	return f.Secret[:redactedPrefixLength] + strings.Repeat("*", len(f.Secret)-redactedPrefixLength)
+}
+
+// Fingerprint returns the fingerprint of the finding in the provided commit.
+// The format "<commit sha>:<path>:<rule identifier>:<line>" is used in the allowlist file to ignore the finding.
+func (f Finding) Fingerprint(commitSHA string) string {
+	return fmt.Sprintf("%s:%s:%s:%d", commitSHA, f.Path, f.RuleIdentifier, f.Line)
+}
+
+type compiledRule struct {
+	identifier  string
+	regex       *regexp.Regexp
+	secretGroup int
+	entropy     float64
+	keywords    []string
+}
+
+// Scanner detects secrets in lines of text using a set of rules.
+type Scanner struct {
+	rules []compiledRule
+}
+
+// NewScanner compiles the provided rules and returns a scanner that uses them.
+func NewScanner(rules []types.SecretScanningRule) (*Scanner, error) {
+	compiled := make([]compiledRule, len(rules))
+	for i, rule := range rules {
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q has an invalid regex: %w", rule.Identifier, err)
+		}
+
+		if rule.SecretGroup < 0 || rule.SecretGroup > regex.NumSubexp() {
+			return nil, fmt.Errorf("rule %q has an invalid secret group %d, the regex has %d group(s)",
+				rule.Identifier, rule.SecretGroup, regex.NumSubexp())
+		}
+
+		keywords := make([]string, len(rule.Keywords))
+		for j, keyword := range rule.Keywords {
+			keywords[j] = strings.ToLower(keyword)
+		}
+
+		compiled[i] = compiledRule{
+			identifier:  rule.Identifier,
+			regex:       regex,
+			secretGroup: rule.SecretGroup,
+			entropy:     rule.Entropy,
+			keywords:    keywords,
+		}
+	}
+
+	return &Scanner{rules: compiled}, nil
+}
+
+// ValidateRules verifies that the provided custom rules are well-formed.
+func ValidateRules(rules []types.SecretScanningRule) error {
+	if len(rules) > maxRules {
+		return fmt.Errorf("at most %d rules are allowed", maxRules)
+	}
+
+	identifiers := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		if rule.Identifier == "" {
+			return errors.New("rule identifier must not be empty")
+		}
+
+		if _, exists := identifiers[rule.Identifier]; exists {
+			return fmt.Errorf("rule identifier %q is used more than once", rule.Identifier)
+		}
+		identifiers[rule.Identifier] = struct{}{}
+
+		if rule.Regex == "" {
+			return fmt.Errorf("rule %q has an empty regex", rule.Identifier)
+		}
+
+		if rule.Entropy < 0 {
+			return fmt.Errorf("rule %q has a negative entropy", rule.Identifier)
+		}
+	}
+
+	_, err := NewScanner(rules)
+	return err
+}
+
+// ScanLine returns the secrets found in a line of a file.
+func (s *Scanner) ScanLine(path string, lineNumber int, line string) []Finding {
+	var (
+		findings  []Finding
+		lowerLine string
+	)
+
+	for _, rule := range s.rules {
+		if len(rule.keywords) > 0 {
+			if lowerLine == "" {
+				lowerLine = strings.ToLower(line)
+			}
+
+			if !containsAny(lowerLine, rule.keywords) {
+				continue
+			}
+		}
+
+		for _, match := range rule.regex.FindAllStringSubmatch(line, -1) {
+			secret := match[rule.secretGroup]
+			if secret == "" {
+				continue
+			}
+
+			if rule.entropy > 0 && shannonEntropy(secret) < rule.entropy {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				RuleIdentifier: rule.identifier,
+				Path:           path,
+				Line:           lineNumber,
+				Secret:         secret,
+			})
+		}
+	}
+
+	return findings
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shannonEntropy returns the Shannon entropy of the string in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
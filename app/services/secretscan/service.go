@@ -0,0 +1,306 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxAllowlistFileSize is the maximum number of bytes of the allowlist file that are read.
+	maxAllowlistFileSize = 1 << 20 // 1 MB
+
+	// maxFindings limits the number of findings of a single push, scanning stops once the limit is reached.
+	maxFindings = 100
+)
+
+type Config struct {
+	// AllowlistFilePaths are the possible paths of the allowlist file, the first existing file is used.
+	AllowlistFilePaths []string
+}
+
+type Service struct {
+	config        Config
+	git           git.Interface
+	settingsStore store.SecretScanningSettingsStore
+	findingStore  store.SecretFindingStore
+}
+
+func NewService(
+	config Config,
+	git git.Interface,
+	settingsStore store.SecretScanningSettingsStore,
+	findingStore store.SecretFindingStore,
+) *Service {
+	return &Service{
+		config:        config,
+		git:           git,
+		settingsStore: settingsStore,
+		findingStore:  findingStore,
+	}
+}
+
+// FindSettings returns the secret scanning settings of a repository.
+// If the settings were never updated, the default (disabled) settings are returned.
+func (s *Service) FindSettings(ctx context.Context, repoID int64) (*types.SecretScanningSettings, error) {
+	settings, err := s.settingsStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.SecretScanningSettings{
+			RepoID:          repoID,
+			Enabled:         false,
+			Mode:            enum.SecretScanningModeBlock,
+			UseDefaultRules: true,
+			Rules:           []types.SecretScanningRule{},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret scanning settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings stores the secret scanning settings of a repository.
+func (s *Service) UpdateSettings(ctx context.Context, settings *types.SecretScanningSettings) error {
+	if err := s.settingsStore.Upsert(ctx, settings); err != nil {
+		return fmt.Errorf("failed to store secret scanning settings: %w", err)
+	}
+
+	return nil
+}
+
+// ListFindings returns the secret findings of a repository and their total count.
+func (s *Service) ListFindings(
+	ctx context.Context,
+	repoID int64,
+	filter *types.SecretFindingFilter,
+) ([]*types.SecretFinding, int64, error) {
+	count, err := s.findingStore.Count(ctx, repoID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count secret findings: %w", err)
+	}
+
+	findings, err := s.findingStore.List(ctx, repoID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list secret findings: %w", err)
+	}
+
+	return findings, count, nil
+}
+
+// RefUpdate is a reference updated by a push.
+type RefUpdate struct {
+	// Ref is the full name of the reference.
+	Ref string
+	// New is the new commit SHA of the reference.
+	New string
+}
+
+type ScanPushInput struct {
+	Repo        *types.Repository
+	PrincipalID int64
+	// AlternateObjectDirs give access to the quarantined objects of the push.
+	AlternateObjectDirs []string
+	RefUpdates          []RefUpdate
+}
+
+type ScanPushOutput struct {
+	Findings []*types.SecretFinding
+	// Blocked is true if the push must be rejected because of the findings.
+	Blocked bool
+}
+
+// ScanPush scans the lines added by the new commits of a push for secrets and records the findings.
+// The allowlist is read from the default branch, so a push can't allowlist its own secrets.
+func (s *Service) ScanPush(ctx context.Context, in ScanPushInput) (ScanPushOutput, error) {
+	settings, err := s.FindSettings(ctx, in.Repo.ID)
+	if err != nil {
+		return ScanPushOutput{}, err
+	}
+
+	if !settings.Enabled {
+		return ScanPushOutput{}, nil
+	}
+
+	var rules []types.SecretScanningRule
+	if settings.UseDefaultRules {
+		rules = append(rules, DefaultRules...)
+	}
+	rules = append(rules, settings.Rules...)
+
+	scanner, err := NewScanner(rules)
+	if err != nil {
+		return ScanPushOutput{}, fmt.Errorf("failed to create secret scanner: %w", err)
+	}
+
+	allowlist, err := s.readAllowlist(ctx, in.Repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to read secret scanning allowlist, scanning without it")
+	}
+
+	readParams := git.ReadParams{
+		RepoUID:             in.Repo.GitUID,
+		AlternateObjectDirs: in.AlternateObjectDirs,
+	}
+
+	blocked := settings.Mode == enum.SecretScanningModeBlock
+	now := time.Now().UnixMilli()
+	scanned := make(map[string]struct{})
+
+	var findings []*types.SecretFinding
+
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New == types.NilSHA || len(findings) >= maxFindings {
+			continue
+		}
+
+		out, err := s.git.ListNewCommitSHAs(ctx, &git.ListNewCommitSHAsParams{
+			ReadParams: readParams,
+			GitRef:     refUpdate.New,
+		})
+		if err != nil {
+			return ScanPushOutput{}, fmt.Errorf("failed to list new commits of %s: %w", refUpdate.Ref, err)
+		}
+
+		for _, sha := range out.SHAs {
+			if _, ok := scanned[sha]; ok {
+				continue
+			}
+			scanned[sha] = struct{}{}
+
+			commitFindings, err := s.scanCommit(ctx, readParams, scanner, allowlist, sha)
+			if err != nil {
+				return ScanPushOutput{}, err
+			}
+
+			for _, f := range commitFindings {
+				findings = append(findings, &types.SecretFinding{
+					RepoID:         in.Repo.ID,
+					PrincipalID:    in.PrincipalID,
+					RuleIdentifier: f.RuleIdentifier,
+					Ref:            refUpdate.Ref,
+					CommitSHA:      sha,
+					Path:           f.Path,
+					Line:           f.Line,
+					Secret:         f.Redacted(),
+					Fingerprint:    f.Fingerprint(sha),
+					Blocked:        blocked,
+					Created:        now,
+				})
+			}
+
+			if len(findings) >= maxFindings {
+				findings = findings[:maxFindings]
+				break
+			}
+		}
+	}
+
+	for _, finding := range findings {
+		if err := s.findingStore.Create(ctx, finding); err != nil {
+			return ScanPushOutput{}, fmt.Errorf("failed to store secret finding: %w", err)
+		}
+	}
+
+	return ScanPushOutput{
+		Findings: findings,
+		Blocked:  blocked && len(findings) > 0,
+	}, nil
+}
+
+func (s *Service) scanCommit(
+	ctx context.Context,
+	readParams git.ReadParams,
+	scanner *Scanner,
+	allowlist *Allowlist,
+	sha string,
+) ([]Finding, error) {
+	out, err := s.git.GetCommitAddedLines(ctx, &git.GetCommitAddedLinesParams{
+		ReadParams: readParams,
+		SHA:        sha,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get added lines of commit %s: %w", sha, err)
+	}
+
+	var findings []Finding
+	for _, file := range out.Files {
+		for _, line := range file.Lines {
+			for _, finding := range scanner.ScanLine(file.Path, line.Number, line.Text) {
+				if !allowlist.Allows(finding, sha) {
+					findings = append(findings, finding)
+				}
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// readAllowlist reads the allowlist file from the default branch of the repository.
+// It returns nil if the repository doesn't contain an allowlist file.
+func (s *Service) readAllowlist(ctx context.Context, repo *types.Repository) (*Allowlist, error) {
+	readParams := git.CreateReadParams(repo)
+
+	for _, path := range s.config.AllowlistFilePaths {
+		node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     "refs/heads/" + repo.DefaultBranch,
+			Path:       path,
+		})
+		if gittypes.IsPathNotFoundError(err) || errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allowlist file %q: %w", path, err)
+		}
+
+		if node.Node.Mode != git.TreeNodeModeFile {
+			continue
+		}
+
+		output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+			ReadParams: readParams,
+			SHA:        node.Node.SHA,
+			SizeLimit:  maxAllowlistFileSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allowlist file content: %w", err)
+		}
+
+		content, err := io.ReadAll(output.Content)
+		_ = output.Content.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read allowlist file content: %w", err)
+		}
+
+		return ParseAllowlist(string(content))
+	}
+
+	return nil, nil
+}
@@ -12,23 +12,32 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package database
+package secretscan
 
 import (
-	"context"
-
-	gitnessAppStore "github.com/harness/gitness/app/store"
-	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
-)
 
-var _ gitnessAppStore.UserGroupStore = (*UserGroupStore)(nil)
+	"github.com/google/wire"
+)
 
-type UserGroupStore struct {
-}
+var WireSet = wire.NewSet(
+	ProvideService,
+)
 
-// Find Dummy Method: to be implemented later.
-func (s *UserGroupStore) FindByIdentifier(_ context.Context, _ int64, _ string) (*types.UserGroup, error) {
-	//nolint: nilnil
-	return nil, store.ErrResourceNotFound
+func ProvideService(
+	config *types.Config,
+	git git.Interface,
+	settingsStore store.SecretScanningSettingsStore,
+	findingStore store.SecretFindingStore,
+) *Service {
+	return NewService(
+		Config{
+			AllowlistFilePaths: config.SecretScanning.AllowlistFilePaths,
+		},
+		git,
+		settingsStore,
+		findingStore,
+	)
 }
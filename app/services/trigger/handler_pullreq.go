@@ -102,6 +102,28 @@ func (s *Service) handleEventPullReqMerged(
 	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqMerged, hook)
 }
 
+func (s *Service) handleEventPullReqMergeQueueBuilt(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueBuiltPayload],
+) error {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionPullReqMergeQueueBuilt,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		After:       event.Payload.MergeSHA,
+	}
+	err := s.augmentPullReqInfo(ctx, hook, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("could not augment pull request info: %w", err)
+	}
+
+	// the pipeline runs against the speculative merge commit of the merge queue.
+	hook.Before = event.Payload.BaseSHA
+	hook.Ref = fmt.Sprintf("refs/pullreq/%d/queue", event.Payload.Number)
+
+	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionPullReqMergeQueueBuilt, hook)
+}
+
 // augmentPullReqInfo adds in information into the hook pertaining to the pull request
 // by querying the database.
 func (s *Service) augmentPullReqInfo(
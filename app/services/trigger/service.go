@@ -132,6 +132,7 @@ func New(
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterMergeQueueBuilt(service.handleEventPullReqMergeQueueBuilt)
 
 			return nil
 		})
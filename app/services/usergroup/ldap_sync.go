@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobTypeLDAPGroupSync = "gitness:usergroup:ldap-sync"
+
+var illegalIdentifierCharacters = regexp.MustCompile(`[^a-zA-Z0-9\-_.]+`)
+
+type ldapGroupSyncJob struct {
+	spacePath         string
+	tx                dbtx.Transactor
+	ldapAuthenticator *ldap.Authenticator
+	spaceStore        store.SpaceStore
+	ldapIdentityStore store.LDAPIdentityStore
+	userGroupStore    store.UserGroupStore
+}
+
+func newLDAPGroupSyncJob(
+	spacePath string,
+	tx dbtx.Transactor,
+	ldapAuthenticator *ldap.Authenticator,
+	spaceStore store.SpaceStore,
+	ldapIdentityStore store.LDAPIdentityStore,
+	userGroupStore store.UserGroupStore,
+) *ldapGroupSyncJob {
+	return &ldapGroupSyncJob{
+		spacePath:         spacePath,
+		tx:                tx,
+		ldapAuthenticator: ldapAuthenticator,
+		spaceStore:        spaceStore,
+		ldapIdentityStore: ldapIdentityStore,
+		userGroupStore:    userGroupStore,
+	}
+}
+
+// Handle syncs the groups of the LDAP directory into user groups of the configured space.
+// Members of the groups are mapped to the users their LDAP identity is linked to (on the first login),
+// users are never created or linked by the sync.
+// User groups that were synced from LDAP but no longer exist in the directory are deleted.
+func (j *ldapGroupSyncJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	space, err := j.spaceStore.FindByRef(ctx, j.spacePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space '%s' for LDAP group sync: %w", j.spacePath, err)
+	}
+
+	ldapGroups, err := j.ldapAuthenticator.ListGroups(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list LDAP groups: %w", err)
+	}
+
+	principalIDs, err := j.mapLDAPUsers(ctx, ldapGroups)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := j.userGroupStore.List(ctx, space.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	existingByIdentifier := make(map[string]*types.UserGroup, len(existing))
+	for _, userGroup := range existing {
+		existingByIdentifier[strings.ToLower(userGroup.Identifier)] = userGroup
+	}
+
+	synced := make(map[int64]struct{}, len(ldapGroups))
+	for _, ldapGroup := range ldapGroups {
+		identifier := userGroupIdentifierFromLDAPName(ldapGroup.Name)
+		if err := check.Identifier(identifier); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("group_dn", ldapGroup.DN).Msg("skipping LDAP group with invalid name")
+			continue
+		}
+
+		userGroup := existingByIdentifier[strings.ToLower(identifier)]
+		if userGroup != nil && userGroup.Source != enum.UserGroupSourceLDAP {
+			log.Ctx(ctx).Warn().Str("group_dn", ldapGroup.DN).
+				Msgf("skipping LDAP group as user group '%s' isn't managed by LDAP", userGroup.Identifier)
+			continue
+		}
+
+		members := make([]int64, 0, len(ldapGroup.MemberDNs))
+		for _, memberDN := range ldapGroup.MemberDNs {
+			if principalID, ok := principalIDs[ldap.NormalizeDN(memberDN)]; ok {
+				members = append(members, principalID)
+			}
+		}
+
+		userGroup, err = j.syncUserGroup(ctx, space.ID, userGroup, identifier, ldapGroup.Name, members)
+		if err != nil {
+			return "", fmt.Errorf("failed to sync LDAP group '%s': %w", ldapGroup.DN, err)
+		}
+
+		synced[userGroup.ID] = struct{}{}
+	}
+
+	deleted := 0
+	for _, userGroup := range existing {
+		if _, ok := synced[userGroup.ID]; ok || userGroup.Source != enum.UserGroupSourceLDAP {
+			continue
+		}
+
+		if err := j.userGroupStore.Delete(ctx, userGroup.ID); err != nil {
+			return "", fmt.Errorf("failed to delete user group '%s': %w", userGroup.Identifier, err)
+		}
+		deleted++
+	}
+
+	result := fmt.Sprintf("synced %d LDAP groups, deleted %d user groups", len(synced), deleted)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// mapLDAPUsers returns the principal IDs of the linked users that are members of any of the groups,
+// indexed by the normalized DN of the LDAP user.
+func (j *ldapGroupSyncJob) mapLDAPUsers(ctx context.Context, ldapGroups []*ldap.Group) (map[string]int64, error) {
+	memberDNs := make(map[string]struct{})
+	for _, ldapGroup := range ldapGroups {
+		for _, memberDN := range ldapGroup.MemberDNs {
+			memberDNs[ldap.NormalizeDN(memberDN)] = struct{}{}
+		}
+	}
+
+	if len(memberDNs) == 0 {
+		return map[string]int64{}, nil
+	}
+
+	entries, err := j.ldapAuthenticator.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LDAP users: %w", err)
+	}
+
+	principalIDs := make(map[string]int64, len(memberDNs))
+	for _, entry := range entries {
+		dn := ldap.NormalizeDN(entry.DN)
+		if _, ok := memberDNs[dn]; !ok {
+			continue
+		}
+
+		identity, err := j.ldapIdentityStore.Find(ctx, entry.ExternalID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find LDAP identity of LDAP entry '%s': %w", entry.DN, err)
+		}
+
+		principalIDs[dn] = identity.PrincipalID
+	}
+
+	return principalIDs, nil
+}
+
+func (j *ldapGroupSyncJob) syncUserGroup(
+	ctx context.Context,
+	spaceID int64,
+	userGroup *types.UserGroup,
+	identifier string,
+	name string,
+	members []int64,
+) (*types.UserGroup, error) {
+	now := time.Now().UnixMilli()
+
+	err := j.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		switch {
+		case userGroup == nil:
+			userGroup = &types.UserGroup{
+				SpaceID:    spaceID,
+				Identifier: identifier,
+				Name:       name,
+				Source:     enum.UserGroupSourceLDAP,
+				Created:    now,
+				Updated:    now,
+			}
+			err = j.userGroupStore.Create(ctx, userGroup)
+		case userGroup.Name != name:
+			userGroup.Name = name
+			userGroup.Updated = now
+			err = j.userGroupStore.Update(ctx, userGroup)
+		}
+		if err != nil {
+			return err
+		}
+
+		return j.userGroupStore.SetMembers(ctx, userGroup.ID, members, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userGroup, nil
+}
+
+// userGroupIdentifierFromLDAPName derives the identifier of a user group from the name of the LDAP group.
+func userGroupIdentifierFromLDAPName(name string) string {
+	identifier := strings.Trim(illegalIdentifierCharacters.ReplaceAllString(name, "-"), "-")
+	if len(identifier) > check.MaxIdentifierLength {
+		identifier = identifier[:check.MaxIdentifierLength]
+	}
+
+	return identifier
+}
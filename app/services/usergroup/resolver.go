@@ -16,20 +16,65 @@ package usergroup
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 )
 
 var _ Resolver = (*GitnessResolver)(nil)
 
+// GitnessResolver resolves user groups stored in the database.
+// User groups are referenced by their scoped identifier "<space_path>/<identifier>".
 type GitnessResolver struct {
+	spaceStore     store.SpaceStore
+	userGroupStore store.UserGroupStore
 }
 
-func NewGitnessResolver() *GitnessResolver {
-	return &GitnessResolver{}
+func NewGitnessResolver(
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+) *GitnessResolver {
+	return &GitnessResolver{
+		spaceStore:     spaceStore,
+		userGroupStore: userGroupStore,
+	}
 }
 
-func (s *GitnessResolver) Resolve(context.Context, string) (*types.UserGroup, error) {
-	// todo: implement once usergroup is supported
-	return nil, ErrNotFound
+func (s *GitnessResolver) Resolve(ctx context.Context, scopedID string) (*types.UserGroup, error) {
+	spacePath, identifier, err := paths.DisectLeaf(scopedID)
+	if err != nil || spacePath == "" {
+		return nil, ErrNotFound
+	}
+
+	space, err := s.spaceStore.FindByRef(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of user group: %w", err)
+	}
+
+	userGroup, err := s.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	members, err := s.userGroupStore.ListMembers(ctx, userGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of user group: %w", err)
+	}
+
+	userGroup.Users = make([]string, len(members))
+	for i, member := range members {
+		userGroup.Users[i] = member.UID
+	}
+
+	return userGroup, nil
 }
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+type Config struct {
+	// LDAPGroupSyncSpace is the path of the space the LDAP groups are synced into.
+	LDAPGroupSyncSpace       string
+	LDAPGroupSyncCron        string
+	LDAPGroupSyncMaxDuration time.Duration
+}
+
+// Service is responsible for syncing user groups from external sources.
+type Service struct {
+	config            Config
+	tx                dbtx.Transactor
+	scheduler         *job.Scheduler
+	executor          *job.Executor
+	ldapAuthenticator *ldap.Authenticator
+	spaceStore        store.SpaceStore
+	ldapIdentityStore store.LDAPIdentityStore
+	userGroupStore    store.UserGroupStore
+}
+
+func NewService(
+	config Config,
+	tx dbtx.Transactor,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	ldapAuthenticator *ldap.Authenticator,
+	spaceStore store.SpaceStore,
+	ldapIdentityStore store.LDAPIdentityStore,
+	userGroupStore store.UserGroupStore,
+) *Service {
+	return &Service{
+		config:            config,
+		tx:                tx,
+		scheduler:         scheduler,
+		executor:          executor,
+		ldapAuthenticator: ldapAuthenticator,
+		spaceStore:        spaceStore,
+		ldapIdentityStore: ldapIdentityStore,
+		userGroupStore:    userGroupStore,
+	}
+}
+
+// Register registers the recurring LDAP group sync job, in case the sync of the LDAP groups is enabled.
+func (s *Service) Register(ctx context.Context) error {
+	if s.ldapAuthenticator == nil || !s.ldapAuthenticator.GroupSyncEnabled() {
+		return nil
+	}
+
+	err := s.executor.Register(jobTypeLDAPGroupSync, newLDAPGroupSyncJob(
+		s.config.LDAPGroupSyncSpace,
+		s.tx,
+		s.ldapAuthenticator,
+		s.spaceStore,
+		s.ldapIdentityStore,
+		s.userGroupStore,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for LDAP group sync: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeLDAPGroupSync,
+		jobTypeLDAPGroupSync,
+		s.config.LDAPGroupSyncCron,
+		s.config.LDAPGroupSyncMaxDuration,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule LDAP group sync job: %w", err)
+	}
+
+	return nil
+}
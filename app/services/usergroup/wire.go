@@ -15,14 +15,50 @@
 package usergroup
 
 import (
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
 	"github.com/google/wire"
 )
 
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideUserGroupResolver,
+	ProvideService,
 )
 
-func ProvideUserGroupResolver() Resolver {
-	return NewGitnessResolver()
+func ProvideUserGroupResolver(
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+) Resolver {
+	return NewGitnessResolver(spaceStore, userGroupStore)
+}
+
+func ProvideService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	ldapAuthenticator *ldap.Authenticator,
+	spaceStore store.SpaceStore,
+	ldapIdentityStore store.LDAPIdentityStore,
+	userGroupStore store.UserGroupStore,
+) *Service {
+	return NewService(
+		Config{
+			LDAPGroupSyncSpace:       config.LDAP.GroupSyncSpace,
+			LDAPGroupSyncCron:        config.LDAP.GroupSyncCRON,
+			LDAPGroupSyncMaxDuration: config.LDAP.GroupSyncMaxDuration,
+		},
+		tx,
+		scheduler,
+		executor,
+		ldapAuthenticator,
+		spaceStore,
+		ldapIdentityStore,
+		userGroupStore,
+	)
 }
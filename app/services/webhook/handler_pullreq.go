@@ -359,3 +359,54 @@ func (s *Service) handleEventPullReqComment(
 			}, nil
 		})
 }
+
+// PullReqLabelAssignedPayload describes the body of the pullreq label assigned trigger.
+type PullReqLabelAssignedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqLabelSegment
+}
+
+func (s *Service) handleEventPullReqLabelAssigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelAssignedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqLabelAssigned,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			return &PullReqLabelAssignedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqLabelAssigned,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqLabelSegment: PullReqLabelSegment{
+					LabelInfo: LabelInfo{
+						ID:    event.Payload.LabelID,
+						Key:   event.Payload.Key,
+						Value: event.Payload.Value,
+					},
+				},
+			}, nil
+		})
+}
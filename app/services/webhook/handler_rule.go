@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RuleBypassedPayload describes the body of the rule bypassed trigger.
+type RuleBypassedPayload struct {
+	BaseSegment
+	Refs   []string   `json:"refs"`
+	Rules  []RuleInfo `json:"rules"`
+	Reason string     `json:"reason"`
+	// PullReq is set if the rules were bypassed by merging a pull request.
+	PullReq *PullReqInfo `json:"pull_req,omitempty"`
+}
+
+// handleEventRuleBypassed handles rule bypassed events
+// and triggers rule bypassed webhooks for the repo.
+func (s *Service) handleEventRuleBypassed(ctx context.Context,
+	event *events.Event[*repoevents.RuleBypassedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRuleBypassed,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			rules := make([]RuleInfo, len(event.Payload.Rules))
+			for i := range event.Payload.Rules {
+				rules[i] = ruleInfoFrom(event.Payload.Rules[i])
+			}
+
+			var pullReqInfo *PullReqInfo
+			if event.Payload.PullReqID != 0 {
+				pr, err := s.findPullReqForEvent(ctx, event.Payload.PullReqID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request: %w", err)
+				}
+				info := pullReqInfoFrom(pr, repo, s.urlProvider)
+				pullReqInfo = &info
+			}
+
+			return &RuleBypassedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRuleBypassed,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				Refs:    event.Payload.Refs,
+				Rules:   rules,
+				Reason:  event.Payload.Reason,
+				PullReq: pullReqInfo,
+			}, nil
+		})
+}
@@ -76,6 +76,11 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// PullReqLabelSegment contains details for all pull req label related payloads for webhooks.
+type PullReqLabelSegment struct {
+	LabelInfo LabelInfo `json:"label"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -244,3 +249,30 @@ type CommentInfo struct {
 	ID   int64  `json:"id"`
 	Text string `json:"text"`
 }
+
+// LabelInfo describes a pull request label for a webhook payload.
+type LabelInfo struct {
+	ID    int64  `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// RuleInfo describes a protection rule for a webhook payload.
+type RuleInfo struct {
+	Identifier string         `json:"identifier"`
+	Type       string         `json:"type"`
+	State      enum.RuleState `json:"state"`
+	SpacePath  string         `json:"space_path,omitempty"`
+	RepoPath   string         `json:"repo_path,omitempty"`
+}
+
+// ruleInfoFrom gets the RuleInfo from a types.RuleInfo.
+func ruleInfoFrom(rule types.RuleInfo) RuleInfo {
+	return RuleInfo{
+		Identifier: rule.Identifier,
+		Type:       string(rule.Type),
+		State:      rule.State,
+		SpacePath:  rule.SpacePath,
+		RepoPath:   rule.RepoPath,
+	}
+}
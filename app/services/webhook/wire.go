@@ -19,6 +19,7 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -37,6 +38,7 @@ func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
@@ -47,7 +49,7 @@ func ProvideService(ctx context.Context,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
 ) (*Service, error) {
-	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
+	return NewService(ctx, config, gitReaderFactory, prReaderFactory, repoReaderFactory,
 		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
 		urlProvider, principalStore, git, encrypter)
 }
@@ -17,11 +17,14 @@ package services
 import (
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/reposize"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
 
@@ -39,9 +42,12 @@ type Services struct {
 	JobScheduler       *job.Scheduler
 	MetricCollector    *metric.Collector
 	RepoSizeCalculator *reposize.Calculator
+	Mirror             *mirror.Service
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	UserGroup          *usergroup.Service
+	MergeQueue         *mergequeue.Service
 }
 
 func ProvideServices(
@@ -51,9 +57,12 @@ func ProvideServices(
 	jobScheduler *job.Scheduler,
 	metricCollector *metric.Collector,
 	repoSizeCalculator *reposize.Calculator,
+	mirrorSvc *mirror.Service,
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	userGroupSvc *usergroup.Service,
+	mergeQueueSvc *mergequeue.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -62,8 +71,11 @@ func ProvideServices(
 		JobScheduler:       jobScheduler,
 		MetricCollector:    metricCollector,
 		RepoSizeCalculator: repoSizeCalculator,
+		Mirror:             mirrorSvc,
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		UserGroup:          userGroupSvc,
+		MergeQueue:         mergeQueueSvc,
 	}
 }
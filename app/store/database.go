@@ -224,6 +224,9 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// ListForks returns a list of all direct forks of a repo (including deleted ones).
+		ListForks(ctx context.Context, repoID int64) ([]*types.Repository, error)
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
@@ -242,6 +245,56 @@ type (
 		ListUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) ([]types.MembershipUser, error)
 		CountSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) (int64, error)
 		ListSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) ([]types.MembershipSpace, error)
+		ListSpaceIDs(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
+	// CustomRoleStore defines the custom membership role data storage.
+	CustomRoleStore interface {
+		// FindByIdentifier finds the custom role of a space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.CustomRole, error)
+
+		// FindInherited finds the custom role with the provided identifier that's available in the space,
+		// either because it's defined in the space itself or in any of its ancestors.
+		FindInherited(ctx context.Context, spaceID int64, identifier string) (*types.CustomRole, error)
+
+		// Create creates a new custom role.
+		Create(ctx context.Context, role *types.CustomRole) error
+
+		// Update updates the display name, the description and the permissions of the custom role.
+		Update(ctx context.Context, role *types.CustomRole) error
+
+		// Delete deletes the custom role with the provided id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all custom roles defined in the space.
+		List(ctx context.Context, spaceID int64) ([]*types.CustomRole, error)
+	}
+
+	// RepoMembershipStore defines the repository membership data storage.
+	RepoMembershipStore interface {
+		// Find finds the membership of a principal in a repo.
+		Find(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembership, error)
+
+		// FindUser finds the membership of a principal in a repo including the principal infos.
+		FindUser(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembershipUser, error)
+
+		// Create creates a new membership of a principal in a repo.
+		Create(ctx context.Context, membership *types.RepoMembership) error
+
+		// Update updates the role of the membership of a principal in a repo.
+		Update(ctx context.Context, membership *types.RepoMembership) error
+
+		// Delete deletes the membership of a principal in a repo.
+		Delete(ctx context.Context, key types.RepoMembershipKey) error
+
+		// CountUsers returns the number of members of a repo that match the provided filter.
+		CountUsers(ctx context.Context, repoID int64, filter types.MembershipUserFilter) (int64, error)
+
+		// ListUsers returns the members of a repo that match the provided filter.
+		ListUsers(ctx context.Context, repoID int64, filter types.MembershipUserFilter) ([]types.RepoMembershipUser, error)
+
+		// ListRepoIDs returns the IDs of all repos the principal is a member of.
+		ListRepoIDs(ctx context.Context, principalID int64) ([]int64, error)
 	}
 
 	// TokenStore defines the token data storage.
@@ -269,6 +322,316 @@ type (
 		Count(ctx context.Context, principalID int64, tokenType enum.TokenType) (int64, error)
 	}
 
+	// TwoFactorStore defines the storage of the second factors of users.
+	TwoFactorStore interface {
+		// FindTOTP finds the TOTP secret of a user.
+		FindTOTP(ctx context.Context, principalID int64) (*types.TOTPSecret, error)
+
+		// UpsertTOTP creates the TOTP secret of a user or replaces the existing one.
+		UpsertTOTP(ctx context.Context, secret *types.TOTPSecret) error
+
+		// UseTOTPStep marks the time step of a valid TOTP code as used and confirms the secret.
+		// It returns false if the step or a later one was used already, i.e. the code is replayed.
+		UseTOTPStep(ctx context.Context, principalID int64, step int64, now int64) (bool, error)
+
+		// DeleteTOTP deletes the TOTP secret of a user.
+		DeleteTOTP(ctx context.Context, principalID int64) error
+
+		// ReplaceRecoveryCodes replaces all recovery codes of a user with the provided code hashes.
+		ReplaceRecoveryCodes(ctx context.Context, principalID int64, hashes []string, now int64) error
+
+		// UseRecoveryCode marks the unused recovery code with the provided hash as used.
+		// It returns false if no such code exists.
+		UseRecoveryCode(ctx context.Context, principalID int64, hash string, now int64) (bool, error)
+
+		// CountRecoveryCodes returns the number of unused recovery codes of a user.
+		CountRecoveryCodes(ctx context.Context, principalID int64) (int, error)
+
+		// DeleteRecoveryCodes deletes all recovery codes of a user.
+		DeleteRecoveryCodes(ctx context.Context, principalID int64) error
+
+		// CreateWebAuthnCredential creates a new WebAuthn credential.
+		CreateWebAuthnCredential(ctx context.Context, credential *types.WebAuthnCredential) error
+
+		// UpdateWebAuthnCredential updates the data and the last usage time of a WebAuthn credential.
+		UpdateWebAuthnCredential(ctx context.Context, credential *types.WebAuthnCredential) error
+
+		// DeleteWebAuthnCredential deletes a WebAuthn credential of a user.
+		DeleteWebAuthnCredential(ctx context.Context, principalID int64, id int64) error
+
+		// ListWebAuthnCredentials returns all WebAuthn credentials of a user.
+		ListWebAuthnCredentials(ctx context.Context, principalID int64) ([]*types.WebAuthnCredential, error)
+
+		// FindLockout finds the failed second factor login attempts of a user.
+		// It returns an empty lockout if the user has no failed attempts recorded.
+		FindLockout(ctx context.Context, principalID int64) (*types.TwoFactorLockout, error)
+
+		// RecordFailedAttempt increments the failed second factor login attempts of a user
+		// and returns the updated number of failed attempts.
+		RecordFailedAttempt(ctx context.Context, principalID int64, now int64) (int, error)
+
+		// LockOut locks the second factor login of a user until the provided time,
+		// invalidates all challenges issued until now and resets the failed attempts.
+		LockOut(ctx context.Context, principalID int64, lockedUntil int64, now int64) error
+
+		// ResetFailedAttempts resets the failed second factor login attempts of a user.
+		ResetFailedAttempts(ctx context.Context, principalID int64, now int64) error
+
+		// ListRequiredSpaces returns all spaces whose members have to login with a second factor.
+		ListRequiredSpaces(ctx context.Context) ([]*types.TwoFactorRequiredSpace, error)
+
+		// AddRequiredSpace requires the members of the space to login with a second factor.
+		// Adding a space that's required already is a no-op.
+		AddRequiredSpace(ctx context.Context, requiredSpace *types.TwoFactorRequiredSpace) error
+
+		// DeleteRequiredSpace removes the second factor requirement of the space.
+		DeleteRequiredSpace(ctx context.Context, spaceID int64) error
+	}
+
+	// AuditLogStore defines the audit log data storage.
+	AuditLogStore interface {
+		// Create records a new audit log entry.
+		Create(ctx context.Context, auditLog *types.AuditLog) error
+
+		// Count returns the number of audit log entries of a space that match the provided criteria.
+		// If spaceID is zero the audit log entries of the whole system are counted.
+		Count(ctx context.Context, spaceID int64, filter *types.AuditLogFilter) (int64, error)
+
+		// List returns the audit log entries of a space that match the provided criteria.
+		// If spaceID is zero the audit log entries of the whole system are listed.
+		List(ctx context.Context, spaceID int64, filter *types.AuditLogFilter) ([]*types.AuditLog, error)
+
+		// DeleteOld removes all audit log entries that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// PublicKeyStore defines the public key data storage.
+	PublicKeyStore interface {
+		// Find fetches a public key by its ID.
+		Find(ctx context.Context, id int64) (*types.PublicKey, error)
+
+		// FindByIdentifier fetches a public key of a principal by its identifier.
+		FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.PublicKey, error)
+
+		// Create creates a new public key.
+		Create(ctx context.Context, publicKey *types.PublicKey) error
+
+		// DeleteByIdentifier deletes a public key of a principal.
+		DeleteByIdentifier(ctx context.Context, principalID int64, identifier string) error
+
+		// MarkAsVerified updates the public key to mark it as verified (i.e. it was used to authenticate a principal).
+		MarkAsVerified(ctx context.Context, id int64, verified int64) error
+
+		// Count returns the number of public keys of a principal that match the provided criteria.
+		Count(ctx context.Context, principalID int64, filter *types.PublicKeyFilter) (int, error)
+
+		// List returns the public keys of a principal that match the provided criteria.
+		List(ctx context.Context, principalID int64, filter *types.PublicKeyFilter) ([]types.PublicKey, error)
+
+		// ListByFingerprint returns public keys given a fingerprint and key usage.
+		ListByFingerprint(
+			ctx context.Context,
+			fingerprint string,
+			usages []enum.PublicKeyUsage,
+		) ([]types.PublicKey, error)
+	}
+
+	// LFSObjectStore defines the git lfs object data storage.
+	LFSObjectStore interface {
+		// Find finds the lfs object of a repository by its oid.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds the lfs objects of a repository with the provided oids.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create creates a new lfs object.
+		Create(ctx context.Context, obj *types.LFSObject) error
+
+		// GetTotalSize returns the total size of all lfs objects of a repository.
+		GetTotalSize(ctx context.Context, repoID int64) (int64, error)
+	}
+
+	// LFSLockStore defines the git lfs lock data storage.
+	LFSLockStore interface {
+		// Find finds the lfs lock of a repository by its id.
+		Find(ctx context.Context, repoID, id int64) (*types.LFSLock, error)
+
+		// FindByPath finds the lfs lock of a file in a repository.
+		FindByPath(ctx context.Context, repoID int64, path string) (*types.LFSLock, error)
+
+		// Create creates a new lfs lock.
+		Create(ctx context.Context, lock *types.LFSLock) error
+
+		// Delete deletes the lfs lock with the provided id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the lfs locks of a repository that match the provided filter, ordered by id.
+		List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]*types.LFSLock, error)
+	}
+
+	// PullMirrorStore defines the repository pull mirror data storage.
+	PullMirrorStore interface {
+		// Find finds the pull mirror configuration of a repository.
+		Find(ctx context.Context, repoID int64) (*types.PullMirror, error)
+
+		// Create creates a new pull mirror configuration.
+		Create(ctx context.Context, mirror *types.PullMirror) error
+
+		// Update updates the remote repository, the credentials and the sync interval of the pull mirror.
+		Update(ctx context.Context, mirror *types.PullMirror) error
+
+		// UpdateSyncStatus stores the outcome of the last sync of the pull mirror.
+		UpdateSyncStatus(ctx context.Context, mirror *types.PullMirror) error
+
+		// Delete deletes the pull mirror configuration of a repository.
+		Delete(ctx context.Context, repoID int64) error
+
+		// ListAll returns the pull mirrors of all repositories that aren't deleted or being imported.
+		ListAll(ctx context.Context) ([]*types.PullMirror, error)
+	}
+
+	// PushMirrorStore defines the repository push mirror data storage.
+	PushMirrorStore interface {
+		// Find finds the push mirror by id.
+		Find(ctx context.Context, id int64) (*types.PushMirror, error)
+
+		// FindByIdentifier finds the push mirror of a repository by its identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.PushMirror, error)
+
+		// Create creates a new push mirror.
+		Create(ctx context.Context, mirror *types.PushMirror) error
+
+		// Update updates the identifier, the remote repository and the credentials of the push mirror.
+		Update(ctx context.Context, mirror *types.PushMirror) error
+
+		// UpdateSyncStatus stores the outcome of the last sync of the push mirror.
+		UpdateSyncStatus(ctx context.Context, mirror *types.PushMirror) error
+
+		// Delete deletes the push mirror with the provided id.
+		Delete(ctx context.Context, id int64) error
+
+		// Count returns the number of push mirrors of a repository.
+		Count(ctx context.Context, repoID int64) (int64, error)
+
+		// List returns all push mirrors of a repository, ordered by identifier.
+		List(ctx context.Context, repoID int64) ([]*types.PushMirror, error)
+
+		// ListDue returns the push mirrors of all active repositories
+		// which aren't waiting for a retry at the provided time.
+		ListDue(ctx context.Context, now int64) ([]*types.PushMirror, error)
+	}
+
+	// SecretScanningSettingsStore defines the repository secret scanning settings data storage.
+	SecretScanningSettingsStore interface {
+		// Find finds the secret scanning settings of a repository.
+		Find(ctx context.Context, repoID int64) (*types.SecretScanningSettings, error)
+
+		// Upsert creates or updates the secret scanning settings of a repository.
+		Upsert(ctx context.Context, settings *types.SecretScanningSettings) error
+	}
+
+	// MergeTemplateStore defines the repository merge commit templates data storage.
+	MergeTemplateStore interface {
+		// Find finds the merge commit templates of a repository.
+		Find(ctx context.Context, repoID int64) (*types.MergeTemplates, error)
+
+		// Upsert creates or updates the merge commit templates of a repository.
+		Upsert(ctx context.Context, templates *types.MergeTemplates) error
+	}
+
+	// SecretFindingStore defines the secret scanning findings data storage.
+	SecretFindingStore interface {
+		// Create stores a new secret finding.
+		Create(ctx context.Context, finding *types.SecretFinding) error
+
+		// Count returns the number of secret findings of a repository that match the provided criteria.
+		Count(ctx context.Context, repoID int64, filter *types.SecretFindingFilter) (int64, error)
+
+		// List returns the secret findings of a repository that match the provided criteria, newest first.
+		List(ctx context.Context, repoID int64, filter *types.SecretFindingFilter) ([]*types.SecretFinding, error)
+	}
+
+	// OIDCIdentityStore defines the storage of the identities of users at OpenID Connect identity providers.
+	OIDCIdentityStore interface {
+		// Find finds the OIDC identity by the issuer and the subject.
+		Find(ctx context.Context, issuer, subject string) (*types.OIDCIdentity, error)
+
+		// Create links a new OIDC identity to a user.
+		Create(ctx context.Context, identity *types.OIDCIdentity) error
+
+		// UpdateLastLogin updates the time of the last login of the OIDC identity.
+		UpdateLastLogin(ctx context.Context, id int64, lastLogin int64) error
+	}
+
+	// LDAPIdentityStore defines the storage of the identities of users in LDAP directories.
+	LDAPIdentityStore interface {
+		// Find finds the LDAP identity by the external ID of the directory user.
+		Find(ctx context.Context, externalID string) (*types.LDAPIdentity, error)
+
+		// Create links a new LDAP identity to a user.
+		Create(ctx context.Context, identity *types.LDAPIdentity) error
+
+		// UpdateLastLogin updates the time of the last login and the current DN of the LDAP identity.
+		UpdateLastLogin(ctx context.Context, id int64, dn string, lastLogin int64) error
+	}
+
+	// UserGroupStore defines the user group data storage.
+	UserGroupStore interface {
+		// Find finds the user group by id.
+		Find(ctx context.Context, id int64) (*types.UserGroup, error)
+
+		// FindByIdentifier finds the user group of a space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.UserGroup, error)
+
+		// Create creates a new user group.
+		Create(ctx context.Context, group *types.UserGroup) error
+
+		// Update updates the identifier, the name and the description of the user group.
+		Update(ctx context.Context, group *types.UserGroup) error
+
+		// Delete deletes the user group with the provided id, together with its members.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all user groups of a space, ordered by identifier.
+		List(ctx context.Context, spaceID int64) ([]*types.UserGroup, error)
+
+		// ListMembers returns the members of the user group, ordered by their UID.
+		ListMembers(ctx context.Context, userGroupID int64) ([]types.PrincipalInfo, error)
+
+		// AddMember adds the principal as a member of the user group.
+		AddMember(ctx context.Context, userGroupID int64, principalID int64, now int64) error
+
+		// RemoveMember removes the principal from the members of the user group.
+		RemoveMember(ctx context.Context, userGroupID int64, principalID int64) error
+
+		// SetMembers replaces the members of the user group with the provided principals.
+		SetMembers(ctx context.Context, userGroupID int64, principalIDs []int64, now int64) error
+	}
+
+	// UserGroupMembershipStore defines the storage of the memberships of user groups in spaces.
+	UserGroupMembershipStore interface {
+		// Find finds the membership of a user group in a space.
+		Find(ctx context.Context, key types.UserGroupMembershipKey) (*types.UserGroupMembership, error)
+
+		// Create creates a new membership of a user group in a space.
+		Create(ctx context.Context, membership *types.UserGroupMembership) error
+
+		// Update updates the role of the membership of a user group in a space.
+		Update(ctx context.Context, membership *types.UserGroupMembership) error
+
+		// Delete deletes the membership of a user group in a space.
+		Delete(ctx context.Context, key types.UserGroupMembershipKey) error
+
+		// List returns all user group memberships of a space, ordered by the identifier of the user group.
+		List(ctx context.Context, spaceID int64) ([]types.UserGroupMembershipInfo, error)
+
+		// ListRoles returns the roles the principal has in the space via the memberships of its user groups.
+		ListRoles(ctx context.Context, spaceID int64, principalID int64) ([]enum.MembershipRole, error)
+
+		// ListSpaceIDs returns the IDs of all spaces the principal is a member of via its user groups.
+		ListSpaceIDs(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
 	// PullReqStore defines the pull request data storage.
 	PullReqStore interface {
 		// Find the pull request by id.
@@ -382,6 +745,75 @@ type (
 		List(ctx context.Context, prID int64) ([]*types.PullReqReviewer, error)
 	}
 
+	// LabelStore defines the pull request label data storage.
+	LabelStore interface {
+		// Find finds a label by ID.
+		Find(ctx context.Context, id int64) (*types.Label, error)
+
+		// Create creates a new label.
+		Create(ctx context.Context, label *types.Label) error
+
+		// Update updates the key, the value, the description and the color of a label.
+		Update(ctx context.Context, label *types.Label) error
+
+		// Delete deletes a label, the label is unassigned from all pull requests.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the labels defined in any of the spaces or in the repository, ordered by key and value.
+		List(ctx context.Context, spaceIDs []int64, repoID *int64, query string) ([]*types.Label, error)
+	}
+
+	// PullReqLabelStore defines the storage of labels assigned to pull requests.
+	PullReqLabelStore interface {
+		// Assign assigns a label to a pull request, it's a no-op if the label is already assigned.
+		Assign(ctx context.Context, prLabel *types.PullReqLabel) error
+
+		// Unassign removes a label from a pull request.
+		Unassign(ctx context.Context, prID, labelID int64) error
+
+		// ListLabels returns the labels assigned to a pull request, ordered by key and value.
+		ListLabels(ctx context.Context, prID int64) ([]*types.Label, error)
+	}
+
+	// MergeQueueStore defines the merge queue data storage.
+	MergeQueueStore interface {
+		// Find finds the merge queue entry of a pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error)
+
+		// FindByMergeSHA finds the merge queue entry whose speculative merge commit is the provided commit.
+		FindByMergeSHA(ctx context.Context, repoID int64, mergeSHA string) (*types.MergeQueueEntry, error)
+
+		// Create adds a pull request to the end of the merge queue of its target branch.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state and the speculative merge commit of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes a pull request from the merge queue.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// ListForBranch returns the merge queue of a branch, ordered by the queue position.
+		ListForBranch(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error)
+
+		// ListBranches returns all branches with a non-empty merge queue.
+		ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error)
+	}
+
+	// AutoMergeStore defines the pull request auto-merge data storage.
+	AutoMergeStore interface {
+		// Find finds the auto-merge of a pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error)
+
+		// Upsert enables auto-merge for a pull request or updates its merge method and actor.
+		Upsert(ctx context.Context, autoMerge *types.AutoMerge) error
+
+		// Delete disables auto-merge for a pull request.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// ListForRepo returns all auto-merges of pull requests targeting a repository.
+		ListForRepo(ctx context.Context, repoID int64) ([]*types.AutoMerge, error)
+	}
+
 	// PullReqFileViewStore stores information about what file a user viewed.
 	PullReqFileViewStore interface {
 		// Upsert inserts or updates the latest viewed sha for a file in a PR.
@@ -744,9 +1176,4 @@ type (
 		// Find returns a plugin given a name and a version.
 		Find(ctx context.Context, name, version string) (*types.Plugin, error)
 	}
-
-	UserGroupStore interface {
-		// FindByIdentifier returns a types.UserGroup given a space ID and identifier.
-		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.UserGroup, error)
-	}
 )
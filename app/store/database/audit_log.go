@@ -0,0 +1,361 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AuditLogStore = (*AuditLogStore)(nil)
+
+// NewAuditLogStore returns a new AuditLogStore.
+func NewAuditLogStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *AuditLogStore {
+	return &AuditLogStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// AuditLogStore implements a store.AuditLogStore backed by a relational database.
+type AuditLogStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type auditLog struct {
+	ID                 int64    `db:"audit_log_id"`
+	SpaceID            null.Int `db:"audit_log_space_id"`
+	Action             string   `db:"audit_log_action"`
+	ResourceType       string   `db:"audit_log_resource_type"`
+	ResourceIdentifier string   `db:"audit_log_resource_identifier"`
+	ResourcePath       string   `db:"audit_log_resource_path"`
+	PrincipalID        int64    `db:"audit_log_principal_id"`
+	ClientIP           string   `db:"audit_log_client_ip"`
+	Diff               string   `db:"audit_log_diff"`
+	Data               string   `db:"audit_log_data"`
+	Created            int64    `db:"audit_log_created"`
+}
+
+const (
+	auditLogColumns = `
+		 audit_log_id
+		,audit_log_space_id
+		,audit_log_action
+		,audit_log_resource_type
+		,audit_log_resource_identifier
+		,audit_log_resource_path
+		,audit_log_principal_id
+		,audit_log_client_ip
+		,audit_log_diff
+		,audit_log_data
+		,audit_log_created`
+)
+
+// Create records a new audit log entry.
+func (s *AuditLogStore) Create(ctx context.Context, auditLog *types.AuditLog) error {
+	const sqlQuery = `
+		INSERT INTO audit_logs (
+			 audit_log_space_id
+			,audit_log_action
+			,audit_log_resource_type
+			,audit_log_resource_identifier
+			,audit_log_resource_path
+			,audit_log_principal_id
+			,audit_log_client_ip
+			,audit_log_diff
+			,audit_log_data
+			,audit_log_created
+		) values (
+			 :audit_log_space_id
+			,:audit_log_action
+			,:audit_log_resource_type
+			,:audit_log_resource_identifier
+			,:audit_log_resource_path
+			,:audit_log_principal_id
+			,:audit_log_client_ip
+			,:audit_log_diff
+			,:audit_log_data
+			,:audit_log_created
+		) RETURNING audit_log_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbAuditLog, err := mapToInternalAuditLog(auditLog)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbAuditLog)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind audit log object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&auditLog.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert audit log")
+	}
+
+	return nil
+}
+
+// Count returns the number of audit log entries of a space that match the provided criteria.
+func (s *AuditLogStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.AuditLogFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_logs")
+
+	stmt, err := s.applySpaceFilter(ctx, stmt, spaceID, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt = s.applyQueryFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing audit log count query")
+	}
+
+	return count, nil
+}
+
+// List returns the audit log entries of a space that match the provided criteria, newest first.
+func (s *AuditLogStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, error) {
+	stmt := database.Builder.
+		Select(auditLogColumns).
+		From("audit_logs")
+
+	stmt, err := s.applySpaceFilter(ctx, stmt, spaceID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt = s.applyQueryFilter(stmt, filter)
+	stmt = stmt.
+		OrderBy("audit_log_created DESC", "audit_log_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*auditLog, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing audit log list query")
+	}
+
+	return s.mapSliceAuditLog(ctx, dst)
+}
+
+// DeleteOld removes all audit log entries that are older than the provided time.
+func (s *AuditLogStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_logs").
+		Where("audit_log_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to execute delete audit logs query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to get number of deleted audit logs")
+	}
+
+	return n, nil
+}
+
+func (s *AuditLogStore) applySpaceFilter(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	spaceID int64,
+	filter *types.AuditLogFilter,
+) (squirrel.SelectBuilder, error) {
+	if spaceID == 0 {
+		return stmt, nil
+	}
+
+	if !filter.Recursive {
+		return stmt.Where("audit_log_space_id = ?", spaceID), nil
+	}
+
+	const sqlQuery = `WITH RECURSIVE SpaceHierarchy AS (
+    SELECT space_id, space_parent_id
+    FROM spaces
+    WHERE space_id = $1
+
+    UNION
+
+    SELECT s.space_id, s.space_parent_id
+    FROM spaces s
+    JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
+)
+SELECT space_id
+FROM SpaceHierarchy h1;`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var spaceIDs []int64
+	if err := db.SelectContext(ctx, &spaceIDs, sqlQuery, spaceID); err != nil {
+		return stmt, database.ProcessSQLErrorf(err, "failed to retrieve spaces")
+	}
+
+	return stmt.Where(squirrel.Eq{"audit_log_space_id": spaceIDs}), nil
+}
+
+func (*AuditLogStore) applyQueryFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditLogFilter,
+) squirrel.SelectBuilder {
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_log_action": filter.Actions})
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_log_resource_type": filter.ResourceTypes})
+	}
+
+	if filter.PrincipalID > 0 {
+		stmt = stmt.Where("audit_log_principal_id = ?", filter.PrincipalID)
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_log_created > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_log_created < ?", filter.CreatedLt)
+	}
+
+	return stmt
+}
+
+func (s *AuditLogStore) mapSliceAuditLog(
+	ctx context.Context,
+	auditLogs []*auditLog,
+) ([]*types.AuditLog, error) {
+	ids := make([]int64, len(auditLogs))
+	for i, l := range auditLogs {
+		ids[i] = l.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit log principal infos: %w", err)
+	}
+
+	m := make([]*types.AuditLog, len(auditLogs))
+	for i, l := range auditLogs {
+		if m[i], err = mapToAuditLog(l); err != nil {
+			return nil, err
+		}
+
+		m[i].Actor = infoMap[l.PrincipalID]
+	}
+
+	return m, nil
+}
+
+func mapToInternalAuditLog(in *types.AuditLog) (*auditLog, error) {
+	diff, err := json.Marshal(in.Diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit log diff: %w", err)
+	}
+
+	data, err := json.Marshal(in.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit log data: %w", err)
+	}
+
+	return &auditLog{
+		ID:                 in.ID,
+		SpaceID:            null.IntFromPtr(in.SpaceID),
+		Action:             string(in.Action),
+		ResourceType:       string(in.ResourceType),
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourcePath:       in.ResourcePath,
+		PrincipalID:        in.PrincipalID,
+		ClientIP:           in.ClientIP,
+		Diff:               string(diff),
+		Data:               string(data),
+		Created:            in.Created,
+	}, nil
+}
+
+func mapToAuditLog(in *auditLog) (*types.AuditLog, error) {
+	l := &types.AuditLog{
+		ID:                 in.ID,
+		SpaceID:            in.SpaceID.Ptr(),
+		Action:             enum.AuditAction(in.Action),
+		ResourceType:       enum.AuditResourceType(in.ResourceType),
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourcePath:       in.ResourcePath,
+		PrincipalID:        in.PrincipalID,
+		ClientIP:           in.ClientIP,
+		Created:            in.Created,
+	}
+
+	if err := json.Unmarshal([]byte(in.Diff), &l.Diff); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit log diff: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(in.Data), &l.Data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit log data: %w", err)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AutoMergeStore = (*AutoMergeStore)(nil)
+
+// NewAutoMergeStore returns a new AutoMergeStore.
+func NewAutoMergeStore(db *sqlx.DB) *AutoMergeStore {
+	return &AutoMergeStore{
+		db: db,
+	}
+}
+
+// AutoMergeStore implements a store.AutoMergeStore backed by a relational database.
+type AutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type autoMerge struct {
+	PullReqID int64            `db:"auto_merge_pullreq_id"`
+	RepoID    int64            `db:"auto_merge_repo_id"`
+	Method    enum.MergeMethod `db:"auto_merge_method"`
+	EnabledBy int64            `db:"auto_merge_enabled_by"`
+	Created   int64            `db:"auto_merge_created"`
+	Updated   int64            `db:"auto_merge_updated"`
+}
+
+const (
+	autoMergeColumns = `
+		 auto_merge_pullreq_id
+		,auto_merge_repo_id
+		,auto_merge_method
+		,auto_merge_enabled_by
+		,auto_merge_created
+		,auto_merge_updated`
+)
+
+// Find finds the auto-merge of a pull request.
+func (s *AutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("pullreq_auto_merges").
+		Where("auto_merge_pullreq_id = ?", pullReqID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &autoMerge{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull request auto-merge")
+	}
+
+	return mapToAutoMerge(dst), nil
+}
+
+// Upsert enables auto-merge for a pull request or updates its merge method and actor.
+func (s *AutoMergeStore) Upsert(ctx context.Context, autoMerge *types.AutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_auto_merges (
+			 auto_merge_pullreq_id
+			,auto_merge_repo_id
+			,auto_merge_method
+			,auto_merge_enabled_by
+			,auto_merge_created
+			,auto_merge_updated
+		) values (
+			 :auto_merge_pullreq_id
+			,:auto_merge_repo_id
+			,:auto_merge_method
+			,:auto_merge_enabled_by
+			,:auto_merge_created
+			,:auto_merge_updated
+		)
+		ON CONFLICT (auto_merge_pullreq_id) DO
+		UPDATE SET
+			 auto_merge_method = :auto_merge_method
+			,auto_merge_enabled_by = :auto_merge_enabled_by
+			,auto_merge_updated = :auto_merge_updated
+		RETURNING auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalAutoMerge(autoMerge))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request auto-merge object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&autoMerge.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert pull request auto-merge query failed")
+	}
+
+	return nil
+}
+
+// Delete disables auto-merge for a pull request.
+func (s *AutoMergeStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_auto_merges
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullReqID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pull request auto-merge")
+	}
+
+	return nil
+}
+
+// ListForRepo returns all auto-merges of pull requests targeting a repository.
+func (s *AutoMergeStore) ListForRepo(ctx context.Context, repoID int64) ([]*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("pullreq_auto_merges").
+		Where("auto_merge_repo_id = ?", repoID).
+		OrderBy("auto_merge_pullreq_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*autoMerge
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list pull request auto-merges query")
+	}
+
+	res := make([]*types.AutoMerge, len(dst))
+	for i := range dst {
+		res[i] = mapToAutoMerge(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToInternalAutoMerge(m *types.AutoMerge) *autoMerge {
+	return &autoMerge{
+		PullReqID: m.PullReqID,
+		RepoID:    m.RepoID,
+		Method:    m.Method,
+		EnabledBy: m.EnabledBy,
+		Created:   m.Created,
+		Updated:   m.Updated,
+	}
+}
+
+func mapToAutoMerge(m *autoMerge) *types.AutoMerge {
+	return &types.AutoMerge{
+		PullReqID: m.PullReqID,
+		RepoID:    m.RepoID,
+		Method:    m.Method,
+		EnabledBy: m.EnabledBy,
+		Created:   m.Created,
+		Updated:   m.Updated,
+	}
+}
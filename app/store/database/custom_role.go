@@ -0,0 +1,303 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CustomRoleStore = (*CustomRoleStore)(nil)
+
+// NewCustomRoleStore returns a new CustomRoleStore.
+func NewCustomRoleStore(db *sqlx.DB) *CustomRoleStore {
+	return &CustomRoleStore{
+		db: db,
+	}
+}
+
+// CustomRoleStore implements a store.CustomRoleStore backed by a relational database.
+type CustomRoleStore struct {
+	db *sqlx.DB
+}
+
+type customRole struct {
+	ID          int64  `db:"custom_role_id"`
+	SpaceID     int64  `db:"custom_role_space_id"`
+	Identifier  string `db:"custom_role_identifier"`
+	DisplayName string `db:"custom_role_display_name"`
+	Description string `db:"custom_role_description"`
+	Permissions string `db:"custom_role_permissions"`
+	CreatedBy   int64  `db:"custom_role_created_by"`
+	Created     int64  `db:"custom_role_created"`
+	Updated     int64  `db:"custom_role_updated"`
+}
+
+const (
+	customRoleColumns = `
+		 custom_role_id
+		,custom_role_space_id
+		,custom_role_identifier
+		,custom_role_display_name
+		,custom_role_description
+		,custom_role_permissions
+		,custom_role_created_by
+		,custom_role_created
+		,custom_role_updated`
+)
+
+// FindByIdentifier finds the custom role of a space by its identifier.
+func (s *CustomRoleStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.CustomRole, error) {
+	stmt := database.Builder.
+		Select(customRoleColumns).
+		From("custom_roles").
+		Where("custom_role_space_id = ?", spaceID).
+		Where("LOWER(custom_role_identifier) = ?", strings.ToLower(identifier))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &customRole{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find custom role")
+	}
+
+	return mapToCustomRole(dst), nil
+}
+
+// FindInherited finds the custom role with the provided identifier that's available in the space.
+// The role is searched in the space itself and then in its ancestors, starting with the closest one.
+func (s *CustomRoleStore) FindInherited(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.CustomRole, error) {
+	const sqlQuery = `
+		WITH RECURSIVE
+			space_parents(space_id, space_parent_id, space_depth) AS (
+				SELECT space_id, space_parent_id, 0
+				FROM spaces
+				WHERE space_id = $1
+				UNION ALL
+				SELECT spaces.space_id, spaces.space_parent_id, space_parents.space_depth + 1
+				FROM spaces
+				INNER JOIN space_parents ON space_parents.space_parent_id = spaces.space_id
+			)
+		SELECT` + customRoleColumns + `
+		FROM custom_roles
+		INNER JOIN space_parents ON space_parents.space_id = custom_role_space_id
+		WHERE LOWER(custom_role_identifier) = $2
+		ORDER BY space_parents.space_depth
+		LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &customRole{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find inherited custom role")
+	}
+
+	return mapToCustomRole(dst), nil
+}
+
+// Create creates a new custom role.
+func (s *CustomRoleStore) Create(ctx context.Context, role *types.CustomRole) error {
+	const sqlQuery = `
+		INSERT INTO custom_roles (
+			 custom_role_space_id
+			,custom_role_identifier
+			,custom_role_display_name
+			,custom_role_description
+			,custom_role_permissions
+			,custom_role_created_by
+			,custom_role_created
+			,custom_role_updated
+		) values (
+			 :custom_role_space_id
+			,:custom_role_identifier
+			,:custom_role_display_name
+			,:custom_role_description
+			,:custom_role_permissions
+			,:custom_role_created_by
+			,:custom_role_created
+			,:custom_role_updated
+		) RETURNING custom_role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalCustomRole(role))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind custom role")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&role.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert custom role query failed")
+	}
+
+	return nil
+}
+
+// Update updates the display name, the description and the permissions of the custom role.
+func (s *CustomRoleStore) Update(ctx context.Context, role *types.CustomRole) error {
+	const sqlQuery = `
+		UPDATE custom_roles
+		SET
+			 custom_role_display_name = :custom_role_display_name
+			,custom_role_description = :custom_role_description
+			,custom_role_permissions = :custom_role_permissions
+			,custom_role_updated = :custom_role_updated
+		WHERE custom_role_id = :custom_role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalCustomRole(role))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind custom role")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update custom role")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the custom role with the provided id.
+func (s *CustomRoleStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM custom_roles
+		WHERE custom_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete custom role")
+	}
+
+	return nil
+}
+
+// List returns all custom roles defined in the space, ordered by identifier.
+func (s *CustomRoleStore) List(ctx context.Context, spaceID int64) ([]*types.CustomRole, error) {
+	stmt := database.Builder.
+		Select(customRoleColumns).
+		From("custom_roles").
+		Where("custom_role_space_id = ?", spaceID).
+		OrderBy("LOWER(custom_role_identifier)")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*customRole
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list custom roles query")
+	}
+
+	res := make([]*types.CustomRole, len(dst))
+	for i := range dst {
+		res[i] = mapToCustomRole(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToCustomRole(r *customRole) *types.CustomRole {
+	return &types.CustomRole{
+		ID:          r.ID,
+		SpaceID:     r.SpaceID,
+		Identifier:  r.Identifier,
+		DisplayName: r.DisplayName,
+		Description: r.Description,
+		Permissions: permissionsFromString(r.Permissions),
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+	}
+}
+
+func mapToInternalCustomRole(r *types.CustomRole) *customRole {
+	return &customRole{
+		ID:          r.ID,
+		SpaceID:     r.SpaceID,
+		Identifier:  r.Identifier,
+		DisplayName: r.DisplayName,
+		Description: r.Description,
+		Permissions: permissionsToString(r.Permissions),
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+	}
+}
+
+// permissionsSeparator defines the character that's used to join permissions for storing them in the DB.
+// ASSUMPTION: permissions are defined in an enum and don't contain ",".
+const permissionsSeparator = ","
+
+func permissionsFromString(s string) []enum.Permission {
+	if s == "" {
+		return nil
+	}
+
+	rawPermissions := strings.Split(s, permissionsSeparator)
+
+	permissions := make([]enum.Permission, len(rawPermissions))
+	for i, rawPermission := range rawPermissions {
+		// ASSUMPTION: permission is valid value (as we wrote it to DB)
+		permissions[i] = enum.Permission(rawPermission)
+	}
+
+	return permissions
+}
+
+func permissionsToString(permissions []enum.Permission) string {
+	rawPermissions := make([]string, len(permissions))
+	for i := range permissions {
+		rawPermissions[i] = string(permissions[i])
+	}
+
+	return strings.Join(rawPermissions, permissionsSeparator)
+}
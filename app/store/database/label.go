@@ -0,0 +1,373 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LabelStore = (*LabelStore)(nil)
+
+// NewLabelStore returns a new LabelStore.
+func NewLabelStore(db *sqlx.DB) *LabelStore {
+	return &LabelStore{
+		db: db,
+	}
+}
+
+// LabelStore implements a store.LabelStore backed by a relational database.
+type LabelStore struct {
+	db *sqlx.DB
+}
+
+type label struct {
+	ID          int64           `db:"label_id"`
+	SpaceID     null.Int        `db:"label_space_id"`
+	RepoID      null.Int        `db:"label_repo_id"`
+	Key         string          `db:"label_key"`
+	Value       string          `db:"label_value"`
+	Description string          `db:"label_description"`
+	Color       enum.LabelColor `db:"label_color"`
+	CreatedBy   int64           `db:"label_created_by"`
+	Created     int64           `db:"label_created"`
+	Updated     int64           `db:"label_updated"`
+}
+
+const (
+	labelColumns = `
+		 label_id
+		,label_space_id
+		,label_repo_id
+		,label_key
+		,label_value
+		,label_description
+		,label_color
+		,label_created_by
+		,label_created
+		,label_updated`
+)
+
+// Find finds a label by ID.
+func (s *LabelStore) Find(ctx context.Context, id int64) (*types.Label, error) {
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels").
+		Where("label_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &label{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find label")
+	}
+
+	return mapToLabel(dst), nil
+}
+
+// Create creates a new label.
+func (s *LabelStore) Create(ctx context.Context, label *types.Label) error {
+	const sqlQuery = `
+		INSERT INTO labels (
+			 label_space_id
+			,label_repo_id
+			,label_key
+			,label_value
+			,label_description
+			,label_color
+			,label_created_by
+			,label_created
+			,label_updated
+		) values (
+			 :label_space_id
+			,:label_repo_id
+			,:label_key
+			,:label_value
+			,:label_description
+			,:label_color
+			,:label_created_by
+			,:label_created
+			,:label_updated
+		) RETURNING label_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalLabel(label))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind label")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&label.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert label query failed")
+	}
+
+	return nil
+}
+
+// Update updates the key, the value, the description and the color of a label.
+func (s *LabelStore) Update(ctx context.Context, label *types.Label) error {
+	const sqlQuery = `
+		UPDATE labels
+		SET
+			 label_key = :label_key
+			,label_value = :label_value
+			,label_description = :label_description
+			,label_color = :label_color
+			,label_updated = :label_updated
+		WHERE label_id = :label_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalLabel(label))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind label")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update label")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a label, the label is unassigned from all pull requests.
+func (s *LabelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM labels
+		WHERE label_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete label")
+	}
+
+	return nil
+}
+
+// List returns the labels defined in any of the spaces or in the repository, ordered by key and value.
+func (s *LabelStore) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	repoID *int64,
+	query string,
+) ([]*types.Label, error) {
+	parents := squirrel.Or{}
+	if len(spaceIDs) > 0 {
+		parents = append(parents, squirrel.Eq{"label_space_id": spaceIDs})
+	}
+	if repoID != nil {
+		parents = append(parents, squirrel.Eq{"label_repo_id": *repoID})
+	}
+
+	if len(parents) == 0 {
+		return []*types.Label{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels").
+		Where(parents).
+		OrderBy("LOWER(label_key)", "LOWER(label_value)", "label_id")
+
+	if query != "" {
+		stmt = stmt.Where("LOWER(label_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*label
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list labels query")
+	}
+
+	return mapToLabels(dst), nil
+}
+
+var _ store.PullReqLabelStore = (*PullReqLabelStore)(nil)
+
+// NewPullReqLabelStore returns a new PullReqLabelStore.
+func NewPullReqLabelStore(db *sqlx.DB) *PullReqLabelStore {
+	return &PullReqLabelStore{
+		db: db,
+	}
+}
+
+// PullReqLabelStore implements a store.PullReqLabelStore backed by a relational database.
+type PullReqLabelStore struct {
+	db *sqlx.DB
+}
+
+type pullReqLabel struct {
+	PullReqID  int64 `db:"pullreq_label_pullreq_id"`
+	LabelID    int64 `db:"pullreq_label_label_id"`
+	AssignedBy int64 `db:"pullreq_label_assigned_by"`
+	Assigned   int64 `db:"pullreq_label_assigned"`
+}
+
+// Assign assigns a label to a pull request, it's a no-op if the label is already assigned.
+func (s *PullReqLabelStore) Assign(ctx context.Context, prLabel *types.PullReqLabel) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_labels (
+			 pullreq_label_pullreq_id
+			,pullreq_label_label_id
+			,pullreq_label_assigned_by
+			,pullreq_label_assigned
+		) values (
+			 :pullreq_label_pullreq_id
+			,:pullreq_label_label_id
+			,:pullreq_label_assigned_by
+			,:pullreq_label_assigned
+		)
+		ON CONFLICT (pullreq_label_pullreq_id, pullreq_label_label_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, &pullReqLabel{
+		PullReqID:  prLabel.PullReqID,
+		LabelID:    prLabel.LabelID,
+		AssignedBy: prLabel.AssignedBy,
+		Assigned:   prLabel.Assigned,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request label")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert pull request label query failed")
+	}
+
+	return nil
+}
+
+// Unassign removes a label from a pull request.
+func (s *PullReqLabelStore) Unassign(ctx context.Context, prID, labelID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_labels
+		WHERE pullreq_label_pullreq_id = $1 AND pullreq_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID, labelID)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pull request label")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListLabels returns the labels assigned to a pull request, ordered by key and value.
+func (s *PullReqLabelStore) ListLabels(ctx context.Context, prID int64) ([]*types.Label, error) {
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("pullreq_labels").
+		InnerJoin("labels ON label_id = pullreq_label_label_id").
+		Where("pullreq_label_pullreq_id = ?", prID).
+		OrderBy("LOWER(label_key)", "LOWER(label_value)", "label_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*label
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list pull request labels query")
+	}
+
+	return mapToLabels(dst), nil
+}
+
+func mapToLabel(l *label) *types.Label {
+	return &types.Label{
+		ID:          l.ID,
+		SpaceID:     l.SpaceID.Ptr(),
+		RepoID:      l.RepoID.Ptr(),
+		Key:         l.Key,
+		Value:       l.Value,
+		Description: l.Description,
+		Color:       l.Color,
+		CreatedBy:   l.CreatedBy,
+		Created:     l.Created,
+		Updated:     l.Updated,
+	}
+}
+
+func mapToLabels(labels []*label) []*types.Label {
+	res := make([]*types.Label, len(labels))
+	for i := range labels {
+		res[i] = mapToLabel(labels[i])
+	}
+
+	return res
+}
+
+func mapToInternalLabel(l *types.Label) *label {
+	return &label{
+		ID:          l.ID,
+		SpaceID:     null.IntFromPtr(l.SpaceID),
+		RepoID:      null.IntFromPtr(l.RepoID),
+		Key:         l.Key,
+		Value:       l.Value,
+		Description: l.Description,
+		Color:       l.Color,
+		CreatedBy:   l.CreatedBy,
+		Created:     l.Created,
+		Updated:     l.Updated,
+	}
+}
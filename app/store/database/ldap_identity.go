@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LDAPIdentityStore = (*LDAPIdentityStore)(nil)
+
+// NewLDAPIdentityStore returns a new LDAPIdentityStore.
+func NewLDAPIdentityStore(db *sqlx.DB) *LDAPIdentityStore {
+	return &LDAPIdentityStore{
+		db: db,
+	}
+}
+
+// LDAPIdentityStore implements a store.LDAPIdentityStore backed by a relational database.
+type LDAPIdentityStore struct {
+	db *sqlx.DB
+}
+
+const (
+	ldapIdentityColumns = `
+		 ldap_identity_id
+		,ldap_identity_principal_id
+		,ldap_identity_external_id
+		,ldap_identity_dn
+		,ldap_identity_created
+		,ldap_identity_last_login`
+)
+
+// Find finds the LDAP identity by the external ID of the directory user.
+func (s *LDAPIdentityStore) Find(ctx context.Context, externalID string) (*types.LDAPIdentity, error) {
+	stmt := database.Builder.
+		Select(ldapIdentityColumns).
+		From("ldap_identities").
+		Where("ldap_identity_external_id = ?", externalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.LDAPIdentity{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find LDAP identity")
+	}
+
+	return dst, nil
+}
+
+// Create links a new LDAP identity to a user.
+func (s *LDAPIdentityStore) Create(ctx context.Context, identity *types.LDAPIdentity) error {
+	const sqlQuery = `
+		INSERT INTO ldap_identities (
+			 ldap_identity_principal_id
+			,ldap_identity_external_id
+			,ldap_identity_dn
+			,ldap_identity_created
+			,ldap_identity_last_login
+		) values (
+			 :ldap_identity_principal_id
+			,:ldap_identity_external_id
+			,:ldap_identity_dn
+			,:ldap_identity_created
+			,:ldap_identity_last_login
+		) RETURNING ldap_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, identity)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind LDAP identity")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert LDAP identity query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastLogin updates the time of the last login and the current DN of the LDAP identity.
+func (s *LDAPIdentityStore) UpdateLastLogin(ctx context.Context, id int64, dn string, lastLogin int64) error {
+	stmt := database.Builder.
+		Update("ldap_identities").
+		Set("ldap_identity_dn", dn).
+		Set("ldap_identity_last_login", lastLogin).
+		Where("ldap_identity_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update LDAP identity")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
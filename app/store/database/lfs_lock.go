@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.LFSLockStore = (*LFSLockStore)(nil)
+
+// NewLFSLockStore returns a new LFSLockStore.
+func NewLFSLockStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *LFSLockStore {
+	return &LFSLockStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// LFSLockStore implements a store.LFSLockStore backed by a relational database.
+type LFSLockStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type lfsLock struct {
+	ID        int64  `db:"lfs_lock_id"`
+	RepoID    int64  `db:"lfs_lock_repo_id"`
+	Path      string `db:"lfs_lock_path"`
+	Ref       string `db:"lfs_lock_ref"`
+	Created   int64  `db:"lfs_lock_created"`
+	CreatedBy int64  `db:"lfs_lock_created_by"`
+}
+
+const (
+	lfsLockColumns = `
+		 lfs_lock_id
+		,lfs_lock_repo_id
+		,lfs_lock_path
+		,lfs_lock_ref
+		,lfs_lock_created
+		,lfs_lock_created_by`
+
+	lfsLockSelectBase = `
+		SELECT` + lfsLockColumns + `
+		FROM lfs_locks`
+)
+
+// Find finds the lfs lock of a repository by its id.
+func (s *LFSLockStore) Find(ctx context.Context, repoID, id int64) (*types.LFSLock, error) {
+	const sqlQuery = lfsLockSelectBase + `
+		WHERE lfs_lock_repo_id = $1 AND lfs_lock_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find lfs lock")
+	}
+
+	return s.mapToLFSLock(ctx, dst), nil
+}
+
+// FindByPath finds the lfs lock of a file in a repository.
+func (s *LFSLockStore) FindByPath(ctx context.Context, repoID int64, path string) (*types.LFSLock, error) {
+	const sqlQuery = lfsLockSelectBase + `
+		WHERE lfs_lock_repo_id = $1 AND lfs_lock_path = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, path); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find lfs lock by path")
+	}
+
+	return s.mapToLFSLock(ctx, dst), nil
+}
+
+// Create creates a new lfs lock.
+func (s *LFSLockStore) Create(ctx context.Context, lock *types.LFSLock) error {
+	const sqlQuery = `
+		INSERT INTO lfs_locks (
+			 lfs_lock_repo_id
+			,lfs_lock_path
+			,lfs_lock_ref
+			,lfs_lock_created
+			,lfs_lock_created_by
+		) values (
+			 :lfs_lock_repo_id
+			,:lfs_lock_path
+			,:lfs_lock_ref
+			,:lfs_lock_created
+			,:lfs_lock_created_by
+		) RETURNING lfs_lock_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalLFSLock(lock))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind lfs lock")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&lock.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert lfs lock query failed")
+	}
+
+	owner, err := s.pCache.Get(ctx, lock.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load lfs lock owner")
+	}
+	if owner != nil {
+		lock.Owner = *owner
+	}
+
+	return nil
+}
+
+// Delete deletes the lfs lock with the provided id.
+func (s *LFSLockStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM lfs_locks
+		WHERE lfs_lock_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "The delete lfs lock query failed")
+	}
+
+	return nil
+}
+
+// List returns the lfs locks of a repository that match the provided filter, ordered by id.
+func (s *LFSLockStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+) ([]*types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID).
+		OrderBy("lfs_lock_id ASC")
+
+	if filter.ID != 0 {
+		stmt = stmt.Where("lfs_lock_id = ?", filter.ID)
+	}
+	if filter.Path != "" {
+		stmt = stmt.Where("lfs_lock_path = ?", filter.Path)
+	}
+	if filter.CreatedBy != 0 {
+		stmt = stmt.Where("lfs_lock_created_by = ?", filter.CreatedBy)
+	}
+	if filter.AfterID != 0 {
+		stmt = stmt.Where("lfs_lock_id > ?", filter.AfterID)
+	}
+	if filter.Limit > 0 {
+		stmt = stmt.Limit(uint64(filter.Limit))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*lfsLock
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list lfs locks query")
+	}
+
+	return s.mapToLFSLocks(ctx, dst), nil
+}
+
+func (s *LFSLockStore) mapToLFSLock(ctx context.Context, in *lfsLock) *types.LFSLock {
+	lock := &types.LFSLock{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Path:      in.Path,
+		Ref:       in.Ref,
+		Created:   in.Created,
+		CreatedBy: in.CreatedBy,
+	}
+
+	owner, err := s.pCache.Get(ctx, in.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load lfs lock owner")
+	}
+	if owner != nil {
+		lock.Owner = *owner
+	}
+
+	return lock
+}
+
+func (s *LFSLockStore) mapToLFSLocks(ctx context.Context, locks []*lfsLock) []*types.LFSLock {
+	res := make([]*types.LFSLock, len(locks))
+	for i := range locks {
+		res[i] = s.mapToLFSLock(ctx, locks[i])
+	}
+	return res
+}
+
+func mapToInternalLFSLock(lock *types.LFSLock) *lfsLock {
+	return &lfsLock{
+		ID:        lock.ID,
+		RepoID:    lock.RepoID,
+		Path:      lock.Path,
+		Ref:       lock.Ref,
+		Created:   lock.Created,
+		CreatedBy: lock.CreatedBy,
+	}
+}
@@ -363,6 +363,28 @@ func (s *MembershipStore) ListSpaces(ctx context.Context,
 	return result, nil
 }
 
+// ListSpaceIDs returns the IDs of all spaces the principal is a direct member of.
+func (s *MembershipStore) ListSpaceIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	stmt := database.Builder.
+		Select("membership_space_id").
+		From("memberships").
+		Where("membership_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert membership space ids query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var spaceIDs []int64
+	if err = db.SelectContext(ctx, &spaceIDs, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing membership space ids query")
+	}
+
+	return spaceIDs, nil
+}
+
 func applyMembershipSpaceFilter(
 	stmt squirrel.SelectBuilder,
 	opts types.MembershipSpaceFilter,
@@ -0,0 +1,321 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	PullReqID          int64                     `db:"merge_queue_entry_pullreq_id"`
+	RepoID             int64                     `db:"merge_queue_entry_repo_id"`
+	TargetBranch       string                    `db:"merge_queue_entry_target_branch"`
+	Method             enum.MergeMethod          `db:"merge_queue_entry_method"`
+	Title              string                    `db:"merge_queue_entry_title"`
+	Message            string                    `db:"merge_queue_entry_message"`
+	State              enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+	SourceSHA          string                    `db:"merge_queue_entry_source_sha"`
+	BaseSHA            string                    `db:"merge_queue_entry_base_sha"`
+	MergeSHA           string                    `db:"merge_queue_entry_merge_sha"`
+	DeleteSourceBranch bool                      `db:"merge_queue_entry_delete_source_branch"`
+	EnqueuedBy         int64                     `db:"merge_queue_entry_enqueued_by"`
+	Created            int64                     `db:"merge_queue_entry_created"`
+	Updated            int64                     `db:"merge_queue_entry_updated"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_pullreq_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_target_branch
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_state
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_delete_source_branch
+		,merge_queue_entry_enqueued_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated`
+)
+
+// Find finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) Find(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_pullreq_id = ?", pullReqID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find merge queue entry")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// FindByMergeSHA finds the merge queue entry whose speculative merge commit is the provided commit.
+func (s *MergeQueueStore) FindByMergeSHA(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		Where("merge_queue_entry_merge_sha = ?", mergeSHA).
+		Limit(1)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find merge queue entry by merge commit")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// Create adds a pull request to the end of the merge queue of its target branch.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_pullreq_id
+			,merge_queue_entry_repo_id
+			,merge_queue_entry_target_branch
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_state
+			,merge_queue_entry_source_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_delete_source_branch
+			,merge_queue_entry_enqueued_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+		) values (
+			 :merge_queue_entry_pullreq_id
+			,:merge_queue_entry_repo_id
+			,:merge_queue_entry_target_branch
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_state
+			,:merge_queue_entry_source_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_delete_source_branch
+			,:merge_queue_entry_enqueued_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind merge queue entry")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state and the speculative merge commit of a merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+		WHERE merge_queue_entry_pullreq_id = :merge_queue_entry_pullreq_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind merge queue entry")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a pull request from the merge queue.
+func (s *MergeQueueStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullReqID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete merge queue entry")
+	}
+
+	return nil
+}
+
+// ListForBranch returns the merge queue of a branch, ordered by the queue position.
+func (s *MergeQueueStore) ListForBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		Where("merge_queue_entry_target_branch = ?", branch).
+		OrderBy("merge_queue_entry_created", "merge_queue_entry_pullreq_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list merge queue entries query")
+	}
+
+	res := make([]*types.MergeQueueEntry, len(dst))
+	for i := range dst {
+		res[i] = mapToMergeQueueEntry(dst[i])
+		res[i].Position = i + 1
+	}
+
+	return res, nil
+}
+
+// ListBranches returns all branches with a non-empty merge queue.
+func (s *MergeQueueStore) ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error) {
+	stmt := database.Builder.
+		Select("DISTINCT merge_queue_entry_repo_id, merge_queue_entry_target_branch").
+		From("merge_queue_entries").
+		OrderBy("merge_queue_entry_repo_id", "merge_queue_entry_target_branch")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []types.MergeQueueBranch
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list merge queue branches query")
+	}
+
+	return dst, nil
+}
+
+func mapToInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		PullReqID:          entry.PullReqID,
+		RepoID:             entry.RepoID,
+		TargetBranch:       entry.TargetBranch,
+		Method:             entry.Method,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		State:              entry.State,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		EnqueuedBy:         entry.EnqueuedBy,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+	}
+}
+
+func mapToMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		PullReqID:          entry.PullReqID,
+		RepoID:             entry.RepoID,
+		TargetBranch:       entry.TargetBranch,
+		Method:             entry.Method,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		State:              entry.State,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		EnqueuedBy:         entry.EnqueuedBy,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+	}
+}
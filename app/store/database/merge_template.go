@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeTemplateStore = (*MergeTemplateStore)(nil)
+
+// NewMergeTemplateStore returns a new MergeTemplateStore.
+func NewMergeTemplateStore(db *sqlx.DB) *MergeTemplateStore {
+	return &MergeTemplateStore{
+		db: db,
+	}
+}
+
+// MergeTemplateStore implements a store.MergeTemplateStore backed by a relational database.
+type MergeTemplateStore struct {
+	db *sqlx.DB
+}
+
+type mergeTemplates struct {
+	RepoID        int64  `db:"merge_template_repo_id"`
+	MergeTitle    string `db:"merge_template_merge_title"`
+	MergeMessage  string `db:"merge_template_merge_message"`
+	SquashTitle   string `db:"merge_template_squash_title"`
+	SquashMessage string `db:"merge_template_squash_message"`
+	CreatedBy     int64  `db:"merge_template_created_by"`
+	Created       int64  `db:"merge_template_created"`
+	Updated       int64  `db:"merge_template_updated"`
+}
+
+const (
+	mergeTemplateColumns = `
+		 merge_template_repo_id
+		,merge_template_merge_title
+		,merge_template_merge_message
+		,merge_template_squash_title
+		,merge_template_squash_message
+		,merge_template_created_by
+		,merge_template_created
+		,merge_template_updated`
+)
+
+// Find finds the merge commit templates of a repository.
+func (s *MergeTemplateStore) Find(ctx context.Context, repoID int64) (*types.MergeTemplates, error) {
+	stmt := database.Builder.
+		Select(mergeTemplateColumns).
+		From("merge_templates").
+		Where("merge_template_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeTemplates{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find merge templates")
+	}
+
+	return mapToMergeTemplates(dst), nil
+}
+
+// Upsert creates or updates the merge commit templates of a repository.
+func (s *MergeTemplateStore) Upsert(ctx context.Context, templates *types.MergeTemplates) error {
+	const sqlQuery = `
+		INSERT INTO merge_templates (
+			 merge_template_repo_id
+			,merge_template_merge_title
+			,merge_template_merge_message
+			,merge_template_squash_title
+			,merge_template_squash_message
+			,merge_template_created_by
+			,merge_template_created
+			,merge_template_updated
+		) values (
+			 :merge_template_repo_id
+			,:merge_template_merge_title
+			,:merge_template_merge_message
+			,:merge_template_squash_title
+			,:merge_template_squash_message
+			,:merge_template_created_by
+			,:merge_template_created
+			,:merge_template_updated
+		)
+		ON CONFLICT (merge_template_repo_id) DO
+		UPDATE SET
+			 merge_template_merge_title = EXCLUDED.merge_template_merge_title
+			,merge_template_merge_message = EXCLUDED.merge_template_merge_message
+			,merge_template_squash_title = EXCLUDED.merge_template_squash_title
+			,merge_template_squash_message = EXCLUDED.merge_template_squash_message
+			,merge_template_updated = EXCLUDED.merge_template_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalMergeTemplates(templates))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind merge templates")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert merge templates query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalMergeTemplates(templates *types.MergeTemplates) *mergeTemplates {
+	return &mergeTemplates{
+		RepoID:        templates.RepoID,
+		MergeTitle:    templates.MergeTitle,
+		MergeMessage:  templates.MergeMessage,
+		SquashTitle:   templates.SquashTitle,
+		SquashMessage: templates.SquashMessage,
+		CreatedBy:     templates.CreatedBy,
+		Created:       templates.Created,
+		Updated:       templates.Updated,
+	}
+}
+
+func mapToMergeTemplates(templates *mergeTemplates) *types.MergeTemplates {
+	return &types.MergeTemplates{
+		RepoID:        templates.RepoID,
+		MergeTitle:    templates.MergeTitle,
+		MergeMessage:  templates.MergeMessage,
+		SquashTitle:   templates.SquashTitle,
+		SquashMessage: templates.SquashMessage,
+		CreatedBy:     templates.CreatedBy,
+		Created:       templates.Created,
+		Updated:       templates.Updated,
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE lfs_locks;
+DROP TABLE lfs_objects;
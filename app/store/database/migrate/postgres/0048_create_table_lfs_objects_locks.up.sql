@@ -0,0 +1,39 @@
+CREATE TABLE lfs_objects (
+ lfs_object_id SERIAL PRIMARY KEY
+,lfs_object_oid TEXT NOT NULL
+,lfs_object_size BIGINT NOT NULL
+,lfs_object_created BIGINT NOT NULL
+,lfs_object_created_by INTEGER NOT NULL
+,lfs_object_repo_id INTEGER NOT NULL
+,CONSTRAINT fk_lfs_object_repo_id FOREIGN KEY (lfs_object_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_lfs_object_created_by FOREIGN KEY (lfs_object_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX lfs_objects_repo_id_oid
+    ON lfs_objects(lfs_object_repo_id, lfs_object_oid);
+
+CREATE TABLE lfs_locks (
+ lfs_lock_id SERIAL PRIMARY KEY
+,lfs_lock_repo_id INTEGER NOT NULL
+,lfs_lock_path TEXT NOT NULL
+,lfs_lock_ref TEXT NOT NULL
+,lfs_lock_created BIGINT NOT NULL
+,lfs_lock_created_by INTEGER NOT NULL
+,CONSTRAINT fk_lfs_lock_repo_id FOREIGN KEY (lfs_lock_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_lfs_lock_created_by FOREIGN KEY (lfs_lock_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX lfs_locks_repo_id_path
+    ON lfs_locks(lfs_lock_repo_id, lfs_lock_path);
@@ -0,0 +1,23 @@
+CREATE TABLE push_mirrors (
+ push_mirror_id SERIAL PRIMARY KEY
+,push_mirror_repo_id INTEGER NOT NULL
+,push_mirror_identifier TEXT NOT NULL
+,push_mirror_url TEXT NOT NULL
+,push_mirror_username TEXT NOT NULL
+,push_mirror_secret_identifier TEXT NOT NULL
+,push_mirror_created_by INTEGER NOT NULL
+,push_mirror_created BIGINT NOT NULL
+,push_mirror_updated BIGINT NOT NULL
+,push_mirror_last_sync BIGINT NOT NULL
+,push_mirror_last_sync_status TEXT NOT NULL
+,push_mirror_last_sync_error TEXT NOT NULL
+,push_mirror_failed_attempts INTEGER NOT NULL
+,push_mirror_next_retry BIGINT NOT NULL
+,CONSTRAINT fk_push_mirror_repo_id FOREIGN KEY (push_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX push_mirrors_repo_id_identifier
+    ON push_mirrors(push_mirror_repo_id, LOWER(push_mirror_identifier));
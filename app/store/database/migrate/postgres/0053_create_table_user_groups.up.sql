@@ -0,0 +1,35 @@
+CREATE TABLE user_groups (
+ user_group_id SERIAL PRIMARY KEY
+,user_group_space_id INTEGER NOT NULL
+,user_group_identifier TEXT NOT NULL
+,user_group_name TEXT NOT NULL
+,user_group_description TEXT NOT NULL
+,user_group_source TEXT NOT NULL
+,user_group_created BIGINT NOT NULL
+,user_group_updated BIGINT NOT NULL
+,CONSTRAINT fk_user_group_space_id FOREIGN KEY (user_group_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_groups_space_id_identifier
+    ON user_groups(user_group_space_id, LOWER(user_group_identifier));
+
+CREATE TABLE user_group_members (
+ user_group_member_user_group_id INTEGER NOT NULL
+,user_group_member_principal_id INTEGER NOT NULL
+,user_group_member_created BIGINT NOT NULL
+,CONSTRAINT pk_user_group_members PRIMARY KEY (user_group_member_user_group_id, user_group_member_principal_id)
+,CONSTRAINT fk_user_group_member_user_group_id FOREIGN KEY (user_group_member_user_group_id)
+    REFERENCES user_groups (user_group_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_user_group_member_principal_id FOREIGN KEY (user_group_member_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX user_group_members_principal_id
+    ON user_group_members(user_group_member_principal_id);
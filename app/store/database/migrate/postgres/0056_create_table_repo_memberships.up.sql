@@ -0,0 +1,24 @@
+CREATE TABLE repo_memberships (
+ repo_membership_repo_id INTEGER NOT NULL
+,repo_membership_principal_id INTEGER NOT NULL
+,repo_membership_created_by INTEGER NOT NULL
+,repo_membership_created BIGINT NOT NULL
+,repo_membership_updated BIGINT NOT NULL
+,repo_membership_role TEXT NOT NULL
+,CONSTRAINT pk_repo_memberships PRIMARY KEY (repo_membership_repo_id, repo_membership_principal_id)
+,CONSTRAINT fk_repo_membership_repo_id FOREIGN KEY (repo_membership_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_membership_principal_id FOREIGN KEY (repo_membership_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_membership_created_by FOREIGN KEY (repo_membership_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX repo_memberships_principal_id
+    ON repo_memberships(repo_membership_principal_id);
@@ -0,0 +1,3 @@
+DROP TABLE user_webauthn_credentials;
+DROP TABLE user_recovery_codes;
+DROP TABLE user_totp_secrets;
@@ -0,0 +1,47 @@
+CREATE TABLE user_totp_secrets (
+ totp_principal_id INTEGER PRIMARY KEY
+,totp_secret BYTEA NOT NULL
+,totp_confirmed BOOLEAN NOT NULL
+,totp_last_used_step BIGINT NOT NULL
+,totp_created BIGINT NOT NULL
+,totp_updated BIGINT NOT NULL
+,CONSTRAINT fk_totp_principal_id FOREIGN KEY (totp_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE user_recovery_codes (
+ recovery_code_id SERIAL PRIMARY KEY
+,recovery_code_principal_id INTEGER NOT NULL
+,recovery_code_hash TEXT NOT NULL
+,recovery_code_used BIGINT
+,recovery_code_created BIGINT NOT NULL
+,CONSTRAINT fk_recovery_code_principal_id FOREIGN KEY (recovery_code_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX user_recovery_codes_principal_id
+    ON user_recovery_codes(recovery_code_principal_id);
+
+CREATE TABLE user_webauthn_credentials (
+ webauthn_credential_id SERIAL PRIMARY KEY
+,webauthn_credential_principal_id INTEGER NOT NULL
+,webauthn_credential_name TEXT NOT NULL
+,webauthn_credential_key TEXT NOT NULL
+,webauthn_credential_data TEXT NOT NULL
+,webauthn_credential_created BIGINT NOT NULL
+,webauthn_credential_last_used BIGINT NOT NULL
+,CONSTRAINT fk_webauthn_credential_principal_id FOREIGN KEY (webauthn_credential_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_webauthn_credentials_key
+    ON user_webauthn_credentials(webauthn_credential_key);
+
+CREATE INDEX user_webauthn_credentials_principal_id
+    ON user_webauthn_credentials(webauthn_credential_principal_id);
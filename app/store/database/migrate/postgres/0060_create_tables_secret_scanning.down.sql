@@ -0,0 +1,2 @@
+DROP TABLE secret_findings;
+DROP TABLE secret_scanning_settings;
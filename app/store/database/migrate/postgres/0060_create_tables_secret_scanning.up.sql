@@ -0,0 +1,36 @@
+CREATE TABLE secret_scanning_settings (
+ secret_scanning_repo_id INTEGER PRIMARY KEY
+,secret_scanning_enabled BOOLEAN NOT NULL
+,secret_scanning_mode TEXT NOT NULL
+,secret_scanning_use_default_rules BOOLEAN NOT NULL
+,secret_scanning_rules TEXT NOT NULL
+,secret_scanning_created_by INTEGER NOT NULL
+,secret_scanning_created BIGINT NOT NULL
+,secret_scanning_updated BIGINT NOT NULL
+,CONSTRAINT fk_secret_scanning_repo_id FOREIGN KEY (secret_scanning_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE secret_findings (
+ secret_finding_id SERIAL PRIMARY KEY
+,secret_finding_repo_id INTEGER NOT NULL
+,secret_finding_principal_id INTEGER NOT NULL
+,secret_finding_rule_identifier TEXT NOT NULL
+,secret_finding_ref TEXT NOT NULL
+,secret_finding_commit_sha TEXT NOT NULL
+,secret_finding_path TEXT NOT NULL
+,secret_finding_line INTEGER NOT NULL
+,secret_finding_secret TEXT NOT NULL
+,secret_finding_fingerprint TEXT NOT NULL
+,secret_finding_blocked BOOLEAN NOT NULL
+,secret_finding_created BIGINT NOT NULL
+,CONSTRAINT fk_secret_finding_repo_id FOREIGN KEY (secret_finding_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX secret_findings_repo_id_created
+    ON secret_findings(secret_finding_repo_id, secret_finding_created);
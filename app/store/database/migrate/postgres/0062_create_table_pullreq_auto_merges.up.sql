@@ -0,0 +1,23 @@
+CREATE TABLE pullreq_auto_merges (
+ auto_merge_pullreq_id INTEGER PRIMARY KEY
+,auto_merge_repo_id INTEGER NOT NULL
+,auto_merge_method TEXT NOT NULL
+,auto_merge_enabled_by INTEGER NOT NULL
+,auto_merge_created BIGINT NOT NULL
+,auto_merge_updated BIGINT NOT NULL
+,CONSTRAINT fk_auto_merge_pullreq_id FOREIGN KEY (auto_merge_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_auto_merge_repo_id FOREIGN KEY (auto_merge_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_auto_merge_enabled_by FOREIGN KEY (auto_merge_enabled_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX pullreq_auto_merges_repo_id
+    ON pullreq_auto_merges(auto_merge_repo_id);
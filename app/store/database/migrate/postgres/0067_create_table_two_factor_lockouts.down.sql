@@ -0,0 +1 @@
+DROP TABLE two_factor_lockouts;
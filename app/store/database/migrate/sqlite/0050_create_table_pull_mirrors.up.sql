@@ -0,0 +1,16 @@
+CREATE TABLE pull_mirrors (
+ pull_mirror_repo_id INTEGER PRIMARY KEY
+,pull_mirror_url TEXT NOT NULL
+,pull_mirror_username TEXT NOT NULL
+,pull_mirror_password BLOB
+,pull_mirror_created_by INTEGER NOT NULL
+,pull_mirror_created BIGINT NOT NULL
+,pull_mirror_updated BIGINT NOT NULL
+,pull_mirror_last_sync BIGINT NOT NULL
+,pull_mirror_last_sync_status TEXT NOT NULL
+,pull_mirror_last_sync_error TEXT NOT NULL
+,CONSTRAINT fk_pull_mirror_repo_id FOREIGN KEY (pull_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,24 @@
+CREATE TABLE user_group_memberships (
+ user_group_membership_space_id INTEGER NOT NULL
+,user_group_membership_user_group_id INTEGER NOT NULL
+,user_group_membership_created_by INTEGER NOT NULL
+,user_group_membership_created BIGINT NOT NULL
+,user_group_membership_updated BIGINT NOT NULL
+,user_group_membership_role TEXT NOT NULL
+,CONSTRAINT pk_user_group_memberships PRIMARY KEY (user_group_membership_space_id, user_group_membership_user_group_id)
+,CONSTRAINT fk_user_group_membership_space_id FOREIGN KEY (user_group_membership_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_user_group_membership_user_group_id FOREIGN KEY (user_group_membership_user_group_id)
+    REFERENCES user_groups (user_group_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_user_group_membership_created_by FOREIGN KEY (user_group_membership_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX user_group_memberships_user_group_id
+    ON user_group_memberships(user_group_membership_user_group_id);
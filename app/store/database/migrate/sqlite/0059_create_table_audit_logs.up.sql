@@ -0,0 +1,19 @@
+CREATE TABLE audit_logs (
+ audit_log_id INTEGER PRIMARY KEY AUTOINCREMENT
+,audit_log_space_id INTEGER
+,audit_log_action TEXT NOT NULL
+,audit_log_resource_type TEXT NOT NULL
+,audit_log_resource_identifier TEXT NOT NULL
+,audit_log_resource_path TEXT NOT NULL
+,audit_log_principal_id INTEGER NOT NULL
+,audit_log_client_ip TEXT NOT NULL
+,audit_log_diff TEXT NOT NULL
+,audit_log_data TEXT NOT NULL
+,audit_log_created BIGINT NOT NULL
+);
+
+CREATE INDEX audit_logs_space_id_created
+    ON audit_logs(audit_log_space_id, audit_log_created);
+
+CREATE INDEX audit_logs_created
+    ON audit_logs(audit_log_created);
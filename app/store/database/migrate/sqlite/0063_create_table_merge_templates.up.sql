@@ -0,0 +1,14 @@
+CREATE TABLE merge_templates (
+ merge_template_repo_id INTEGER PRIMARY KEY
+,merge_template_merge_title TEXT NOT NULL
+,merge_template_merge_message TEXT NOT NULL
+,merge_template_squash_title TEXT NOT NULL
+,merge_template_squash_message TEXT NOT NULL
+,merge_template_created_by INTEGER NOT NULL
+,merge_template_created BIGINT NOT NULL
+,merge_template_updated BIGINT NOT NULL
+,CONSTRAINT fk_merge_template_repo_id FOREIGN KEY (merge_template_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
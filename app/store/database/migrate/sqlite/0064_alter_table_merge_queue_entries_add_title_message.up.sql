@@ -0,0 +1,2 @@
+ALTER TABLE merge_queue_entries ADD COLUMN merge_queue_entry_title TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_queue_entries ADD COLUMN merge_queue_entry_message TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE pullreq_labels;
+DROP TABLE labels;
@@ -0,0 +1,11 @@
+CREATE TABLE two_factor_lockouts (
+ two_factor_lockout_principal_id INTEGER PRIMARY KEY
+,two_factor_lockout_failed_attempts INTEGER NOT NULL
+,two_factor_lockout_locked_until BIGINT NOT NULL
+,two_factor_lockout_invalid_before BIGINT NOT NULL
+,two_factor_lockout_updated BIGINT NOT NULL
+,CONSTRAINT fk_two_factor_lockout_principal_id FOREIGN KEY (two_factor_lockout_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
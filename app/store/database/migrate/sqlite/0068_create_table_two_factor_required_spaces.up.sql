@@ -0,0 +1,13 @@
+CREATE TABLE two_factor_required_spaces (
+ two_factor_required_space_id INTEGER PRIMARY KEY
+,two_factor_required_space_created_by INTEGER NOT NULL
+,two_factor_required_space_created BIGINT NOT NULL
+,CONSTRAINT fk_two_factor_required_space_id FOREIGN KEY (two_factor_required_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_two_factor_required_space_created_by FOREIGN KEY (two_factor_required_space_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
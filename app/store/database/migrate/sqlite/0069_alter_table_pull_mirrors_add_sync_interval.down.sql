@@ -0,0 +1 @@
+ALTER TABLE pull_mirrors DROP COLUMN pull_mirror_sync_interval_minutes;
@@ -0,0 +1 @@
+ALTER TABLE pull_mirrors ADD COLUMN pull_mirror_sync_interval_minutes INTEGER NOT NULL DEFAULT 0;
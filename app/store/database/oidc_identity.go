@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OIDCIdentityStore = (*OIDCIdentityStore)(nil)
+
+// NewOIDCIdentityStore returns a new OIDCIdentityStore.
+func NewOIDCIdentityStore(db *sqlx.DB) *OIDCIdentityStore {
+	return &OIDCIdentityStore{
+		db: db,
+	}
+}
+
+// OIDCIdentityStore implements a store.OIDCIdentityStore backed by a relational database.
+type OIDCIdentityStore struct {
+	db *sqlx.DB
+}
+
+const (
+	oidcIdentityColumns = `
+		 oidc_identity_id
+		,oidc_identity_principal_id
+		,oidc_identity_issuer
+		,oidc_identity_subject
+		,oidc_identity_created
+		,oidc_identity_last_login`
+)
+
+// Find finds the OIDC identity by the issuer and the subject.
+func (s *OIDCIdentityStore) Find(ctx context.Context, issuer, subject string) (*types.OIDCIdentity, error) {
+	stmt := database.Builder.
+		Select(oidcIdentityColumns).
+		From("oidc_identities").
+		Where("oidc_identity_issuer = ?", issuer).
+		Where("oidc_identity_subject = ?", subject)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.OIDCIdentity{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find OIDC identity")
+	}
+
+	return dst, nil
+}
+
+// Create links a new OIDC identity to a user.
+func (s *OIDCIdentityStore) Create(ctx context.Context, identity *types.OIDCIdentity) error {
+	const sqlQuery = `
+		INSERT INTO oidc_identities (
+			 oidc_identity_principal_id
+			,oidc_identity_issuer
+			,oidc_identity_subject
+			,oidc_identity_created
+			,oidc_identity_last_login
+		) values (
+			 :oidc_identity_principal_id
+			,:oidc_identity_issuer
+			,:oidc_identity_subject
+			,:oidc_identity_created
+			,:oidc_identity_last_login
+		) RETURNING oidc_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, identity)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind OIDC identity")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert OIDC identity query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastLogin updates the time of the last login of the OIDC identity.
+func (s *OIDCIdentityStore) UpdateLastLogin(ctx context.Context, id int64, lastLogin int64) error {
+	stmt := database.Builder.
+		Update("oidc_identities").
+		Set("oidc_identity_last_login", lastLogin).
+		Where("oidc_identity_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update OIDC identity")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullMirrorStore = (*PullMirrorStore)(nil)
+
+// NewPullMirrorStore returns a new PullMirrorStore.
+func NewPullMirrorStore(db *sqlx.DB) *PullMirrorStore {
+	return &PullMirrorStore{
+		db: db,
+	}
+}
+
+// PullMirrorStore implements a store.PullMirrorStore backed by a relational database.
+type PullMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pullMirror struct {
+	RepoID         int64                 `db:"pull_mirror_repo_id"`
+	URL            string                `db:"pull_mirror_url"`
+	Username       string                `db:"pull_mirror_username"`
+	Password       []byte                `db:"pull_mirror_password"`
+	CreatedBy      int64                 `db:"pull_mirror_created_by"`
+	Created        int64                 `db:"pull_mirror_created"`
+	Updated        int64                 `db:"pull_mirror_updated"`
+	SyncInterval   int64                 `db:"pull_mirror_sync_interval_minutes"`
+	LastSync       int64                 `db:"pull_mirror_last_sync"`
+	LastSyncStatus enum.MirrorSyncStatus `db:"pull_mirror_last_sync_status"`
+	LastSyncError  string                `db:"pull_mirror_last_sync_error"`
+}
+
+const (
+	pullMirrorColumns = `
+		 pull_mirror_repo_id
+		,pull_mirror_url
+		,pull_mirror_username
+		,pull_mirror_password
+		,pull_mirror_created_by
+		,pull_mirror_created
+		,pull_mirror_updated
+		,pull_mirror_sync_interval_minutes
+		,pull_mirror_last_sync
+		,pull_mirror_last_sync_status
+		,pull_mirror_last_sync_error`
+)
+
+// Find finds the pull mirror configuration of a repository.
+func (s *PullMirrorStore) Find(ctx context.Context, repoID int64) (*types.PullMirror, error) {
+	stmt := database.Builder.
+		Select(pullMirrorColumns).
+		From("pull_mirrors").
+		Where("pull_mirror_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullMirror{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull mirror")
+	}
+
+	return mapToPullMirror(dst), nil
+}
+
+// Create creates a new pull mirror configuration.
+func (s *PullMirrorStore) Create(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		INSERT INTO pull_mirrors (
+			 pull_mirror_repo_id
+			,pull_mirror_url
+			,pull_mirror_username
+			,pull_mirror_password
+			,pull_mirror_created_by
+			,pull_mirror_created
+			,pull_mirror_updated
+			,pull_mirror_sync_interval_minutes
+			,pull_mirror_last_sync
+			,pull_mirror_last_sync_status
+			,pull_mirror_last_sync_error
+		) values (
+			 :pull_mirror_repo_id
+			,:pull_mirror_url
+			,:pull_mirror_username
+			,:pull_mirror_password
+			,:pull_mirror_created_by
+			,:pull_mirror_created
+			,:pull_mirror_updated
+			,:pull_mirror_sync_interval_minutes
+			,:pull_mirror_last_sync
+			,:pull_mirror_last_sync_status
+			,:pull_mirror_last_sync_error
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalPullMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull mirror")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert pull mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the remote repository, the credentials and the sync interval of the pull mirror.
+func (s *PullMirrorStore) Update(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		UPDATE pull_mirrors
+		SET
+			 pull_mirror_url = :pull_mirror_url
+			,pull_mirror_username = :pull_mirror_username
+			,pull_mirror_password = :pull_mirror_password
+			,pull_mirror_sync_interval_minutes = :pull_mirror_sync_interval_minutes
+			,pull_mirror_updated = :pull_mirror_updated
+		WHERE pull_mirror_repo_id = :pull_mirror_repo_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+// UpdateSyncStatus stores the outcome of the last sync of the pull mirror.
+func (s *PullMirrorStore) UpdateSyncStatus(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		UPDATE pull_mirrors
+		SET
+			 pull_mirror_last_sync = :pull_mirror_last_sync
+			,pull_mirror_last_sync_status = :pull_mirror_last_sync_status
+			,pull_mirror_last_sync_error = :pull_mirror_last_sync_error
+		WHERE pull_mirror_repo_id = :pull_mirror_repo_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+func (s *PullMirrorStore) update(ctx context.Context, sqlQuery string, mirror *types.PullMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalPullMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull mirror")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update pull mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the pull mirror configuration of a repository.
+func (s *PullMirrorStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+		DELETE FROM pull_mirrors
+		WHERE pull_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pull mirror")
+	}
+
+	return nil
+}
+
+// ListAll returns the pull mirrors of all repositories that aren't deleted or being imported.
+func (s *PullMirrorStore) ListAll(ctx context.Context) ([]*types.PullMirror, error) {
+	stmt := database.Builder.
+		Select(pullMirrorColumns).
+		From("pull_mirrors").
+		InnerJoin("repositories ON repo_id = pull_mirror_repo_id").
+		Where("repo_deleted IS NULL").
+		Where("repo_importing = ?", false).
+		OrderBy("pull_mirror_repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullMirror
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list pull mirrors query")
+	}
+
+	res := make([]*types.PullMirror, len(dst))
+	for i := range dst {
+		res[i] = mapToPullMirror(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToInternalPullMirror(mirror *types.PullMirror) *pullMirror {
+	return &pullMirror{
+		RepoID:         mirror.RepoID,
+		URL:            mirror.URL,
+		Username:       mirror.Username,
+		Password:       mirror.Password,
+		CreatedBy:      mirror.CreatedBy,
+		Created:        mirror.Created,
+		Updated:        mirror.Updated,
+		SyncInterval:   mirror.SyncIntervalMinutes,
+		LastSync:       mirror.LastSync,
+		LastSyncStatus: mirror.LastSyncStatus,
+		LastSyncError:  mirror.LastSyncError,
+	}
+}
+
+func mapToPullMirror(mirror *pullMirror) *types.PullMirror {
+	return &types.PullMirror{
+		RepoID:              mirror.RepoID,
+		URL:                 mirror.URL,
+		Username:            mirror.Username,
+		Password:            mirror.Password,
+		CreatedBy:           mirror.CreatedBy,
+		Created:             mirror.Created,
+		Updated:             mirror.Updated,
+		SyncIntervalMinutes: mirror.SyncInterval,
+		LastSync:            mirror.LastSync,
+		LastSyncStatus:      mirror.LastSyncStatus,
+		LastSyncError:       mirror.LastSyncError,
+	}
+}
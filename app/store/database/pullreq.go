@@ -438,6 +438,11 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	for _, labelID := range opts.LabelIDs {
+		stmt = stmt.Where(`pullreq_id IN (
+			SELECT pullreq_label_pullreq_id FROM pullreq_labels WHERE pullreq_label_label_id = ?)`, labelID)
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -490,6 +495,11 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	for _, labelID := range opts.LabelIDs {
+		stmt = stmt.Where(`pullreq_id IN (
+			SELECT pullreq_label_pullreq_id FROM pullreq_labels WHERE pullreq_label_label_id = ?)`, labelID)
+	}
+
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -0,0 +1,344 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PushMirrorStore = (*PushMirrorStore)(nil)
+
+// NewPushMirrorStore returns a new PushMirrorStore.
+func NewPushMirrorStore(db *sqlx.DB) *PushMirrorStore {
+	return &PushMirrorStore{
+		db: db,
+	}
+}
+
+// PushMirrorStore implements a store.PushMirrorStore backed by a relational database.
+type PushMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pushMirror struct {
+	ID               int64                 `db:"push_mirror_id"`
+	RepoID           int64                 `db:"push_mirror_repo_id"`
+	Identifier       string                `db:"push_mirror_identifier"`
+	URL              string                `db:"push_mirror_url"`
+	Username         string                `db:"push_mirror_username"`
+	SecretIdentifier string                `db:"push_mirror_secret_identifier"`
+	CreatedBy        int64                 `db:"push_mirror_created_by"`
+	Created          int64                 `db:"push_mirror_created"`
+	Updated          int64                 `db:"push_mirror_updated"`
+	LastSync         int64                 `db:"push_mirror_last_sync"`
+	LastSyncStatus   enum.MirrorSyncStatus `db:"push_mirror_last_sync_status"`
+	LastSyncError    string                `db:"push_mirror_last_sync_error"`
+	FailedAttempts   int                   `db:"push_mirror_failed_attempts"`
+	NextRetry        int64                 `db:"push_mirror_next_retry"`
+}
+
+const (
+	pushMirrorColumns = `
+		 push_mirror_id
+		,push_mirror_repo_id
+		,push_mirror_identifier
+		,push_mirror_url
+		,push_mirror_username
+		,push_mirror_secret_identifier
+		,push_mirror_created_by
+		,push_mirror_created
+		,push_mirror_updated
+		,push_mirror_last_sync
+		,push_mirror_last_sync_status
+		,push_mirror_last_sync_error
+		,push_mirror_failed_attempts
+		,push_mirror_next_retry`
+)
+
+// Find finds the push mirror by id.
+func (s *PushMirrorStore) Find(ctx context.Context, id int64) (*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		Where("push_mirror_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByIdentifier finds the push mirror of a repository by its identifier.
+func (s *PushMirrorStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		Where("push_mirror_repo_id = ?", repoID).
+		Where("LOWER(push_mirror_identifier) = ?", strings.ToLower(identifier))
+
+	return s.find(ctx, stmt)
+}
+
+func (s *PushMirrorStore) find(ctx context.Context, stmt squirrel.SelectBuilder) (*types.PushMirror, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find push mirror")
+	}
+
+	return mapToPushMirror(dst), nil
+}
+
+// Create creates a new push mirror.
+func (s *PushMirrorStore) Create(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		INSERT INTO push_mirrors (
+			 push_mirror_repo_id
+			,push_mirror_identifier
+			,push_mirror_url
+			,push_mirror_username
+			,push_mirror_secret_identifier
+			,push_mirror_created_by
+			,push_mirror_created
+			,push_mirror_updated
+			,push_mirror_last_sync
+			,push_mirror_last_sync_status
+			,push_mirror_last_sync_error
+			,push_mirror_failed_attempts
+			,push_mirror_next_retry
+		) values (
+			 :push_mirror_repo_id
+			,:push_mirror_identifier
+			,:push_mirror_url
+			,:push_mirror_username
+			,:push_mirror_secret_identifier
+			,:push_mirror_created_by
+			,:push_mirror_created
+			,:push_mirror_updated
+			,:push_mirror_last_sync
+			,:push_mirror_last_sync_status
+			,:push_mirror_last_sync_error
+			,:push_mirror_failed_attempts
+			,:push_mirror_next_retry
+		) RETURNING push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind push mirror")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert push mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the identifier, the remote repository and the credentials of the push mirror.
+func (s *PushMirrorStore) Update(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_identifier = :push_mirror_identifier
+			,push_mirror_url = :push_mirror_url
+			,push_mirror_username = :push_mirror_username
+			,push_mirror_secret_identifier = :push_mirror_secret_identifier
+			,push_mirror_updated = :push_mirror_updated
+		WHERE push_mirror_id = :push_mirror_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+// UpdateSyncStatus stores the outcome of the last sync of the push mirror.
+func (s *PushMirrorStore) UpdateSyncStatus(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_last_sync = :push_mirror_last_sync
+			,push_mirror_last_sync_status = :push_mirror_last_sync_status
+			,push_mirror_last_sync_error = :push_mirror_last_sync_error
+			,push_mirror_failed_attempts = :push_mirror_failed_attempts
+			,push_mirror_next_retry = :push_mirror_next_retry
+		WHERE push_mirror_id = :push_mirror_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+func (s *PushMirrorStore) update(ctx context.Context, sqlQuery string, mirror *types.PushMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind push mirror")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the push mirror with the provided id.
+func (s *PushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM push_mirrors
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete push mirror")
+	}
+
+	return nil
+}
+
+// Count returns the number of push mirrors of a repository.
+func (s *PushMirrorStore) Count(ctx context.Context, repoID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("push_mirrors").
+		Where("push_mirror_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count push mirrors query")
+	}
+
+	return count, nil
+}
+
+// List returns all push mirrors of a repository, ordered by identifier.
+func (s *PushMirrorStore) List(ctx context.Context, repoID int64) ([]*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		Where("push_mirror_repo_id = ?", repoID).
+		OrderBy("LOWER(push_mirror_identifier)")
+
+	return s.list(ctx, stmt)
+}
+
+// ListDue returns the push mirrors of all active repositories which aren't waiting for a retry at the provided time.
+func (s *PushMirrorStore) ListDue(ctx context.Context, now int64) ([]*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		InnerJoin("repositories ON repo_id = push_mirror_repo_id").
+		Where("repo_deleted IS NULL").
+		Where("repo_importing = ?", false).
+		Where("push_mirror_next_retry <= ?", now).
+		OrderBy("push_mirror_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PushMirrorStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.PushMirror, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pushMirror
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list push mirrors query")
+	}
+
+	res := make([]*types.PushMirror, len(dst))
+	for i := range dst {
+		res[i] = mapToPushMirror(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToInternalPushMirror(mirror *types.PushMirror) *pushMirror {
+	return &pushMirror{
+		ID:               mirror.ID,
+		RepoID:           mirror.RepoID,
+		Identifier:       mirror.Identifier,
+		URL:              mirror.URL,
+		Username:         mirror.Username,
+		SecretIdentifier: mirror.SecretIdentifier,
+		CreatedBy:        mirror.CreatedBy,
+		Created:          mirror.Created,
+		Updated:          mirror.Updated,
+		LastSync:         mirror.LastSync,
+		LastSyncStatus:   mirror.LastSyncStatus,
+		LastSyncError:    mirror.LastSyncError,
+		FailedAttempts:   mirror.FailedAttempts,
+		NextRetry:        mirror.NextRetry,
+	}
+}
+
+func mapToPushMirror(mirror *pushMirror) *types.PushMirror {
+	return &types.PushMirror{
+		ID:               mirror.ID,
+		RepoID:           mirror.RepoID,
+		Identifier:       mirror.Identifier,
+		URL:              mirror.URL,
+		Username:         mirror.Username,
+		SecretIdentifier: mirror.SecretIdentifier,
+		CreatedBy:        mirror.CreatedBy,
+		Created:          mirror.Created,
+		Updated:          mirror.Updated,
+		LastSync:         mirror.LastSync,
+		LastSyncStatus:   mirror.LastSyncStatus,
+		LastSyncError:    mirror.LastSyncError,
+		FailedAttempts:   mirror.FailedAttempts,
+		NextRetry:        mirror.NextRetry,
+	}
+}
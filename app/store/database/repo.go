@@ -683,6 +683,29 @@ FROM SpaceHierarchy h1;`
 	return s.mapToRepos(ctx, repos)
 }
 
+// ListForks returns all repositories (including deleted ones) that are direct forks of the repository.
+func (s *RepoStore) ListForks(ctx context.Context, repoID int64) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID).
+		OrderBy("repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 type repoSize struct {
 	ID          int64  `db:"repo_id"`
 	GitUID      string `db:"repo_git_uid"`
@@ -0,0 +1,389 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SecretScanningSettingsStore = (*SecretScanningSettingsStore)(nil)
+
+// NewSecretScanningSettingsStore returns a new SecretScanningSettingsStore.
+func NewSecretScanningSettingsStore(db *sqlx.DB) *SecretScanningSettingsStore {
+	return &SecretScanningSettingsStore{
+		db: db,
+	}
+}
+
+// SecretScanningSettingsStore implements a store.SecretScanningSettingsStore backed by a relational database.
+type SecretScanningSettingsStore struct {
+	db *sqlx.DB
+}
+
+type secretScanningSettings struct {
+	RepoID          int64                   `db:"secret_scanning_repo_id"`
+	Enabled         bool                    `db:"secret_scanning_enabled"`
+	Mode            enum.SecretScanningMode `db:"secret_scanning_mode"`
+	UseDefaultRules bool                    `db:"secret_scanning_use_default_rules"`
+	Rules           string                  `db:"secret_scanning_rules"`
+	CreatedBy       int64                   `db:"secret_scanning_created_by"`
+	Created         int64                   `db:"secret_scanning_created"`
+	Updated         int64                   `db:"secret_scanning_updated"`
+}
+
+const (
+	secretScanningSettingsColumns = `
+		 secret_scanning_repo_id
+		,secret_scanning_enabled
+		,secret_scanning_mode
+		,secret_scanning_use_default_rules
+		,secret_scanning_rules
+		,secret_scanning_created_by
+		,secret_scanning_created
+		,secret_scanning_updated`
+)
+
+// Find finds the secret scanning settings of a repository.
+func (s *SecretScanningSettingsStore) Find(
+	ctx context.Context,
+	repoID int64,
+) (*types.SecretScanningSettings, error) {
+	stmt := database.Builder.
+		Select(secretScanningSettingsColumns).
+		From("secret_scanning_settings").
+		Where("secret_scanning_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &secretScanningSettings{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find secret scanning settings")
+	}
+
+	return mapToSecretScanningSettings(dst)
+}
+
+// Upsert creates or updates the secret scanning settings of a repository.
+func (s *SecretScanningSettingsStore) Upsert(ctx context.Context, settings *types.SecretScanningSettings) error {
+	const sqlQuery = `
+		INSERT INTO secret_scanning_settings (
+			 secret_scanning_repo_id
+			,secret_scanning_enabled
+			,secret_scanning_mode
+			,secret_scanning_use_default_rules
+			,secret_scanning_rules
+			,secret_scanning_created_by
+			,secret_scanning_created
+			,secret_scanning_updated
+		) values (
+			 :secret_scanning_repo_id
+			,:secret_scanning_enabled
+			,:secret_scanning_mode
+			,:secret_scanning_use_default_rules
+			,:secret_scanning_rules
+			,:secret_scanning_created_by
+			,:secret_scanning_created
+			,:secret_scanning_updated
+		)
+		ON CONFLICT (secret_scanning_repo_id) DO
+		UPDATE SET
+			 secret_scanning_enabled = EXCLUDED.secret_scanning_enabled
+			,secret_scanning_mode = EXCLUDED.secret_scanning_mode
+			,secret_scanning_use_default_rules = EXCLUDED.secret_scanning_use_default_rules
+			,secret_scanning_rules = EXCLUDED.secret_scanning_rules
+			,secret_scanning_updated = EXCLUDED.secret_scanning_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbSettings, err := mapToInternalSecretScanningSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbSettings)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret scanning settings")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert secret scanning settings query failed")
+	}
+
+	return nil
+}
+
+func mapToInternalSecretScanningSettings(settings *types.SecretScanningSettings) (*secretScanningSettings, error) {
+	rules := settings.Rules
+	if rules == nil {
+		rules = []types.SecretScanningRule{}
+	}
+
+	rulesJSON, err := json.Marshal(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret scanning rules: %w", err)
+	}
+
+	return &secretScanningSettings{
+		RepoID:          settings.RepoID,
+		Enabled:         settings.Enabled,
+		Mode:            settings.Mode,
+		UseDefaultRules: settings.UseDefaultRules,
+		Rules:           string(rulesJSON),
+		CreatedBy:       settings.CreatedBy,
+		Created:         settings.Created,
+		Updated:         settings.Updated,
+	}, nil
+}
+
+func mapToSecretScanningSettings(settings *secretScanningSettings) (*types.SecretScanningSettings, error) {
+	var rules []types.SecretScanningRule
+	if err := json.Unmarshal([]byte(settings.Rules), &rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret scanning rules: %w", err)
+	}
+
+	return &types.SecretScanningSettings{
+		RepoID:          settings.RepoID,
+		Enabled:         settings.Enabled,
+		Mode:            settings.Mode,
+		UseDefaultRules: settings.UseDefaultRules,
+		Rules:           rules,
+		CreatedBy:       settings.CreatedBy,
+		Created:         settings.Created,
+		Updated:         settings.Updated,
+	}, nil
+}
+
+var _ store.SecretFindingStore = (*SecretFindingStore)(nil)
+
+// NewSecretFindingStore returns a new SecretFindingStore.
+func NewSecretFindingStore(db *sqlx.DB) *SecretFindingStore {
+	return &SecretFindingStore{
+		db: db,
+	}
+}
+
+// SecretFindingStore implements a store.SecretFindingStore backed by a relational database.
+type SecretFindingStore struct {
+	db *sqlx.DB
+}
+
+type secretFinding struct {
+	ID             int64  `db:"secret_finding_id"`
+	RepoID         int64  `db:"secret_finding_repo_id"`
+	PrincipalID    int64  `db:"secret_finding_principal_id"`
+	RuleIdentifier string `db:"secret_finding_rule_identifier"`
+	Ref            string `db:"secret_finding_ref"`
+	CommitSHA      string `db:"secret_finding_commit_sha"`
+	Path           string `db:"secret_finding_path"`
+	Line           int    `db:"secret_finding_line"`
+	Secret         string `db:"secret_finding_secret"`
+	Fingerprint    string `db:"secret_finding_fingerprint"`
+	Blocked        bool   `db:"secret_finding_blocked"`
+	Created        int64  `db:"secret_finding_created"`
+}
+
+const (
+	secretFindingColumns = `
+		 secret_finding_id
+		,secret_finding_repo_id
+		,secret_finding_principal_id
+		,secret_finding_rule_identifier
+		,secret_finding_ref
+		,secret_finding_commit_sha
+		,secret_finding_path
+		,secret_finding_line
+		,secret_finding_secret
+		,secret_finding_fingerprint
+		,secret_finding_blocked
+		,secret_finding_created`
+)
+
+// Create stores a new secret finding.
+func (s *SecretFindingStore) Create(ctx context.Context, finding *types.SecretFinding) error {
+	const sqlQuery = `
+		INSERT INTO secret_findings (
+			 secret_finding_repo_id
+			,secret_finding_principal_id
+			,secret_finding_rule_identifier
+			,secret_finding_ref
+			,secret_finding_commit_sha
+			,secret_finding_path
+			,secret_finding_line
+			,secret_finding_secret
+			,secret_finding_fingerprint
+			,secret_finding_blocked
+			,secret_finding_created
+		) values (
+			 :secret_finding_repo_id
+			,:secret_finding_principal_id
+			,:secret_finding_rule_identifier
+			,:secret_finding_ref
+			,:secret_finding_commit_sha
+			,:secret_finding_path
+			,:secret_finding_line
+			,:secret_finding_secret
+			,:secret_finding_fingerprint
+			,:secret_finding_blocked
+			,:secret_finding_created
+		) RETURNING secret_finding_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalSecretFinding(finding))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret finding object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&finding.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert secret finding")
+	}
+
+	return nil
+}
+
+// Count returns the number of secret findings of a repository that match the provided criteria.
+func (s *SecretFindingStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.SecretFindingFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("secret_findings").
+		Where("secret_finding_repo_id = ?", repoID)
+
+	stmt = applySecretFindingFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing secret finding count query")
+	}
+
+	return count, nil
+}
+
+// List returns the secret findings of a repository that match the provided criteria, newest first.
+func (s *SecretFindingStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.SecretFindingFilter,
+) ([]*types.SecretFinding, error) {
+	stmt := database.Builder.
+		Select(secretFindingColumns).
+		From("secret_findings").
+		Where("secret_finding_repo_id = ?", repoID)
+
+	stmt = applySecretFindingFilter(stmt, filter)
+	stmt = stmt.
+		OrderBy("secret_finding_created DESC", "secret_finding_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*secretFinding, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing secret finding list query")
+	}
+
+	res := make([]*types.SecretFinding, len(dst))
+	for i := range dst {
+		res[i] = mapToSecretFinding(dst[i])
+	}
+
+	return res, nil
+}
+
+func applySecretFindingFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.SecretFindingFilter,
+) squirrel.SelectBuilder {
+	if filter.RuleIdentifier != "" {
+		stmt = stmt.Where("secret_finding_rule_identifier = ?", filter.RuleIdentifier)
+	}
+
+	if filter.CommitSHA != "" {
+		stmt = stmt.Where("secret_finding_commit_sha = ?", filter.CommitSHA)
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(secret_finding_path) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func mapToInternalSecretFinding(finding *types.SecretFinding) *secretFinding {
+	return &secretFinding{
+		ID:             finding.ID,
+		RepoID:         finding.RepoID,
+		PrincipalID:    finding.PrincipalID,
+		RuleIdentifier: finding.RuleIdentifier,
+		Ref:            finding.Ref,
+		CommitSHA:      finding.CommitSHA,
+		Path:           finding.Path,
+		Line:           finding.Line,
+		Secret:         finding.Secret,
+		Fingerprint:    finding.Fingerprint,
+		Blocked:        finding.Blocked,
+		Created:        finding.Created,
+	}
+}
+
+func mapToSecretFinding(finding *secretFinding) *types.SecretFinding {
+	return &types.SecretFinding{
+		ID:             finding.ID,
+		RepoID:         finding.RepoID,
+		PrincipalID:    finding.PrincipalID,
+		RuleIdentifier: finding.RuleIdentifier,
+		Ref:            finding.Ref,
+		CommitSHA:      finding.CommitSHA,
+		Path:           finding.Path,
+		Line:           finding.Line,
+		Secret:         finding.Secret,
+		Fingerprint:    finding.Fingerprint,
+		Blocked:        finding.Blocked,
+		Created:        finding.Created,
+	}
+}
@@ -17,6 +17,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,19 +47,19 @@ type TokenStore struct {
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToToken(dst), nil
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +70,14 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToToken(dst), nil
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	query, arg, err := db.BindNamed(tokenInsert, mapToInternalToken(token))
 	if err != nil {
 		return database.ProcessSQLErrorf(err, "Failed to bind token object")
 	}
@@ -153,7 +154,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +162,83 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	res := make([]*types.Token, len(dst))
+	for i := range dst {
+		res[i] = mapToToken(dst[i])
+	}
+
+	return res, nil
+}
+
+// token is used to store the scopes and restrictions of a token in the DB.
+type token struct {
+	types.Token
+	RawScopes   string `db:"token_scopes"`
+	RawSpaceIDs string `db:"token_space_ids"`
+	RawRepoIDs  string `db:"token_repo_ids"`
+}
+
+// tokenListSeparator defines the character that's used to join scopes and ids for storing them in the DB.
+// ASSUMPTION: scopes are defined in an enum and don't contain ",".
+const tokenListSeparator = ","
+
+func mapToToken(in *token) *types.Token {
+	res := in.Token
+
+	if in.RawScopes != "" {
+		rawScopes := strings.Split(in.RawScopes, tokenListSeparator)
+		res.Scopes = make([]enum.Permission, len(rawScopes))
+		for i, rawScope := range rawScopes {
+			// ASSUMPTION: scope is valid value (as we wrote it to DB)
+			res.Scopes[i] = enum.Permission(rawScope)
+		}
+	}
+
+	res.SpaceIDs = idsFromString(in.RawSpaceIDs)
+	res.RepoIDs = idsFromString(in.RawRepoIDs)
+
+	return &res
+}
+
+func mapToInternalToken(in *types.Token) *token {
+	rawScopes := make([]string, len(in.Scopes))
+	for i := range in.Scopes {
+		rawScopes[i] = string(in.Scopes[i])
+	}
+
+	return &token{
+		Token:       *in,
+		RawScopes:   strings.Join(rawScopes, tokenListSeparator),
+		RawSpaceIDs: idsToString(in.SpaceIDs),
+		RawRepoIDs:  idsToString(in.RepoIDs),
+	}
+}
+
+func idsFromString(s string) []int64 {
+	if s == "" {
+		return nil
+	}
+
+	rawIDs := strings.Split(s, tokenListSeparator)
+
+	ids := make([]int64, 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		// ASSUMPTION: id is valid value (as we wrote it to DB)
+		id, _ := strconv.ParseInt(rawID, 10, 64)
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func idsToString(ids []int64) string {
+	rawIDs := make([]string, len(ids))
+	for i := range ids {
+		rawIDs[i] = strconv.FormatInt(ids[i], 10)
+	}
+
+	return strings.Join(rawIDs, tokenListSeparator)
 }
 
 const tokenSelectBase = `
@@ -173,6 +250,9 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_scopes
+,token_space_ids
+,token_repo_ids
 FROM tokens
 ` //#nosec G101
 
@@ -208,6 +288,9 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_scopes
+	,token_space_ids
+	,token_repo_ids
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +298,8 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_scopes
+	,:token_space_ids
+	,:token_repo_ids
 ) RETURNING token_id
 `
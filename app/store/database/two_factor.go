@@ -0,0 +1,738 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.TwoFactorStore = (*TwoFactorStore)(nil)
+
+// NewTwoFactorStore returns a new TwoFactorStore.
+func NewTwoFactorStore(db *sqlx.DB) *TwoFactorStore {
+	return &TwoFactorStore{
+		db: db,
+	}
+}
+
+// TwoFactorStore implements a store.TwoFactorStore backed by a relational database.
+type TwoFactorStore struct {
+	db *sqlx.DB
+}
+
+type totpSecret struct {
+	PrincipalID  int64  `db:"totp_principal_id"`
+	Secret       []byte `db:"totp_secret"`
+	Confirmed    bool   `db:"totp_confirmed"`
+	LastUsedStep int64  `db:"totp_last_used_step"`
+	Created      int64  `db:"totp_created"`
+	Updated      int64  `db:"totp_updated"`
+}
+
+type webAuthnCredential struct {
+	ID          int64  `db:"webauthn_credential_id"`
+	PrincipalID int64  `db:"webauthn_credential_principal_id"`
+	Name        string `db:"webauthn_credential_name"`
+	Key         string `db:"webauthn_credential_key"`
+	Data        string `db:"webauthn_credential_data"`
+	Created     int64  `db:"webauthn_credential_created"`
+	LastUsed    int64  `db:"webauthn_credential_last_used"`
+}
+
+type twoFactorLockout struct {
+	PrincipalID    int64 `db:"two_factor_lockout_principal_id"`
+	FailedAttempts int   `db:"two_factor_lockout_failed_attempts"`
+	LockedUntil    int64 `db:"two_factor_lockout_locked_until"`
+	InvalidBefore  int64 `db:"two_factor_lockout_invalid_before"`
+	Updated        int64 `db:"two_factor_lockout_updated"`
+}
+
+type twoFactorRequiredSpace struct {
+	SpaceID   int64 `db:"two_factor_required_space_id"`
+	CreatedBy int64 `db:"two_factor_required_space_created_by"`
+	Created   int64 `db:"two_factor_required_space_created"`
+}
+
+const (
+	totpSecretColumns = `
+		 totp_principal_id
+		,totp_secret
+		,totp_confirmed
+		,totp_last_used_step
+		,totp_created
+		,totp_updated`
+
+	webAuthnCredentialColumns = `
+		 webauthn_credential_id
+		,webauthn_credential_principal_id
+		,webauthn_credential_name
+		,webauthn_credential_key
+		,webauthn_credential_data
+		,webauthn_credential_created
+		,webauthn_credential_last_used`
+
+	twoFactorLockoutColumns = `
+		 two_factor_lockout_principal_id
+		,two_factor_lockout_failed_attempts
+		,two_factor_lockout_locked_until
+		,two_factor_lockout_invalid_before
+		,two_factor_lockout_updated`
+
+	twoFactorRequiredSpaceColumns = `
+		 two_factor_required_space_id
+		,two_factor_required_space_created_by
+		,two_factor_required_space_created`
+)
+
+// FindTOTP finds the TOTP secret of a user.
+func (s *TwoFactorStore) FindTOTP(ctx context.Context, principalID int64) (*types.TOTPSecret, error) {
+	stmt := database.Builder.
+		Select(totpSecretColumns).
+		From("user_totp_secrets").
+		Where("totp_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &totpSecret{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find TOTP secret")
+	}
+
+	return mapToTOTPSecret(dst), nil
+}
+
+// UpsertTOTP creates the TOTP secret of a user or replaces the existing one.
+func (s *TwoFactorStore) UpsertTOTP(ctx context.Context, secret *types.TOTPSecret) error {
+	const sqlQuery = `
+		INSERT INTO user_totp_secrets (
+			 totp_principal_id
+			,totp_secret
+			,totp_confirmed
+			,totp_last_used_step
+			,totp_created
+			,totp_updated
+		) values (
+			 :totp_principal_id
+			,:totp_secret
+			,:totp_confirmed
+			,:totp_last_used_step
+			,:totp_created
+			,:totp_updated
+		)
+		ON CONFLICT (totp_principal_id) DO
+		UPDATE SET
+			 totp_secret = EXCLUDED.totp_secret
+			,totp_confirmed = EXCLUDED.totp_confirmed
+			,totp_last_used_step = EXCLUDED.totp_last_used_step
+			,totp_created = EXCLUDED.totp_created
+			,totp_updated = EXCLUDED.totp_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalTOTPSecret(secret))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind TOTP secret")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert TOTP secret query failed")
+	}
+
+	return nil
+}
+
+// UseTOTPStep marks the time step of a valid TOTP code as used and confirms the secret.
+// The update only succeeds if the step is newer than the last used one, which makes it safe
+// against concurrent logins with the same code.
+func (s *TwoFactorStore) UseTOTPStep(ctx context.Context, principalID int64, step int64, now int64) (bool, error) {
+	stmt := database.Builder.
+		Update("user_totp_secrets").
+		Set("totp_last_used_step", step).
+		Set("totp_confirmed", true).
+		Set("totp_updated", now).
+		Where("totp_principal_id = ?", principalID).
+		Where("totp_last_used_step < ?", step)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed to update TOTP secret")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	return count > 0, nil
+}
+
+// DeleteTOTP deletes the TOTP secret of a user.
+func (s *TwoFactorStore) DeleteTOTP(ctx context.Context, principalID int64) error {
+	stmt := database.Builder.
+		Delete("user_totp_secrets").
+		Where("totp_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete TOTP secret")
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes replaces all recovery codes of a user with the provided code hashes.
+func (s *TwoFactorStore) ReplaceRecoveryCodes(
+	ctx context.Context,
+	principalID int64,
+	hashes []string,
+	now int64,
+) error {
+	if err := s.DeleteRecoveryCodes(ctx, principalID); err != nil {
+		return err
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	insert := database.Builder.
+		Insert("user_recovery_codes").
+		Columns(
+			"recovery_code_principal_id",
+			"recovery_code_hash",
+			"recovery_code_created",
+		)
+	for _, hash := range hashes {
+		insert = insert.Values(principalID, hash, now)
+	}
+
+	sql, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert recovery codes")
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks the unused recovery code with the provided hash as used.
+func (s *TwoFactorStore) UseRecoveryCode(
+	ctx context.Context,
+	principalID int64,
+	hash string,
+	now int64,
+) (bool, error) {
+	stmt := database.Builder.
+		Update("user_recovery_codes").
+		Set("recovery_code_used", now).
+		Where("recovery_code_principal_id = ?", principalID).
+		Where("recovery_code_hash = ?", hash).
+		Where("recovery_code_used IS NULL")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed to update recovery code")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	return count > 0, nil
+}
+
+// CountRecoveryCodes returns the number of unused recovery codes of a user.
+func (s *TwoFactorStore) CountRecoveryCodes(ctx context.Context, principalID int64) (int, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("user_recovery_codes").
+		Where("recovery_code_principal_id = ?", principalID).
+		Where("recovery_code_used IS NULL")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count recovery codes query")
+	}
+
+	return count, nil
+}
+
+// DeleteRecoveryCodes deletes all recovery codes of a user.
+func (s *TwoFactorStore) DeleteRecoveryCodes(ctx context.Context, principalID int64) error {
+	stmt := database.Builder.
+		Delete("user_recovery_codes").
+		Where("recovery_code_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete recovery codes")
+	}
+
+	return nil
+}
+
+// CreateWebAuthnCredential creates a new WebAuthn credential.
+func (s *TwoFactorStore) CreateWebAuthnCredential(ctx context.Context, credential *types.WebAuthnCredential) error {
+	const sqlQuery = `
+		INSERT INTO user_webauthn_credentials (
+			 webauthn_credential_principal_id
+			,webauthn_credential_name
+			,webauthn_credential_key
+			,webauthn_credential_data
+			,webauthn_credential_created
+			,webauthn_credential_last_used
+		) values (
+			 :webauthn_credential_principal_id
+			,:webauthn_credential_name
+			,:webauthn_credential_key
+			,:webauthn_credential_data
+			,:webauthn_credential_created
+			,:webauthn_credential_last_used
+		) RETURNING webauthn_credential_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalWebAuthnCredential(credential))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind WebAuthn credential")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&credential.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert WebAuthn credential query failed")
+	}
+
+	return nil
+}
+
+// UpdateWebAuthnCredential updates the data and the last usage time of a WebAuthn credential.
+func (s *TwoFactorStore) UpdateWebAuthnCredential(ctx context.Context, credential *types.WebAuthnCredential) error {
+	const sqlQuery = `
+		UPDATE user_webauthn_credentials
+		SET
+			 webauthn_credential_data = :webauthn_credential_data
+			,webauthn_credential_last_used = :webauthn_credential_last_used
+		WHERE webauthn_credential_id = :webauthn_credential_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalWebAuthnCredential(credential))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind WebAuthn credential")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update WebAuthn credential")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebAuthnCredential deletes a WebAuthn credential of a user.
+func (s *TwoFactorStore) DeleteWebAuthnCredential(ctx context.Context, principalID int64, id int64) error {
+	stmt := database.Builder.
+		Delete("user_webauthn_credentials").
+		Where(squirrel.Eq{
+			"webauthn_credential_principal_id": principalID,
+			"webauthn_credential_id":           id,
+		})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete WebAuthn credential")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListWebAuthnCredentials returns all WebAuthn credentials of a user, ordered by creation time.
+func (s *TwoFactorStore) ListWebAuthnCredentials(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.WebAuthnCredential, error) {
+	stmt := database.Builder.
+		Select(webAuthnCredentialColumns).
+		From("user_webauthn_credentials").
+		Where("webauthn_credential_principal_id = ?", principalID).
+		OrderBy("webauthn_credential_created")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*webAuthnCredential
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list WebAuthn credentials query")
+	}
+
+	res := make([]*types.WebAuthnCredential, len(dst))
+	for i := range dst {
+		res[i] = mapToWebAuthnCredential(dst[i])
+	}
+
+	return res, nil
+}
+
+// FindLockout finds the failed second factor login attempts of a user.
+// It returns an empty lockout if the user has no failed attempts recorded.
+func (s *TwoFactorStore) FindLockout(ctx context.Context, principalID int64) (*types.TwoFactorLockout, error) {
+	stmt := database.Builder.
+		Select(twoFactorLockoutColumns).
+		From("two_factor_lockouts").
+		Where("two_factor_lockout_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &twoFactorLockout{}
+	err = db.GetContext(ctx, dst, sql, args...)
+	if err != nil {
+		err = database.ProcessSQLErrorf(err, "Failed to find two-factor lockout")
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return &types.TwoFactorLockout{PrincipalID: principalID}, nil
+		}
+		return nil, err
+	}
+
+	return mapToTwoFactorLockout(dst), nil
+}
+
+// RecordFailedAttempt increments the failed second factor login attempts of a user
+// and returns the updated number of failed attempts.
+func (s *TwoFactorStore) RecordFailedAttempt(ctx context.Context, principalID int64, now int64) (int, error) {
+	const sqlQuery = `
+		INSERT INTO two_factor_lockouts (
+			 two_factor_lockout_principal_id
+			,two_factor_lockout_failed_attempts
+			,two_factor_lockout_locked_until
+			,two_factor_lockout_invalid_before
+			,two_factor_lockout_updated
+		) values (
+			 $1
+			,1
+			,0
+			,0
+			,$2
+		)
+		ON CONFLICT (two_factor_lockout_principal_id) DO
+		UPDATE SET
+			 two_factor_lockout_failed_attempts = two_factor_lockouts.two_factor_lockout_failed_attempts + 1
+			,two_factor_lockout_updated = EXCLUDED.two_factor_lockout_updated
+		RETURNING two_factor_lockout_failed_attempts`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var failedAttempts int
+	if err := db.QueryRowContext(ctx, sqlQuery, principalID, now).Scan(&failedAttempts); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to record failed two-factor attempt")
+	}
+
+	return failedAttempts, nil
+}
+
+// LockOut locks the second factor login of a user until the provided time,
+// invalidates all challenges issued until now and resets the failed attempts.
+func (s *TwoFactorStore) LockOut(ctx context.Context, principalID int64, lockedUntil int64, now int64) error {
+	stmt := database.Builder.
+		Update("two_factor_lockouts").
+		Set("two_factor_lockout_failed_attempts", 0).
+		Set("two_factor_lockout_locked_until", lockedUntil).
+		Set("two_factor_lockout_invalid_before", now).
+		Set("two_factor_lockout_updated", now).
+		Where("two_factor_lockout_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to lock out two-factor login")
+	}
+
+	return nil
+}
+
+// ResetFailedAttempts resets the failed second factor login attempts of a user.
+func (s *TwoFactorStore) ResetFailedAttempts(ctx context.Context, principalID int64, now int64) error {
+	stmt := database.Builder.
+		Update("two_factor_lockouts").
+		Set("two_factor_lockout_failed_attempts", 0).
+		Set("two_factor_lockout_updated", now).
+		Where("two_factor_lockout_principal_id = ?", principalID).
+		Where("two_factor_lockout_failed_attempts > 0")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to reset failed two-factor attempts")
+	}
+
+	return nil
+}
+
+// ListRequiredSpaces returns all spaces whose members have to login with a second factor.
+// The paths of the spaces aren't populated.
+func (s *TwoFactorStore) ListRequiredSpaces(ctx context.Context) ([]*types.TwoFactorRequiredSpace, error) {
+	stmt := database.Builder.
+		Select(twoFactorRequiredSpaceColumns).
+		From("two_factor_required_spaces").
+		OrderBy("two_factor_required_space_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*twoFactorRequiredSpace, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list two-factor required spaces")
+	}
+
+	res := make([]*types.TwoFactorRequiredSpace, len(dst))
+	for i := range dst {
+		res[i] = mapToTwoFactorRequiredSpace(dst[i])
+	}
+
+	return res, nil
+}
+
+// AddRequiredSpace requires the members of the space to login with a second factor.
+// Adding a space that's required already is a no-op.
+func (s *TwoFactorStore) AddRequiredSpace(ctx context.Context, requiredSpace *types.TwoFactorRequiredSpace) error {
+	const sqlQuery = `
+		INSERT INTO two_factor_required_spaces (
+			 two_factor_required_space_id
+			,two_factor_required_space_created_by
+			,two_factor_required_space_created
+		) values (
+			 :two_factor_required_space_id
+			,:two_factor_required_space_created_by
+			,:two_factor_required_space_created
+		)
+		ON CONFLICT (two_factor_required_space_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalTwoFactorRequiredSpace(requiredSpace))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind two-factor required space")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert two-factor required space query failed")
+	}
+
+	return nil
+}
+
+// DeleteRequiredSpace removes the second factor requirement of the space.
+func (s *TwoFactorStore) DeleteRequiredSpace(ctx context.Context, spaceID int64) error {
+	stmt := database.Builder.
+		Delete("two_factor_required_spaces").
+		Where("two_factor_required_space_id = ?", spaceID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete two-factor required space")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapToTOTPSecret(s *totpSecret) *types.TOTPSecret {
+	return &types.TOTPSecret{
+		PrincipalID:  s.PrincipalID,
+		Secret:       s.Secret,
+		Confirmed:    s.Confirmed,
+		LastUsedStep: s.LastUsedStep,
+		Created:      s.Created,
+		Updated:      s.Updated,
+	}
+}
+
+func mapToInternalTOTPSecret(s *types.TOTPSecret) *totpSecret {
+	return &totpSecret{
+		PrincipalID:  s.PrincipalID,
+		Secret:       s.Secret,
+		Confirmed:    s.Confirmed,
+		LastUsedStep: s.LastUsedStep,
+		Created:      s.Created,
+		Updated:      s.Updated,
+	}
+}
+
+func mapToWebAuthnCredential(c *webAuthnCredential) *types.WebAuthnCredential {
+	return &types.WebAuthnCredential{
+		ID:          c.ID,
+		PrincipalID: c.PrincipalID,
+		Name:        c.Name,
+		Key:         c.Key,
+		Data:        json.RawMessage(c.Data),
+		Created:     c.Created,
+		LastUsed:    c.LastUsed,
+	}
+}
+
+func mapToInternalWebAuthnCredential(c *types.WebAuthnCredential) *webAuthnCredential {
+	return &webAuthnCredential{
+		ID:          c.ID,
+		PrincipalID: c.PrincipalID,
+		Name:        c.Name,
+		Key:         c.Key,
+		Data:        string(c.Data),
+		Created:     c.Created,
+		LastUsed:    c.LastUsed,
+	}
+}
+
+func mapToTwoFactorLockout(l *twoFactorLockout) *types.TwoFactorLockout {
+	return &types.TwoFactorLockout{
+		PrincipalID:    l.PrincipalID,
+		FailedAttempts: l.FailedAttempts,
+		LockedUntil:    l.LockedUntil,
+		InvalidBefore:  l.InvalidBefore,
+		Updated:        l.Updated,
+	}
+}
+
+func mapToTwoFactorRequiredSpace(s *twoFactorRequiredSpace) *types.TwoFactorRequiredSpace {
+	return &types.TwoFactorRequiredSpace{
+		SpaceID:   s.SpaceID,
+		CreatedBy: s.CreatedBy,
+		Created:   s.Created,
+	}
+}
+
+func mapToInternalTwoFactorRequiredSpace(s *types.TwoFactorRequiredSpace) *twoFactorRequiredSpace {
+	return &twoFactorRequiredSpace{
+		SpaceID:   s.SpaceID,
+		CreatedBy: s.CreatedBy,
+		Created:   s.Created,
+	}
+}
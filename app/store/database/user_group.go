@@ -0,0 +1,370 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupStore = (*UserGroupStore)(nil)
+
+// NewUserGroupStore returns a new UserGroupStore.
+func NewUserGroupStore(db *sqlx.DB) *UserGroupStore {
+	return &UserGroupStore{
+		db: db,
+	}
+}
+
+// UserGroupStore implements a store.UserGroupStore backed by a relational database.
+type UserGroupStore struct {
+	db *sqlx.DB
+}
+
+type userGroup struct {
+	ID          int64                `db:"user_group_id"`
+	SpaceID     int64                `db:"user_group_space_id"`
+	Identifier  string               `db:"user_group_identifier"`
+	Name        string               `db:"user_group_name"`
+	Description string               `db:"user_group_description"`
+	Source      enum.UserGroupSource `db:"user_group_source"`
+	Created     int64                `db:"user_group_created"`
+	Updated     int64                `db:"user_group_updated"`
+}
+
+const (
+	userGroupColumns = `
+		 user_group_id
+		,user_group_space_id
+		,user_group_identifier
+		,user_group_name
+		,user_group_description
+		,user_group_source
+		,user_group_created
+		,user_group_updated`
+)
+
+// Find finds the user group by id.
+func (s *UserGroupStore) Find(ctx context.Context, id int64) (*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("user_groups").
+		Where("user_group_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByIdentifier finds the user group of a space by its identifier.
+func (s *UserGroupStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("user_groups").
+		Where("user_group_space_id = ?", spaceID).
+		Where("LOWER(user_group_identifier) = ?", strings.ToLower(identifier))
+
+	return s.find(ctx, stmt)
+}
+
+func (s *UserGroupStore) find(ctx context.Context, stmt squirrel.SelectBuilder) (*types.UserGroup, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroup{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user group")
+	}
+
+	return mapToUserGroup(dst), nil
+}
+
+// Create creates a new user group.
+func (s *UserGroupStore) Create(ctx context.Context, group *types.UserGroup) error {
+	const sqlQuery = `
+		INSERT INTO user_groups (
+			 user_group_space_id
+			,user_group_identifier
+			,user_group_name
+			,user_group_description
+			,user_group_source
+			,user_group_created
+			,user_group_updated
+		) values (
+			 :user_group_space_id
+			,:user_group_identifier
+			,:user_group_name
+			,:user_group_description
+			,:user_group_source
+			,:user_group_created
+			,:user_group_updated
+		) RETURNING user_group_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalUserGroup(group))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&group.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert user group query failed")
+	}
+
+	return nil
+}
+
+// Update updates the identifier, the name and the description of the user group.
+func (s *UserGroupStore) Update(ctx context.Context, group *types.UserGroup) error {
+	const sqlQuery = `
+		UPDATE user_groups
+		SET
+			 user_group_identifier = :user_group_identifier
+			,user_group_name = :user_group_name
+			,user_group_description = :user_group_description
+			,user_group_updated = :user_group_updated
+		WHERE user_group_id = :user_group_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalUserGroup(group))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update user group")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the user group with the provided id, together with its members.
+func (s *UserGroupStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM user_groups
+		WHERE user_group_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete user group")
+	}
+
+	return nil
+}
+
+// List returns all user groups of a space, ordered by identifier.
+func (s *UserGroupStore) List(ctx context.Context, spaceID int64) ([]*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("user_groups").
+		Where("user_group_space_id = ?", spaceID).
+		OrderBy("LOWER(user_group_identifier)")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*userGroup
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user groups query")
+	}
+
+	res := make([]*types.UserGroup, len(dst))
+	for i := range dst {
+		res[i] = mapToUserGroup(dst[i])
+	}
+
+	return res, nil
+}
+
+// ListMembers returns the members of the user group, ordered by their UID.
+func (s *UserGroupStore) ListMembers(ctx context.Context, userGroupID int64) ([]types.PrincipalInfo, error) {
+	stmt := database.Builder.
+		Select(principalInfoCommonColumns).
+		From("user_group_members").
+		InnerJoin("principals ON principal_id = user_group_member_principal_id").
+		Where("user_group_member_user_group_id = ?", userGroupID).
+		OrderBy("principal_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*principalInfo
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group members query")
+	}
+
+	res := make([]types.PrincipalInfo, len(dst))
+	for i := range dst {
+		res[i] = mapToPrincipalInfo(dst[i])
+	}
+
+	return res, nil
+}
+
+// AddMember adds the principal as a member of the user group.
+func (s *UserGroupStore) AddMember(ctx context.Context, userGroupID int64, principalID int64, now int64) error {
+	const sqlQuery = `
+		INSERT INTO user_group_members (
+			 user_group_member_user_group_id
+			,user_group_member_principal_id
+			,user_group_member_created
+		) values ($1, $2, $3)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID, now); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert user group member")
+	}
+
+	return nil
+}
+
+// RemoveMember removes the principal from the members of the user group.
+func (s *UserGroupStore) RemoveMember(ctx context.Context, userGroupID int64, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM user_group_members
+		WHERE user_group_member_user_group_id = $1 AND
+		      user_group_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete user group member")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// SetMembers replaces the members of the user group with the provided principals.
+// Principals that are already members of the user group are kept as they are.
+func (s *UserGroupStore) SetMembers(ctx context.Context, userGroupID int64, principalIDs []int64, now int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	stmt := database.Builder.
+		Delete("user_group_members").
+		Where("user_group_member_user_group_id = ?", userGroupID)
+	if len(principalIDs) > 0 {
+		stmt = stmt.Where(squirrel.NotEq{"user_group_member_principal_id": principalIDs})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete user group members")
+	}
+
+	if len(principalIDs) == 0 {
+		return nil
+	}
+
+	insert := database.Builder.
+		Insert("user_group_members").
+		Columns(
+			"user_group_member_user_group_id",
+			"user_group_member_principal_id",
+			"user_group_member_created",
+		).
+		Suffix("ON CONFLICT DO NOTHING")
+	for _, principalID := range principalIDs {
+		insert = insert.Values(userGroupID, principalID, now)
+	}
+
+	sql, args, err = insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert user group members")
+	}
+
+	return nil
+}
+
+func mapToInternalUserGroup(group *types.UserGroup) *userGroup {
+	return &userGroup{
+		ID:          group.ID,
+		SpaceID:     group.SpaceID,
+		Identifier:  group.Identifier,
+		Name:        group.Name,
+		Description: group.Description,
+		Source:      group.Source,
+		Created:     group.Created,
+		Updated:     group.Updated,
+	}
+}
+
+func mapToUserGroup(group *userGroup) *types.UserGroup {
+	return &types.UserGroup{
+		ID:          group.ID,
+		SpaceID:     group.SpaceID,
+		Identifier:  group.Identifier,
+		Name:        group.Name,
+		Description: group.Description,
+		Source:      group.Source,
+		Created:     group.Created,
+		Updated:     group.Updated,
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMembershipStore = (*UserGroupMembershipStore)(nil)
+
+// NewUserGroupMembershipStore returns a new UserGroupMembershipStore.
+func NewUserGroupMembershipStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *UserGroupMembershipStore {
+	return &UserGroupMembershipStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMembershipStore implements store.UserGroupMembershipStore backed by a relational database.
+type UserGroupMembershipStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMembership struct {
+	SpaceID     int64 `db:"user_group_membership_space_id"`
+	UserGroupID int64 `db:"user_group_membership_user_group_id"`
+
+	CreatedBy int64 `db:"user_group_membership_created_by"`
+	Created   int64 `db:"user_group_membership_created"`
+	Updated   int64 `db:"user_group_membership_updated"`
+
+	Role enum.MembershipRole `db:"user_group_membership_role"`
+}
+
+type userGroupMembershipInfo struct {
+	userGroupMembership
+	userGroup
+}
+
+const (
+	userGroupMembershipColumns = `
+		 user_group_membership_space_id
+		,user_group_membership_user_group_id
+		,user_group_membership_created_by
+		,user_group_membership_created
+		,user_group_membership_updated
+		,user_group_membership_role`
+)
+
+// Find finds the membership of a user group in a space.
+func (s *UserGroupMembershipStore) Find(
+	ctx context.Context,
+	key types.UserGroupMembershipKey,
+) (*types.UserGroupMembership, error) {
+	stmt := database.Builder.
+		Select(userGroupMembershipColumns).
+		From("user_group_memberships").
+		Where("user_group_membership_space_id = ?", key.SpaceID).
+		Where("user_group_membership_user_group_id = ?", key.UserGroupID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroupMembership{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user group membership")
+	}
+
+	result := mapToUserGroupMembership(dst)
+
+	return &result, nil
+}
+
+// Create creates a new membership of a user group in a space.
+func (s *UserGroupMembershipStore) Create(ctx context.Context, membership *types.UserGroupMembership) error {
+	const sqlQuery = `
+		INSERT INTO user_group_memberships (
+			 user_group_membership_space_id
+			,user_group_membership_user_group_id
+			,user_group_membership_created_by
+			,user_group_membership_created
+			,user_group_membership_updated
+			,user_group_membership_role
+		) values (
+			 :user_group_membership_space_id
+			,:user_group_membership_user_group_id
+			,:user_group_membership_created_by
+			,:user_group_membership_created
+			,:user_group_membership_updated
+			,:user_group_membership_role
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalUserGroupMembership(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group membership")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert user group membership")
+	}
+
+	return nil
+}
+
+// Update updates the role of the membership of a user group in a space.
+func (s *UserGroupMembershipStore) Update(ctx context.Context, membership *types.UserGroupMembership) error {
+	const sqlQuery = `
+		UPDATE user_group_memberships
+		SET
+			 user_group_membership_updated = :user_group_membership_updated
+			,user_group_membership_role = :user_group_membership_role
+		WHERE user_group_membership_space_id = :user_group_membership_space_id AND
+		      user_group_membership_user_group_id = :user_group_membership_user_group_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalUserGroupMembership(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group membership")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update user group membership")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the membership of a user group in a space.
+func (s *UserGroupMembershipStore) Delete(ctx context.Context, key types.UserGroupMembershipKey) error {
+	const sqlQuery = `
+		DELETE FROM user_group_memberships
+		WHERE user_group_membership_space_id = $1 AND
+		      user_group_membership_user_group_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key.SpaceID, key.UserGroupID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete user group membership")
+	}
+
+	return nil
+}
+
+// List returns all user group memberships of a space, ordered by the identifier of the user group.
+func (s *UserGroupMembershipStore) List(ctx context.Context, spaceID int64) ([]types.UserGroupMembershipInfo, error) {
+	stmt := database.Builder.
+		Select(userGroupMembershipColumns+","+userGroupColumns).
+		From("user_group_memberships").
+		InnerJoin("user_groups ON user_group_id = user_group_membership_user_group_id").
+		Where("user_group_membership_space_id = ?", spaceID).
+		OrderBy("LOWER(user_group_identifier)")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*userGroupMembershipInfo
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group memberships query")
+	}
+
+	ids := make([]int64, len(dst))
+	for i, m := range dst {
+		ids[i] = m.CreatedBy
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group membership principal infos: %w", err)
+	}
+
+	res := make([]types.UserGroupMembershipInfo, len(dst))
+	for i, m := range dst {
+		res[i].UserGroupMembership = mapToUserGroupMembership(&m.userGroupMembership)
+		res[i].UserGroup = *mapToUserGroup(&m.userGroup)
+		if addedBy, ok := infoMap[m.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
+
+// ListRoles returns the roles the principal has in the space via the memberships of its user groups.
+func (s *UserGroupMembershipStore) ListRoles(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+) ([]enum.MembershipRole, error) {
+	stmt := database.Builder.
+		Select("user_group_membership_role").
+		From("user_group_memberships").
+		InnerJoin("user_group_members ON user_group_member_user_group_id = user_group_membership_user_group_id").
+		Where("user_group_membership_space_id = ?", spaceID).
+		Where("user_group_member_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var roles []enum.MembershipRole
+	if err = db.SelectContext(ctx, &roles, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group membership roles query")
+	}
+
+	return roles, nil
+}
+
+// ListSpaceIDs returns the IDs of all spaces the principal is a member of via its user groups.
+func (s *UserGroupMembershipStore) ListSpaceIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	stmt := database.Builder.
+		Select("DISTINCT user_group_membership_space_id").
+		From("user_group_memberships").
+		InnerJoin("user_group_members ON user_group_member_user_group_id = user_group_membership_user_group_id").
+		Where("user_group_member_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var spaceIDs []int64
+	if err = db.SelectContext(ctx, &spaceIDs, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group membership spaces query")
+	}
+
+	return spaceIDs, nil
+}
+
+func mapToUserGroupMembership(m *userGroupMembership) types.UserGroupMembership {
+	return types.UserGroupMembership{
+		UserGroupMembershipKey: types.UserGroupMembershipKey{
+			SpaceID:     m.SpaceID,
+			UserGroupID: m.UserGroupID,
+		},
+		CreatedBy: m.CreatedBy,
+		Created:   m.Created,
+		Updated:   m.Updated,
+		Role:      m.Role,
+	}
+}
+
+func mapToInternalUserGroupMembership(m *types.UserGroupMembership) *userGroupMembership {
+	return &userGroupMembership{
+		SpaceID:     m.SpaceID,
+		UserGroupID: m.UserGroupID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Role:        m.Role,
+	}
+}
@@ -43,7 +43,27 @@ var WireSet = wire.NewSet(
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
 	ProvideMembershipStore,
+	ProvideRepoMembershipStore,
+	ProvideCustomRoleStore,
 	ProvideTokenStore,
+	ProvidePublicKeyStore,
+	ProvideTwoFactorStore,
+	ProvideAuditLogStore,
+	ProvideLFSObjectStore,
+	ProvideLFSLockStore,
+	ProvidePullMirrorStore,
+	ProvidePushMirrorStore,
+	ProvideSecretScanningSettingsStore,
+	ProvideSecretFindingStore,
+	ProvideMergeQueueStore,
+	ProvideAutoMergeStore,
+	ProvideMergeTemplateStore,
+	ProvideLabelStore,
+	ProvidePullReqLabelStore,
+	ProvideOIDCIdentityStore,
+	ProvideLDAPIdentityStore,
+	ProvideUserGroupStore,
+	ProvideUserGroupMembershipStore,
 	ProvidePullReqStore,
 	ProvidePullReqActivityStore,
 	ProvideCodeCommentView,
@@ -182,11 +202,54 @@ func ProvideMembershipStore(
 	return NewMembershipStore(db, principalInfoCache, spacePathStore)
 }
 
+// ProvideRepoMembershipStore provides a repo membership store.
+func ProvideRepoMembershipStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RepoMembershipStore {
+	return NewRepoMembershipStore(db, principalInfoCache)
+}
+
+// ProvideCustomRoleStore provides a custom role store.
+func ProvideCustomRoleStore(db *sqlx.DB) store.CustomRoleStore {
+	return NewCustomRoleStore(db)
+}
+
 // ProvideTokenStore provides a token store.
 func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
 }
 
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvideTwoFactorStore provides a two-factor authentication store.
+func ProvideTwoFactorStore(db *sqlx.DB) store.TwoFactorStore {
+	return NewTwoFactorStore(db)
+}
+
+// ProvideLFSObjectStore provides a git lfs object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideLFSLockStore provides a git lfs lock store.
+func ProvideLFSLockStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.LFSLockStore {
+	return NewLFSLockStore(db, principalInfoCache)
+}
+
+// ProvidePullMirrorStore provides a repository pull mirror store.
+func ProvidePullMirrorStore(db *sqlx.DB) store.PullMirrorStore {
+	return NewPullMirrorStore(db)
+}
+
+// ProvidePushMirrorStore provides a repository push mirror store.
+func ProvidePushMirrorStore(db *sqlx.DB) store.PushMirrorStore {
+	return NewPushMirrorStore(db)
+}
+
 // ProvidePullReqStore provides a pull request store.
 func ProvidePullReqStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
@@ -239,3 +302,69 @@ func ProvideCheckStore(db *sqlx.DB,
 ) store.CheckStore {
 	return NewCheckStore(db, principalInfoCache)
 }
+
+// ProvideOIDCIdentityStore provides an OIDC identity store.
+func ProvideOIDCIdentityStore(db *sqlx.DB) store.OIDCIdentityStore {
+	return NewOIDCIdentityStore(db)
+}
+
+// ProvideLDAPIdentityStore provides an LDAP identity store.
+func ProvideLDAPIdentityStore(db *sqlx.DB) store.LDAPIdentityStore {
+	return NewLDAPIdentityStore(db)
+}
+
+// ProvideUserGroupStore provides a user group store.
+func ProvideUserGroupStore(db *sqlx.DB) store.UserGroupStore {
+	return NewUserGroupStore(db)
+}
+
+// ProvideUserGroupMembershipStore provides a user group membership store.
+func ProvideUserGroupMembershipStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.UserGroupMembershipStore {
+	return NewUserGroupMembershipStore(db, principalInfoCache)
+}
+
+// ProvideAuditLogStore provides an audit log store.
+func ProvideAuditLogStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.AuditLogStore {
+	return NewAuditLogStore(db, principalInfoCache)
+}
+
+// ProvideSecretScanningSettingsStore provides a secret scanning settings store.
+func ProvideSecretScanningSettingsStore(db *sqlx.DB) store.SecretScanningSettingsStore {
+	return NewSecretScanningSettingsStore(db)
+}
+
+// ProvideSecretFindingStore provides a secret finding store.
+func ProvideSecretFindingStore(db *sqlx.DB) store.SecretFindingStore {
+	return NewSecretFindingStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvideAutoMergeStore provides a pull request auto-merge store.
+func ProvideAutoMergeStore(db *sqlx.DB) store.AutoMergeStore {
+	return NewAutoMergeStore(db)
+}
+
+// ProvideMergeTemplateStore provides a merge commit templates store.
+func ProvideMergeTemplateStore(db *sqlx.DB) store.MergeTemplateStore {
+	return NewMergeTemplateStore(db)
+}
+
+// ProvideLabelStore provides a label store.
+func ProvideLabelStore(db *sqlx.DB) store.LabelStore {
+	return NewLabelStore(db)
+}
+
+// ProvidePullReqLabelStore provides a pull request label store.
+func ProvidePullReqLabelStore(db *sqlx.DB) store.PullReqLabelStore {
+	return NewPullReqLabelStore(db)
+}
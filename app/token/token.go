@@ -33,6 +33,13 @@ const (
 	userSessionTokenLifeTime time.Duration = 30 * 24 * time.Hour // 30 days.
 )
 
+// Restrictions optionally limit the access granted by a token.
+type Restrictions struct {
+	Scopes   []enum.Permission
+	SpaceIDs []int64
+	RepoIDs  []int64
+}
+
 func CreateUserSession(
 	ctx context.Context,
 	tokenStore store.TokenStore,
@@ -48,6 +55,7 @@ func CreateUserSession(
 		principal,
 		identifier,
 		ptr.Duration(userSessionTokenLifeTime),
+		Restrictions{},
 	)
 }
 
@@ -58,6 +66,7 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	restrictions Restrictions,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -67,6 +76,7 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		restrictions,
 	)
 }
 
@@ -86,6 +96,7 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		Restrictions{},
 	)
 }
 
@@ -97,6 +108,7 @@ func create(
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	restrictions Restrictions,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -113,6 +125,9 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		Scopes:      restrictions.Scopes,
+		SpaceIDs:    restrictions.SpaceIDs,
+		RepoIDs:     restrictions.RepoIDs,
 	}
 
 	err := tokenStore.Create(ctx, &token)
@@ -48,6 +48,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(repoPath string) string
 
+	// GenerateGITCloneSSHURL generates the public git clone URL via ssh for the provided repo path.
+	// NOTE: url is empty in case the ssh server isn't enabled.
+	GenerateGITCloneSSHURL(repoPath string) string
+
 	// GenerateUIRepoURL returns the url for the UI screen of a repository.
 	GenerateUIRepoURL(repoPath string) string
 
@@ -68,6 +72,12 @@ type Provider interface {
 
 	// GetAPIProto returns the proto for the API hostname
 	GetAPIProto() string
+
+	// GenerateAPIURL returns the public url of the provided path of the rest api.
+	GenerateAPIURL(path string) string
+
+	// GenerateUIURL returns the public url of the provided path of the UI.
+	GenerateUIURL(path string) string
 }
 
 // Provider provides the URLs of the gitness system.
@@ -87,6 +97,9 @@ type provider struct {
 	// NOTE: we store it as url.URL so we can derive clone URLS without errors.
 	gitURL *url.URL
 
+	// gitSSHURL stores the URL the git ssh server is available at (nil if not enabled).
+	gitSSHURL *url.URL
+
 	// uiURL stores the raw URL to the ui endpoints.
 	uiURL *url.URL
 }
@@ -96,6 +109,7 @@ func NewProvider(
 	containerURLRaw string,
 	apiURLRaw string,
 	gitURLRaw,
+	gitSSHURLRaw,
 	uiURLRaw string,
 ) (Provider, error) {
 	// remove trailing '/' to make usage easier
@@ -103,6 +117,7 @@ func NewProvider(
 	containerURLRaw = strings.TrimRight(containerURLRaw, "/")
 	apiURLRaw = strings.TrimRight(apiURLRaw, "/")
 	gitURLRaw = strings.TrimRight(gitURLRaw, "/")
+	gitSSHURLRaw = strings.TrimRight(gitSSHURLRaw, "/")
 	uiURLRaw = strings.TrimRight(uiURLRaw, "/")
 
 	internalURL, err := url.Parse(internalURLRaw)
@@ -125,6 +140,14 @@ func NewProvider(
 		return nil, fmt.Errorf("provided gitURLRaw '%s' is invalid: %w", gitURLRaw, err)
 	}
 
+	var gitSSHURL *url.URL
+	if gitSSHURLRaw != "" {
+		gitSSHURL, err = url.Parse(gitSSHURLRaw)
+		if err != nil {
+			return nil, fmt.Errorf("provided gitSSHURLRaw '%s' is invalid: %w", gitSSHURLRaw, err)
+		}
+	}
+
 	uiURL, err := url.Parse(uiURLRaw)
 	if err != nil {
 		return nil, fmt.Errorf("provided uiURLRaw '%s' is invalid: %w", uiURLRaw, err)
@@ -135,6 +158,7 @@ func NewProvider(
 		containerURL: containerURL,
 		apiURL:       apiURL,
 		gitURL:       gitURL,
+		gitSSHURL:    gitSSHURL,
 		uiURL:        uiURL,
 	}, nil
 }
@@ -161,6 +185,19 @@ func (p *provider) GenerateGITCloneURL(repoPath string) string {
 	return p.gitURL.JoinPath(repoPath).String()
 }
 
+func (p *provider) GenerateGITCloneSSHURL(repoPath string) string {
+	if p.gitSSHURL == nil {
+		return ""
+	}
+
+	repoPath = path.Clean(repoPath)
+	if !strings.HasSuffix(repoPath, GITSuffix) {
+		repoPath += GITSuffix
+	}
+
+	return p.gitSSHURL.JoinPath(repoPath).String()
+}
+
 func (p *provider) GenerateUIBuildURL(repoPath, pipelineIdentifier string, seqNumber int64) string {
 	return p.uiURL.JoinPath(repoPath, "pipelines",
 		pipelineIdentifier, "execution", strconv.Itoa(int(seqNumber))).String()
@@ -189,3 +226,11 @@ func (p *provider) GetGITHostname() string {
 func (p *provider) GetAPIProto() string {
 	return p.apiURL.Scheme
 }
+
+func (p *provider) GenerateAPIURL(path string) string {
+	return p.apiURL.JoinPath(path).String()
+}
+
+func (p *provider) GenerateUIURL(path string) string {
+	return p.uiURL.JoinPath(path).String()
+}
@@ -16,13 +16,13 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
 	"cloud.google.com/go/storage"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
@@ -70,24 +70,23 @@ func (c *GCSStore) Upload(ctx context.Context, file io.Reader, filePath string)
 		return fmt.Errorf("failed to retrieve latest client: %w", err)
 	}
 
+	// canceling the context of the writer aborts the upload, so partial content never gets stored.
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	bkt := gcsClient.Bucket(c.config.Bucket)
-	wc := bkt.Object(filePath).NewWriter(ctx)
-	defer func() {
-		cErr := wc.Close()
-		if cErr != nil {
-			log.Ctx(ctx).Err(cErr).
-				Msgf("failed to close gcs blob writer for file '%s' in bucket '%s'", filePath, c.config.Bucket)
-		}
-	}()
+	wc := bkt.Object(filePath).NewWriter(writeCtx)
 	if _, err := io.Copy(wc, file); err != nil {
-		// Remove the file if it was created.
-		deleteErr := gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
-		if deleteErr != nil {
-			return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, deleteErr)
-		}
+		cancel()
+		_ = wc.Close()
 		return fmt.Errorf("failed to write file to GCS: %w", err)
 	}
 
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("failed to close gcs blob writer for file '%s' in bucket '%s': %w",
+			filePath, c.config.Bucket, err)
+	}
+
 	return nil
 }
 
@@ -108,8 +107,21 @@ func (c *GCSStore) GetSignedURL(ctx context.Context, filePath string) (string, e
 	return signedURL, nil
 }
 
-func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error) {
-	return nil, fmt.Errorf("not implemented")
+func (c *GCSStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	rc, err := gcsClient.Bucket(c.config.Bucket).Object(filePath).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file '%s' from bucket '%s': %w", filePath, c.config.Bucket, err)
+	}
+
+	return rc, nil
 }
 
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
@@ -16,11 +16,16 @@ package account
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
 	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/client"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -42,11 +47,19 @@ func (c *loginCommand) run(*kingpin.ParseContext) error {
 		Password:        password,
 	}
 
-	ts, err := provide.OpenClient(c.server).Login(ctx, in)
+	cl := provide.OpenClient(c.server)
+	ts, err := cl.Login(ctx, in)
 	if err != nil {
 		return err
 	}
 
+	if ts.TwoFactor != nil {
+		ts, err = loginTwoFactor(ctx, cl, ts.TwoFactor)
+		if err != nil {
+			return err
+		}
+	}
+
 	return ss.
 		SetURI(c.server).
 		// login token always has an expiry date
@@ -55,6 +68,40 @@ func (c *loginCommand) run(*kingpin.ParseContext) error {
 		Store()
 }
 
+// loginTwoFactor completes the login with the authenticator app code or a recovery code.
+func loginTwoFactor(
+	ctx context.Context,
+	cl client.Client,
+	challenge *types.TwoFactorChallenge,
+) (*types.LoginResponse, error) {
+	if challenge.EnrollmentRequired {
+		return nil, errors.New("two-factor authentication has to be set up via the web UI before logging in")
+	}
+
+	code := textui.TwoFactorCode()
+
+	// recovery codes are of the form xxxxx-xxxxx, authenticator app codes are digits only.
+	method := enum.TwoFactorMethodTOTP
+	if strings.Contains(code, "-") {
+		method = enum.TwoFactorMethodRecoveryCode
+	}
+
+	ts, err := cl.LoginTwoFactor(ctx, &user.LoginTwoFactorInput{
+		ChallengeToken: challenge.ChallengeToken,
+		Method:         method,
+		Code:           code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ts.TokenResponse == nil {
+		return nil, errors.New("login did not return a token")
+	}
+
+	return ts, nil
+}
+
 // RegisterLogin helper function to register the logout command.
 func RegisterLogin(app *kingpin.Application) {
 	c := &loginCommand{}
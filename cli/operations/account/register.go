@@ -16,6 +16,7 @@ package account
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -57,6 +58,10 @@ func (c *registerCommand) run(*kingpin.ParseContext) error {
 		return err
 	}
 
+	if ts.TokenResponse == nil {
+		return errors.New("two-factor authentication is required, please log in to set it up")
+	}
+
 	return ss.
 		SetURI(c.server).
 		// register token always has an expiry date
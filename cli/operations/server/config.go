@@ -44,10 +44,13 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP       = "http"
+	schemeHTTPS      = "https"
+	schemeSSH        = "ssh"
+	gitnessHomeDir   = ".gitness"
+	blobDir          = "blob"
+	keywordSearchDir = "keywordsearch"
+	sshHostKeyFile   = "ssh/gitness_host_ed25519"
 )
 
 // LoadConfig returns the system configuration from the
@@ -95,6 +98,15 @@ func LoadConfig() (*types.Config, error) {
 		}
 	}
 
+	if config.Server.SSH.Enabled && config.Server.SSH.HostKeyPath == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+
+		config.Server.SSH.HostKeyPath = filepath.Join(homedir, gitnessHomeDir, sshHostKeyFile)
+	}
+
 	return config, nil
 }
 
@@ -164,6 +176,13 @@ func backfillURLs(config *types.Config) error {
 	if config.URL.UI == "" {
 		config.URL.UI = baseURL.String()
 	}
+	if config.Server.SSH.Enabled && config.URL.GitSSH == "" {
+		sshPort := ""
+		if config.Server.SSH.Port != 22 {
+			sshPort = fmt.Sprint(config.Server.SSH.Port)
+		}
+		config.URL.GitSSH = combineToRawURL(schemeSSH, config.Server.SSH.DefaultUser+"@"+host, sshPort, "")
+	}
 
 	return nil
 }
@@ -345,6 +364,7 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		AuditLogsRetentionTime:           config.AuditLog.RetentionTime,
 	}
 }
 
@@ -356,12 +376,24 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 }
 
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
-func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+func ProvideKeywordSearchConfig(config *types.Config) (keywordsearch.Config, error) {
+	// Prefix home directory in case no explicit index directory is provided
+	if config.KeywordSearch.IndexDir == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return keywordsearch.Config{}, err
+		}
+
+		config.KeywordSearch.IndexDir = filepath.Join(homedir, gitnessHomeDir, keywordSearchDir)
+	}
+
 	return keywordsearch.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
-	}
+		IndexDir:        config.KeywordSearch.IndexDir,
+		MaxFileSize:     config.KeywordSearch.MaxFileSize,
+	}, nil
 }
 
 func ProvideJobsConfig(config *types.Config) job.Config {
@@ -216,3 +216,40 @@ func TestBackfillURLsCustom(t *testing.T) {
 	require.Equal(t, "https://Git:443/Git/p", config.URL.Git)
 	require.Equal(t, "http://UI:80/UI/p", config.URL.UI)
 }
+
+func TestBackfillURLsSSH(t *testing.T) {
+	config := &types.Config{}
+	config.Server.HTTP.Port = 1234
+	config.Server.SSH.Enabled = true
+	config.Server.SSH.Port = 3022
+	config.Server.SSH.DefaultUser = "git"
+	config.URL.Base = "https://xyz:4321/test"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "ssh://git@xyz:3022", config.URL.GitSSH)
+}
+
+func TestBackfillURLsSSHStripsDefaultPort(t *testing.T) {
+	config := &types.Config{}
+	config.Server.HTTP.Port = 1234
+	config.Server.SSH.Enabled = true
+	config.Server.SSH.Port = 22
+	config.Server.SSH.DefaultUser = "git"
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "ssh://git@localhost", config.URL.GitSSH)
+}
+
+func TestBackfillURLsSSHDisabled(t *testing.T) {
+	config := &types.Config{}
+	config.Server.HTTP.Port = 1234
+
+	err := backfillURLs(config)
+	require.NoError(t, err)
+
+	require.Empty(t, config.URL.GitSSH)
+}
@@ -22,6 +22,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/harness/gitness/app/gitssh"
 	"github.com/harness/gitness/app/pipeline/logger"
 	"github.com/harness/gitness/profiler"
 	"github.com/harness/gitness/types"
@@ -100,17 +101,41 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if err := system.services.Mirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register mirror service")
+			return err
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
 		}
 
+		if err := system.services.UserGroup.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register user group service")
+			return err
+		}
+
+		if err := system.services.MergeQueue.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge queue service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
+
+	// start ssh server
+	var shutdownSSH gitssh.ShutdownFunction
+	if config.Server.SSH.Enabled {
+		var gSSH *errgroup.Group
+		gSSH, shutdownSSH = system.sshServer.ListenAndServe(ctx)
+		g.Go(gSSH.Wait)
+	}
+
 	if c.enableCI {
 		// start populating plugins
 		g.Go(func() error {
@@ -132,6 +157,8 @@ func (c *command) run(*kingpin.ParseContext) error {
 
 	log.Info().
 		Int("port", config.Server.HTTP.Port).
+		Bool("ssh.enabled", config.Server.SSH.Enabled).
+		Int("ssh.port", config.Server.SSH.Port).
 		Str("revision", version.GitCommit).
 		Str("repository", version.GitRepository).
 		Stringer("version", version.Version).
@@ -152,6 +179,12 @@ func (c *command) run(*kingpin.ParseContext) error {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
 
+	if shutdownSSH != nil {
+		if sErr := shutdownSSH(shutdownCtx); sErr != nil {
+			log.Err(sErr).Msg("failed to shutdown ssh server gracefully")
+		}
+	}
+
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
 	log.Info().Msg("wait for subroutines to complete")
@@ -16,6 +16,7 @@ package server
 
 import (
 	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/gitssh"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
@@ -27,17 +28,19 @@ import (
 type System struct {
 	bootstrap       bootstrap.Bootstrap
 	server          *server.Server
+	sshServer       *gitssh.Server
 	resolverManager *resolver.Manager
 	poller          *poller.Poller
 	services        services.Services
 }
 
 // NewSystem returns a new system structure.
-func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, poller *poller.Poller,
+func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, sshServer *gitssh.Server, poller *poller.Poller,
 	resolverManager *resolver.Manager, services services.Services) *System {
 	return &System{
 		bootstrap:       bootstrap,
 		server:          server,
+		sshServer:       sshServer,
 		poller:          poller,
 		resolverManager: resolverManager,
 		services:        services,
@@ -23,6 +23,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/drone/funcmap"
 	"github.com/gotidy/ptr"
@@ -39,6 +40,9 @@ token:       {{ .AccessToken }}
 type createPATCommand struct {
 	identifier  string
 	lifetimeInS int64
+	scopes      []string
+	spaces      []string
+	repos       []string
 
 	json bool
 	tmpl string
@@ -53,9 +57,17 @@ func (c *createPATCommand) run(*kingpin.ParseContext) error {
 		lifeTime = ptr.Duration(time.Duration(int64(time.Second) * c.lifetimeInS))
 	}
 
+	scopes := make([]enum.Permission, len(c.scopes))
+	for i := range c.scopes {
+		scopes[i] = enum.Permission(c.scopes[i])
+	}
+
 	in := user.CreateTokenInput{
 		Identifier: c.identifier,
 		Lifetime:   lifeTime,
+		Scopes:     scopes,
+		Spaces:     c.spaces,
+		Repos:      c.repos,
 	}
 
 	tokenResp, err := provide.Client().UserCreatePAT(ctx, in)
@@ -87,6 +99,15 @@ func registerCreatePAT(app *kingpin.CmdClause) {
 	cmd.Arg("lifetime", "the lifetime of the token in seconds").
 		Int64Var(&c.lifetimeInS)
 
+	cmd.Flag("scope", "limit the token to the permission (can be repeated)").
+		StringsVar(&c.scopes)
+
+	cmd.Flag("space", "restrict the token to the space (can be repeated)").
+		StringsVar(&c.spaces)
+
+	cmd.Flag("repo", "restrict the token to the repository (can be repeated)").
+		StringsVar(&c.repos)
+
 	cmd.Flag("json", "json encode the output").
 		BoolVar(&c.json)
 
@@ -54,6 +54,16 @@ func LoginIdentifier() string {
 	return strings.TrimSpace(id)
 }
 
+// TwoFactorCode returns the authenticator app code or a recovery code from stdin.
+func TwoFactorCode() string {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter Authentication Code or Recovery Code: ")
+	code, _ := reader.ReadString('\n')
+
+	return strings.TrimSpace(code)
+}
+
 // DisplayName returns the display name from stdin.
 func DisplayName() string {
 	reader := bufio.NewReader(os.Stdin)
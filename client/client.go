@@ -69,16 +69,27 @@ func (c *HTTPClient) SetDebug(debug bool) {
 }
 
 // Login authenticates the user and returns a JWT token.
-func (c *HTTPClient) Login(ctx context.Context, input *user.LoginInput) (*types.TokenResponse, error) {
-	out := new(types.TokenResponse)
+func (c *HTTPClient) Login(ctx context.Context, input *user.LoginInput) (*types.LoginResponse, error) {
+	out := new(types.LoginResponse)
 	uri := fmt.Sprintf("%s/api/v1/login", c.base)
 	err := c.post(ctx, uri, true, input, out)
 	return out, err
 }
 
+// LoginTwoFactor completes a login pending the second factor and returns a JWT token.
+func (c *HTTPClient) LoginTwoFactor(
+	ctx context.Context,
+	input *user.LoginTwoFactorInput,
+) (*types.LoginResponse, error) {
+	out := new(types.LoginResponse)
+	uri := fmt.Sprintf("%s/api/v1/login/2fa", c.base)
+	err := c.post(ctx, uri, true, input, out)
+	return out, err
+}
+
 // Register registers a new  user and returns a JWT token.
-func (c *HTTPClient) Register(ctx context.Context, input *user.RegisterInput) (*types.TokenResponse, error) {
-	out := new(types.TokenResponse)
+func (c *HTTPClient) Register(ctx context.Context, input *user.RegisterInput) (*types.LoginResponse, error) {
+	out := new(types.LoginResponse)
 	uri := fmt.Sprintf("%s/api/v1/register", c.base)
 	err := c.post(ctx, uri, true, input, out)
 	return out, err
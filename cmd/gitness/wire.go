@@ -14,6 +14,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -34,11 +35,16 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/twofactor"
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/gitssh"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -51,18 +57,26 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/mergetemplate"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
@@ -107,6 +121,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		pullreqservice.WireSet,
 		services.WireSet,
 		server.WireSet,
+		gitssh.WireSet,
 		url.WireSet,
 		space.WireSet,
 		limiter.WireSet,
@@ -116,14 +131,20 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
+		lfs.WireSet,
 		service.WireSet,
 		principal.WireSet,
 		system.WireSet,
 		authn.WireSet,
 		authz.WireSet,
+		oidc.WireSet,
+		ldap.WireSet,
+		twofactor.WireSet,
+		audit.WireSet,
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		checkevents.WireSet,
 		storage.WireSet,
 		adapter.WireSet,
 		cliserver.ProvideGitConfig,
@@ -174,12 +195,19 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		exporter.WireSet,
 		metric.WireSet,
 		reposize.WireSet,
+		mirror.WireSet,
+		secretscan.WireSet,
+		mergequeue.WireSet,
+		merger.WireSet,
+		mergetemplate.WireSet,
+		label.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
 		usergroup.WireSet,
+		gitsignature.WireSet,
 		openapi.WireSet,
 	)
 	return &cliserver.System{}, nil
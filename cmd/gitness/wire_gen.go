@@ -13,6 +13,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -33,11 +34,16 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/twofactor"
 	"github.com/harness/gitness/app/bootstrap"
+	events5 "github.com/harness/gitness/app/events/check"
 	events4 "github.com/harness/gitness/app/events/git"
 	events3 "github.com/harness/gitness/app/events/pullreq"
 	events2 "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/gitssh"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -50,18 +56,26 @@ import (
 	"github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/gitsignature"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/merger"
+	"github.com/harness/gitness/app/services/mergetemplate"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/secretscan"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
@@ -109,20 +123,46 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore)
+	userGroupMembershipStore := database.ProvideUserGroupMembershipStore(db, principalInfoCache)
+	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore)
+	repoMembershipStore := database.ProvideRepoMembershipStore(db, principalInfoCache)
+	customRoleStore := database.ProvideCustomRoleStore(db)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore, userGroupMembershipStore, repoStore, repoMembershipStore, customRoleStore)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, repoStore)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
+	userGroupStore := database.ProvideUserGroupStore(db)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
-	serviceController := service.NewController(principalUID, authorizer, principalStore)
-	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
+	publicKeyStore := database.ProvidePublicKeyStore(db)
+	oidcIdentityStore := database.ProvideOIDCIdentityStore(db)
+	ldapIdentityStore := database.ProvideLDAPIdentityStore(db)
 	provider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
-	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore)
+	oidcProvider, err := oidc.ProvideProvider(config, provider)
+	if err != nil {
+		return nil, err
+	}
+	ldapAuthenticator, err := ldap.ProvideAuthenticator(config)
+	if err != nil {
+		return nil, err
+	}
+	twoFactorStore := database.ProvideTwoFactorStore(db)
+	encrypter, err := encrypt.ProvideEncrypter(config)
+	if err != nil {
+		return nil, err
+	}
+	twofactorAuthenticator, err := twofactor.ProvideAuthenticator(config, provider, encrypter)
+	if err != nil {
+		return nil, err
+	}
+	auditLogStore := database.ProvideAuditLogStore(db, principalInfoCache)
+	auditService := audit.ProvideService(auditLogStore)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, userGroupMembershipStore, repoMembershipStore, publicKeyStore, spaceStore, oidcIdentityStore, oidcProvider, ldapAuthenticator, ldapIdentityStore, repoStore, twoFactorStore, twofactorAuthenticator, auditLogStore, auditService)
+	serviceController := service.NewController(principalUID, authorizer, principalStore)
+	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
 	protectionManager, err := protection.ProvideManager(ruleStore)
@@ -149,10 +189,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
-	encrypter, err := encrypt.ProvideEncrypter(config)
-	if err != nil {
-		return nil, err
-	}
 	jobStore := database.ProvideJobStore(db)
 	pubsubConfig := server.ProvidePubsubConfig(config)
 	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
@@ -165,14 +201,21 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	keywordsearchConfig, err := server.ProvideKeywordSearchConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	localIndexSearcher, err := keywordsearch.ProvideLocalIndexSearcher(keywordsearchConfig, gitInterface)
+	if err != nil {
+		return nil, err
+	}
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
 	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
 	if err != nil {
 		return nil, err
 	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
-	usergroupResolver := usergroup.ProvideUserGroupResolver()
+	usergroupResolver := usergroup.ProvideUserGroupResolver(spaceStore, userGroupStore)
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
 	eventsConfig := server.ProvideEventsConfig(config)
 	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
@@ -187,7 +230,37 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, mutexManager)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	lfsObjectStore := database.ProvideLFSObjectStore(db)
+	pullMirrorStore := database.ProvidePullMirrorStore(db)
+	verifier := gitsignature.ProvideVerifier(gitInterface, principalStore, publicKeyStore)
+	pushMirrorStore := database.ProvidePushMirrorStore(db)
+	secretStore := database.ProvideSecretStore(db)
+	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mirrorService, err := mirror.ProvideService(ctx, config, provider, gitInterface, encrypter, repoStore, secretStore, pullMirrorStore, pushMirrorStore, jobScheduler, executor, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	secretScanningSettingsStore := database.ProvideSecretScanningSettingsStore(db)
+	secretFindingStore := database.ProvideSecretFindingStore(db)
+	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
+	mergeTemplateStore := database.ProvideMergeTemplateStore(db)
+	mergetemplateService := mergetemplate.ProvideService(gitInterface, mergeTemplateStore, pullReqReviewerStore)
+	labelStore := database.ProvideLabelStore(db)
+	pullReqLabelStore := database.ProvidePullReqLabelStore(db)
+	labelService := label.ProvideService(labelStore, pullReqLabelStore, spaceStore)
+	secretscanService := secretscan.ProvideService(config, gitInterface, secretScanningSettingsStore, secretFindingStore)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, mutexManager, lfsObjectStore, blobStore, verifier, pullMirrorStore, encrypter, pushMirrorStore, secretStore, mirrorService, repoMembershipStore, customRoleStore, auditService, secretscanService, mergetemplateService, labelService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
@@ -208,13 +281,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
-	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db)
 	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, userGroupStore, userGroupMembershipStore, customRoleStore, auditLogStore, auditService, labelService)
 	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore)
 	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
@@ -225,32 +297,42 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
 	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
-	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
 	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
 	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	readerFactory2, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService)
+	autoMergeStore := database.ProvideAutoMergeStore(db)
+	mergeQueueStore := database.ProvideMergeQueueStore(db)
+	mergerService := merger.ProvideService(gitInterface, pullReqStore, pullReqActivityStore, eventsReporter, streamer, mergetemplateService)
+	mergequeueService, err := mergequeue.ProvideService(ctx, config, provider, gitInterface, mutexManager, authorizer, repoStore, pullReqStore, pullReqActivityStore, pullReqReviewerStore, pullReqLabelStore, checkStore, mergeQueueStore, principalStore, principalInfoCache, protectionManager, codeownersService, eventsReporter, eventsReaderFactory, readerFactory2, streamer, jobScheduler, executor, mergetemplateService, mergerService, verifier)
+	if err != nil {
+		return nil, err
+	}
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer, readerFactory2, autoMergeStore, pullReqReviewerStore, principalStore, checkStore, protectionManager, codeownersService, authorizer, mutexManager, mergequeueService, mergerService, pullReqLabelStore, verifier)
+	if err != nil {
+		return nil, err
+	}
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService, usergroupResolver, auditService, reporter, mergequeueService, mergerService, labelService, verifier)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	readerFactory3, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory3, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
 	if err != nil {
 		return nil, err
 	}
@@ -259,30 +341,35 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, verifier, pullMirrorStore, auditService, reporter, secretscanService)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, auditService)
 	principalController := principal.ProvideController(principalStore)
 	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
+	reporter3, err := events5.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v, reporter3)
 	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
+	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
+	apiHandler, err := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
 	if err != nil {
 		return nil, err
 	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	lfsLockStore := database.ProvideLFSLockStore(db, principalInfoCache)
+	lfsController := lfs.ProvideController(config, authorizer, repoStore, lfsObjectStore, lfsLockStore, blobStore, provider)
+	gitHandler, err := router.ProvideGitHandler(config, provider, authenticator, repoController, lfsController)
 	if err != nil {
 		return nil, err
 	}
-	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
-	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
 	openapiService := openapi.ProvideOpenAPIService()
 	webHandler := router.ProvideWebHandler(config, openapiService)
 	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
+	gitsshServer := gitssh.ProvideServer(config, principalStore, publicKeyStore, repoController)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, reporter3)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
@@ -304,7 +391,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, auditLogStore)
 	if err != nil {
 		return nil, err
 	}
@@ -315,12 +402,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
 	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, repoStore, indexer)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, resolverManager, servicesServices)
+	usergroupService := usergroup.ProvideService(config, transactor, jobScheduler, executor, ldapAuthenticator, spaceStore, ldapIdentityStore, userGroupStore)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, mirrorService, cleanupService, notificationService, keywordsearchService, usergroupService, mergequeueService)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, gitsshServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
 	"code.gitea.io/gitea/modules/git"
@@ -47,7 +48,8 @@ type Adapter interface {
 	Push(ctx context.Context, repoPath string, opts types.PushOptions) error
 	ReadTree(ctx context.Context, repoPath, ref string, w io.Writer, args ...string) error
 	GetTreeNode(ctx context.Context, repoPath string, ref string, treePath string) (*types.TreeNode, error)
-	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string) ([]types.TreeNode, error)
+	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string,
+		recursive bool) ([]types.TreeNode, error)
 	PathsDetails(ctx context.Context, repoPath string, ref string, paths []string) ([]types.PathDetails, error)
 	GetSubmodule(ctx context.Context, repoPath string, ref string, treePath string) (*types.Submodule, error)
 	GetBlob(ctx context.Context, repoPath string, sha string, sizeLimit int64) (*types.BlobReader, error)
@@ -75,6 +77,7 @@ type Adapter interface {
 		requests []types.CommitDivergenceRequest, max int32) ([]types.CommitDivergence, error)
 	GetRef(ctx context.Context, repoPath string, reference string) (string, error)
 	UpdateRef(ctx context.Context, envVars map[string]string, repoPath, reference, newValue, oldValue string) error
+	RunPostReceiveHook(ctx context.Context, envVars map[string]string, refUpdates []hook.ReferenceUpdate) error
 	CreateTemporaryRepoForPR(ctx context.Context, reposTempPath string, pr *types.PullRequest,
 		baseBranch, trackingBranch string) (types.TempRepository, error)
 	Merge(ctx context.Context, pr *types.PullRequest, mergeMethod enum.MergeMethod, baseBranch, trackingBranch string,
@@ -83,6 +86,8 @@ type Adapter interface {
 	IsAncestor(ctx context.Context, repoPath, ancestorCommitSHA, descendantCommitSHA string) (bool, error)
 	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int) types.BlameReader
 	Sync(ctx context.Context, repoPath string, source string, refSpecs []string) error
+	FetchObjects(ctx context.Context, repoPath string, source string, objectSHAs []string, ref string) error
+	RepackAll(ctx context.Context, repoPath string) error
 
 	//
 	// Diff operations
@@ -106,6 +111,35 @@ type Adapter interface {
 		sha string,
 		w io.Writer) error
 
+	Archive(ctx context.Context,
+		repoPath string,
+		ref string,
+		format enum.ArchiveFormat,
+		prefix string,
+		paths []string,
+		w io.Writer) error
+
+	GetObjectSignatures(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		shas []string) ([]types.ObjectSignature, error)
+
+	ListNewCommitSHAs(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		ref string,
+		baseRef string) ([]string, error)
+
+	ListNewCommits(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		ref string) ([]types.NewCommit, error)
+
+	GetCommitAddedLines(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		sha string) ([]types.FileAddedLines, error)
+
 	DiffShortStat(ctx context.Context,
 		repoPath string,
 		baseRef string,
@@ -143,6 +177,7 @@ type Adapter interface {
 		ctx context.Context,
 		repoPath string,
 		service string,
+		statelessRPC bool,
 		stdin io.Reader,
 		stdout io.Writer,
 		env ...string,
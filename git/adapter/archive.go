@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+
+	"code.gitea.io/gitea/modules/git"
+)
+
+// Archive writes an archive of the tree of the provided ref in the requested format.
+// If paths are provided, only the files under the provided paths are part of the archive.
+func (a Adapter) Archive(
+	ctx context.Context,
+	repoPath string,
+	ref string,
+	format enum.ArchiveFormat,
+	prefix string,
+	paths []string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if ref == "" {
+		return errors.InvalidArgument("ref cannot be empty")
+	}
+	if !format.IsValid() {
+		return errors.InvalidArgument("unsupported archive format %q", format)
+	}
+
+	args := make([]string, 0, 6+len(paths))
+	args = append(args, "archive", "--format="+string(format))
+	if prefix != "" {
+		args = append(args, "--prefix="+prefix)
+	}
+	args = append(args, ref, "--")
+	args = append(args, paths...)
+
+	stderr := new(bytes.Buffer)
+	cmd := git.NewCommand(ctx, args...)
+	if err := cmd.Run(&git.RunOpts{
+		Dir:    repoPath,
+		Stdout: w,
+		Stderr: stderr,
+	}); err != nil {
+		// exit status 128 - fatal: pathspec 'a/b' did not match any files
+		if strings.Contains(stderr.String(), "did not match any files") {
+			return errors.NotFound("path not found: %s", strings.TrimSpace(stderr.String()))
+		}
+		return processGiteaErrorf(err, "failed to create archive: %v", stderr)
+	}
+
+	return nil
+}
@@ -38,9 +38,11 @@ type WriteCloserError interface {
 
 // CatFileBatch opens git cat-file --batch in the provided repo and returns a stdin pipe,
 // a stdout reader and cancel function.
+// Optional alternate object directories can be provided to access objects that aren't part of the repository yet.
 func CatFileBatch(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs ...string,
 ) (WriteCloserError, *bufio.Reader, func()) {
 	const bufferSize = 32 * 1024
 	// We often want to feed the commits in order into cat-file --batch,
@@ -64,7 +66,10 @@ func CatFileBatch(
 
 	go func() {
 		stderr := bytes.Buffer{}
-		cmd := command.New("cat-file", command.WithFlag("--batch"))
+		cmd := command.New("cat-file",
+			command.WithFlag("--batch"),
+			command.WithAlternateObjectDirs(alternateObjectDirs...),
+		)
 		err := cmd.Run(ctx,
 			command.WithDir(repoPath),
 			command.WithStdin(batchStdinReader),
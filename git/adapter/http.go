@@ -36,7 +36,7 @@ func (a Adapter) InfoRefs(
 	env ...string,
 ) error {
 	cmd := &bytes.Buffer{}
-	if err := git.NewCommand(ctx, service, "--stateless-rpc", "--advertise-refs", ".").
+	if err := git.NewCommand(ctx, servicePackArgs(service, "--stateless-rpc", "--advertise-refs", ".")...).
 		Run(&git.RunOpts{
 			Env:    env,
 			Dir:    repoPath,
@@ -62,6 +62,7 @@ func (a Adapter) ServicePack(
 	ctx context.Context,
 	repoPath string,
 	service string,
+	statelessRPC bool,
 	stdin io.Reader,
 	stdout io.Writer,
 	env ...string,
@@ -72,8 +73,15 @@ func (a Adapter) ServicePack(
 	var (
 		stderr bytes.Buffer
 	)
-	cmd := git.NewCommand(ctx, service, "--stateless-rpc", repoPath)
-	cmd.SetDescription(fmt.Sprintf("%s %s %s [repo_path: %s]", git.GitExecutable, service, "--stateless-rpc", repoPath))
+
+	// without stateless rpc the command advertises the refs itself and serves the whole exchange (e.g. for ssh).
+	cmd := git.NewCommand(ctx, servicePackArgs(service)...)
+	if statelessRPC {
+		cmd.AddArguments("--stateless-rpc")
+	}
+	cmd.AddArguments(repoPath)
+	cmd.SetDescription(fmt.Sprintf("%s %s [stateless_rpc: %t, repo_path: %s]",
+		git.GitExecutable, service, statelessRPC, repoPath))
 	err := cmd.Run(&git.RunOpts{
 		Dir:               repoPath,
 		Env:               env,
@@ -88,6 +96,17 @@ func (a Adapter) ServicePack(
 	return err
 }
 
+// servicePackArgs returns the git arguments required to run the service.
+// The receive-pack service advertises push options, so clients can send them to the server hooks.
+func servicePackArgs(service string, args ...string) []string {
+	var result []string
+	if service == "receive-pack" {
+		result = append(result, "-c", "receive.advertisePushOptions=true")
+	}
+	result = append(result, service)
+	return append(result, args...)
+}
+
 func packetWrite(str string) []byte {
 	s := strconv.FormatInt(int64(len(str)+4), 16)
 	if len(s)%4 != 0 {
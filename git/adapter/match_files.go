@@ -34,7 +34,7 @@ func (a Adapter) MatchFiles(
 	pattern string,
 	maxSize int,
 ) ([]types.FileContent, error) {
-	nodes, err := lsDirectory(ctx, repoPath, rev, treePath)
+	nodes, err := lsDirectory(ctx, repoPath, rev, treePath, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list files in match files: %w", err)
 	}
@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/types"
+)
+
+// maxAddedLinesFileSize is the max number of bytes of added lines returned per file by GetCommitAddedLines.
+const maxAddedLinesFileSize = 1 << 20 // 1 MB
+
+// ListNewCommits returns the details of all commits reachable from the provided ref
+// that aren't reachable from any of the existing references of the repository,
+// including the files each commit adds or modifies and their blob sizes.
+// Optional alternate object directories can be provided to access objects that aren't part of the repository yet.
+func (a Adapter) ListNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	ref string,
+) ([]types.NewCommit, error) {
+	shas, err := a.ListNewCommitSHAs(ctx, repoPath, alternateObjectDirs, ref, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shas) == 0 {
+		return nil, nil
+	}
+
+	commits, err := readNewCommits(ctx, repoPath, alternateObjectDirs, shas)
+	if err != nil {
+		return nil, err
+	}
+
+	var blobSHAs []string
+	blobPaths := make([][]string, len(commits))
+	for i := range commits {
+		paths, blobs, err := listCommitChangedBlobs(ctx, repoPath, alternateObjectDirs, commits[i].SHA)
+		if err != nil {
+			return nil, err
+		}
+
+		blobPaths[i] = paths
+		blobSHAs = append(blobSHAs, blobs...)
+	}
+
+	sizes, err := readBlobSizes(ctx, repoPath, alternateObjectDirs, blobSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := 0
+	for i := range commits {
+		commits[i].Files = make([]types.NewCommitFile, len(blobPaths[i]))
+		for j, path := range blobPaths[i] {
+			commits[i].Files[j] = types.NewCommitFile{
+				Path: path,
+				Size: sizes[blobSHAs[idx]],
+			}
+			idx++
+		}
+	}
+
+	return commits, nil
+}
+
+// readNewCommits reads the author and the message of the provided commits.
+func readNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []string,
+) ([]types.NewCommit, error) {
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs...)
+	defer func() {
+		cancel()
+		_ = writer.Close()
+	}()
+
+	commits := make([]types.NewCommit, len(shas))
+
+	for i, sha := range shas {
+		if _, err := writer.Write([]byte(sha + "\n")); err != nil {
+			return nil, err
+		}
+
+		_, typ, size, err := ReadBatchHeaderLine(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.IsNotFound(err) {
+				return nil, errors.NotFound("commit with sha %s does not exist", sha)
+			}
+			return nil, err
+		}
+
+		raw, err := io.ReadAll(io.LimitReader(reader, size))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = reader.Discard(1); err != nil {
+			return nil, err
+		}
+
+		if types.GitObjectType(typ) != types.GitObjectTypeCommit {
+			return nil, errors.InvalidArgument("git object %s is of type '%s', expected commit", sha, typ)
+		}
+
+		author, err := parseObjectHeaderSignature(raw, "author")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse author of commit '%s': %w", sha, err)
+		}
+
+		var message string
+		if idx := bytes.Index(raw, []byte("\n\n")); idx >= 0 {
+			message = string(raw[idx+2:])
+		}
+
+		commits[i] = types.NewCommit{
+			SHA:     sha,
+			Author:  author.Identity,
+			Message: message,
+		}
+	}
+
+	return commits, nil
+}
+
+// listCommitChangedBlobs returns the paths and the blob SHAs of all files
+// that the commit adds or modifies compared to its parent. Merge commits are compared to their first parent.
+// Deleted files and submodules are not returned.
+func listCommitChangedBlobs(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	sha string,
+) ([]string, []string, error) {
+	cmd := command.New("diff-tree",
+		command.WithFlag("--no-commit-id", "--no-renames", "-r", "-z", "--root"),
+		command.WithFlag("--diff-merges=first-parent"),
+		command.WithArg(sha),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	stdout := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(stdout)); err != nil {
+		return nil, nil, processGiteaErrorf(err, "failed to list changes of commit %s", sha)
+	}
+
+	// With -z every change is printed as ":<old mode> <new mode> <old sha> <new sha> <status>\0<path>\0".
+	fields := strings.Split(strings.TrimSuffix(stdout.String(), "\x00"), "\x00")
+	if len(fields)%2 != 0 {
+		return nil, nil, fmt.Errorf("unexpected diff-tree output for commit %s", sha)
+	}
+
+	var paths, blobs []string
+	for i := 0; i+1 < len(fields); i += 2 {
+		meta := strings.Fields(strings.TrimPrefix(fields[i], ":"))
+		if len(meta) != 5 {
+			return nil, nil, fmt.Errorf("unexpected diff-tree line '%s' for commit %s", fields[i], sha)
+		}
+
+		newMode, newSHA, status := meta[1], meta[3], meta[4]
+		if status == "D" || newMode == "160000" {
+			continue
+		}
+
+		paths = append(paths, fields[i+1])
+		blobs = append(blobs, newSHA)
+	}
+
+	return paths, blobs, nil
+}
+
+// readBlobSizes returns the sizes of the provided blobs without reading their content.
+func readBlobSizes(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []string,
+) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(shas))
+	if len(shas) == 0 {
+		return sizes, nil
+	}
+
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-check"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	stdin := strings.NewReader(strings.Join(shas, "\n") + "\n")
+	stdout := &bytes.Buffer{}
+	if err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(stdin),
+		command.WithStdout(stdout),
+	); err != nil {
+		return nil, processGiteaErrorf(err, "failed to read blob sizes")
+	}
+
+	// Every line has the format "<sha> <type> <size>", or "<sha> missing" if the object doesn't exist.
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) != 3 {
+			return nil, errors.NotFound("blob '%s' not found", scanner.Text())
+		}
+
+		size, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of blob '%s': %w", parts[0], err)
+		}
+
+		sizes[parts[0]] = size
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cat-file output: %w", err)
+	}
+
+	return sizes, nil
+}
+
+// GetCommitAddedLines returns the lines that the commit added to each file compared to its parent.
+// Merge commits are compared to their first parent and binary files are skipped.
+// The diff is streamed and at most maxAddedLinesFileSize bytes of added lines are returned per file.
+// Optional alternate object directories can be provided to access objects that aren't part of the repository yet.
+func (a Adapter) GetCommitAddedLines(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	sha string,
+) ([]types.FileAddedLines, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if sha == "" {
+		return nil, errors.InvalidArgument("commit sha cannot be empty")
+	}
+
+	cmd := command.New("diff-tree",
+		command.WithFlag("--no-commit-id", "--no-renames", "--no-color", "--no-ext-diff", "--no-textconv"),
+		command.WithFlag("-p", "-U0", "-r", "--root"),
+		command.WithFlag("--diff-merges=first-parent"),
+		command.WithArg(sha),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	pipeRead, pipeWrite := io.Pipe()
+	defer func() {
+		// unblocks the command in case the parsing failed before the whole output was read.
+		_ = pipeRead.Close()
+	}()
+
+	go func() {
+		var err error
+
+		defer func() {
+			// If running of the command below fails, make the pipe reader also fail with the same error.
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(pipeWrite))
+		if err != nil {
+			err = processGiteaErrorf(err, "failed to get diff of commit %s", sha)
+		}
+	}()
+
+	files, err := parser.ParseAddedLines(pipeRead, maxAddedLinesFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff of commit %s: %w", sha, err)
+	}
+
+	return files, nil
+}
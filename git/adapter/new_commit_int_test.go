@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAdapter_ListNewCommits_MergeCommit(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlistnewcommitsmerge")
+	defer teardown()
+
+	_, baseSHA := writeFile(t, repo, "a.txt", "a", nil)
+	_, branchSHA := writeFile(t, repo, "b.txt", "bb", []string{baseSHA.String()})
+	_, mergeSHA := writeFile(t, repo, "c.txt", "ccc", []string{baseSHA.String(), branchSHA.String()})
+
+	commits, err := git.ListNewCommits(context.Background(), repo.Path, nil, mergeSHA.String())
+	if err != nil {
+		t.Fatalf("failed to list new commits: %v", err)
+	}
+
+	if len(commits) != 3 {
+		t.Fatalf("expected 3 new commits, got %d", len(commits))
+	}
+
+	for _, commit := range commits {
+		if commit.SHA != mergeSHA.String() {
+			continue
+		}
+
+		// the merge commit is compared to its first parent, so it contains the changes of the merged branch.
+		expected := map[string]int64{"b.txt": 2, "c.txt": 3}
+		if len(commit.Files) != len(expected) {
+			t.Fatalf("expected %d files of the merge commit, got %v", len(expected), commit.Files)
+		}
+
+		for _, file := range commit.Files {
+			if size, ok := expected[file.Path]; !ok || size != file.Size {
+				t.Errorf("unexpected file of the merge commit: %+v", file)
+			}
+		}
+
+		return
+	}
+
+	t.Errorf("merge commit %s not found in the new commits", mergeSHA.String())
+}
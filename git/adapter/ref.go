@@ -21,6 +21,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
@@ -275,7 +276,8 @@ func (a Adapter) updateRefWithHooks(
 		return fmt.Errorf("pre-receive call failed with: %w", err)
 	}
 	if out.Error != nil {
-		return fmt.Errorf("pre-receive call returned error: %q", *out.Error)
+		// the update was rejected by the pre-receive hook (e.g. it contains secrets).
+		return errors.PreconditionFailed("pre-receive hook rejected the update: %s", *out.Error)
 	}
 
 	if a.traceGit {
@@ -327,3 +329,34 @@ func (a Adapter) updateRefWithHooks(
 
 	return nil
 }
+
+// RunPostReceiveHook calls the post-receive githook for reference updates that were done without githooks
+// (e.g. by fetching from a remote repository).
+func (a Adapter) RunPostReceiveHook(
+	ctx context.Context,
+	envVars map[string]string,
+	refUpdates []hook.ReferenceUpdate,
+) error {
+	githookClient, err := a.githookFactory.NewClient(ctx, envVars)
+	if err != nil {
+		return fmt.Errorf("failed to create githook client: %w", err)
+	}
+
+	out, err := githookClient.PostReceive(ctx, hook.PostReceiveInput{
+		RefUpdates: refUpdates,
+	})
+	if err != nil {
+		return fmt.Errorf("post-receive call failed with: %w", err)
+	}
+	if out.Error != nil {
+		return fmt.Errorf("post-receive call returned error: %q", *out.Error)
+	}
+
+	if a.traceGit {
+		log.Ctx(ctx).Trace().
+			Str("git", "post-receive").
+			Msgf("post-receive call succeeded with output:\n%s", strings.Join(out.Messages, "\n"))
+	}
+
+	return nil
+}
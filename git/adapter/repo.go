@@ -219,6 +219,78 @@ func (a Adapter) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided objects (and all objects reachable from them) from the source repository.
+// If a reference is provided, it's updated to point to the fetched object as part of the fetch,
+// otherwise no references are updated and the caller is responsible to keep the fetched objects referenced.
+func (a Adapter) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []string,
+	ref string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+	if ref != "" && len(objectSHAs) != 1 {
+		return fmt.Errorf("exactly one object is required to update the reference %q", ref)
+	}
+
+	args := []string{
+		"-c", "advice.fetchShowForcedUpdates=false",
+		"-c", "credential.helper=",
+		"-c", "uploadpack.allowAnySHA1InWant=true",
+		"fetch",
+		"--quiet",
+		"--no-auto-gc",
+		"--no-tags",
+		"--no-write-fetch-head",
+		"--no-show-forced-updates",
+		"--atomic",
+		source,
+	}
+	if ref != "" {
+		args = append(args, "+"+objectSHAs[0]+":"+ref)
+	} else {
+		args = append(args, objectSHAs...)
+	}
+
+	cmd := gitea.NewCommand(ctx, args...)
+	_, _, err := cmd.RunStdString(&gitea.RunOpts{
+		Dir:               repoPath,
+		UseContextTimeout: true,
+	})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
+// RepackAll packs all objects reachable in the repository, including the ones borrowed from alternates,
+// into a single pack and removes redundant packs and loose objects.
+func (a Adapter) RepackAll(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "-q"),
+	)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return fmt.Errorf("failed to repack objects: %w", err)
+	}
+
+	return nil
+}
+
 func (a Adapter) AddFiles(
 	repoPath string,
 	all bool,
@@ -291,11 +363,17 @@ func (a Adapter) Push(
 	if opts.Mirror {
 		cmd.AddArguments("--mirror")
 	}
+	if opts.Prune {
+		cmd.AddArguments("--prune")
+	}
 	cmd.AddArguments("--", opts.Remote)
 
 	if len(opts.Branch) > 0 {
 		cmd.AddArguments(opts.Branch)
 	}
+	if len(opts.RefSpecs) > 0 {
+		cmd.AddArguments(opts.RefSpecs...)
+	}
 
 	// remove credentials if there are any
 	if strings.Contains(opts.Remote, "://") && strings.Contains(opts.Remote, "@") {
@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/types"
+)
+
+// GetObjectSignatures reads the provided commits and annotated tags and returns their signatures.
+// Objects that aren't signed are returned without signature.
+// Optional alternate object directories can be provided to access objects that aren't part of the repository yet.
+func (a Adapter) GetObjectSignatures(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []string,
+) ([]types.ObjectSignature, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs...)
+	defer func() {
+		cancel()
+		_ = writer.Close()
+	}()
+
+	signatures := make([]types.ObjectSignature, len(shas))
+
+	for i, sha := range shas {
+		if _, err := writer.Write([]byte(sha + "\n")); err != nil {
+			return nil, err
+		}
+
+		objectSHA, typ, size, err := ReadBatchHeaderLine(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.IsNotFound(err) {
+				return nil, errors.NotFound("object with sha %s does not exist", sha)
+			}
+			return nil, err
+		}
+
+		raw, err := io.ReadAll(io.LimitReader(reader, size))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = reader.Discard(1); err != nil {
+			return nil, err
+		}
+
+		signatures[i], err = parseObjectSignature(types.GitObjectType(typ), raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signature of object '%s': %w", sha, err)
+		}
+
+		signatures[i].SHA = string(objectSHA)
+	}
+
+	return signatures, nil
+}
+
+func parseObjectSignature(typ types.GitObjectType, raw []byte) (types.ObjectSignature, error) {
+	var (
+		signerHeader string
+		sig          parser.ObjectSignature
+		signed       bool
+	)
+
+	switch typ {
+	case types.GitObjectTypeCommit:
+		signerHeader = "committer"
+		sig, signed = parser.CommitSignature(raw)
+	case types.GitObjectTypeTag:
+		signerHeader = "tagger"
+		sig, signed = parser.TagSignature(raw)
+	default:
+		return types.ObjectSignature{}, errors.InvalidArgument("git object is of type '%s', expected commit or tag", typ)
+	}
+
+	signer, err := parseObjectHeaderSignature(raw, signerHeader)
+	if err != nil {
+		return types.ObjectSignature{}, err
+	}
+
+	result := types.ObjectSignature{
+		Type:   typ,
+		Signer: signer.Identity,
+	}
+
+	if signed {
+		result.Scheme = sig.Scheme
+		result.Signature = sig.Signature
+		result.SignedContent = sig.SignedContent
+	}
+
+	return result, nil
+}
+
+// parseObjectHeaderSignature finds the header with the provided name in the raw object and parses its signature.
+func parseObjectHeaderSignature(raw []byte, header string) (types.Signature, error) {
+	prefix := []byte(header + " ")
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		if len(line) == 0 {
+			// end of headers
+			break
+		}
+
+		if bytes.HasPrefix(line, prefix) {
+			return parseSignatureFromCatFileLine(string(line[len(prefix):]))
+		}
+	}
+
+	return types.Signature{}, fmt.Errorf("object is missing the '%s' header", header)
+}
+
+// ListNewCommitSHAs returns the SHAs of all commits reachable from the provided ref
+// that aren't reachable from any of the existing references of the repository.
+// If a base ref is provided, the commits that aren't reachable from the base ref are returned instead.
+// Optional alternate object directories can be provided to access objects that aren't part of the repository yet.
+func (a Adapter) ListNewCommitSHAs(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	ref string,
+	baseRef string,
+) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if ref == "" {
+		return nil, errors.InvalidArgument("ref cannot be empty")
+	}
+
+	cmd := command.New("rev-list",
+		command.WithArg(ref, "--not"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	if baseRef != "" {
+		cmd.Add(command.WithArg(baseRef))
+	} else {
+		cmd.Add(command.WithArg("--all"))
+	}
+
+	stdout := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(stdout)); err != nil {
+		return nil, processGiteaErrorf(err, "failed to list new commits")
+	}
+
+	var shas []string
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if sha := strings.TrimSpace(scanner.Text()); sha != "" {
+			shas = append(shas, sha)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rev-list output: %w", err)
+	}
+
+	return shas, nil
+}
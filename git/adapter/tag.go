@@ -25,6 +25,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/types"
 )
 
@@ -188,15 +189,14 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 		return tag, err
 	}
 
-	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
+	// remainder is message and gpg (remove appended signature, leading and tailing new lines)
+	message := string(bytes.Trim(parser.TagMessageWithoutSignature(data[p:]), "\n"))
 
 	// handle gpg signature
 	pgpEnd := strings.Index(message, pgpSignatureEndToken)
 	if pgpEnd > -1 {
 		messageStart := pgpEnd + len(pgpSignatureEndToken)
 		// for now we just remove the signature (and trim any separating new lines)
-		// TODO: add support for GPG signature of tags
 		message = strings.TrimLeft(message[messageStart:], "\n")
 	}
 
@@ -64,12 +64,18 @@ func lsTree(
 	repoPath string,
 	rev string,
 	treePath string,
+	recursive bool,
 ) ([]types.TreeNode, error) {
 	if repoPath == "" {
 		return nil, ErrRepositoryPathEmpty
 	}
 	cmd := command.New("ls-tree",
 		command.WithFlag("-z"),
+	)
+	if recursive {
+		cmd.Add(command.WithFlag("-r"))
+	}
+	cmd.Add(
 		command.WithArg(rev),
 		command.WithArg(treePath),
 	)
@@ -130,12 +136,14 @@ func lsTree(
 	return list, nil
 }
 
-// lsFile returns all tree node entries in the requested directory.
+// lsDirectory returns all tree node entries in the requested directory.
+// If recursive is set, the entries of all sub-directories are returned as well (sub-trees themselves are omitted).
 func lsDirectory(
 	ctx context.Context,
 	repoPath string,
 	rev string,
 	treePath string,
+	recursive bool,
 ) ([]types.TreeNode, error) {
 	treePath = path.Clean(treePath)
 	if treePath == "" {
@@ -144,7 +152,7 @@ func lsDirectory(
 		treePath += "/"
 	}
 
-	return lsTree(ctx, repoPath, rev, treePath)
+	return lsTree(ctx, repoPath, rev, treePath, recursive)
 }
 
 // lsFile returns one tree node entry.
@@ -156,7 +164,7 @@ func lsFile(
 ) (types.TreeNode, error) {
 	treePath = cleanTreePath(treePath)
 
-	list, err := lsTree(ctx, repoPath, rev, treePath)
+	list, err := lsTree(ctx, repoPath, rev, treePath, false)
 	if err != nil {
 		return types.TreeNode{}, fmt.Errorf("failed to ls file: %w", err)
 	}
@@ -206,8 +214,13 @@ func (a Adapter) GetTreeNode(ctx context.Context, repoPath, rev, treePath string
 }
 
 // ListTreeNodes lists the child nodes of a tree reachable from ref via the specified path.
-func (a Adapter) ListTreeNodes(ctx context.Context, repoPath, rev, treePath string) ([]types.TreeNode, error) {
-	list, err := lsDirectory(ctx, repoPath, rev, treePath)
+// If recursive is set, all blobs of the tree and its sub-trees are returned instead.
+func (a Adapter) ListTreeNodes(
+	ctx context.Context,
+	repoPath, rev, treePath string,
+	recursive bool,
+) ([]types.TreeNode, error) {
+	list, err := lsDirectory(ctx, repoPath, rev, treePath, recursive)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
 	}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+)
+
+type ArchiveParams struct {
+	ReadParams
+	// GitRef is the branch, tag or commit that's archived.
+	GitRef string
+	Format enum.ArchiveFormat
+	// Prefix is prepended to the path of every file in the archive (optional).
+	Prefix string
+	// Paths restricts the archive to the files under the provided paths (optional).
+	Paths []string
+}
+
+func (p *ArchiveParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitRef == "" {
+		return errors.InvalidArgument("git ref cannot be empty")
+	}
+
+	if !p.Format.IsValid() {
+		return errors.InvalidArgument("unsupported archive format '%s'", p.Format)
+	}
+
+	return nil
+}
+
+// Archive writes an archive of the repository at the provided git ref to the writer.
+func (s *Service) Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	// resolve the commit first to fail with a proper error before anything is written.
+	commit, err := s.adapter.GetCommit(ctx, repoPath, params.GitRef)
+	if err != nil {
+		return fmt.Errorf("failed to get commit for ref '%s': %w", params.GitRef, err)
+	}
+
+	err = s.adapter.Archive(ctx, repoPath, commit.SHA, params.Format, params.Prefix, params.Paths, w)
+	if err != nil {
+		return fmt.Errorf("failed to archive ref '%s': %w", params.GitRef, err)
+	}
+
+	return nil
+}
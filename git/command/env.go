@@ -28,6 +28,13 @@ const (
 	GitTracePerformance = "GIT_TRACE_PERFORMANCE"
 	GitTraceSetup       = "GIT_TRACE_SETUP"
 	GitExecPath         = "GIT_EXEC_PATH" // tells Git where to find its binaries.
+
+	GitObjectDir           = "GIT_OBJECT_DIRECTORY"
+	GitAlternateObjectDirs = "GIT_ALTERNATE_OBJECT_DIRECTORIES"
+	GitQuarantinePath      = "GIT_QUARANTINE_PATH"
+
+	GitPushOptionCount  = "GIT_PUSH_OPTION_COUNT"
+	GitPushOptionPrefix = "GIT_PUSH_OPTION_"
 )
 
 // Envs custom key value store for environment variables.
@@ -16,7 +16,9 @@ package command
 
 import (
 	"io"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -105,6 +107,16 @@ func WithConfig(key, value string) CmdOptionFunc {
 	}
 }
 
+// WithAlternateObjectDirs function sets alternate object directories to the command.
+// It's used to access objects that aren't part of the repository yet (e.g. quarantined objects of a push).
+func WithAlternateObjectDirs(dirs ...string) CmdOptionFunc {
+	return func(c *Command) {
+		if len(dirs) > 0 {
+			c.Envs[GitAlternateObjectDirs] = strings.Join(dirs, string(os.PathListSeparator))
+		}
+	}
+}
+
 // RunOption contains option for running a command.
 type RunOption struct {
 	// Dir is location of repo.
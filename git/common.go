@@ -21,6 +21,11 @@ import (
 // ReadParams contains the base parameters for read operations.
 type ReadParams struct {
 	RepoUID string
+
+	// AlternateObjectDirs contains additional object directories that are used to read objects
+	// which aren't part of the repository yet (e.g. the quarantined objects of a push).
+	// All directories have to be located inside of the repository.
+	AlternateObjectDirs []string
 }
 
 func (p ReadParams) Validate() error {
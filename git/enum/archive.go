@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"strings"
+)
+
+// ArchiveFormat is the format of a repository archive.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip   ArchiveFormat = "zip"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+)
+
+// ArchiveFormats contains all supported archive formats.
+var ArchiveFormats = []ArchiveFormat{
+	ArchiveFormatZip,
+	ArchiveFormatTarGz,
+}
+
+func (f ArchiveFormat) IsValid() bool {
+	for _, format := range ArchiveFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseArchiveFormatFromName splits an archive name (e.g. "main.tar.gz") into its base name and format.
+func ParseArchiveFormatFromName(name string) (string, ArchiveFormat, bool) {
+	for _, format := range ArchiveFormats {
+		suffix := "." + string(format)
+		if base := strings.TrimSuffix(name, suffix); base != name && base != "" {
+			return base, format, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "testing"
+
+func TestParseArchiveFormatFromName(t *testing.T) {
+	tests := []struct {
+		name           string
+		expectedBase   string
+		expectedFormat ArchiveFormat
+		expectedOK     bool
+	}{
+		{name: "main.zip", expectedBase: "main", expectedFormat: ArchiveFormatZip, expectedOK: true},
+		{name: "main.tar.gz", expectedBase: "main", expectedFormat: ArchiveFormatTarGz, expectedOK: true},
+		{name: "v1.0.tar.gz", expectedBase: "v1.0", expectedFormat: ArchiveFormatTarGz, expectedOK: true},
+		{name: "feature/x.zip", expectedBase: "feature/x", expectedFormat: ArchiveFormatZip, expectedOK: true},
+		{name: "main.gz", expectedOK: false},
+		{name: "main.tar", expectedOK: false},
+		{name: "main", expectedOK: false},
+		{name: ".zip", expectedOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base, format, ok := ParseArchiveFormatFromName(test.name)
+			if ok != test.expectedOK {
+				t.Fatalf("expected ok=%t, got %t", test.expectedOK, ok)
+			}
+
+			if base != test.expectedBase || format != test.expectedFormat {
+				t.Errorf("expected (%q, %q), got (%q, %q)", test.expectedBase, test.expectedFormat, base, format)
+			}
+		})
+	}
+}
@@ -24,17 +24,23 @@ const (
 	MergeMethodSquash MergeMethod = "squash"
 	// MergeMethodRebase rebase before merging.
 	MergeMethodRebase MergeMethod = "rebase"
+	// MergeMethodFastForward fast-forward the base branch, fails if the head branch is behind the base branch.
+	MergeMethodFastForward MergeMethod = "fast-forward"
+	// MergeMethodRebaseMerge rebase before merging, then create merge commit.
+	MergeMethodRebaseMerge MergeMethod = "rebase-merge"
 )
 
 var MergeMethods = []MergeMethod{
 	MergeMethodMerge,
 	MergeMethodSquash,
 	MergeMethodRebase,
+	MergeMethodFastForward,
+	MergeMethodRebaseMerge,
 }
 
 func (m MergeMethod) Sanitize() (MergeMethod, bool) {
 	switch m {
-	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase, MergeMethodFastForward, MergeMethodRebaseMerge:
 		return m, true
 	default:
 		return MergeMethodMerge, false
@@ -0,0 +1,292 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	gitObjectsDir     = "objects"
+	gitAlternatesFile = "objects/info/alternates"
+
+	forkRefSpecBranches = "+refs/heads/*:refs/heads/*"
+	forkRefSpecTags     = "+refs/tags/*:refs/tags/*"
+
+	fileMode600 = 0o600
+)
+
+type ForkRepositoryParams struct {
+	// Fork operation is similar to create, the UID of the new repository doesn't exist yet.
+	// Only take actor and envars as input and create WriteParams manually
+	Actor   Identity
+	EnvVars map[string]string
+
+	// SourceRepoUID is the UID of the repository that's being forked.
+	SourceRepoUID string
+
+	// DefaultBranch is the default branch of the fork (optional, default: default branch of the source repository).
+	DefaultBranch string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if err := p.Actor.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository is mandatory")
+	}
+
+	return nil
+}
+
+type ForkRepositoryOutput struct {
+	UID           string
+	DefaultBranch string
+}
+
+// ForkRepository creates a new repository that borrows all objects of the source repository via git alternates,
+// and copies all branches and tags of the source repository.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+		return nil, errors.NotFound("source repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check the status of the source repository: %w", err)
+	}
+
+	defaultBranch := params.DefaultBranch
+	if defaultBranch == "" {
+		var err error
+		defaultBranch, err = s.adapter.GetDefaultBranch(ctx, sourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default branch of the source repository: %w", err)
+		}
+	}
+
+	uid, err := NewRepositoryUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new uid: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Msgf("Fork git repository '%s' into new repository with uid '%s'", params.SourceRepoUID, uid)
+
+	writeParams := WriteParams{
+		RepoUID: uid,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err = s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		defaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, uid)
+
+	defer func() {
+		if err != nil {
+			if errCleanup := s.DeleteRepositoryBestEffort(ctx, uid); errCleanup != nil {
+				log.Ctx(ctx).Warn().Err(errCleanup).Msg("failed to cleanup fork repo dir")
+			}
+		}
+	}()
+
+	// share the objects of the source repository
+	alternates := path.Join(sourcePath, gitObjectsDir) + "\n"
+	err = os.WriteFile(path.Join(repoPath, gitAlternatesFile), []byte(alternates), fileMode600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write alternates file: %w", err)
+	}
+
+	// all objects are available via alternates already, so fetching just copies the references.
+	err = s.adapter.Sync(ctx, repoPath, sourcePath, []string{forkRefSpecBranches, forkRefSpecTags})
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy references of the source repository: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		UID:           uid,
+		DefaultBranch: defaultBranch,
+	}, nil
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+
+	// Source is the UID of the repository the objects are fetched from.
+	Source string
+
+	ObjectSHAs []string
+
+	// RefName and RefType optionally define a reference that's set to the fetched object.
+	// The reference is written by the same git operation that fetches the object, so the fetched objects
+	// are never left unreferenced (and can't be garbage collected before the reference is updated).
+	// Requires exactly one object SHA.
+	RefName string
+	RefType enum.RefType
+
+	// RefOldValue is an optional value that can be used to ensure that the reference
+	// is updated iff its current value is matching the provided value (NilSHA if the reference shouldn't exist).
+	RefOldValue string
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Source == "" {
+		return errors.InvalidArgument("source repository is mandatory")
+	}
+
+	for _, sha := range p.ObjectSHAs {
+		if !isValidGitSHA(sha) {
+			return errors.InvalidArgument("invalid object SHA '%s'", sha)
+		}
+	}
+
+	if p.RefName != "" && len(p.ObjectSHAs) != 1 {
+		return errors.InvalidArgument("exactly one object SHA is required when a reference is provided")
+	}
+
+	return nil
+}
+
+// FetchObjects fetches the provided objects from the source repository
+// and optionally points the provided reference to the fetched object.
+// Fetching from the same repository is a no-op.
+func (s *Service) FetchObjects(
+	ctx context.Context,
+	params *FetchObjectsParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if params.Source == params.RepoUID {
+		return nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.Source)
+
+	var reference string
+	if params.RefName != "" {
+		var err error
+		reference, err = GetRefPath(params.RefName, params.RefType)
+		if err != nil {
+			return fmt.Errorf("failed to get reference path of '%s': %w", params.RefName, err)
+		}
+
+		if err = s.checkRefValue(ctx, repoPath, reference, params.RefOldValue); err != nil {
+			return err
+		}
+	}
+
+	err := s.adapter.FetchObjects(ctx, repoPath, sourcePath, params.ObjectSHAs, reference)
+	if err != nil {
+		return fmt.Errorf("failed to fetch objects from repository '%s': %w", params.Source, err)
+	}
+
+	return nil
+}
+
+// checkRefValue ensures that the reference is pointing to the expected value (NilSHA if it shouldn't exist).
+// The check is skipped if no expected value is provided.
+func (s *Service) checkRefValue(ctx context.Context, repoPath, reference, expectedValue string) error {
+	if expectedValue == "" {
+		return nil
+	}
+
+	value, err := s.adapter.GetRef(ctx, repoPath, reference)
+	if types.IsNotFoundError(err) {
+		value = types.NilSHA
+	} else if err != nil {
+		return fmt.Errorf("failed to get current value of reference '%s': %w", reference, err)
+	}
+
+	if value != expectedValue {
+		return errors.PreconditionFailed("reference '%s' is on SHA '%s' which doesn't match expected SHA '%s'",
+			reference, value, expectedValue)
+	}
+
+	return nil
+}
+
+type DetachForkParams struct {
+	WriteParams
+}
+
+// DetachFork makes the repository self-contained: Objects borrowed from other repositories
+// via alternates are copied into the repository, after which the alternates are removed.
+// It has to be called for all forks of a repository before the repository is deleted.
+func (s *Service) DetachFork(
+	ctx context.Context,
+	params *DetachForkParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	alternatesPath := path.Join(repoPath, gitAlternatesFile)
+
+	if _, err := os.Stat(alternatesPath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check the alternates file: %w", err)
+	}
+
+	err := s.adapter.RepackAll(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy borrowed objects: %w", err)
+	}
+
+	err = os.Remove(alternatesPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove the alternates file: %w", err)
+	}
+
+	return nil
+}
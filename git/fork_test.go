@@ -0,0 +1,317 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+var testIdentity = Identity{Name: "test", Email: "test@test.com"}
+
+type testClientFactory struct{}
+
+func (f *testClientFactory) NewClient(context.Context, map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+func setupTestService(t *testing.T) *Service {
+	t.Helper()
+
+	root := t.TempDir()
+
+	gitAdapter, err := adapter.New(
+		types.Config{},
+		adapter.NewInMemoryLastCommitCache(time.Minute),
+		&testClientFactory{},
+	)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %v", err)
+	}
+
+	s, err := New(
+		types.Config{Root: root, HookPath: path.Join(root, "hook")},
+		gitAdapter,
+		storage.NewLocalStore(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create git service: %v", err)
+	}
+
+	return s
+}
+
+// setupTestRepo creates an empty repository and returns its UID.
+func setupTestRepo(t *testing.T, s *Service) string {
+	t.Helper()
+
+	uid, err := NewRepositoryUID()
+	if err != nil {
+		t.Fatalf("failed to create repository uid: %v", err)
+	}
+
+	err = s.adapter.InitRepository(context.Background(), getFullPathForRepo(s.reposRoot, uid), true)
+	if err != nil {
+		t.Fatalf("failed to initialize repository: %v", err)
+	}
+
+	return uid
+}
+
+// commitTestFile commits the file to the repository and points the branch to the new commit.
+func commitTestFile(t *testing.T, s *Service, uid, branch, file, content string, parents ...string) string {
+	t.Helper()
+	ctx := context.Background()
+	repoPath := getFullPathForRepo(s.reposRoot, uid)
+
+	repo, err := gitea.OpenRepository(ctx, repoPath)
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	oid, err := repo.HashObject(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("failed to hash object: %v", err)
+	}
+
+	if err = repo.AddObjectToIndex("100644", oid, file); err != nil {
+		t.Fatalf("failed to add object to index: %v", err)
+	}
+
+	tree, err := repo.WriteTree()
+	if err != nil {
+		t.Fatalf("failed to write tree: %v", err)
+	}
+
+	signature := &gitea.Signature{Name: testIdentity.Name, Email: testIdentity.Email}
+	commitSHA, err := repo.CommitTree(signature, signature, tree, gitea.CommitTreeOpts{
+		Message: "add " + file,
+		Parents: parents,
+	})
+	if err != nil {
+		t.Fatalf("failed to commit tree: %v", err)
+	}
+
+	_, _, err = gitea.NewCommand(ctx, "update-ref", gitea.BranchPrefix+branch, commitSHA.String()).
+		RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if err != nil {
+		t.Fatalf("failed to update branch %s: %v", branch, err)
+	}
+
+	return commitSHA.String()
+}
+
+func requireRef(t *testing.T, s *Service, uid, ref, expected string) {
+	t.Helper()
+
+	value, err := s.adapter.GetRef(context.Background(), getFullPathForRepo(s.reposRoot, uid), ref)
+	if err != nil {
+		t.Fatalf("failed to get reference %s: %v", ref, err)
+	}
+
+	if value != expected {
+		t.Fatalf("expected reference %s to point to %s, got %s", ref, expected, value)
+	}
+}
+
+func requireCommit(t *testing.T, s *Service, uid, sha string) {
+	t.Helper()
+
+	_, err := s.adapter.GetCommit(context.Background(), getFullPathForRepo(s.reposRoot, uid), sha)
+	if err != nil {
+		t.Fatalf("expected commit %s to exist in repository %s: %v", sha, uid, err)
+	}
+}
+
+func TestService_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	s := setupTestService(t)
+
+	sourceUID := setupTestRepo(t, s)
+	mainSHA := commitTestFile(t, s, sourceUID, "main", "a.txt", "a")
+	featureSHA := commitTestFile(t, s, sourceUID, "feature", "b.txt", "b", mainSHA)
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:         testIdentity,
+		SourceRepoUID: sourceUID,
+		DefaultBranch: "main",
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %v", err)
+	}
+
+	if out.DefaultBranch != "main" {
+		t.Errorf("expected default branch main, got %s", out.DefaultBranch)
+	}
+
+	requireRef(t, s, out.UID, gitea.BranchPrefix+"main", mainSHA)
+	requireRef(t, s, out.UID, gitea.BranchPrefix+"feature", featureSHA)
+
+	alternates, err := os.ReadFile(path.Join(getFullPathForRepo(s.reposRoot, out.UID), gitAlternatesFile))
+	if err != nil {
+		t.Fatalf("failed to read alternates file of the fork: %v", err)
+	}
+
+	expectedAlternates := path.Join(getFullPathForRepo(s.reposRoot, sourceUID), gitObjectsDir) + "\n"
+	if string(alternates) != expectedAlternates {
+		t.Errorf("expected alternates %q, got %q", expectedAlternates, string(alternates))
+	}
+
+	_, err = s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:         testIdentity,
+		SourceRepoUID: "doesnotexist",
+	})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected not found error when forking a missing repository, got %v", err)
+	}
+}
+
+func TestService_DetachFork(t *testing.T) {
+	ctx := context.Background()
+	s := setupTestService(t)
+
+	sourceUID := setupTestRepo(t, s)
+	mainSHA := commitTestFile(t, s, sourceUID, "main", "a.txt", "a")
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:         testIdentity,
+		SourceRepoUID: sourceUID,
+		DefaultBranch: "main",
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %v", err)
+	}
+
+	forkSHA := commitTestFile(t, s, out.UID, "main", "b.txt", "b", mainSHA)
+
+	params := &DetachForkParams{WriteParams: WriteParams{RepoUID: out.UID, Actor: testIdentity}}
+	if err = s.DetachFork(ctx, params); err != nil {
+		t.Fatalf("failed to detach fork: %v", err)
+	}
+
+	forkPath := getFullPathForRepo(s.reposRoot, out.UID)
+	if _, err = os.Stat(path.Join(forkPath, gitAlternatesFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected the alternates file to be removed, got %v", err)
+	}
+
+	// the fork must be self-contained after it got detached.
+	if err = os.RemoveAll(getFullPathForRepo(s.reposRoot, sourceUID)); err != nil {
+		t.Fatalf("failed to remove source repository: %v", err)
+	}
+
+	requireCommit(t, s, out.UID, mainSHA)
+	requireCommit(t, s, out.UID, forkSHA)
+
+	// detaching a repository that isn't a fork (anymore) is a no-op.
+	if err = s.DetachFork(ctx, params); err != nil {
+		t.Fatalf("failed to detach repository that isn't a fork: %v", err)
+	}
+}
+
+func TestService_FetchObjects(t *testing.T) {
+	ctx := context.Background()
+	s := setupTestService(t)
+
+	targetUID := setupTestRepo(t, s)
+	mainSHA := commitTestFile(t, s, targetUID, "main", "a.txt", "a")
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:         testIdentity,
+		SourceRepoUID: targetUID,
+		DefaultBranch: "main",
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %v", err)
+	}
+
+	// the commits of the pull request only exist in the fork.
+	sourceUID := out.UID
+	prSHA1 := commitTestFile(t, s, sourceUID, "feature", "b.txt", "b", mainSHA)
+	prSHA2 := commitTestFile(t, s, sourceUID, "feature", "c.txt", "c", prSHA1)
+
+	writeParams := WriteParams{RepoUID: targetUID, Actor: testIdentity}
+	headRef := "refs/pullreq/1/head"
+
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceUID,
+		ObjectSHAs:  []string{prSHA1},
+		RefName:     "1",
+		RefType:     enum.RefTypePullReqHead,
+		RefOldValue: types.NilSHA,
+	})
+	if err != nil {
+		t.Fatalf("failed to fetch pull request commits: %v", err)
+	}
+
+	requireCommit(t, s, targetUID, prSHA1)
+	requireRef(t, s, targetUID, headRef, prSHA1)
+
+	// the reference isn't updated if it doesn't point to the expected commit.
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceUID,
+		ObjectSHAs:  []string{prSHA2},
+		RefName:     "1",
+		RefType:     enum.RefTypePullReqHead,
+		RefOldValue: mainSHA,
+	})
+	if !errors.IsPreconditionFailed(err) {
+		t.Fatalf("expected precondition failed error, got %v", err)
+	}
+
+	requireRef(t, s, targetUID, headRef, prSHA1)
+
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceUID,
+		ObjectSHAs:  []string{prSHA2},
+		RefName:     "1",
+		RefType:     enum.RefTypePullReqHead,
+		RefOldValue: prSHA1,
+	})
+	if err != nil {
+		t.Fatalf("failed to fetch updated pull request commits: %v", err)
+	}
+
+	requireCommit(t, s, targetUID, prSHA2)
+	requireRef(t, s, targetUID, headRef, prSHA2)
+
+	// a reference can only point to a single object.
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceUID,
+		ObjectSHAs:  []string{prSHA1, prSHA2},
+		RefName:     "1",
+		RefType:     enum.RefTypePullReqHead,
+	})
+	if !errors.IsInvalidArgument(err) {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+}
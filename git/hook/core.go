@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/harness/gitness/git/command"
 )
 
 // CLICore implements the core of a githook cli. It uses the client and execution timeout
@@ -48,7 +51,9 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 	}
 
 	in := PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		Environment: getEnvironmentFromEnv(),
+		PushOptions: getPushOptionsFromEnv(),
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -87,6 +92,45 @@ func (c *CLICore) PostReceive(ctx context.Context) error {
 	return handleServerHookOutput(out, err)
 }
 
+// getEnvironmentFromEnv returns the environment of the git operation.
+// During the pre-receive hook git keeps all received objects in a quarantine directory,
+// which has to be used as alternate object dir to be able to read the new objects.
+func getEnvironmentFromEnv() Environment {
+	quarantinePath, err := getEnvironmentVariable(command.GitQuarantinePath)
+	if err != nil {
+		return Environment{}
+	}
+
+	return Environment{
+		AlternateObjectDirs: []string{quarantinePath},
+	}
+}
+
+// getPushOptionsFromEnv returns the push options the client sent with the push.
+// Git only exposes push options to the hooks if receive.advertisePushOptions is enabled.
+func getPushOptionsFromEnv() []string {
+	countStr, err := getEnvironmentVariable(command.GitPushOptionCount)
+	if err != nil {
+		return nil
+	}
+
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count <= 0 {
+		return nil
+	}
+
+	options := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		option, err := getEnvironmentVariable(command.GitPushOptionPrefix + strconv.Itoa(i))
+		if err != nil {
+			continue
+		}
+		options = append(options, option)
+	}
+
+	return options
+}
+
 //nolint:forbidigo // outputing to CMD as that's where git reads the data
 func handleServerHookOutput(out Output, err error) error {
 	if err != nil {
@@ -39,10 +39,23 @@ type PostReceiveInput struct {
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
 }
 
+// Environment contains the information required to access the objects of a git operation.
+type Environment struct {
+	// AlternateObjectDirs contains the object directories required to access objects
+	// that aren't part of the repository yet (e.g. the quarantined objects of a push).
+	AlternateObjectDirs []string `json:"alternate_object_dirs,omitempty"`
+}
+
 // PreReceiveInput represents the input of the pre-receive git hook.
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// Environment contains the information required to access the objects of the git operation.
+	Environment Environment `json:"environment"`
+
+	// PushOptions contains the push options provided by the client (e.g. "git push -o <option>").
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -46,8 +46,17 @@ type Interface interface {
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	// FetchObjects fetches the objects (and everything reachable from them) from another repository.
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
+	// DetachFork copies all objects borrowed from the forked repository and stops sharing objects with it.
+	DetachFork(ctx context.Context, params *DetachForkParams) error
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
+	// Archive writes an archive (zip, tar.gz) of the repository at the provided git ref.
+	Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error
+
 	/*
 	 * Commits service
 	 */
@@ -58,6 +67,15 @@ type Interface interface {
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
 	IsAncestor(ctx context.Context, params IsAncestorParams) (IsAncestorOutput, error)
+	// GetObjectSignatures returns the signatures of commits and annotated tags.
+	GetObjectSignatures(ctx context.Context, params *GetObjectSignaturesParams) (*GetObjectSignaturesOutput, error)
+	// ListNewCommitSHAs lists the commits of a git ref that aren't reachable from any existing reference
+	// (or from the base ref, if provided).
+	ListNewCommitSHAs(ctx context.Context, params *ListNewCommitSHAsParams) (*ListNewCommitSHAsOutput, error)
+	// ListNewCommits lists the details of the commits of a git ref that aren't reachable from any existing reference.
+	ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error)
+	// GetCommitAddedLines returns the lines a commit added to each of its files.
+	GetCommitAddedLines(ctx context.Context, params *GetCommitAddedLinesParams) (*GetCommitAddedLinesOutput, error)
 
 	/*
 	 * Git Cli Service
@@ -33,7 +33,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If it's different from the RepoUID, the head commits are fetched into the repo prior to merging.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -140,6 +140,10 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		mergeFunc = merge.Squash
 	case enum.MergeMethodRebase:
 		mergeFunc = merge.Rebase
+	case enum.MergeMethodFastForward:
+		mergeFunc = merge.FastForward
+	case enum.MergeMethodRebaseMerge:
+		mergeFunc = merge.RebaseMerge
 	default:
 		// should not happen, the call to Sanitize above should handle this case.
 		panic("unsupported merge method")
@@ -183,9 +187,14 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
 
-	headCommitSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.adapter.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
-		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
+		return MergeOutput{}, fmt.Errorf("failed to get merge head branch commit SHA: %w", err)
 	}
 
 	if params.HeadExpectedSHA != "" && params.HeadExpectedSHA != headCommitSHA {
@@ -196,6 +205,15 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			params.HeadExpectedSHA)
 	}
 
+	// the head branch is in another repository (fork) - its commits are needed in this repository.
+	if headRepoPath != repoPath {
+		err = s.adapter.FetchObjects(ctx, repoPath, headRepoPath, []string{headCommitSHA}, "")
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head commit from repo %q: %w",
+				params.HeadRepoUID, err)
+		}
+	}
+
 	mergeBaseCommitSHA, _, err := s.adapter.GetMergeBase(ctx, repoPath, "origin", baseCommitSHA, headCommitSHA)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get merge base: %w", err)
@@ -205,6 +223,13 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, errors.InvalidArgument("head branch doesn't contain any new commits.")
 	}
 
+	if mergeMethod == enum.MergeMethodFastForward && baseCommitSHA != mergeBaseCommitSHA {
+		return MergeOutput{}, errors.PreconditionFailed(
+			"Fast-forward merge is not possible: head branch '%s' doesn't contain the latest commit of base branch '%s'.",
+			params.HeadBranch,
+			params.BaseBranch)
+	}
+
 	// find short stat and number of commits
 
 	shortStat, err := s.adapter.DiffShortStat(ctx, repoPath, baseCommitSHA, headCommitSHA, true)
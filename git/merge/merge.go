@@ -110,8 +110,6 @@ func mergeInternal(
 }
 
 // Rebase merges two the commits (targetSHA and sourceSHA) using the Rebase method.
-//
-//nolint:gocognit // refactor if needed.
 func Rebase(
 	ctx context.Context,
 	repoPath, tmpDir string,
@@ -120,80 +118,156 @@ func Rebase(
 	mergeBaseSHA, targetSHA, sourceSHA string,
 ) (mergeSHA string, conflicts []string, err error) {
 	err = runInSharedRepo(ctx, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
-		sourceSHAs, err := s.CommitSHAsForRebase(ctx, mergeBaseSHA, sourceSHA)
+		mergeSHA, conflicts, err = rebaseInternal(ctx, s, committer, mergeBaseSHA, targetSHA, sourceSHA)
+		return err
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("merge method=rebase: %w", err)
+	}
+
+	return mergeSHA, conflicts, nil
+}
+
+// RebaseMerge merges two the commits (targetSHA and sourceSHA) using the RebaseMerge method:
+// The source commits are rebased on top of the target commit and a merge commit is created for the rebased commits.
+func RebaseMerge(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	author, committer *types.Signature,
+	message string,
+	mergeBaseSHA, targetSHA, sourceSHA string,
+) (mergeSHA string, conflicts []string, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
+		var rebasedSHA string
+
+		rebasedSHA, conflicts, err = rebaseInternal(ctx, s, committer, mergeBaseSHA, targetSHA, sourceSHA)
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			return nil
+		}
+
+		// all commits are empty after the rebase - there's nothing to merge.
+		if rebasedSHA == targetSHA {
+			mergeSHA = targetSHA
+			return nil
+		}
+
+		treeSHA, err := s.GetTreeSHA(ctx, rebasedSHA)
 		if err != nil {
-			return fmt.Errorf("failed to find commit list in rebase merge: %w", err)
+			return fmt.Errorf("failed to get tree sha of rebased commit: %w", err)
 		}
 
-		lastCommitSHA := targetSHA
-		lastTreeSHA, err := s.GetTreeSHA(ctx, targetSHA)
+		mergeSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, targetSHA, rebasedSHA)
 		if err != nil {
-			return fmt.Errorf("failed to get tree sha for target: %w", err)
-		}
-
-		for _, commitSHA := range sourceSHAs {
-			var treeSHA string
-
-			commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
-			if err != nil {
-				return fmt.Errorf("failed to get commit data in rebase merge: %w", err)
-			}
-
-			// rebase merge preserves the commit author (and date) and the commit message, but changes the committer.
-			author := &commitInfo.Author
-			message := commitInfo.Title
-			if commitInfo.Message != "" {
-				message += "\n\n" + commitInfo.Message
-			}
-
-			mergeTreeMergeBaseSHA := ""
-			if len(commitInfo.ParentSHAs) > 0 {
-				// use parent of commit as merge base to only apply changes introduced by commit.
-				// See example usage of when --merge-base was introduced:
-				// https://github.com/git/git/commit/66265a693e8deb3ab86577eb7f69940410044081
-				//
-				// NOTE: CommitSHAsForRebase only returns non-merge commits.
-				mergeTreeMergeBaseSHA = commitInfo.ParentSHAs[0]
-			}
-
-			treeSHA, conflicts, err = s.MergeTree(ctx, mergeTreeMergeBaseSHA, lastCommitSHA, commitSHA)
-			if err != nil {
-				return fmt.Errorf("failed to merge tree in rebase merge: %w", err)
-			}
-			if len(conflicts) > 0 {
-				return nil
-			}
-
-			// Drop any commit which after being rebased would be empty.
-			// There's two cases in which that can happen:
-			// 1. Empty commit.
-			//    Github is dropping empty commits, so we'll do the same.
-			// 2. The changes of the commit already exist on the target branch.
-			//    Git's `git rebase` is dropping such commits on default (and so does Github)
-			//    https://git-scm.com/docs/git-rebase#Documentation/git-rebase.txt---emptydropkeepask
-			if treeSHA == lastTreeSHA {
-				log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after rebase", commitSHA)
-				continue
-			}
-
-			lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
-			if err != nil {
-				return fmt.Errorf("failed to commit tree in rebase merge: %w", err)
-			}
-			lastTreeSHA = treeSHA
-		}
-
-		mergeSHA = lastCommitSHA
+			return fmt.Errorf("commit tree failed: %w", err)
+		}
 
 		return nil
 	})
 	if err != nil {
-		return "", nil, fmt.Errorf("merge method=rebase: %w", err)
+		return "", nil, fmt.Errorf("merge method=rebase-merge: %w", err)
 	}
 
 	return mergeSHA, conflicts, nil
 }
 
+// FastForward merges two the commits (targetSHA and sourceSHA) using the FastForward method:
+// No new commit is created, the source commit becomes the new target commit.
+// It fails if the source commit doesn't contain the target commit.
+func FastForward(
+	_ context.Context,
+	_, _ string,
+	_, _ *types.Signature, // commit author and committer aren't used here - no commit is created
+	_ string, // commit message isn't used here
+	mergeBaseSHA, targetSHA, sourceSHA string,
+) (mergeSHA string, conflicts []string, err error) {
+	if mergeBaseSHA != targetSHA {
+		return "", nil, fmt.Errorf("merge method=fast-forward: target commit %s is not an ancestor of %s",
+			targetSHA, sourceSHA)
+	}
+
+	return sourceSHA, nil, nil
+}
+
+// rebaseInternal rebases the source commits (from mergeBaseSHA to sourceSHA) on top of the target commit
+// and returns the SHA of the last rebased commit.
+//
+//nolint:gocognit // refactor if needed.
+func rebaseInternal(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	committer *types.Signature,
+	mergeBaseSHA, targetSHA, sourceSHA string,
+) (lastCommitSHA string, conflicts []string, err error) {
+	sourceSHAs, err := s.CommitSHAsForRebase(ctx, mergeBaseSHA, sourceSHA)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find commit list in rebase merge: %w", err)
+	}
+
+	lastCommitSHA = targetSHA
+	lastTreeSHA, err := s.GetTreeSHA(ctx, targetSHA)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get tree sha for target: %w", err)
+	}
+
+	for _, commitSHA := range sourceSHAs {
+		var treeSHA string
+
+		commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get commit data in rebase merge: %w", err)
+		}
+
+		// rebase merge preserves the commit author (and date) and the commit message, but changes the committer.
+		author := &commitInfo.Author
+		message := commitInfo.Title
+		if commitInfo.Message != "" {
+			message += "\n\n" + commitInfo.Message
+		}
+
+		mergeTreeMergeBaseSHA := ""
+		if len(commitInfo.ParentSHAs) > 0 {
+			// use parent of commit as merge base to only apply changes introduced by commit.
+			// See example usage of when --merge-base was introduced:
+			// https://github.com/git/git/commit/66265a693e8deb3ab86577eb7f69940410044081
+			//
+			// NOTE: CommitSHAsForRebase only returns non-merge commits.
+			mergeTreeMergeBaseSHA = commitInfo.ParentSHAs[0]
+		}
+
+		treeSHA, conflicts, err = s.MergeTree(ctx, mergeTreeMergeBaseSHA, lastCommitSHA, commitSHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to merge tree in rebase merge: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return "", conflicts, nil
+		}
+
+		// Drop any commit which after being rebased would be empty.
+		// There's two cases in which that can happen:
+		// 1. Empty commit.
+		//    Github is dropping empty commits, so we'll do the same.
+		// 2. The changes of the commit already exist on the target branch.
+		//    Git's `git rebase` is dropping such commits on default (and so does Github)
+		//    https://git-scm.com/docs/git-rebase#Documentation/git-rebase.txt---emptydropkeepask
+		if treeSHA == lastTreeSHA {
+			log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after rebase", commitSHA)
+			continue
+		}
+
+		lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to commit tree in rebase merge: %w", err)
+		}
+		lastTreeSHA = treeSHA
+	}
+
+	return lastCommitSHA, nil, nil
+}
+
 // runInSharedRepo is helper function used to run the provided function inside a shared repository.
 func runInSharedRepo(
 	ctx context.Context,
@@ -0,0 +1,314 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	testAuthor = &types.Signature{
+		Identity: types.Identity{Name: "author", Email: "author@example.com"},
+		When:     time.Unix(1700000000, 0),
+	}
+	testCommitter = &types.Signature{
+		Identity: types.Identity{Name: "committer", Email: "committer@example.com"},
+		When:     time.Unix(1700000100, 0),
+	}
+)
+
+func runGit(t *testing.T, repoPath string, stdin string, cmd *command.Command) string {
+	t.Helper()
+
+	output := &bytes.Buffer{}
+	err := cmd.Run(context.Background(),
+		command.WithDir(repoPath),
+		command.WithStdin(strings.NewReader(stdin)),
+		command.WithStdout(output))
+	if err != nil {
+		t.Fatalf("failed to run git command: %v", err)
+	}
+
+	return strings.TrimSpace(output.String())
+}
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	runGit(t, repoPath, "", command.New("init", command.WithFlag("--bare")))
+
+	return repoPath
+}
+
+// commitFiles creates a commit with the provided files (file name -> content) as its tree.
+func commitFiles(t *testing.T, repoPath string, files map[string]string, message string, parents ...string) string {
+	t.Helper()
+
+	var treeInput strings.Builder
+	for name, content := range files {
+		blobSHA := runGit(t, repoPath, content, command.New("hash-object",
+			command.WithFlag("-w", "--stdin")))
+		treeInput.WriteString("100644 blob " + blobSHA + "\t" + name + "\n")
+	}
+
+	treeSHA := runGit(t, repoPath, treeInput.String(), command.New("mktree"))
+
+	cmd := command.New("commit-tree",
+		command.WithArg(treeSHA),
+		command.WithFlag("-m", message),
+		command.WithAuthor(testAuthor.Identity.Name, testAuthor.Identity.Email),
+		command.WithCommitter(testAuthor.Identity.Name, testAuthor.Identity.Email))
+	for _, parent := range parents {
+		cmd.Add(command.WithFlag("-p", parent))
+	}
+
+	return runGit(t, repoPath, "", cmd)
+}
+
+func parentsOf(t *testing.T, repoPath, commitSHA string) []string {
+	t.Helper()
+
+	output := runGit(t, repoPath, "", command.New("show",
+		command.WithFlag("--no-patch", "--format=%P"),
+		command.WithArg(commitSHA)))
+
+	return strings.Fields(output)
+}
+
+func filesOf(t *testing.T, repoPath, commitSHA string) []string {
+	t.Helper()
+
+	output := runGit(t, repoPath, "", command.New("ls-tree",
+		command.WithFlag("--name-only"),
+		command.WithArg(commitSHA)))
+
+	files := strings.Fields(output)
+	sort.Strings(files)
+
+	return files
+}
+
+func formatOf(t *testing.T, repoPath, commitSHA, format string) string {
+	t.Helper()
+
+	return runGit(t, repoPath, "", command.New("show",
+		command.WithFlag("--no-patch", "--format="+format),
+		command.WithArg(commitSHA)))
+}
+
+// requireMergeTreeMergeBase skips the test if the installed git doesn't support
+// the --merge-base flag of merge-tree (added in git 2.40), which rebase relies on.
+func requireMergeTreeMergeBase(t *testing.T) {
+	t.Helper()
+
+	output := runGit(t, t.TempDir(), "", command.New("version"))
+
+	var major, minor int
+	if _, err := fmt.Sscanf(output, "git version %d.%d", &major, &minor); err != nil {
+		t.Fatalf("failed to parse git version %q: %v", output, err)
+	}
+
+	if major < 2 || major == 2 && minor < 40 {
+		t.Skipf("%s doesn't support merge-tree --merge-base", output)
+	}
+}
+
+func TestFastForward(t *testing.T) {
+	repoPath := setupRepo(t)
+
+	baseSHA := commitFiles(t, repoPath, map[string]string{"a": "a"}, "base")
+	sourceSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "b": "b"}, "source", baseSHA)
+	targetSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "c": "c"}, "target", baseSHA)
+
+	mergeSHA, conflicts, err := FastForward(context.Background(), repoPath, t.TempDir(),
+		testAuthor, testCommitter, "", baseSHA, baseSHA, sourceSHA)
+	if err != nil {
+		t.Fatalf("failed to fast-forward: %v", err)
+	}
+
+	if len(conflicts) > 0 {
+		t.Errorf("expected no conflicts, got %v", conflicts)
+	}
+
+	if mergeSHA != sourceSHA {
+		t.Errorf("expected fast-forward to the source commit %s, got %s", sourceSHA, mergeSHA)
+	}
+
+	// the source branch is behind the target branch, so it doesn't contain the target commit.
+	mergeSHA, _, err = FastForward(context.Background(), repoPath, t.TempDir(),
+		testAuthor, testCommitter, "", baseSHA, targetSHA, sourceSHA)
+	if err == nil {
+		t.Fatalf("expected fast-forward to fail, got merge commit %s", mergeSHA)
+	}
+
+	if mergeSHA != "" {
+		t.Errorf("expected no merge commit, got %s", mergeSHA)
+	}
+}
+
+func TestRebaseMerge(t *testing.T) {
+	requireMergeTreeMergeBase(t)
+
+	repoPath := setupRepo(t)
+
+	baseSHA := commitFiles(t, repoPath, map[string]string{"a": "a"}, "base")
+	targetSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "b": "b"}, "target", baseSHA)
+	source1SHA := commitFiles(t, repoPath, map[string]string{"a": "a", "c": "c"}, "source 1", baseSHA)
+	source2SHA := commitFiles(t, repoPath, map[string]string{"a": "a", "c": "c", "d": "d"}, "source 2", source1SHA)
+
+	mergeSHA, conflicts, err := RebaseMerge(context.Background(), repoPath, t.TempDir(),
+		testAuthor, testCommitter, "merge message", baseSHA, targetSHA, source2SHA)
+	if err != nil {
+		t.Fatalf("failed to rebase-merge: %v", err)
+	}
+
+	if len(conflicts) > 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	if diff := cmp.Diff([]string{"a", "b", "c", "d"}, filesOf(t, repoPath, mergeSHA)); diff != "" {
+		t.Errorf("unexpected files of the merge commit: %s", diff)
+	}
+
+	if message := formatOf(t, repoPath, mergeSHA, "%B"); message != "merge message" {
+		t.Errorf("expected merge commit message 'merge message', got %q", message)
+	}
+
+	// the merge commit's first parent is the target commit, the second parent is the last rebased commit.
+	mergeParents := parentsOf(t, repoPath, mergeSHA)
+	if len(mergeParents) != 2 || mergeParents[0] != targetSHA {
+		t.Fatalf("expected merge commit parents [%s <rebased commit>], got %v", targetSHA, mergeParents)
+	}
+
+	rebased2SHA := mergeParents[1]
+	if rebased2SHA == source2SHA {
+		t.Fatalf("expected the source commit to be rebased")
+	}
+
+	rebased2Parents := parentsOf(t, repoPath, rebased2SHA)
+	if len(rebased2Parents) != 1 || rebased2Parents[0] == source1SHA {
+		t.Fatalf("expected the rebased commit to have the rebased first commit as parent, got %v", rebased2Parents)
+	}
+
+	rebased1SHA := rebased2Parents[0]
+	if diff := cmp.Diff([]string{targetSHA}, parentsOf(t, repoPath, rebased1SHA)); diff != "" {
+		t.Errorf("expected the first rebased commit to have the target commit as parent: %s", diff)
+	}
+
+	// rebased commits preserve the author and the message of the original commits, but change the committer.
+	for rebasedSHA, expectedMessage := range map[string]string{rebased1SHA: "source 1", rebased2SHA: "source 2"} {
+		if message := formatOf(t, repoPath, rebasedSHA, "%s"); message != expectedMessage {
+			t.Errorf("expected message %q of the rebased commit, got %q", expectedMessage, message)
+		}
+
+		if author := formatOf(t, repoPath, rebasedSHA, "%an"); author != testAuthor.Identity.Name {
+			t.Errorf("expected author %q of the rebased commit, got %q", testAuthor.Identity.Name, author)
+		}
+
+		if committer := formatOf(t, repoPath, rebasedSHA, "%cn"); committer != testCommitter.Identity.Name {
+			t.Errorf("expected committer %q of the rebased commit, got %q", testCommitter.Identity.Name, committer)
+		}
+	}
+}
+
+func TestRebaseMerge_EmptyAfterRebase(t *testing.T) {
+	requireMergeTreeMergeBase(t)
+
+	repoPath := setupRepo(t)
+
+	baseSHA := commitFiles(t, repoPath, map[string]string{"a": "a"}, "base")
+	targetSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "b": "b"}, "target", baseSHA)
+	sourceSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "b": "b"}, "source", baseSHA)
+
+	// the changes of the source commit exist on the target branch already, so nothing is merged.
+	mergeSHA, conflicts, err := RebaseMerge(context.Background(), repoPath, t.TempDir(),
+		testAuthor, testCommitter, "merge message", baseSHA, targetSHA, sourceSHA)
+	if err != nil {
+		t.Fatalf("failed to rebase-merge: %v", err)
+	}
+
+	if len(conflicts) > 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	if mergeSHA != targetSHA {
+		t.Errorf("expected the target commit %s as result, got %s", targetSHA, mergeSHA)
+	}
+}
+
+func TestRebaseMerge_Conflict(t *testing.T) {
+	requireMergeTreeMergeBase(t)
+
+	repoPath := setupRepo(t)
+
+	baseSHA := commitFiles(t, repoPath, map[string]string{"a": "a"}, "base")
+	targetSHA := commitFiles(t, repoPath, map[string]string{"a": "target"}, "target", baseSHA)
+	sourceSHA := commitFiles(t, repoPath, map[string]string{"a": "source"}, "source", baseSHA)
+
+	mergeSHA, conflicts, err := RebaseMerge(context.Background(), repoPath, t.TempDir(),
+		testAuthor, testCommitter, "merge message", baseSHA, targetSHA, sourceSHA)
+	if err != nil {
+		t.Fatalf("failed to rebase-merge: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"a"}, conflicts); diff != "" {
+		t.Errorf("unexpected conflicts: %s", diff)
+	}
+
+	if mergeSHA != "" {
+		t.Errorf("expected no merge commit, got %s", mergeSHA)
+	}
+}
+
+func TestRebase(t *testing.T) {
+	requireMergeTreeMergeBase(t)
+
+	repoPath := setupRepo(t)
+
+	baseSHA := commitFiles(t, repoPath, map[string]string{"a": "a"}, "base")
+	targetSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "b": "b"}, "target", baseSHA)
+	sourceSHA := commitFiles(t, repoPath, map[string]string{"a": "a", "c": "c"}, "source", baseSHA)
+
+	mergeSHA, conflicts, err := Rebase(context.Background(), repoPath, t.TempDir(),
+		testAuthor, testCommitter, "", baseSHA, targetSHA, sourceSHA)
+	if err != nil {
+		t.Fatalf("failed to rebase: %v", err)
+	}
+
+	if len(conflicts) > 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+
+	// no merge commit is created, the rebased commit is on top of the target commit.
+	if diff := cmp.Diff([]string{targetSHA}, parentsOf(t, repoPath, mergeSHA)); diff != "" {
+		t.Errorf("expected the rebased commit to have the target commit as parent: %s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"a", "b", "c"}, filesOf(t, repoPath, mergeSHA)); diff != "" {
+		t.Errorf("unexpected files of the rebased commit: %s", diff)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+)
+
+type ListNewCommitsParams struct {
+	ReadParams
+	// GitRef is the branch, tag or commit from which the commits are listed.
+	GitRef string
+}
+
+func (p *ListNewCommitsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitRef == "" {
+		return errors.InvalidArgument("git ref cannot be empty")
+	}
+
+	return nil
+}
+
+// NewCommit contains the details of a commit that isn't reachable from any existing reference.
+type NewCommit struct {
+	SHA     string
+	Author  Identity
+	Message string
+	// Files contains the files added or modified by the commit.
+	// Merge commits contain the files changed compared to their first parent.
+	Files []NewCommitFile
+}
+
+// NewCommitFile contains the path and the blob size of a file added or modified by a commit.
+type NewCommitFile struct {
+	Path string
+	Size int64
+}
+
+type ListNewCommitsOutput struct {
+	Commits []NewCommit
+}
+
+// ListNewCommits lists the details of the commits reachable from the git ref
+// that aren't reachable from any existing reference.
+// Together with the quarantine dir as alternate object dir it returns the commits introduced by a push.
+func (s *Service) ListNewCommits(
+	ctx context.Context,
+	params *ListNewCommitsParams,
+) (*ListNewCommitsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return nil, err
+	}
+
+	gitCommits, err := s.adapter.ListNewCommits(ctx, repoPath, params.AlternateObjectDirs, params.GitRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	commits := make([]NewCommit, len(gitCommits))
+	for i, c := range gitCommits {
+		files := make([]NewCommitFile, len(c.Files))
+		for j, f := range c.Files {
+			files[j] = NewCommitFile{
+				Path: f.Path,
+				Size: f.Size,
+			}
+		}
+
+		commits[i] = NewCommit{
+			SHA: c.SHA,
+			Author: Identity{
+				Name:  c.Author.Name,
+				Email: c.Author.Email,
+			},
+			Message: c.Message,
+			Files:   files,
+		}
+	}
+
+	return &ListNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
+
+type GetCommitAddedLinesParams struct {
+	ReadParams
+	SHA string
+}
+
+func (p *GetCommitAddedLinesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if !isValidGitSHA(p.SHA) {
+		return errors.InvalidArgument("the provided commit sha '%s' is of invalid format.", p.SHA)
+	}
+
+	return nil
+}
+
+// FileAddedLines contains the lines a commit added to a file.
+type FileAddedLines struct {
+	Path  string
+	Lines []AddedLine
+}
+
+// AddedLine is a single added line, Number is the line number in the new version of the file.
+type AddedLine struct {
+	Number int
+	Text   string
+}
+
+type GetCommitAddedLinesOutput struct {
+	Files []FileAddedLines
+}
+
+// GetCommitAddedLines returns the lines the commit added to each file compared to its parent.
+// Binary files are skipped, merge commits are compared to their first parent
+// and the added lines of very large files are truncated.
+func (s *Service) GetCommitAddedLines(
+	ctx context.Context,
+	params *GetCommitAddedLinesParams,
+) (*GetCommitAddedLinesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return nil, err
+	}
+
+	gitFiles, err := s.adapter.GetCommitAddedLines(ctx, repoPath, params.AlternateObjectDirs, params.SHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get added lines of commit: %w", err)
+	}
+
+	files := make([]FileAddedLines, len(gitFiles))
+	for i, f := range gitFiles {
+		lines := make([]AddedLine, len(f.Lines))
+		for j, l := range f.Lines {
+			lines[j] = AddedLine{
+				Number: l.Number,
+				Text:   l.Text,
+			}
+		}
+
+		files[i] = FileAddedLines{
+			Path:  f.Path,
+			Lines: lines,
+		}
+	}
+
+	return &GetCommitAddedLinesOutput{
+		Files: files,
+	}, nil
+}
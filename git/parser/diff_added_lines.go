@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/types"
+)
+
+// ParseAddedLines parses the output of a unified diff (generated with -U0) and returns the added lines per file.
+// Deleted files and binary files don't have added lines and are not returned.
+// If maxFileSize is positive, at most maxFileSize bytes of added lines are returned per file,
+// the remaining lines of the file are skipped and longer lines are truncated.
+func ParseAddedLines(r io.Reader, maxFileSize int) ([]types.FileAddedLines, error) {
+	var (
+		files    []types.FileAddedLines
+		current  *types.FileAddedLines
+		inHunk   bool
+		lineNum  int
+		fileSize int
+	)
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := readLine(reader, maxFileSize)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read diff: %w", err)
+		}
+
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+
+			switch {
+			case strings.HasPrefix(line, "diff --git "):
+				current = nil
+				inHunk = false
+			case !inHunk && strings.HasPrefix(line, "+++ "):
+				current = nil
+				fileSize = 0
+				if path, ok := parseNewFilePath(line[len("+++ "):]); ok {
+					files = append(files, types.FileAddedLines{Path: path})
+					current = &files[len(files)-1]
+				}
+			case strings.HasPrefix(line, "@@ "):
+				hunk, ok := ParseDiffHunkHeader(line)
+				inHunk = ok
+				lineNum = hunk.NewLine
+			case inHunk && current != nil && strings.HasPrefix(line, "+"):
+				text := line[1:]
+				if maxFileSize > 0 && fileSize+len(text) > maxFileSize {
+					// the limit of the file is reached, the remaining lines of the file are skipped.
+					current = nil
+					break
+				}
+
+				fileSize += len(text)
+				current.Lines = append(current.Lines, types.AddedLine{
+					Number: lineNum,
+					Text:   text,
+				})
+				lineNum++
+			case inHunk && strings.HasPrefix(line, " "):
+				lineNum++
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	// Files without added lines (e.g. only deletions or mode changes) are not of interest.
+	result := files[:0]
+	for _, file := range files {
+		if len(file.Lines) > 0 {
+			result = append(result, file)
+		}
+	}
+
+	return result, nil
+}
+
+// readLine reads the next line including the line break. If maxLength is positive,
+// the line is truncated to maxLength bytes and the rest of the line is discarded without buffering it.
+func readLine(r *bufio.Reader, maxLength int) (string, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if maxLength <= 0 || len(line) < maxLength {
+			line = append(line, chunk...)
+		}
+
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+
+		if maxLength > 0 && len(line) > maxLength {
+			line = line[:maxLength]
+		}
+
+		return string(line), err
+	}
+}
+
+// parseNewFilePath parses the path of the "+++" line of a diff, which is either "/dev/null" or "b/<path>".
+// Paths with special characters are quoted by git.
+func parseNewFilePath(s string) (string, bool) {
+	if strings.HasPrefix(s, `"`) {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return "", false
+		}
+		s = unquoted
+	}
+
+	if !strings.HasPrefix(s, "b/") {
+		return "", false
+	}
+
+	return s[len("b/"):], true
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAddedLines(t *testing.T) {
+	input := `diff --git a/new_file.txt b/new_file.txt
+new file mode 100644
+index 0000000..fb0c863
+--- /dev/null
++++ b/new_file.txt
+@@ -0,0 +1,2 @@
++password = "hunter2"
+++++ not a header
+diff --git a/changed_file.txt b/changed_file.txt
+index f043b93..e9449b5 100644
+--- a/changed_file.txt
++++ b/changed_file.txt
+@@ -7 +7,2 @@
+-Removed line 1
++Added line 1
++Added line 2
+@@ -27,0 +29 @@
++Added line
+\ No newline at end of file
+diff --git a/deleted_file.txt b/deleted_file.txt
+deleted file mode 100644
+index f043b93..0000000
+--- a/deleted_file.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-This is content of
+-a deleted file
+diff --git a/image.png b/image.png
+new file mode 100644
+index 0000000..3d7c2b1
+Binary files /dev/null and b/image.png differ
+diff --git "a/dir/with \"quotes\".txt" "b/dir/with \"quotes\".txt"
+new file mode 100644
+index 0000000..a1b2c3d
+--- /dev/null
++++ "b/dir/with \"quotes\".txt"
+@@ -0,0 +1 @@
++quoted
+`
+
+	want := []types.FileAddedLines{
+		{
+			Path: "new_file.txt",
+			Lines: []types.AddedLine{
+				{Number: 1, Text: `password = "hunter2"`},
+				{Number: 2, Text: "+++ not a header"},
+			},
+		},
+		{
+			Path: "changed_file.txt",
+			Lines: []types.AddedLine{
+				{Number: 7, Text: "Added line 1"},
+				{Number: 8, Text: "Added line 2"},
+				{Number: 29, Text: "Added line"},
+			},
+		},
+		{
+			Path: `dir/with "quotes".txt`,
+			Lines: []types.AddedLine{
+				{Number: 1, Text: "quoted"},
+			},
+		},
+	}
+
+	got, err := ParseAddedLines(strings.NewReader(input), 0)
+	if err != nil {
+		t.Errorf("got error: %v", err)
+		return
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseAddedLines_MaxFileSize(t *testing.T) {
+	input := `diff --git a/large.txt b/large.txt
+new file mode 100644
+index 0000000..fb0c863
+--- /dev/null
++++ b/large.txt
+@@ -0,0 +1,3 @@
++0123456789
++abcdefghij
++klmnopqrst
+diff --git a/minified.js b/minified.js
+new file mode 100644
+index 0000000..e9449b5
+--- /dev/null
++++ b/minified.js
+@@ -0,0 +1 @@
++` + strings.Repeat("x", 100) + `
+diff --git a/small.txt b/small.txt
+new file mode 100644
+index 0000000..a1b2c3d
+--- /dev/null
++++ b/small.txt
+@@ -0,0 +1 @@
++small
+`
+
+	want := []types.FileAddedLines{
+		{
+			Path: "large.txt",
+			Lines: []types.AddedLine{
+				{Number: 1, Text: "0123456789"},
+				{Number: 2, Text: "abcdefghij"},
+			},
+		},
+		{
+			Path: "minified.js",
+			Lines: []types.AddedLine{
+				{Number: 1, Text: strings.Repeat("x", 24)},
+			},
+		},
+		{
+			Path: "small.txt",
+			Lines: []types.AddedLine{
+				{Number: 1, Text: "small"},
+			},
+		},
+	}
+
+	got, err := ParseAddedLines(strings.NewReader(input), 25)
+	if err != nil {
+		t.Errorf("got error: %v", err)
+		return
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+)
+
+const (
+	// LFSPointerMaxSize is the maximum size of a git lfs pointer file.
+	// Any blob that is larger can't be a pointer file.
+	LFSPointerMaxSize = 1024
+
+	lfsPointerVersionPrefix = "version https://git-lfs.github.com/spec/"
+)
+
+var (
+	regexLFSOID  = regexp.MustCompile(`(?m)^oid sha256:([a-f0-9]{64})$`)
+	regexLFSSize = regexp.MustCompile(`(?m)^size ([0-9]+)$`)
+)
+
+// LFSPointer contains the information of a git lfs pointer file.
+type LFSPointer struct {
+	OID  string
+	Size int64
+}
+
+// IsLFSPointer checks whether the provided content is a git lfs pointer file and returns the pointer information.
+func IsLFSPointer(content []byte) (LFSPointer, bool) {
+	if len(content) > LFSPointerMaxSize {
+		return LFSPointer{}, false
+	}
+
+	if !bytes.HasPrefix(content, []byte(lfsPointerVersionPrefix)) {
+		return LFSPointer{}, false
+	}
+
+	oidMatch := regexLFSOID.FindSubmatch(content)
+	if oidMatch == nil {
+		return LFSPointer{}, false
+	}
+
+	sizeMatch := regexLFSSize.FindSubmatch(content)
+	if sizeMatch == nil {
+		return LFSPointer{}, false
+	}
+
+	size, err := strconv.ParseInt(string(sizeMatch[1]), 10, 64)
+	if err != nil || size < 0 {
+		return LFSPointer{}, false
+	}
+
+	return LFSPointer{
+		OID:  string(oidMatch[1]),
+		Size: size,
+	}, true
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsLFSPointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+	tests := []struct {
+		name    string
+		content string
+		want    LFSPointer
+		wantOK  bool
+	}{
+		{
+			name:    "valid",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			want:    LFSPointer{OID: oid, Size: 12345},
+			wantOK:  true,
+		},
+		{
+			name: "valid-with-extension",
+			content: "version https://git-lfs.github.com/spec/v1\next-0-foo sha256:" + oid +
+				"\noid sha256:" + oid + "\nsize 1\n",
+			want:   LFSPointer{OID: oid, Size: 1},
+			wantOK: true,
+		},
+		{
+			name:    "missing-version",
+			content: "oid sha256:" + oid + "\nsize 12345\n",
+		},
+		{
+			name:    "missing-oid",
+			content: "version https://git-lfs.github.com/spec/v1\nsize 12345\n",
+		},
+		{
+			name:    "invalid-oid",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:xyz\nsize 12345\n",
+		},
+		{
+			name:    "missing-size",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n",
+		},
+		{
+			name: "too-large",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n" +
+				strings.Repeat("x", LFSPointerMaxSize),
+		},
+		{
+			name:    "regular-file",
+			content: "hello world\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := IsLFSPointer([]byte(test.content))
+			if ok != test.wantOK {
+				t.Errorf("want ok=%t, got ok=%t", test.wantOK, ok)
+				return
+			}
+
+			if got != test.want {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+
+	"github.com/harness/gitness/git/enum"
+)
+
+const (
+	commitHeaderSignature       = "gpgsig"
+	commitHeaderSignatureSHA256 = "gpgsig-sha256"
+
+	signatureBeginPGP  = "-----BEGIN PGP SIGNATURE-----"
+	signatureBeginSSH  = "-----BEGIN SSH SIGNATURE-----"
+	signatureBeginX509 = "-----BEGIN SIGNED MESSAGE-----"
+)
+
+// ObjectSignature contains the signature of a git object and the content that got signed.
+type ObjectSignature struct {
+	Scheme        enum.SignatureScheme
+	Signature     []byte
+	SignedContent []byte
+}
+
+// CommitSignature extracts the signature from the raw data of a commit object (as returned by cat-file).
+// The signature is stored in the "gpgsig" (or "gpgsig-sha256") header, the signed content
+// is the commit object without that header. Returns false in case the commit isn't signed.
+func CommitSignature(raw []byte) (ObjectSignature, bool) {
+	var (
+		signature []byte
+		content   = make([]byte, 0, len(raw))
+		inHeaders = true
+		inSig     = false
+		collect   = false
+	)
+
+	for len(raw) > 0 {
+		var line []byte
+		if idx := bytes.IndexByte(raw, '\n'); idx >= 0 {
+			line, raw = raw[:idx+1], raw[idx+1:]
+		} else {
+			line, raw = raw, nil
+		}
+
+		if !inHeaders {
+			content = append(content, line...)
+			continue
+		}
+
+		if inSig {
+			// continuation lines of a header start with a single space.
+			if len(line) > 0 && line[0] == ' ' {
+				if collect {
+					signature = append(signature, line[1:]...)
+				}
+				continue
+			}
+			inSig = false
+		}
+
+		if len(line) == 1 && line[0] == '\n' {
+			inHeaders = false
+			content = append(content, line...)
+			continue
+		}
+
+		// both signature headers are excluded from the signed content, but only the first one is used.
+		var value []byte
+		var found bool
+		if value, found = bytes.CutPrefix(line, []byte(commitHeaderSignature+" ")); !found {
+			value, found = bytes.CutPrefix(line, []byte(commitHeaderSignatureSHA256+" "))
+		}
+		if found {
+			inSig = true
+			collect = signature == nil
+			if collect {
+				signature = append([]byte{}, value...)
+			}
+			continue
+		}
+
+		content = append(content, line...)
+	}
+
+	if len(signature) == 0 {
+		return ObjectSignature{}, false
+	}
+
+	return ObjectSignature{
+		Scheme:        signatureSchemeOf(signature),
+		Signature:     signature,
+		SignedContent: content,
+	}, true
+}
+
+// TagSignature extracts the signature from the raw data of a tag object (as returned by cat-file).
+// The signature is appended to the tag message, the signed content is everything before it.
+// Returns false in case the tag isn't signed.
+func TagSignature(raw []byte) (ObjectSignature, bool) {
+	idx := signatureStart(raw)
+	if idx < 0 {
+		return ObjectSignature{}, false
+	}
+
+	return ObjectSignature{
+		Scheme:        signatureSchemeOf(raw[idx:]),
+		Signature:     raw[idx:],
+		SignedContent: raw[:idx],
+	}, true
+}
+
+// TagMessageWithoutSignature returns the tag message without the appended signature (if any).
+func TagMessageWithoutSignature(message []byte) []byte {
+	idx := signatureStart(message)
+	if idx < 0 {
+		return message
+	}
+
+	return message[:idx]
+}
+
+// signatureStart returns the index of the line that starts the signature, or -1 if there's none.
+func signatureStart(data []byte) int {
+	for _, token := range []string{signatureBeginPGP, signatureBeginSSH, signatureBeginX509} {
+		if bytes.HasPrefix(data, []byte(token)) {
+			return 0
+		}
+
+		if idx := bytes.LastIndex(data, []byte("\n"+token)); idx >= 0 {
+			return idx + 1
+		}
+	}
+
+	return -1
+}
+
+func signatureSchemeOf(signature []byte) enum.SignatureScheme {
+	switch {
+	case bytes.HasPrefix(signature, []byte(signatureBeginPGP)):
+		return enum.SignatureSchemePGP
+	case bytes.HasPrefix(signature, []byte(signatureBeginSSH)):
+		return enum.SignatureSchemeSSH
+	default:
+		return enum.SignatureSchemeUnknown
+	}
+}
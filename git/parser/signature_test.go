@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/enum"
+)
+
+func TestCommitSignature(t *testing.T) {
+	const headers = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Max <max@example.com> 1700000000 +0000\n" +
+		"committer Max <max@example.com> 1700000000 +0000\n"
+
+	tests := []struct {
+		name          string
+		raw           string
+		wantOK        bool
+		wantScheme    enum.SignatureScheme
+		wantSignature string
+		wantContent   string
+	}{
+		{
+			name: "unsigned",
+			raw:  headers + "\ntitle\n",
+		},
+		{
+			name: "pgp",
+			raw: headers +
+				"gpgsig -----BEGIN PGP SIGNATURE-----\n \n abc\n -----END PGP SIGNATURE-----\n" +
+				"\ntitle\n\n gpgsig in message\n",
+			wantOK:        true,
+			wantScheme:    enum.SignatureSchemePGP,
+			wantSignature: "-----BEGIN PGP SIGNATURE-----\n\nabc\n-----END PGP SIGNATURE-----\n",
+			wantContent:   headers + "\ntitle\n\n gpgsig in message\n",
+		},
+		{
+			name: "ssh-sha256-header",
+			raw: headers +
+				"gpgsig-sha256 -----BEGIN SSH SIGNATURE-----\n abc\n -----END SSH SIGNATURE-----\n" +
+				"\ntitle",
+			wantOK:        true,
+			wantScheme:    enum.SignatureSchemeSSH,
+			wantSignature: "-----BEGIN SSH SIGNATURE-----\nabc\n-----END SSH SIGNATURE-----\n",
+			wantContent:   headers + "\ntitle",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sig, ok := CommitSignature([]byte(test.raw))
+			if ok != test.wantOK {
+				t.Fatalf("ok mismatch: want=%t got=%t", test.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if sig.Scheme != test.wantScheme {
+				t.Errorf("scheme mismatch: want=%q got=%q", test.wantScheme, sig.Scheme)
+			}
+			if string(sig.Signature) != test.wantSignature {
+				t.Errorf("signature mismatch: want=%q got=%q", test.wantSignature, sig.Signature)
+			}
+			if string(sig.SignedContent) != test.wantContent {
+				t.Errorf("content mismatch: want=%q got=%q", test.wantContent, sig.SignedContent)
+			}
+		})
+	}
+}
+
+func TestTagSignature(t *testing.T) {
+	const content = "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"type commit\n" +
+		"tag v1.0.0\n" +
+		"tagger Max <max@example.com> 1700000000 +0000\n" +
+		"\nrelease\n"
+	const signature = "-----BEGIN PGP SIGNATURE-----\n\nabc\n-----END PGP SIGNATURE-----\n"
+
+	if _, ok := TagSignature([]byte(content)); ok {
+		t.Errorf("expected unsigned tag")
+	}
+
+	sig, ok := TagSignature([]byte(content + signature))
+	if !ok {
+		t.Fatalf("expected signed tag")
+	}
+
+	if sig.Scheme != enum.SignatureSchemePGP {
+		t.Errorf("scheme mismatch: got=%q", sig.Scheme)
+	}
+	if string(sig.Signature) != signature {
+		t.Errorf("signature mismatch: got=%q", sig.Signature)
+	}
+	if string(sig.SignedContent) != content {
+		t.Errorf("content mismatch: got=%q", sig.SignedContent)
+	}
+
+	if msg := TagMessageWithoutSignature([]byte("release\n" + signature)); string(msg) != "release\n" {
+		t.Errorf("message mismatch: got=%q", msg)
+	}
+}
@@ -17,6 +17,9 @@ package git
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/errors"
 )
 
 const (
@@ -34,3 +37,19 @@ func getFullPathForRepo(reposRoot, uid string) string {
 		fmt.Sprintf("%s.%s", uid[4:], gitRepoSuffix), // remainder with .git
 	)
 }
+
+// validateAlternateObjectDirs ensures that all provided alternate object dirs are located inside the repository.
+func validateAlternateObjectDirs(repoPath string, dirs []string) error {
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			return errors.InvalidArgument("alternate object dir '%s' has to be an absolute path", dir)
+		}
+
+		rel, err := filepath.Rel(repoPath, filepath.Clean(dir))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return errors.InvalidArgument("alternate object dir '%s' is outside of the repository", dir)
+		}
+	}
+
+	return nil
+}
@@ -25,6 +25,11 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefSpecs [OPTIONAL] limits the push to the references matching the refspecs.
+	// References of the remote repository that match the refspecs, but don't exist locally are deleted.
+	// By default all references of the repository are mirrored to the remote repository.
+	RefSpecs []string
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -56,15 +61,27 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 		if err != nil {
 			return errors.Internal(err, "push to repo failed")
 		}
+		if len(params.RefSpecs) > 0 {
+			// there's nothing to push yet
+			return nil
+		}
 		return errors.InvalidArgument("cannot push empty repo")
 	}
 
-	err = s.adapter.Push(ctx, repoPath, types.PushOptions{
+	pushOpts := types.PushOptions{
 		Remote: params.RemoteURL,
 		Force:  false,
 		Env:    nil,
 		Mirror: true,
-	})
+	}
+	if len(params.RefSpecs) > 0 {
+		pushOpts.Force = true
+		pushOpts.Mirror = false
+		pushOpts.Prune = true
+		pushOpts.RefSpecs = params.RefSpecs
+	}
+
+	err = s.adapter.Push(ctx, repoPath, pushOpts)
 	if err != nil {
 		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
 	}
@@ -112,6 +112,7 @@ func GetRefPath(refName string, refType enum.RefType) (string, error) {
 		refPullReqPrefix      = "refs/pullreq/"
 		refPullReqHeadSuffix  = "/head"
 		refPullReqMergeSuffix = "/merge"
+		refPullReqQueueSuffix = "/queue"
 	)
 
 	switch refType {
@@ -125,6 +126,8 @@ func GetRefPath(refName string, refType enum.RefType) (string, error) {
 		return refPullReqPrefix + refName + refPullReqHeadSuffix, nil
 	case enum.RefTypePullReqMerge:
 		return refPullReqPrefix + refName + refPullReqMergeSuffix, nil
+	case enum.RefTypePullReqQueue:
+		return refPullReqPrefix + refName + refPullReqQueueSuffix, nil
 	case enum.RefTypeUndefined:
 		fallthrough
 	default:
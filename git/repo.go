@@ -22,11 +22,13 @@ import (
 	"path"
 	"regexp"
 	"runtime/debug"
+	"sort"
 	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/types"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
@@ -114,6 +116,9 @@ type SyncRepositoryParams struct {
 	// RefSpecs [OPTIONAL] allows to override the refspecs that are being synced from the remote repository.
 	// By default all references present on the remote repository will be fetched (including scm internal ones).
 	RefSpecs []string
+
+	// RunPostReceive [OPTIONAL] triggers the post-receive githook for all branches and tags updated by the sync.
+	RunPostReceive bool
 }
 
 type SyncRepositoryOutput struct {
@@ -280,12 +285,35 @@ func (s *Service) SyncRepository(
 		}
 	}
 
+	var refsBefore, refsAfter map[string]string
+	if params.RunPostReceive {
+		refsBefore, err = s.listBranchAndTagRefs(ctx, repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("SyncRepository: failed to list references before sync: %w", err)
+		}
+	}
+
 	// sync repo content
 	err = s.adapter.Sync(ctx, repoPath, params.Source, params.RefSpecs)
 	if err != nil {
 		return nil, fmt.Errorf("SyncRepository: failed to sync git repo: %w", err)
 	}
 
+	if params.RunPostReceive {
+		refsAfter, err = s.listBranchAndTagRefs(ctx, repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("SyncRepository: failed to list references after sync: %w", err)
+		}
+
+		refUpdates := diffRefs(refsBefore, refsAfter)
+		if len(refUpdates) > 0 {
+			err = s.adapter.RunPostReceiveHook(ctx, params.EnvVars, refUpdates)
+			if err != nil {
+				return nil, fmt.Errorf("SyncRepository: failed to run post-receive hook: %w", err)
+			}
+		}
+	}
+
 	// get remote default branch
 	defaultBranch, err := s.adapter.GetRemoteDefaultBranch(ctx, params.Source)
 	if errors.Is(err, types.ErrNoDefaultBranch) {
@@ -308,6 +336,64 @@ func (s *Service) SyncRepository(
 	}, nil
 }
 
+// listBranchAndTagRefs returns all branches and tags of the repository mapped to the SHAs they point to.
+func (s *Service) listBranchAndTagRefs(ctx context.Context, repoPath string) (map[string]string, error) {
+	refs := make(map[string]string)
+
+	err := s.adapter.WalkReferences(ctx, repoPath, func(wre types.WalkReferencesEntry) error {
+		ref, ok := wre[types.GitReferenceFieldRefName]
+		if !ok {
+			return errors.New("ref entry didn't contain the ref name")
+		}
+		sha, ok := wre[types.GitReferenceFieldObjectName]
+		if !ok {
+			return errors.New("ref entry didn't contain the ref object sha")
+		}
+
+		refs[ref] = sha
+
+		return nil
+	}, &types.WalkReferencesOptions{
+		Patterns: []string{gitReferenceNamePrefixBranch, gitReferenceNamePrefixTag},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk references: %w", err)
+	}
+
+	return refs, nil
+}
+
+// diffRefs returns the reference updates required to get from the old to the new state of references.
+func diffRefs(oldRefs, newRefs map[string]string) []hook.ReferenceUpdate {
+	refUpdates := make([]hook.ReferenceUpdate, 0)
+
+	for ref, newSHA := range newRefs {
+		oldSHA, ok := oldRefs[ref]
+		if !ok {
+			oldSHA = types.NilSHA
+		}
+		if oldSHA == newSHA {
+			continue
+		}
+
+		refUpdates = append(refUpdates, hook.ReferenceUpdate{Ref: ref, Old: oldSHA, New: newSHA})
+	}
+
+	for ref, oldSHA := range oldRefs {
+		if _, ok := newRefs[ref]; ok {
+			continue
+		}
+
+		refUpdates = append(refUpdates, hook.ReferenceUpdate{Ref: ref, Old: oldSHA, New: types.NilSHA})
+	}
+
+	sort.Slice(refUpdates, func(i, j int) bool {
+		return refUpdates[i].Ref < refUpdates[j].Ref
+	})
+
+	return refUpdates
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
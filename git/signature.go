@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+)
+
+type GetObjectSignaturesParams struct {
+	ReadParams
+	// SHAs contains the SHAs of the commits and annotated tags.
+	SHAs []string
+}
+
+func (p *GetObjectSignaturesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	for _, sha := range p.SHAs {
+		if !isValidGitSHA(sha) {
+			return errors.InvalidArgument("invalid object SHA '%s'", sha)
+		}
+	}
+
+	return nil
+}
+
+// ObjectSignature contains the signature of a commit or an annotated tag and the content that got signed.
+type ObjectSignature struct {
+	SHA string
+	// Signer is the committer of a commit or the tagger of a tag.
+	Signer Identity
+	// Scheme, Signature and SignedContent are empty if the object isn't signed.
+	Scheme        enum.SignatureScheme
+	Signature     []byte
+	SignedContent []byte
+}
+
+func (s ObjectSignature) IsSigned() bool {
+	return len(s.Signature) > 0
+}
+
+type GetObjectSignaturesOutput struct {
+	// Signatures are returned in the same order as the provided SHAs.
+	Signatures []ObjectSignature
+}
+
+// GetObjectSignatures returns the signatures of the provided commits and annotated tags.
+func (s *Service) GetObjectSignatures(
+	ctx context.Context,
+	params *GetObjectSignaturesParams,
+) (*GetObjectSignaturesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(params.SHAs) == 0 {
+		return &GetObjectSignaturesOutput{}, nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return nil, err
+	}
+
+	gitSignatures, err := s.adapter.GetObjectSignatures(ctx, repoPath, params.AlternateObjectDirs, params.SHAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object signatures: %w", err)
+	}
+
+	signatures := make([]ObjectSignature, len(gitSignatures))
+	for i, sig := range gitSignatures {
+		signatures[i] = ObjectSignature{
+			SHA: sig.SHA,
+			Signer: Identity{
+				Name:  sig.Signer.Name,
+				Email: sig.Signer.Email,
+			},
+			Scheme:        sig.Scheme,
+			Signature:     sig.Signature,
+			SignedContent: sig.SignedContent,
+		}
+	}
+
+	return &GetObjectSignaturesOutput{
+		Signatures: signatures,
+	}, nil
+}
+
+type ListNewCommitSHAsParams struct {
+	ReadParams
+	// GitRef is the branch, tag or commit from which the commits are listed.
+	GitRef string
+	// BaseRef is optional. If provided, the commits that aren't reachable from the base ref are listed,
+	// instead of the commits that aren't reachable from any existing reference.
+	BaseRef string
+}
+
+func (p *ListNewCommitSHAsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitRef == "" {
+		return errors.InvalidArgument("git ref cannot be empty")
+	}
+
+	return nil
+}
+
+type ListNewCommitSHAsOutput struct {
+	SHAs []string
+}
+
+// ListNewCommitSHAs lists the commits reachable from the git ref that aren't reachable from any existing reference,
+// or from the base ref if one is provided.
+// Together with the quarantine dir as alternate object dir it returns the commits introduced by a push.
+func (s *Service) ListNewCommitSHAs(
+	ctx context.Context,
+	params *ListNewCommitSHAsParams,
+) (*ListNewCommitSHAsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return nil, err
+	}
+
+	shas, err := s.adapter.ListNewCommitSHAs(ctx, repoPath, params.AlternateObjectDirs,
+		params.GitRef, params.BaseRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	return &ListNewCommitSHAsOutput{
+		SHAs: shas,
+	}, nil
+}
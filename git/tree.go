@@ -55,6 +55,8 @@ type ListTreeNodeParams struct {
 	GitREF              string
 	Path                string
 	IncludeLatestCommit bool
+	// Recursive lists all blobs of the tree and its sub-trees (sub-trees themselves aren't returned).
+	Recursive bool
 }
 
 type ListTreeNodeOutput struct {
@@ -130,7 +132,8 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 		ctx,
 		repoPath,
 		params.GitREF,
-		params.Path)
+		params.Path,
+		params.Recursive)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
 	}
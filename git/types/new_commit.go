@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// NewCommit contains the details of a commit that isn't reachable from any existing reference yet.
+type NewCommit struct {
+	SHA     string
+	Author  Identity
+	Message string
+	// Files contains the files added or modified by the commit compared to its parent.
+	// Merge commits contain the files changed compared to their first parent.
+	Files []NewCommitFile
+}
+
+// NewCommitFile contains the path and the blob size of a file added or modified by a commit.
+type NewCommitFile struct {
+	Path string
+	Size int64
+}
+
+// FileAddedLines contains the lines a commit added to a file.
+type FileAddedLines struct {
+	Path  string
+	Lines []AddedLine
+}
+
+// AddedLine is a single line added to a file, Number is the line number in the new version of the file.
+type AddedLine struct {
+	Number int
+	Text   string
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/git/enum"
+)
+
+// ObjectSignature contains the signature of a commit or an annotated tag.
+type ObjectSignature struct {
+	SHA  string
+	Type GitObjectType
+
+	// Signer is the identity that claims the signature (committer of a commit or tagger of a tag).
+	Signer Identity
+
+	// Scheme, Signature and SignedContent are empty if the object isn't signed.
+	Scheme        enum.SignatureScheme
+	Signature     []byte
+	SignedContent []byte
+}
@@ -232,6 +232,8 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	Prune          bool
+	RefSpecs       []string
 }
 
 type TreeNodeWithCommit struct {
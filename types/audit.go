@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// AuditLog represents a single security-relevant action recorded in the audit log.
+type AuditLog struct {
+	ID int64 `json:"id"`
+
+	// SpaceID is the ID of the space the resource belongs to (nil for system-wide resources).
+	SpaceID *int64 `json:"space_id,omitempty"`
+
+	Action             enum.AuditAction       `json:"action"`
+	ResourceType       enum.AuditResourceType `json:"resource_type"`
+	ResourceIdentifier string                 `json:"resource_identifier"`
+	// ResourcePath is the path of the space or repository the resource belongs to at the time of the action.
+	ResourcePath string `json:"resource_path,omitempty"`
+
+	PrincipalID int64          `json:"-"`
+	Actor       *PrincipalInfo `json:"actor"`
+	ClientIP    string         `json:"client_ip,omitempty"`
+
+	// Diff contains the changed fields of the resource.
+	Diff map[string]AuditLogChange `json:"diff,omitempty"`
+	// Data contains additional information about the action (e.g. the reason of a rule bypass).
+	Data map[string]string `json:"data,omitempty"`
+
+	Created int64 `json:"created"`
+}
+
+// AuditLogChange holds the value of a resource field before and after the action.
+type AuditLogChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditLogFilter stores audit log query parameters.
+type AuditLogFilter struct {
+	Pagination
+	Actions       []enum.AuditAction       `json:"actions"`
+	ResourceTypes []enum.AuditResourceType `json:"resource_types"`
+	PrincipalID   int64                    `json:"principal_id"`
+	CreatedGt     int64                    `json:"created_gt"`
+	CreatedLt     int64                    `json:"created_lt"`
+	// Recursive includes the audit logs of all subspaces.
+	Recursive bool `json:"recursive"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// AutoMerge is a request to merge a pull request automatically
+// once it satisfies all protection rules of its target branch.
+type AutoMerge struct {
+	PullReqID int64            `json:"pullreq_id"`
+	RepoID    int64            `json:"repo_id"`
+	Method    enum.MergeMethod `json:"method"`
+	// EnabledBy is the principal on whose behalf the pull request gets merged.
+	EnabledBy int64 `json:"enabled_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
@@ -83,6 +83,11 @@ type Config struct {
 		// (either running directly or via a port exposed in a docker container).
 		// Value is derived from HTTP.Server unless explicitly specified (e.g. http://host.docker.internal:3000).
 		Container string `envconfig:"GITNESS_URL_CONTAINER"`
+
+		// GitSSH defines the external URL via which the GIT SSH server is reachable.
+		// Value is derived from Base and SSH.Server unless explicitly specified (e.g. ssh://git@localhost:3022).
+		// NOTE: Only used in case the ssh server is enabled.
+		GitSSH string `envconfig:"GITNESS_URL_GIT_SSH"`
 	}
 
 	// Git defines the git configuration parameters
@@ -121,6 +126,9 @@ type Config struct {
 		HTTP struct {
 			Port  int    `envconfig:"GITNESS_HTTP_PORT" default:"3000"`
 			Proto string `envconfig:"GITNESS_HTTP_PROTO" default:"http"`
+			// TrustedProxies are the IP addresses and CIDR ranges of the reverse proxies in front of gitness.
+			// The X-Forwarded-For and X-Real-IP headers are ignored unless the request is sent by a trusted proxy.
+			TrustedProxies []string `envconfig:"GITNESS_HTTP_TRUSTED_PROXIES"`
 		}
 
 		// Acme defines Acme configuration parameters.
@@ -130,6 +138,17 @@ type Config struct {
 			Email   bool   `envconfig:"GITNESS_ACME_EMAIL"`
 			Host    string `envconfig:"GITNESS_ACME_HOST"`
 		}
+
+		// SSH defines the configuration parameters of the git ssh server.
+		SSH struct {
+			Enabled bool `envconfig:"GITNESS_SSH_ENABLED"`
+			Port    int  `envconfig:"GITNESS_SSH_PORT" default:"3022"`
+			// DefaultUser is the user used in the generated ssh clone urls (any user is accepted by the server).
+			DefaultUser string `envconfig:"GITNESS_SSH_DEFAULT_USER" default:"git"`
+			// HostKeyPath is the path to the private host key of the server.
+			// If not provided, a host key is generated and stored in the gitness home directory.
+			HostKeyPath string `envconfig:"GITNESS_SSH_HOST_KEY_PATH"`
+		}
 	}
 
 	// CI defines configuration related to build executions.
@@ -173,12 +192,88 @@ type Config struct {
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
 	}
 
+	// LFS defines the git large file storage configuration parameters.
+	LFS struct {
+		// RepoQuota is the maximum total size (in bytes) of the lfs objects of a repository (0 means unlimited).
+		RepoQuota int64 `envconfig:"GITNESS_LFS_REPO_QUOTA" default:"0"`
+	}
+
 	// Token defines token configuration parameters.
 	Token struct {
 		CookieName string        `envconfig:"GITNESS_TOKEN_COOKIE_NAME" default:"token"`
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// OIDC defines the configuration of the login via an OpenID Connect identity provider.
+	OIDC struct {
+		Enabled bool `envconfig:"GITNESS_OIDC_ENABLED" default:"false"`
+		// Issuer is the issuer URL of the identity provider, used for the discovery of its endpoints.
+		Issuer       string   `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string   `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string   `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+		Scopes       []string `envconfig:"GITNESS_OIDC_SCOPES" default:"openid,profile,email"`
+		// AutoProvision creates a new user on the first login if no user can be linked via its verified email.
+		AutoProvision bool `envconfig:"GITNESS_OIDC_AUTO_PROVISION" default:"true"`
+		// GroupsClaim is the name of the claim that contains the groups of the user.
+		GroupsClaim string `envconfig:"GITNESS_OIDC_GROUPS_CLAIM" default:"groups"`
+		// GroupMappings maps groups of the identity provider to space memberships,
+		// using the format "<group>=<space_path>:<role>" (e.g. "developers=acme/backend:contributor").
+		GroupMappings []string `envconfig:"GITNESS_OIDC_GROUP_MAPPINGS"`
+	}
+
+	// LDAP defines the configuration of the login via an LDAP directory (e.g. Active Directory).
+	LDAP struct {
+		Enabled bool `envconfig:"GITNESS_LDAP_ENABLED" default:"false"`
+		// URL is the address of the directory server (e.g. ldaps://ldap.example.com:636).
+		URL                string `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool   `envconfig:"GITNESS_LDAP_START_TLS" default:"false"`
+		InsecureSkipVerify bool   `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY" default:"false"`
+		// BindDN and BindPassword are the credentials of the service account used to search the directory.
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		UserBaseDN string `envconfig:"GITNESS_LDAP_USER_BASE_DN"`
+		// UserFilter is the filter used to find a user, %s is replaced with the (escaped) login of the user.
+		UserFilter string `envconfig:"GITNESS_LDAP_USER_FILTER" default:"(&(objectClass=person)(uid=%s))"`
+		// IDAttribute permanently identifies a user (e.g. entryUUID, or objectGUID for Active Directory).
+		IDAttribute          string `envconfig:"GITNESS_LDAP_ID_ATTRIBUTE" default:"entryUUID"`
+		UIDAttribute         string `envconfig:"GITNESS_LDAP_UID_ATTRIBUTE" default:"uid"`
+		EmailAttribute       string `envconfig:"GITNESS_LDAP_EMAIL_ATTRIBUTE" default:"mail"`
+		DisplayNameAttribute string `envconfig:"GITNESS_LDAP_DISPLAY_NAME_ATTRIBUTE" default:"displayName"`
+		// AutoProvision creates a new user on the first login if no user exists with the same uid or email.
+		AutoProvision bool `envconfig:"GITNESS_LDAP_AUTO_PROVISION" default:"true"`
+		// LinkExistingUsers links LDAP users to existing users with the same uid or email on the first login.
+		// Only enable it if the directory is trusted to manage the uids and emails of all existing users.
+		LinkExistingUsers bool `envconfig:"GITNESS_LDAP_LINK_EXISTING_USERS" default:"false"`
+
+		// GroupBaseDN enables the sync of the directory groups into user groups if set.
+		GroupBaseDN          string `envconfig:"GITNESS_LDAP_GROUP_BASE_DN"`
+		GroupFilter          string `envconfig:"GITNESS_LDAP_GROUP_FILTER" default:"(objectClass=groupOfNames)"`
+		GroupNameAttribute   string `envconfig:"GITNESS_LDAP_GROUP_NAME_ATTRIBUTE" default:"cn"`
+		GroupMemberAttribute string `envconfig:"GITNESS_LDAP_GROUP_MEMBER_ATTRIBUTE" default:"member"`
+		// GroupSyncSpace is the path of the space the synced user groups are created in.
+		GroupSyncSpace       string        `envconfig:"GITNESS_LDAP_GROUP_SYNC_SPACE"`
+		GroupSyncCRON        string        `envconfig:"GITNESS_LDAP_GROUP_SYNC_CRON" default:"0 * * * *"`
+		GroupSyncMaxDuration time.Duration `envconfig:"GITNESS_LDAP_GROUP_SYNC_MAX_DURATION" default:"10m"`
+	}
+
+	// TwoFactor defines the configuration of the two-factor authentication of users.
+	// NOTE: Logins via OIDC rely on the identity provider to enforce additional factors.
+	TwoFactor struct {
+		// Required requires all users to login with a second factor.
+		Required bool `envconfig:"GITNESS_2FA_REQUIRED" default:"false"`
+		// RequiredSpaces requires the members of the spaces with the provided paths to login with a second factor.
+		RequiredSpaces []string `envconfig:"GITNESS_2FA_REQUIRED_SPACES"`
+		// Issuer is the name the TOTP secrets are labeled with in authenticator apps.
+		Issuer string `envconfig:"GITNESS_2FA_ISSUER" default:"Gitness"`
+		// ChallengeLifetime is the time a user has to provide the second factor after the password was verified.
+		ChallengeLifetime time.Duration `envconfig:"GITNESS_2FA_CHALLENGE_LIFETIME" default:"5m"`
+		// MaxFailedAttempts is the number of failed second factor attempts after which a user is locked out.
+		MaxFailedAttempts int `envconfig:"GITNESS_2FA_MAX_FAILED_ATTEMPTS" default:"5"`
+		// LockoutDuration is the time a user can't login with a second factor after too many failed attempts.
+		LockoutDuration time.Duration `envconfig:"GITNESS_2FA_LOCKOUT_DURATION" default:"15m"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -308,6 +403,11 @@ type Config struct {
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 	}
 
+	AuditLog struct {
+		// RetentionTime is the duration after which audit log entries will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_LOG_RETENTION_TIME" default:"8760h"` // 365 days
+	}
+
 	Trigger struct {
 		Concurrency int `envconfig:"GITNESS_TRIGGER_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
@@ -326,10 +426,36 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	Mirror struct {
+		Enabled bool `envconfig:"GITNESS_MIRROR_ENABLED" default:"true"`
+		// CRON defines how often the pull mirrors are checked, the mirrors are synced once their sync interval passed.
+		CRON string `envconfig:"GITNESS_MIRROR_CRON" default:"*/5 * * * *"`
+		// SyncInterval is the sync interval of pull mirrors that don't define their own sync interval.
+		SyncInterval time.Duration `envconfig:"GITNESS_MIRROR_SYNC_INTERVAL" default:"30m"`
+		PushCRON     string        `envconfig:"GITNESS_MIRROR_PUSH_CRON" default:"*/10 * * * *"`
+		MaxDuration  time.Duration `envconfig:"GITNESS_MIRROR_MAX_DURATION" default:"25m"`
+		NumWorkers   int           `envconfig:"GITNESS_MIRROR_NUM_WORKERS" default:"3"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
 
+	SecretScanning struct {
+		// AllowlistFilePaths are the possible paths of the allowlist file on the default branch.
+		//nolint:lll
+		AllowlistFilePaths []string `envconfig:"GITNESS_SECRET_SCANNING_ALLOWLIST_FILEPATH" default:".secretsignore,.harness/secretsignore"`
+	}
+
+	MergeQueue struct {
+		// CRON defines how often all merge queues are processed, in case an event got lost.
+		// The merge queues are processed on pull request and status check events as well.
+		CRON        string        `envconfig:"GITNESS_MERGE_QUEUE_CRON" default:"*/10 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_MERGE_QUEUE_MAX_DURATION" default:"5m"`
+		// MaxSpeculativeMerges is the maximum number of queued pull requests that are tested in parallel.
+		MaxSpeculativeMerges int `envconfig:"GITNESS_MERGE_QUEUE_MAX_SPECULATIVE_MERGES" default:"5"`
+	}
+
 	SMTP struct {
 		Host     string `envconfig:"GITNESS_SMTP_HOST"`
 		Port     int    `envconfig:"GITNESS_SMTP_PORT"`
@@ -347,6 +473,11 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+		// IndexDir is the directory where the keyword search indexes are stored.
+		// Value is derived from the gitness home directory unless explicitly specified.
+		IndexDir string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_DIR"`
+		// MaxFileSize is the maximum size of a file (in bytes) to be indexed.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"` // 1 MiB
 	}
 
 	Repos struct {
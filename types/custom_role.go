@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// CustomRole is a membership role defined in a space with a custom set of permissions.
+// It can be used by memberships of the space and of all spaces and repositories within it.
+type CustomRole struct {
+	ID          int64             `json:"id"`
+	SpaceID     int64             `json:"space_id"`
+	Identifier  string            `json:"identifier"`
+	DisplayName string            `json:"display_name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+	CreatedBy   int64             `json:"created_by"`
+	Created     int64             `json:"created"`
+	Updated     int64             `json:"updated"`
+}
+
+// HasPermission returns true if the custom role grants the permission.
+func (r *CustomRole) HasPermission(permission enum.Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
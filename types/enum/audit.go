@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AuditAction represents an action recorded in the audit log.
+type AuditAction string
+
+// AuditAction enumeration.
+const (
+	AuditActionCreated     AuditAction = "created"
+	AuditActionUpdated     AuditAction = "updated"
+	AuditActionDeleted     AuditAction = "deleted"
+	AuditActionRestored    AuditAction = "restored"
+	AuditActionPurged      AuditAction = "purged"
+	AuditActionForcePushed AuditAction = "force_pushed"
+	AuditActionBypassed    AuditAction = "bypassed"
+)
+
+var auditActions = sortEnum([]AuditAction{
+	AuditActionCreated,
+	AuditActionUpdated,
+	AuditActionDeleted,
+	AuditActionRestored,
+	AuditActionPurged,
+	AuditActionForcePushed,
+	AuditActionBypassed,
+})
+
+func (AuditAction) Enum() []interface{} { return toInterfaceSlice(auditActions) }
+func (a AuditAction) Sanitize() (AuditAction, bool) {
+	return Sanitize(a, GetAllAuditActions)
+}
+func GetAllAuditActions() ([]AuditAction, AuditAction) {
+	return auditActions, ""
+}
+
+// AuditResourceType represents the type of the resource an audited action was performed on.
+type AuditResourceType string
+
+// AuditResourceType enumeration.
+const (
+	AuditResourceTypeRepository           AuditResourceType = "repository"
+	AuditResourceTypeBranch               AuditResourceType = "branch"
+	AuditResourceTypePullRequest          AuditResourceType = "pull_request"
+	AuditResourceTypeRule                 AuditResourceType = "rule"
+	AuditResourceTypeRepoMembership       AuditResourceType = "repo_membership"
+	AuditResourceTypeMembership           AuditResourceType = "membership"
+	AuditResourceTypeCustomRole           AuditResourceType = "custom_role"
+	AuditResourceTypeToken                AuditResourceType = "token"
+	AuditResourceTypeUser                 AuditResourceType = "user"
+	AuditResourceTypeSecretScanning       AuditResourceType = "secret_scanning"
+	AuditResourceTypeTwoFactorRequirement AuditResourceType = "two_factor_requirement"
+	AuditResourceTypeUserGroup            AuditResourceType = "user_group"
+	AuditResourceTypeUserGroupMember      AuditResourceType = "user_group_member"
+	AuditResourceTypeUserGroupMembership  AuditResourceType = "user_group_membership"
+	AuditResourceTypePublicKey            AuditResourceType = "public_key"
+)
+
+var auditResourceTypes = sortEnum([]AuditResourceType{
+	AuditResourceTypeRepository,
+	AuditResourceTypeBranch,
+	AuditResourceTypePullRequest,
+	AuditResourceTypeRule,
+	AuditResourceTypeRepoMembership,
+	AuditResourceTypeMembership,
+	AuditResourceTypeCustomRole,
+	AuditResourceTypeToken,
+	AuditResourceTypeUser,
+	AuditResourceTypeSecretScanning,
+	AuditResourceTypeTwoFactorRequirement,
+	AuditResourceTypeUserGroup,
+	AuditResourceTypeUserGroupMember,
+	AuditResourceTypeUserGroupMembership,
+	AuditResourceTypePublicKey,
+})
+
+func (AuditResourceType) Enum() []interface{} { return toInterfaceSlice(auditResourceTypes) }
+func (t AuditResourceType) Sanitize() (AuditResourceType, bool) {
+	return Sanitize(t, GetAllAuditResourceTypes)
+}
+func GetAllAuditResourceTypes() ([]AuditResourceType, AuditResourceType) {
+	return auditResourceTypes, ""
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// LabelColor defines the color of a label.
+type LabelColor string
+
+// LabelColor enumeration.
+const (
+	LabelColorBlue   LabelColor = "blue"
+	LabelColorBrown  LabelColor = "brown"
+	LabelColorCyan   LabelColor = "cyan"
+	LabelColorGreen  LabelColor = "green"
+	LabelColorGrey   LabelColor = "grey"
+	LabelColorOrange LabelColor = "orange"
+	LabelColorPink   LabelColor = "pink"
+	LabelColorPurple LabelColor = "purple"
+	LabelColorRed    LabelColor = "red"
+	LabelColorYellow LabelColor = "yellow"
+)
+
+var labelColors = sortEnum([]LabelColor{
+	LabelColorBlue,
+	LabelColorBrown,
+	LabelColorCyan,
+	LabelColorGreen,
+	LabelColorGrey,
+	LabelColorOrange,
+	LabelColorPink,
+	LabelColorPurple,
+	LabelColorRed,
+	LabelColorYellow,
+})
+
+func (LabelColor) Enum() []interface{} { return toInterfaceSlice(labelColors) }
+func (c LabelColor) Sanitize() (LabelColor, bool) {
+	return Sanitize(c, GetAllLabelColors)
+}
+func GetAllLabelColors() ([]LabelColor, LabelColor) {
+	return labelColors, LabelColorGrey
+}
+
+// PullReqLabelActivityType defines the type of change of the pull request labels.
+type PullReqLabelActivityType string
+
+// PullReqLabelActivityType enumeration.
+const (
+	PullReqLabelActivityTypeAssign   PullReqLabelActivityType = "assign"
+	PullReqLabelActivityTypeReassign PullReqLabelActivityType = "reassign"
+	PullReqLabelActivityTypeUnassign PullReqLabelActivityType = "unassign"
+)
@@ -30,6 +30,21 @@ var MembershipRoles = sortEnum([]MembershipRole{
 	MembershipRoleSpaceOwner,
 })
 
+// RepoMembershipRoles are the roles that can be granted by repository memberships.
+var RepoMembershipRoles = sortEnum([]MembershipRole{
+	MembershipRoleReader,
+	MembershipRoleExecutor,
+	MembershipRoleContributor,
+	MembershipRoleRepoOwner,
+})
+
+// SanitizeRepoRole sanitizes the role of a repository membership.
+func (m MembershipRole) SanitizeRepoRole() (MembershipRole, bool) {
+	return Sanitize(m, GetAllRepoMembershipRoles)
+}
+
+func GetAllRepoMembershipRoles() ([]MembershipRole, MembershipRole) { return RepoMembershipRoles, "" }
+
 var membershipRoleReaderPermissions = slices.Clip(slices.Insert([]Permission{}, 0,
 	PermissionRepoView,
 	PermissionSpaceView,
@@ -87,14 +102,32 @@ var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRo
 	PermissionTemplateView,
 ))
 
+var membershipRoleRepoOwnerPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
+	PermissionRepoEdit,
+	PermissionRepoDelete,
+	PermissionRepoPush,
+	PermissionRepoReportCommitCheck,
+
+	PermissionPipelineEdit,
+	PermissionPipelineExecute,
+	PermissionPipelineDelete,
+))
+
 func init() {
 	slices.Sort(membershipRoleReaderPermissions)
 	slices.Sort(membershipRoleExecutorPermissions)
 	slices.Sort(membershipRoleContributorPermissions)
 	slices.Sort(membershipRoleSpaceOwnerPermissions)
+	slices.Sort(membershipRoleRepoOwnerPermissions)
+}
+
+// IsCustom returns true in case the role isn't one of the predefined roles.
+// The permissions of a custom role are defined by the custom role of the space with the same identifier.
+func (m MembershipRole) IsCustom() bool {
+	return m.Permissions() == nil
 }
 
-// Permissions returns the list of permissions for the role.
+// Permissions returns the list of permissions for the role (nil for custom roles).
 func (m MembershipRole) Permissions() []Permission {
 	switch m {
 	case MembershipRoleReader:
@@ -105,6 +138,8 @@ func (m MembershipRole) Permissions() []Permission {
 		return membershipRoleContributorPermissions
 	case MembershipRoleSpaceOwner:
 		return membershipRoleSpaceOwnerPermissions
+	case MembershipRoleRepoOwner:
+		return membershipRoleRepoOwnerPermissions
 	default:
 		return nil
 	}
@@ -115,4 +150,5 @@ const (
 	MembershipRoleExecutor    MembershipRole = "executor"
 	MembershipRoleContributor MembershipRole = "contributor"
 	MembershipRoleSpaceOwner  MembershipRole = "space_owner"
+	MembershipRoleRepoOwner   MembershipRole = "repo_owner"
 )
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueEntryState defines the state of a pull request in the merge queue.
+type MergeQueueEntryState string
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStateQueued means the pull request is waiting for its speculative merge commit.
+	MergeQueueEntryStateQueued MergeQueueEntryState = "queued"
+	// MergeQueueEntryStateTesting means the required checks run against the speculative merge commit.
+	MergeQueueEntryStateTesting MergeQueueEntryState = "testing"
+)
+
+var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
+	MergeQueueEntryStateQueued,
+	MergeQueueEntryStateTesting,
+})
+
+func (MergeQueueEntryState) Enum() []interface{} { return toInterfaceSlice(mergeQueueEntryStates) }
+func (s MergeQueueEntryState) Sanitize() (MergeQueueEntryState, bool) {
+	return Sanitize(s, GetAllMergeQueueEntryStates)
+}
+func GetAllMergeQueueEntryStates() ([]MergeQueueEntryState, MergeQueueEntryState) {
+	return mergeQueueEntryStates, MergeQueueEntryStateQueued
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MirrorSyncStatus represents the outcome of the last synchronization of a repository mirror.
+type MirrorSyncStatus string
+
+// MirrorSyncStatus enumeration.
+const (
+	MirrorSyncStatusPending MirrorSyncStatus = "pending"
+	MirrorSyncStatusSuccess MirrorSyncStatus = "success"
+	MirrorSyncStatusFailed  MirrorSyncStatus = "failed"
+)
+
+var mirrorSyncStatuses = sortEnum([]MirrorSyncStatus{
+	MirrorSyncStatusPending,
+	MirrorSyncStatusSuccess,
+	MirrorSyncStatusFailed,
+})
+
+func (MirrorSyncStatus) Enum() []interface{} { return toInterfaceSlice(mirrorSyncStatuses) }
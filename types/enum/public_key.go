@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"strings"
+)
+
+// PublicKeyUsage represents usage type of public key.
+type PublicKeyUsage string
+
+// PublicKeyUsage enumeration.
+const (
+	PublicKeyUsageAuth PublicKeyUsage = "auth"
+	PublicKeyUsageSign PublicKeyUsage = "sign"
+)
+
+var publicKeyUsages = sortEnum([]PublicKeyUsage{
+	PublicKeyUsageAuth,
+	PublicKeyUsageSign,
+})
+
+func (PublicKeyUsage) Enum() []interface{} { return toInterfaceSlice(publicKeyUsages) }
+func (s PublicKeyUsage) Sanitize() (PublicKeyUsage, bool) {
+	return Sanitize(s, GetAllPublicKeyUsages)
+}
+func GetAllPublicKeyUsages() ([]PublicKeyUsage, PublicKeyUsage) {
+	return publicKeyUsages, PublicKeyUsageAuth
+}
+
+// PublicKeyScheme represents the scheme of a public key.
+type PublicKeyScheme string
+
+// PublicKeyScheme enumeration.
+const (
+	PublicKeySchemeSSH PublicKeyScheme = "ssh"
+	PublicKeySchemePGP PublicKeyScheme = "pgp"
+)
+
+var publicKeySchemes = sortEnum([]PublicKeyScheme{
+	PublicKeySchemeSSH,
+	PublicKeySchemePGP,
+})
+
+func (PublicKeyScheme) Enum() []interface{} { return toInterfaceSlice(publicKeySchemes) }
+func (s PublicKeyScheme) Sanitize() (PublicKeyScheme, bool) {
+	return Sanitize(s, GetAllPublicKeySchemes)
+}
+func GetAllPublicKeySchemes() ([]PublicKeyScheme, PublicKeyScheme) {
+	return publicKeySchemes, PublicKeySchemeSSH
+}
+
+// GitSignatureStatus represents the verification status of a git commit or tag signature.
+type GitSignatureStatus string
+
+// GitSignatureStatus enumeration.
+const (
+	// GitSignatureStatusVerified is used if the signature is valid and belongs to a key of the signer.
+	GitSignatureStatusVerified GitSignatureStatus = "verified"
+	// GitSignatureStatusUnverified is used if the object isn't signed or the signature can't be checked.
+	GitSignatureStatusUnverified GitSignatureStatus = "unverified"
+	// GitSignatureStatusUnknownKey is used if the signing key doesn't belong to the signer.
+	GitSignatureStatusUnknownKey GitSignatureStatus = "unknown_key"
+	// GitSignatureStatusBadSignature is used if the signature doesn't match the signed content.
+	GitSignatureStatusBadSignature GitSignatureStatus = "bad_signature"
+)
+
+var gitSignatureStatuses = sortEnum([]GitSignatureStatus{
+	GitSignatureStatusVerified,
+	GitSignatureStatusUnverified,
+	GitSignatureStatusUnknownKey,
+	GitSignatureStatusBadSignature,
+})
+
+func (GitSignatureStatus) Enum() []interface{} { return toInterfaceSlice(gitSignatureStatuses) }
+
+// PublicKeySort is used to specify sorting of public keys.
+type PublicKeySort string
+
+// PublicKeySort enumeration.
+const (
+	PublicKeySortCreated    PublicKeySort = created
+	PublicKeySortIdentifier PublicKeySort = identifier
+)
+
+var publicKeySorts = sortEnum([]PublicKeySort{
+	PublicKeySortCreated,
+	PublicKeySortIdentifier,
+})
+
+func (PublicKeySort) Enum() []interface{} { return toInterfaceSlice(publicKeySorts) }
+func (s PublicKeySort) Sanitize() (PublicKeySort, bool) {
+	return Sanitize(s, GetAllPublicKeySorts)
+}
+func GetAllPublicKeySorts() ([]PublicKeySort, PublicKeySort) {
+	return publicKeySorts, PublicKeySortCreated
+}
+
+// ParsePublicKeySort parses the public key sorting option.
+func ParsePublicKeySort(s string) PublicKeySort {
+	if strings.ToLower(s) == identifier {
+		return PublicKeySortIdentifier
+	}
+
+	return PublicKeySortCreated
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SecretScanningMode defines what happens if secret scanning detects secrets in a push.
+type SecretScanningMode string
+
+// SecretScanningMode enumeration.
+const (
+	// SecretScanningModeBlock rejects pushes that contain secrets.
+	SecretScanningModeBlock SecretScanningMode = "block"
+	// SecretScanningModeRecord accepts pushes that contain secrets and only records the findings.
+	SecretScanningModeRecord SecretScanningMode = "record"
+)
+
+var secretScanningModes = sortEnum([]SecretScanningMode{
+	SecretScanningModeBlock,
+	SecretScanningModeRecord,
+})
+
+func (SecretScanningMode) Enum() []interface{} { return toInterfaceSlice(secretScanningModes) }
+func (m SecretScanningMode) Sanitize() (SecretScanningMode, bool) {
+	return Sanitize(m, GetAllSecretScanningModes)
+}
+func GetAllSecretScanningModes() ([]SecretScanningMode, SecretScanningMode) {
+	return secretScanningModes, SecretScanningModeBlock
+}
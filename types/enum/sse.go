@@ -27,5 +27,6 @@ const (
 	SSETypeRepositoryImportCompleted SSEType = "repository_import_completed"
 	SSETypeRepositoryExportCompleted SSEType = "repository_export_completed"
 
-	SSETypePullRequestUpdated SSEType = "pullreq_updated"
+	SSETypePullRequestUpdated           SSEType = "pullreq_updated"
+	SSETypePullRequestMergeQueueUpdated SSEType = "pullreq_merge_queue_updated"
 )
@@ -40,6 +40,9 @@ const (
 	TriggerActionPullReqClosed = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged = "pullreq_merged"
+	// TriggerActionPullReqMergeQueueBuilt gets triggered when the speculative merge commit
+	// of a pull request in the merge queue is built.
+	TriggerActionPullReqMergeQueueBuilt TriggerAction = "pullreq_merge_queue_built"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -49,7 +52,8 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 		t == TriggerActionPullReqBranchUpdated ||
 		t == TriggerActionPullReqReopened ||
 		t == TriggerActionPullReqClosed ||
-		t == TriggerActionPullReqMerged {
+		t == TriggerActionPullReqMerged ||
+		t == TriggerActionPullReqMergeQueueBuilt {
 		return TriggerEventPullRequest
 	}
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
@@ -75,6 +79,7 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionPullReqBranchUpdated,
 	TriggerActionPullReqClosed,
 	TriggerActionPullReqMerged,
+	TriggerActionPullReqMergeQueueBuilt,
 })
 
 // Trigger types.
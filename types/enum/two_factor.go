@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TwoFactorMethod represents a method that can be used as the second factor of a login.
+type TwoFactorMethod string
+
+// TwoFactorMethod enumeration.
+const (
+	// TwoFactorMethodTOTP uses a time-based one-time password generated by an authenticator app.
+	TwoFactorMethodTOTP TwoFactorMethod = "totp"
+	// TwoFactorMethodWebAuthn uses a security key or platform authenticator.
+	TwoFactorMethodWebAuthn TwoFactorMethod = "webauthn"
+	// TwoFactorMethodRecoveryCode uses one of the single-use recovery codes of the user.
+	TwoFactorMethodRecoveryCode TwoFactorMethod = "recovery_code"
+)
+
+var twoFactorMethods = sortEnum([]TwoFactorMethod{
+	TwoFactorMethodTOTP,
+	TwoFactorMethodWebAuthn,
+	TwoFactorMethodRecoveryCode,
+})
+
+func (TwoFactorMethod) Enum() []interface{} { return toInterfaceSlice(twoFactorMethods) }
+func (m TwoFactorMethod) Sanitize() (TwoFactorMethod, bool) {
+	return Sanitize(m, GetAllTwoFactorMethods)
+}
+func GetAllTwoFactorMethods() ([]TwoFactorMethod, TwoFactorMethod) {
+	return twoFactorMethods, ""
+}
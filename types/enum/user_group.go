@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// UserGroupSource represents the source a user group is managed by.
+type UserGroupSource string
+
+// UserGroupSource enumeration.
+const (
+	// UserGroupSourceGitness is a user group that's managed in gitness.
+	UserGroupSourceGitness UserGroupSource = "gitness"
+	// UserGroupSourceLDAP is a user group that's synced from an LDAP directory.
+	UserGroupSourceLDAP UserGroupSource = "ldap"
+)
+
+var userGroupSources = sortEnum([]UserGroupSource{
+	UserGroupSourceGitness,
+	UserGroupSourceLDAP,
+})
+
+func (UserGroupSource) Enum() []interface{} { return toInterfaceSlice(userGroupSources) }
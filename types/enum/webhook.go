@@ -158,6 +158,11 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+	// WebhookTriggerPullReqLabelAssigned gets triggered when a label is assigned to a pull request.
+	WebhookTriggerPullReqLabelAssigned WebhookTrigger = "pullreq_label_assigned"
+
+	// WebhookTriggerRuleBypassed gets triggered when protection rules are bypassed by a merge or a push.
+	WebhookTriggerRuleBypassed WebhookTrigger = "rule_bypassed"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +178,6 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqLabelAssigned,
+	WebhookTriggerRuleBypassed,
 })
@@ -70,6 +70,16 @@ type Commit struct {
 	Author     Signature       `json:"author"`
 	Committer  Signature       `json:"committer"`
 	DiffStats  CommitDiffStats `json:"diff_stats"`
+
+	Verification *GitSignatureVerification `json:"verification,omitempty"`
+}
+
+// GitSignatureVerification contains the result of the signature verification of a commit or a tag.
+type GitSignatureVerification struct {
+	Status         enum.GitSignatureStatus `json:"status"`
+	Scheme         enum.PublicKeyScheme    `json:"scheme,omitempty"`
+	KeyFingerprint string                  `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo          `json:"signer,omitempty"`
 }
 
 type Signature struct {
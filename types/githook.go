@@ -22,7 +22,9 @@ import (
 type GithookInputBase struct {
 	RepoID      int64
 	PrincipalID int64
+	ClientIP    string
 	Internal    bool // Internal calls originate from Gitness, and external calls are direct git pushes.
+	ScanSecrets bool // ScanSecrets is set for internal calls that push commits with user provided content.
 }
 
 // GithookPreReceiveInput is the input for the pre-receive githook api call.
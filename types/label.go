@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Label is a pull request label defined in a space or in a repository.
+// The labels of a space are available to all repositories in the space and its subspaces.
+//
+// A label with a value is scoped, for example "priority=high".
+// A pull request can have only one scoped label with the same key.
+type Label struct {
+	ID          int64           `json:"id"`
+	SpaceID     *int64          `json:"space_id,omitempty"`
+	RepoID      *int64          `json:"repo_id,omitempty"`
+	Key         string          `json:"key"`
+	Value       string          `json:"value,omitempty"`
+	Description string          `json:"description"`
+	Color       enum.LabelColor `json:"color"`
+	CreatedBy   int64           `json:"created_by"`
+	Created     int64           `json:"created"`
+	Updated     int64           `json:"updated"`
+}
+
+// Name returns the label key, followed by "=" and the value if the label is scoped.
+func (l *Label) Name() string {
+	if l.Value == "" {
+		return l.Key
+	}
+
+	return l.Key + "=" + l.Value
+}
+
+// Matches returns true if the label matches the provided label name.
+// A name without a value matches all labels with the key.
+func (l *Label) Matches(name string) bool {
+	key, value, hasValue := strings.Cut(name, "=")
+	if !strings.EqualFold(l.Key, key) {
+		return false
+	}
+
+	return !hasValue || strings.EqualFold(l.Value, value)
+}
+
+// LabelFilter stores label query parameters.
+type LabelFilter struct {
+	Query string `json:"query"`
+	// Inherited includes the labels of the parent spaces.
+	Inherited bool `json:"inherited"`
+}
+
+// PullReqLabel is a label assigned to a pull request.
+type PullReqLabel struct {
+	PullReqID  int64 `json:"pullreq_id"`
+	LabelID    int64 `json:"label_id"`
+	AssignedBy int64 `json:"assigned_by"`
+	Assigned   int64 `json:"assigned"`
+}
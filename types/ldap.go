@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// LDAPIdentity links a user of an LDAP directory to a gitness user.
+// The directory user is identified by its immutable external ID (e.g. objectGUID or entryUUID),
+// so renamed or recreated directory entries can't take over the linked user.
+type LDAPIdentity struct {
+	ID          int64  `db:"ldap_identity_id"           json:"-"`
+	PrincipalID int64  `db:"ldap_identity_principal_id" json:"principal_id"`
+	ExternalID  string `db:"ldap_identity_external_id"  json:"external_id"`
+	DN          string `db:"ldap_identity_dn"           json:"dn"`
+	Created     int64  `db:"ldap_identity_created"      json:"created"`
+	LastLogin   int64  `db:"ldap_identity_last_login"   json:"last_login"`
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry is a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	PullReqID    int64            `json:"pullreq_id"`
+	RepoID       int64            `json:"repo_id"`
+	TargetBranch string           `json:"target_branch"`
+	Method       enum.MergeMethod `json:"method"`
+	// Title and Message override the merge commit title and message generated from the repository templates.
+	Title   string                    `json:"title,omitempty"`
+	Message string                    `json:"message,omitempty"`
+	State   enum.MergeQueueEntryState `json:"state"`
+	// SourceSHA is the source branch commit that was approved for merging.
+	SourceSHA string `json:"source_sha"`
+	// BaseSHA is the commit the speculative merge commit is built on top of:
+	// Either the target branch commit or the speculative merge commit of the previous entry.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeSHA is the speculative merge commit the required checks are run against.
+	MergeSHA           string `json:"merge_sha,omitempty"`
+	DeleteSourceBranch bool   `json:"delete_source_branch"`
+	EnqueuedBy         int64  `json:"enqueued_by"`
+	Created            int64  `json:"created"`
+	Updated            int64  `json:"updated"`
+
+	// Position is the one-based position of the entry in the merge queue.
+	Position int `json:"position"`
+}
+
+// MergeQueue is the list of pull requests waiting to be merged into a branch.
+type MergeQueue struct {
+	RepoID       int64             `json:"repo_id"`
+	TargetBranch string            `json:"target_branch"`
+	Entries      []MergeQueueEntry `json:"entries"`
+}
+
+// MergeQueueBranch identifies a branch with a non-empty merge queue.
+type MergeQueueBranch struct {
+	RepoID       int64  `db:"merge_queue_entry_repo_id"`
+	TargetBranch string `db:"merge_queue_entry_target_branch"`
+}
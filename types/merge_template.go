@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MergeTemplates contains the templates of the commit titles and messages used
+// when the pull requests of a repository are merged.
+// An empty template means that the default title or message is used.
+//
+// The templates can contain the following placeholders:
+//   - {pr_title}: The title of the pull request.
+//   - {pr_number}: The number of the pull request.
+//   - {pr_description}: The description of the pull request.
+//   - {source_branch}: The source branch of the pull request.
+//   - {target_branch}: The target branch of the pull request.
+//   - {source_repo}: The path of the source repository of the pull request.
+//   - {co_authors}: A "Co-authored-by" trailer line for each author of the pull request commits.
+//   - {reviewers}: A "Reviewed-by" trailer line for each reviewer that approved the pull request.
+type MergeTemplates struct {
+	RepoID int64 `json:"repo_id"`
+	// MergeTitle and MergeMessage are used by the merge and the rebase-merge methods.
+	MergeTitle   string `json:"merge_title"`
+	MergeMessage string `json:"merge_message"`
+	// SquashTitle and SquashMessage are used by the squash method.
+	SquashTitle   string `json:"squash_title"`
+	SquashMessage string `json:"squash_message"`
+	CreatedBy     int64  `json:"created_by"`
+	Created       int64  `json:"created"`
+	Updated       int64  `json:"updated"`
+}
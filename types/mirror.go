@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullMirror represents the configuration of a repository that mirrors a remote repository.
+// All branches and tags of the repository are periodically synced from the remote repository.
+type PullMirror struct {
+	RepoID    int64  `json:"repo_id"`
+	URL       string `json:"url"`
+	Username  string `json:"username"`
+	Password  []byte `json:"-"` // encrypted
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+
+	// SyncIntervalMinutes defines how often the mirror is synced (0 means the default interval of the server).
+	SyncIntervalMinutes int64 `json:"sync_interval_minutes"`
+
+	LastSync       int64                 `json:"last_sync"`
+	LastSyncStatus enum.MirrorSyncStatus `json:"last_sync_status"`
+	LastSyncError  string                `json:"last_sync_error,omitempty"`
+}
+
+// PushMirror represents a remote repository to which all branches and tags of the repository are pushed
+// after every change and periodically.
+type PushMirror struct {
+	ID               int64  `json:"id"`
+	RepoID           int64  `json:"repo_id"`
+	Identifier       string `json:"identifier"`
+	URL              string `json:"url"`
+	Username         string `json:"username"`
+	SecretIdentifier string `json:"secret_identifier"`
+	CreatedBy        int64  `json:"created_by"`
+	Created          int64  `json:"created"`
+	Updated          int64  `json:"updated"`
+
+	LastSync       int64                 `json:"last_sync"`
+	LastSyncStatus enum.MirrorSyncStatus `json:"last_sync_status"`
+	LastSyncError  string                `json:"last_sync_error,omitempty"`
+
+	// FailedAttempts is the number of consecutive failed syncs.
+	FailedAttempts int `json:"failed_attempts"`
+	// NextRetry is the earliest time a failed sync is retried automatically.
+	NextRetry int64 `json:"next_retry,omitempty"`
+}
@@ -95,6 +95,7 @@ type PullReqFilter struct {
 	TargetRepoID  int64               `json:"-"`
 	TargetBranch  string              `json:"target_branch"`
 	States        []enum.PullReqState `json:"state"`
+	LabelIDs      []int64             `json:"label_id"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
 }
@@ -154,6 +155,10 @@ type MergeResponse struct {
 	AllowedMethods []enum.MergeMethod `json:"allowed_methods,omitempty"`
 	ConflictFiles  []string           `json:"conflict_files,omitempty"`
 	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+
+	// Queued is true if the pull request has been added to the merge queue instead of being merged.
+	Queued        bool `json:"queued,omitempty"`
+	QueuePosition int  `json:"queue_position,omitempty"`
 }
 
 type MergeViolations struct {
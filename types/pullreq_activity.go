@@ -190,6 +190,9 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadLabel{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -220,10 +223,12 @@ func (a *PullRequestActivityPayloadCodeComment) ActivityType() enum.PullReqActiv
 }
 
 type PullRequestActivityPayloadMerge struct {
-	MergeMethod enum.MergeMethod `json:"merge_method"`
-	MergeSHA    string           `json:"merge_sha"`
-	TargetSHA   string           `json:"target_sha"`
-	SourceSHA   string           `json:"source_sha"`
+	MergeMethod   enum.MergeMethod `json:"merge_method"`
+	MergeSHA      string           `json:"merge_sha"`
+	TargetSHA     string           `json:"target_sha"`
+	SourceSHA     string           `json:"source_sha"`
+	RulesBypassed []RuleInfo       `json:"rules_bypassed,omitempty"`
+	BypassReason  string           `json:"bypass_reason,omitempty"`
 }
 
 func (a *PullRequestActivityPayloadMerge) ActivityType() enum.PullReqActivityType {
@@ -275,3 +280,35 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+type PullRequestActivityPayloadMergeQueue struct {
+	Enqueued bool   `json:"enqueued"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
+type PullRequestActivityPayloadAutoMerge struct {
+	Enabled bool             `json:"enabled"`
+	Method  enum.MergeMethod `json:"method,omitempty"`
+	Reason  string           `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+type PullRequestActivityPayloadLabel struct {
+	Type  enum.PullReqLabelActivityType `json:"type"`
+	Label string                        `json:"label"`
+	Color enum.LabelColor               `json:"color"`
+	// OldLabel is the scoped label with the same key that was replaced by the assigned label.
+	OldLabel string          `json:"old_label,omitempty"`
+	OldColor enum.LabelColor `json:"old_color,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadLabel) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeLabelModify
+}
@@ -52,7 +52,8 @@ type Repository struct {
 	Importing bool `json:"importing"`
 
 	// git urls
-	GitURL string `json:"git_url"`
+	GitURL    string `json:"git_url"`
+	GitSSHURL string `json:"git_ssh_url,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -79,6 +80,13 @@ func (r Repository) GetGitUID() string {
 	return r.GitUID
 }
 
+// IsForkRelative returns true if one repository is a fork of the other, or if both are forks of the same repository.
+func (r Repository) IsForkRelative(other *Repository) bool {
+	return r.ForkID == other.ID ||
+		other.ForkID == r.ID ||
+		(r.ForkID != 0 && r.ForkID == other.ForkID)
+}
+
 // RepoFilter stores repo query parameters.
 type RepoFilter struct {
 	Page              int           `json:"page"`
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// SecretScanningSettings contains the secret scanning configuration of a repository.
+// If enabled, the lines added by pushed commits are scanned for secrets.
+type SecretScanningSettings struct {
+	RepoID  int64                   `json:"repo_id"`
+	Enabled bool                    `json:"enabled"`
+	Mode    enum.SecretScanningMode `json:"mode"`
+	// UseDefaultRules enables the built-in rule set in addition to the custom rules.
+	UseDefaultRules bool                 `json:"use_default_rules"`
+	Rules           []SecretScanningRule `json:"rules"`
+	CreatedBy       int64                `json:"created_by"`
+	Created         int64                `json:"created"`
+	Updated         int64                `json:"updated"`
+}
+
+// SecretScanningRule describes how to detect a kind of secret.
+type SecretScanningRule struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description,omitempty"`
+	// Regex is the regular expression that matches the secret.
+	Regex string `json:"regex"`
+	// SecretGroup is the index of the regex capture group that contains the secret, zero means the whole match.
+	SecretGroup int `json:"secret_group,omitempty"`
+	// Entropy is the minimum Shannon entropy the secret must have to be reported, zero disables the check.
+	Entropy float64 `json:"entropy,omitempty"`
+	// Keywords, if not empty, limit the regex to lines that contain at least one of the keywords (case-insensitive).
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// SecretFinding is a secret that was detected in a pushed commit.
+type SecretFinding struct {
+	ID             int64  `json:"id"`
+	RepoID         int64  `json:"repo_id"`
+	PrincipalID    int64  `json:"principal_id"`
+	RuleIdentifier string `json:"rule_identifier"`
+	Ref            string `json:"ref"`
+	CommitSHA      string `json:"commit_sha"`
+	Path           string `json:"path"`
+	Line           int    `json:"line"`
+	// Secret is the redacted secret, only the beginning of the secret is visible.
+	Secret string `json:"secret"`
+	// Fingerprint identifies the finding and can be added to the allowlist file to ignore it.
+	Fingerprint string `json:"fingerprint"`
+	// Blocked is true if the push got rejected because of the finding.
+	Blocked bool  `json:"blocked"`
+	Created int64 `json:"created"`
+}
+
+// SecretFindingFilter stores secret finding query parameters.
+type SecretFindingFilter struct {
+	ListQueryFilter
+	RuleIdentifier string `json:"rule_identifier"`
+	CommitSHA      string `json:"commit_sha"`
+}
@@ -32,6 +32,18 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+
+	// Scopes optionally limits the permissions granted by the token (no limitation if empty).
+	Scopes []enum.Permission `db:"-" json:"scopes,omitempty"`
+	// SpaceIDs and RepoIDs optionally restrict the token to the listed spaces (including everything within)
+	// and repositories. The token isn't restricted to any resources if both are empty.
+	SpaceIDs []int64 `db:"-" json:"space_ids,omitempty"`
+	RepoIDs  []int64 `db:"-" json:"repo_ids,omitempty"`
+}
+
+// IsRestricted returns true in case the token is restricted to specific spaces or repositories.
+func (t *Token) IsRestricted() bool {
+	return len(t.SpaceIDs) > 0 || len(t.RepoIDs) > 0
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// TOTPSecret is the secret a user generates time-based one-time passwords with.
+type TOTPSecret struct {
+	PrincipalID int64 `json:"-"`
+	// Secret is the encrypted base32 secret shared with the authenticator app.
+	Secret []byte `json:"-"`
+	// Confirmed is set once the user provided a valid code, only confirmed secrets are used for the login.
+	Confirmed bool `json:"confirmed"`
+	// LastUsedStep is the time step of the last accepted code, used to reject replayed codes.
+	LastUsedStep int64 `json:"-"`
+	Created      int64 `json:"created"`
+	Updated      int64 `json:"updated"`
+}
+
+// WebAuthnCredential is a security key (or platform authenticator) registered by a user.
+type WebAuthnCredential struct {
+	ID          int64  `json:"id"`
+	PrincipalID int64  `json:"-"`
+	Name        string `json:"name"`
+	// Key is the base64 (URL encoding) ID of the credential assigned by the authenticator.
+	Key string `json:"key"`
+	// Data is the serialized credential, including the public key and the signature counter.
+	Data     json.RawMessage `json:"-"`
+	Created  int64           `json:"created"`
+	LastUsed int64           `json:"last_used"`
+}
+
+// TwoFactorLockout tracks the failed second factor login attempts of a user.
+type TwoFactorLockout struct {
+	PrincipalID    int64
+	FailedAttempts int
+	// LockedUntil is the time until which the user can't complete a login with a second factor.
+	LockedUntil int64
+	// InvalidBefore is the time of the last lockout, challenges issued before it are rejected.
+	InvalidBefore int64
+	Updated       int64
+}
+
+// IsLocked returns true if the second factor login of the user is locked at the provided time.
+func (l *TwoFactorLockout) IsLocked(now int64) bool {
+	return l.LockedUntil > now
+}
+
+// TwoFactorRequiredSpace is a space whose members have to login with a second factor.
+type TwoFactorRequiredSpace struct {
+	SpaceID   int64  `json:"space_id"`
+	Path      string `json:"path"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+}
+
+// TwoFactorStatus describes the second factors a user has configured.
+type TwoFactorStatus struct {
+	Required            bool                  `json:"required"`
+	TOTPEnabled         bool                  `json:"totp_enabled"`
+	WebAuthnCredentials []*WebAuthnCredential `json:"webauthn_credentials"`
+	RecoveryCodesLeft   int                   `json:"recovery_codes_left"`
+}
+
+// IsEnabled returns true if the user has any second factor configured.
+func (s *TwoFactorStatus) IsEnabled() bool {
+	return s.TOTPEnabled || len(s.WebAuthnCredentials) > 0
+}
+
+// Methods returns the methods the user can complete a login with.
+func (s *TwoFactorStatus) Methods() []enum.TwoFactorMethod {
+	var methods []enum.TwoFactorMethod
+	if s.TOTPEnabled {
+		methods = append(methods, enum.TwoFactorMethodTOTP)
+	}
+	if len(s.WebAuthnCredentials) > 0 {
+		methods = append(methods, enum.TwoFactorMethodWebAuthn)
+	}
+	if s.RecoveryCodesLeft > 0 {
+		methods = append(methods, enum.TwoFactorMethodRecoveryCode)
+	}
+
+	return methods
+}
+
+// TOTPEnrollment contains the information required to add a TOTP secret to an authenticator app.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	// URL is the otpauth:// URL of the secret, usually rendered as QR code.
+	URL string `json:"url"`
+}
+
+// RecoveryCodes are the single-use codes a user can login with if no other second factor is available.
+// The codes are only returned once, when they are generated.
+type RecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
+
+// TwoFactorChallenge is returned by the login in case the user has to provide a second factor.
+type TwoFactorChallenge struct {
+	// ChallengeToken is a short-lived token identifying the pending login.
+	ChallengeToken string                 `json:"challenge_token"`
+	Methods        []enum.TwoFactorMethod `json:"methods"`
+	// EnrollmentRequired is set if two-factor authentication is required but the user hasn't configured it yet.
+	// The user has to enroll a TOTP secret to complete the login.
+	EnrollmentRequired bool `json:"enrollment_required"`
+}
+
+// LoginResponse is the result of a login attempt. It either contains the session token
+// or the challenge for the second factor required to complete the login.
+type LoginResponse struct {
+	*TokenResponse
+	TwoFactor *TwoFactorChallenge `json:"two_factor,omitempty"`
+	// RecoveryCodes are returned if they were generated as part of the login (on enrollment).
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
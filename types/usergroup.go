@@ -15,9 +15,44 @@
 // Package types defines common data structures.
 package types
 
+import "github.com/harness/gitness/types/enum"
+
+// UserGroup represents a group of users within a space.
 type UserGroup struct {
-	Identifier  string `json:"identifier"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Users       []string
+	ID          int64                `json:"id"`
+	SpaceID     int64                `json:"space_id"`
+	Identifier  string               `json:"identifier"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Source      enum.UserGroupSource `json:"source"`
+	Created     int64                `json:"created"`
+	Updated     int64                `json:"updated"`
+
+	// Users contains the UIDs of the members of the user group.
+	Users []string `json:"users,omitempty"`
+}
+
+// UserGroupMembershipKey can be used as a key for finding the membership of a user group in a space.
+type UserGroupMembershipKey struct {
+	SpaceID     int64
+	UserGroupID int64
+}
+
+// UserGroupMembership represents the membership of a user group in a space.
+// All members of the user group get the role of the membership in the space.
+type UserGroupMembership struct {
+	UserGroupMembershipKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+}
+
+// UserGroupMembershipInfo adds user group info to the UserGroupMembership data.
+type UserGroupMembershipInfo struct {
+	UserGroupMembership
+	UserGroup UserGroup     `json:"user_group"`
+	AddedBy   PrincipalInfo `json:"added_by"`
 }